@@ -0,0 +1,215 @@
+// Command edgegrid-debug signs and executes a single API request with EdgeGrid credentials,
+// printing timing, trace identifiers and redacted signature details. It helps diagnosing
+// credential and signing problems.
+//
+// Usage:
+//
+//	edgegrid-debug [-edgerc ~/.edgerc] [-section default] [-X GET] [-d '{"json":"body"}'] [-H 'Name: value'] /papi/v1/contracts
+package main
+
+import (
+	"bytes"
+	"crypto/tls"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptrace"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/edgegrid"
+)
+
+type headerFlags []string
+
+func (h *headerFlags) String() string {
+	return strings.Join(*h, ", ")
+}
+
+func (h *headerFlags) Set(v string) error {
+	*h = append(*h, v)
+	return nil
+}
+
+// traceHeaders are response headers identifying the request on Akamai side
+var traceHeaders = []string{"X-Trace-Id", "X-Akamai-Request-Id", "Akamai-Request-Id", "X-Request-Id", "Akamai-Grn"}
+
+func main() {
+	os.Exit(runCommand(os.Args[1:], os.Stdout, os.Stderr, http.DefaultClient))
+}
+
+// runCommand parses the command line arguments, runs the command with the client and returns its exit code:
+// 2 for invalid arguments, 1 if the request could not be made
+func runCommand(args []string, stdout, stderr io.Writer, client *http.Client) int {
+	flags := flag.NewFlagSet("edgegrid-debug", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	var headers headerFlags
+	var (
+		edgerc   = flags.String("edgerc", edgegrid.DefaultConfigFile, "path to the .edgerc file")
+		section  = flags.String("section", edgegrid.DefaultSection, "section of the .edgerc file")
+		method   = flags.String("X", http.MethodGet, "request method")
+		body     = flags.String("d", "", "request body; prefix with @ to read it from a file")
+		showBody = flags.Bool("show-body", true, "print the response body")
+	)
+	flags.Var(&headers, "H", "additional request header, e.g. 'Accept: application/json'; may be repeated")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if flags.NArg() != 1 {
+		fmt.Fprintln(stderr, "usage: edgegrid-debug [flags] <path>")
+		flags.PrintDefaults()
+		return 2
+	}
+
+	if err := run(client, stdout, flags.Arg(0), *edgerc, *section, *method, *body, headers, *showBody); err != nil {
+		fmt.Fprintf(stderr, "edgegrid-debug: %s\n", err)
+		return 1
+	}
+	return 0
+}
+
+func run(client *http.Client, out io.Writer, path, edgerc, section, method, body string, headers []string, showBody bool) error {
+	config, err := edgegrid.New(edgegrid.WithEnv(true), edgegrid.WithFile(edgerc), edgegrid.WithSection(section))
+	if err != nil {
+		return err
+	}
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Config:\n  host: %s\n  client_token: %s\n  access_token: %s\n  client_secret: %s\n",
+		config.Host, redact(config.ClientToken), redact(config.AccessToken), redact(config.ClientSecret))
+	if config.AccountKey != "" {
+		fmt.Fprintf(out, "  account_key: %s\n", config.AccountKey)
+	}
+
+	data, err := readBody(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(strings.ToUpper(method), path, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	for _, h := range headers {
+		name, value, ok := strings.Cut(h, ":")
+		if !ok {
+			return fmt.Errorf("invalid header %q", h)
+		}
+		req.Header.Add(strings.TrimSpace(name), strings.TrimSpace(value))
+	}
+	if len(data) > 0 && req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	config.SignRequest(req)
+	fmt.Fprintf(out, "\nRequest:\n  %s %s\n  Authorization: %s\n", req.Method, req.URL.String(), redactAuthorization(req.Header.Get("Authorization")))
+
+	timings, trace := newTimings()
+	req = req.WithContext(httptrace.WithClientTrace(req.Context(), trace))
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	timings.done = time.Now()
+
+	fmt.Fprintf(out, "\nResponse:\n  %s\n", resp.Status)
+	for _, h := range traceHeaders {
+		if v := resp.Header.Get(h); v != "" {
+			fmt.Fprintf(out, "  %s: %s\n", h, v)
+		}
+	}
+	fmt.Fprintf(out, "\nTiming:\n%s", timings)
+
+	if showBody && len(respBody) > 0 {
+		fmt.Fprintf(out, "\nBody:\n%s\n", respBody)
+	}
+	return nil
+}
+
+func readBody(body string) ([]byte, error) {
+	if strings.HasPrefix(body, "@") {
+		return ioutil.ReadFile(strings.TrimPrefix(body, "@"))
+	}
+	return []byte(body), nil
+}
+
+// redact keeps only the beginning and the end of a secret
+func redact(s string) string {
+	if len(s) <= 12 {
+		return strings.Repeat("*", len(s))
+	}
+	return s[:4] + strings.Repeat("*", len(s)-8) + s[len(s)-4:]
+}
+
+// redactAuthorization redacts tokens and signature in an EdgeGrid Authorization header while keeping timestamp and nonce
+func redactAuthorization(auth string) string {
+	authType, params, ok := strings.Cut(auth, " ")
+	if !ok {
+		return redact(auth)
+	}
+	parts := strings.Split(params, ";")
+	for i, p := range parts {
+		name, value, ok := strings.Cut(p, "=")
+		if !ok {
+			continue
+		}
+		switch name {
+		case "client_token", "access_token", "signature":
+			parts[i] = name + "=" + redact(value)
+		}
+	}
+	return authType + " " + strings.Join(parts, ";")
+}
+
+type timings struct {
+	start, dnsStart, dnsDone, connectStart, connectDone, tlsStart, tlsDone, firstByte, done time.Time
+}
+
+func newTimings() (*timings, *httptrace.ClientTrace) {
+	t := &timings{start: time.Now()}
+	return t, &httptrace.ClientTrace{
+		DNSStart:             func(httptrace.DNSStartInfo) { t.dnsStart = time.Now() },
+		DNSDone:              func(httptrace.DNSDoneInfo) { t.dnsDone = time.Now() },
+		ConnectStart:         func(string, string) { t.connectStart = time.Now() },
+		ConnectDone:          func(string, string, error) { t.connectDone = time.Now() },
+		TLSHandshakeStart:    func() { t.tlsStart = time.Now() },
+		TLSHandshakeDone:     func(tls.ConnectionState, error) { t.tlsDone = time.Now() },
+		GotFirstResponseByte: func() { t.firstByte = time.Now() },
+	}
+}
+
+func (t *timings) String() string {
+	phases := map[string]time.Duration{}
+	add := func(name string, from, to time.Time) {
+		if !from.IsZero() && !to.IsZero() {
+			phases[name] = to.Sub(from)
+		}
+	}
+	add("1 dns", t.dnsStart, t.dnsDone)
+	add("2 connect", t.connectStart, t.connectDone)
+	add("3 tls", t.tlsStart, t.tlsDone)
+	add("4 first byte", t.start, t.firstByte)
+	add("5 total", t.start, t.done)
+
+	names := make([]string, 0, len(phases))
+	for n := range phases {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, n := range names {
+		fmt.Fprintf(&b, "  %-11s %s\n", n[2:]+":", phases[n].Round(time.Microsecond))
+	}
+	return b.String()
+}
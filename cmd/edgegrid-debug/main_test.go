@@ -0,0 +1,148 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunCommand(t *testing.T) {
+	var method, path, body string
+	var header http.Header
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		method, path, body = r.Method, r.URL.Path, string(data)
+		header = r.Header.Clone()
+		w.Header().Set("X-Trace-Id", "trace-123")
+		w.WriteHeader(http.StatusOK)
+		_, err = w.Write([]byte(`{"contracts":[]}`))
+		assert.NoError(t, err)
+	}))
+	defer mockServer.Close()
+
+	edgerc := filepath.Join(t.TempDir(), "edgerc")
+	require.NoError(t, ioutil.WriteFile(edgerc, []byte(fmt.Sprintf(`[test]
+host = %s
+client_token = akab-client-token-xxx
+client_secret = akab-client-secret-xxx
+access_token = akab-access-token-xxx
+`, mockServer.Listener.Addr().String())), 0600))
+
+	tests := map[string]struct {
+		args             []string
+		expectedCode     int
+		expectedMethod   string
+		expectedBody     string
+		expectedHeaders  map[string]string
+		expectedOutput   []string
+		unexpectedOutput []string
+		expectedError    string
+	}{
+		"signed GET request": {
+			args:           []string{"-edgerc", edgerc, "-section", "test", "/papi/v1/contracts"},
+			expectedMethod: http.MethodGet,
+			expectedOutput: []string{
+				"client_token: akab*************-xxx",
+				"GET https://" + mockServer.Listener.Addr().String() + "/papi/v1/contracts",
+				"Authorization: EG1-HMAC-SHA256 client_token=akab*************-xxx;",
+				"200 OK",
+				"X-Trace-Id: trace-123",
+				"total:",
+				`{"contracts":[]}`,
+			},
+			unexpectedOutput: []string{"akab-client-token-xxx", "akab-client-secret-xxx", "akab-access-token-xxx"},
+		},
+		"POST request with body and headers": {
+			args:             []string{"-edgerc", edgerc, "-section", "test", "-X", "post", "-d", `{"name":"a"}`, "-H", "Accept: application/json", "-show-body=false", "/papi/v1/properties"},
+			expectedMethod:   http.MethodPost,
+			expectedBody:     `{"name":"a"}`,
+			expectedHeaders:  map[string]string{"Content-Type": "application/json", "Accept": "application/json"},
+			expectedOutput:   []string{"POST https://", "200 OK"},
+			unexpectedOutput: []string{`{"contracts":[]}`},
+		},
+		"missing path": {
+			args:          []string{"-edgerc", edgerc},
+			expectedCode:  2,
+			expectedError: "usage: edgegrid-debug [flags] <path>",
+		},
+		"unknown flag": {
+			args:          []string{"-unknown", "/papi/v1/contracts"},
+			expectedCode:  2,
+			expectedError: "flag provided but not defined: -unknown",
+		},
+		"missing section": {
+			args:          []string{"-edgerc", edgerc, "-section", "missing", "/papi/v1/contracts"},
+			expectedCode:  1,
+			expectedError: "edgegrid-debug: ",
+		},
+		"invalid header": {
+			args:          []string{"-edgerc", edgerc, "-section", "test", "-H", "invalid", "/papi/v1/contracts"},
+			expectedCode:  1,
+			expectedError: `edgegrid-debug: invalid header "invalid"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			method, path, body, header = "", "", "", nil
+			var stdout, stderr bytes.Buffer
+			code := runCommand(test.args, &stdout, &stderr, mockServer.Client())
+			assert.Equal(t, test.expectedCode, code, stderr.String())
+			if test.expectedError != "" {
+				assert.Contains(t, stderr.String(), test.expectedError)
+				assert.Empty(t, method, "no request must be sent")
+				return
+			}
+
+			assert.Empty(t, stderr.String())
+			assert.Equal(t, test.expectedMethod, method)
+			assert.Equal(t, test.args[len(test.args)-1], path)
+			assert.Equal(t, test.expectedBody, body)
+			authorization := header.Get("Authorization")
+			assert.True(t, strings.HasPrefix(authorization, "EG1-HMAC-SHA256 client_token=akab-client-token-xxx;"), "unexpected authorization: %s", authorization)
+			for name, value := range test.expectedHeaders {
+				assert.Equal(t, value, header.Get(name))
+			}
+			for _, s := range test.expectedOutput {
+				assert.Contains(t, stdout.String(), s)
+			}
+			for _, s := range test.unexpectedOutput {
+				assert.NotContains(t, stdout.String(), s)
+			}
+		})
+	}
+}
+
+func TestRedactAuthorization(t *testing.T) {
+	tests := map[string]struct {
+		given    string
+		expected string
+	}{
+		"edgegrid header": {
+			given:    "EG1-HMAC-SHA256 client_token=akab-client-token-xxx;access_token=akab-access-token-xxx;timestamp=20210101T00:00:00+0000;nonce=abc;signature=c2lnbmF0dXJlLXZhbHVl",
+			expected: "EG1-HMAC-SHA256 client_token=akab*************-xxx;access_token=akab*************-xxx;timestamp=20210101T00:00:00+0000;nonce=abc;signature=c2ln************bHVl",
+		},
+		"short value": {
+			given:    "EG1-HMAC-SHA256 client_token=short;nonce=abc",
+			expected: "EG1-HMAC-SHA256 client_token=*****;nonce=abc",
+		},
+		"not edgegrid": {
+			given:    "secret",
+			expected: "******",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, redactAuthorization(test.given))
+		})
+	}
+}
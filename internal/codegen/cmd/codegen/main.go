@@ -0,0 +1,86 @@
+// Command codegen generates service package endpoints from an Akamai OpenAPI spec in JSON format.
+//
+// It is meant to be run from go:generate directives in pkg/<service>, e.g.:
+//
+//	//go:generate go run github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/internal/codegen/cmd/codegen -spec ../../specs/appsec.json -package appsec -client appsec -receiver p -interface ReputationProfile -ops get-reputation-profiles -out reputation_profile.gen.go -mock-out reputation_profile_mocks.gen.go
+//
+// With -mock-out, methods of the package Mock implementing the generated interface are written to a second file,
+// so that mocks stay in sync with the interface.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/internal/codegen"
+)
+
+func main() {
+	var (
+		specPath  = flag.String("spec", "", "path to the OpenAPI spec (JSON)")
+		out       = flag.String("out", "", "output file; stdout if empty")
+		pkg       = flag.String("package", os.Getenv("GOPACKAGE"), "target package name")
+		client    = flag.String("client", "", "name of the unexported client struct")
+		receiver  = flag.String("receiver", "", "receiver name used on the client struct")
+		iface     = flag.String("interface", "", "name of the generated interface")
+		operation = flag.String("ops", "", "comma separated list of operationIds to generate; all if empty")
+		mockOut   = flag.String("mock-out", "", "output file of the Mock methods implementing the interface; not generated if empty")
+	)
+	flag.Parse()
+
+	if err := run(*specPath, *out, *mockOut, codegen.Config{
+		Package:    *pkg,
+		Interface:  *iface,
+		Client:     *client,
+		Receiver:   *receiver,
+		Operations: splitList(*operation),
+		Source:     filepath.Base(*specPath),
+	}); err != nil {
+		fmt.Fprintf(os.Stderr, "codegen: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(specPath, out, mockOut string, cfg codegen.Config) error {
+	f, err := os.Open(specPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	spec, err := codegen.Load(f)
+	if err != nil {
+		return err
+	}
+	src, err := codegen.Generate(spec, cfg)
+	if err != nil {
+		return err
+	}
+	if mockOut != "" {
+		mockSrc, err := codegen.GenerateMock(spec, cfg)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(mockOut, mockSrc, 0644); err != nil {
+			return err
+		}
+	}
+	if out == "" {
+		_, err = os.Stdout.Write(src)
+		return err
+	}
+	return os.WriteFile(out, src, 0644)
+}
+
+func splitList(s string) []string {
+	var result []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
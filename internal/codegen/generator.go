@@ -0,0 +1,536 @@
+// Package codegen generates endpoint code for the pkg service packages from Akamai OpenAPI specs.
+//
+// The generated code follows the layout of the hand-written endpoints: a sub-interface with one method
+// per operation, request structs with Validate methods, response structs, per-operation sentinel errors
+// and method implementations built on top of session.Session. The target package is expected to
+// declare ErrStructValidation and an Error(*http.Response) error method on its client, as every
+// service package does.
+package codegen
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+type (
+	// Config controls what is generated and into which package
+	Config struct {
+		// Package is the name of the target Go package, e.g. "appsec"
+		Package string
+		// Interface is the name of the generated sub-interface, e.g. "ReputationProfile"
+		Interface string
+		// Client is the name of the unexported client struct implementing the interface, e.g. "appsec"
+		Client string
+		// Receiver is the receiver name used on the client struct, e.g. "p"
+		Receiver string
+		// Operations restricts generation to the given operationIds; all operations are generated if empty
+		Operations []string
+		// Source is recorded in the generated file header
+		Source string
+	}
+
+	generator struct {
+		spec    *Spec
+		cfg     Config
+		imports map[string]bool
+		decls   []string
+		named   map[string]bool
+	}
+
+	endpoint struct {
+		name        string
+		doc         string
+		seeURL      string
+		method      string
+		path        string
+		pathParams  []param
+		queryParams []param
+		body        string
+		request     string
+		response    string
+		status      int
+	}
+
+	param struct {
+		field    string
+		name     string
+		typ      string
+		required bool
+	}
+)
+
+var (
+	// ErrNoOperations is returned when the spec does not contain any of the requested operations
+	ErrNoOperations = errors.New("no operations to generate")
+
+	pathParamRegexp = regexp.MustCompile(`\{([^}]+)\}`)
+)
+
+// Generate renders gofmt-ed Go source for operations in spec
+func Generate(spec *Spec, cfg Config) ([]byte, error) {
+	if cfg.Package == "" || cfg.Interface == "" || cfg.Client == "" {
+		return nil, errors.New("package, interface and client are required")
+	}
+	if cfg.Receiver == "" {
+		cfg.Receiver = cfg.Client[:1]
+	}
+
+	g := newGenerator(spec, cfg)
+	endpoints, err := g.endpoints()
+	if err != nil {
+		return nil, err
+	}
+
+	return formatSource(g.render(endpoints))
+}
+
+func newGenerator(spec *Spec, cfg Config) *generator {
+	return &generator{
+		spec:    spec,
+		cfg:     cfg,
+		imports: map[string]bool{"context": true, "fmt": true, "net/http": true},
+		named:   make(map[string]bool),
+	}
+}
+
+// endpoints returns the endpoints of the operations selected by the config
+func (g *generator) endpoints() ([]endpoint, error) {
+	wanted := make(map[string]bool, len(g.cfg.Operations))
+	for _, id := range g.cfg.Operations {
+		wanted[id] = true
+	}
+
+	var endpoints []endpoint
+	for _, ref := range g.spec.operations() {
+		if len(wanted) > 0 && !wanted[ref.op.OperationID] {
+			continue
+		}
+		e, err := g.endpoint(ref)
+		if err != nil {
+			return nil, fmt.Errorf("%s %s: %w", strings.ToUpper(ref.method), ref.path, err)
+		}
+		endpoints = append(endpoints, e)
+	}
+	if len(endpoints) == 0 {
+		return nil, ErrNoOperations
+	}
+	return endpoints, nil
+}
+
+func formatSource(src []byte) ([]byte, error) {
+	formatted, err := format.Source(src)
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w\n%s", err, src)
+	}
+	return formatted, nil
+}
+
+func (g *generator) endpoint(ref operationRef) (endpoint, error) {
+	op := ref.op
+	id := op.OperationID
+	if id == "" {
+		id = ref.method + " " + ref.path
+	}
+	e := endpoint{
+		name:   goName(id),
+		method: ref.method,
+		path:   g.spec.basePath() + ref.path,
+	}
+	e.doc = thirdPerson(op.Summary)
+	if e.doc == "" {
+		e.doc = "calls the " + id + " operation"
+	}
+	if op.ExternalDocs != nil {
+		e.seeURL = op.ExternalDocs.URL
+	}
+
+	params := append(append([]*Parameter{}, ref.shared...), op.Parameters...)
+	for _, p := range params {
+		p, err := g.spec.resolveParameter(p)
+		if err != nil {
+			return e, err
+		}
+		pp := param{field: goName(p.Name), name: p.Name, typ: g.scalarType(p.Schema), required: p.Required}
+		switch p.In {
+		case "path":
+			pp.required = true
+			e.pathParams = append(e.pathParams, pp)
+		case "query":
+			e.queryParams = append(e.queryParams, pp)
+		}
+	}
+
+	if op.RequestBody != nil {
+		if schema := jsonSchema(op.RequestBody.Content); schema != nil {
+			e.body = g.goType(schema, e.name+"RequestBody", true)
+		}
+	}
+	if len(e.pathParams) > 0 || len(e.queryParams) > 0 || e.body != "" {
+		e.request = e.name + "Request"
+	}
+
+	for _, status := range []int{http.StatusOK, http.StatusCreated, http.StatusAccepted, http.StatusNoContent} {
+		resp, ok := op.Responses[fmt.Sprint(status)]
+		if !ok {
+			continue
+		}
+		e.status = status
+		if schema := jsonSchema(resp.Content); schema != nil {
+			e.response = strings.TrimPrefix(g.goType(schema, e.name+"Response", true), "*")
+		}
+		break
+	}
+	if e.status == 0 {
+		return e, errors.New("no successful response defined")
+	}
+
+	return e, nil
+}
+
+func jsonSchema(content map[string]MediaType) *Schema {
+	var types []string
+	for t := range content {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	for _, t := range types {
+		if strings.Contains(t, "json") {
+			return content[t].Schema
+		}
+	}
+	return nil
+}
+
+func (g *generator) scalarType(s *Schema) string {
+	if s == nil {
+		return "string"
+	}
+	switch s.Type {
+	case "integer":
+		if s.Format == "int32" {
+			return "int"
+		}
+		return "int64"
+	case "number":
+		return "float64"
+	case "boolean":
+		return "bool"
+	}
+	return "string"
+}
+
+// goType returns the Go type for a schema, declaring named struct types for objects along the way
+func (g *generator) goType(s *Schema, hint string, required bool) string {
+	if s == nil {
+		return "interface{}"
+	}
+	if s.Ref != "" {
+		name := refName(s.Ref)
+		target := g.spec.Components.Schemas[name]
+		typ := g.declare(goName(name), target)
+		if target != nil && target.Type == "object" && len(target.Properties) > 0 && !required {
+			return "*" + typ
+		}
+		return typ
+	}
+	switch s.Type {
+	case "array":
+		return "[]" + strings.TrimPrefix(g.goType(s.Items, singular(hint), true), "*")
+	case "object":
+		if len(s.Properties) == 0 {
+			return "map[string]interface{}"
+		}
+		typ := g.declare(hint, s)
+		if !required {
+			return "*" + typ
+		}
+		return typ
+	case "":
+		return "interface{}"
+	}
+	return g.scalarType(s)
+}
+
+// declare adds a named type for s once and returns its name
+func (g *generator) declare(name string, s *Schema) string {
+	if g.named[name] {
+		return name
+	}
+	g.named[name] = true
+
+	var b strings.Builder
+	if s == nil || s.Type != "object" || len(s.Properties) == 0 {
+		underlying := "map[string]interface{}"
+		if s != nil && s.Type != "object" {
+			underlying = strings.TrimPrefix(g.goType(&Schema{Type: s.Type, Format: s.Format, Items: s.Items}, name, true), "*")
+		}
+		fmt.Fprintf(&b, "// %s is generated from the %s schema\n%s %s\n", name, name, name, underlying)
+		g.decls = append(g.decls, b.String())
+		return name
+	}
+
+	required := make(map[string]bool, len(s.Required))
+	for _, r := range s.Required {
+		required[r] = true
+	}
+	var props []string
+	for p := range s.Properties {
+		props = append(props, p)
+	}
+	sort.Strings(props)
+
+	// reserve a slot so that nested types are declared after their parent
+	idx := len(g.decls)
+	g.decls = append(g.decls, "")
+
+	fmt.Fprintf(&b, "// %s is generated from the %s schema\n%s struct {\n", name, name, name)
+	for _, p := range props {
+		field := goName(p)
+		typ := g.goType(s.Properties[p], name+field, required[p])
+		tag := p
+		if !required[p] {
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(&b, "%s %s `json:\"%s\"`\n", field, typ, tag)
+	}
+	b.WriteString("}\n")
+	g.decls[idx] = b.String()
+	return name
+}
+
+func (g *generator) render(endpoints []endpoint) []byte {
+	var body bytes.Buffer
+	w := func(format string, args ...interface{}) { fmt.Fprintf(&body, format, args...) }
+
+	// interface and request types
+	w("type (\n")
+	w("// %s contains operations generated from the OpenAPI spec\n%s interface {\n", g.cfg.Interface, g.cfg.Interface)
+	for i, e := range endpoints {
+		if i > 0 {
+			w("\n")
+		}
+		w("// %s %s\n", e.name, e.doc)
+		if e.seeURL != "" {
+			w("//\n// See: %s\n", e.seeURL)
+		}
+		w("%s(context.Context%s) %s\n", e.name, e.paramSignature(), e.resultSignature())
+	}
+	w("}\n")
+
+	for _, e := range endpoints {
+		if e.request == "" {
+			continue
+		}
+		w("\n// %s contains request parameters for %s\n%s struct {\n", e.request, e.name, e.request)
+		for _, p := range e.pathParams {
+			w("%s %s\n", p.field, p.typ)
+		}
+		for _, p := range e.queryParams {
+			tag := p.name
+			if !p.required {
+				tag += ",omitempty"
+			}
+			w("%s %s `query:\"%s\"`\n", p.field, p.typ, tag)
+		}
+		if e.body != "" {
+			w("Body %s\n", e.body)
+		}
+		w("}\n")
+	}
+	for _, d := range g.decls {
+		w("\n%s", d)
+	}
+	w(")\n\n")
+
+	// sentinel errors
+	w("var (\n")
+	for _, e := range endpoints {
+		w("// Err%s is returned in case an error occurs on %s operation\n", e.name, e.name)
+		w("Err%s = errors.New(%q)\n", e.name, errorText(e.name))
+	}
+	w(")\n")
+	g.imports["errors"] = true
+
+	for _, e := range endpoints {
+		if e.request != "" {
+			g.renderValidate(&body, e)
+		}
+	}
+	for _, e := range endpoints {
+		g.renderMethod(&body, e)
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by internal/codegen")
+	if g.cfg.Source != "" {
+		fmt.Fprintf(&out, " from %s", g.cfg.Source)
+	}
+	fmt.Fprintf(&out, ". DO NOT EDIT.\n\npackage %s\n\n", g.cfg.Package)
+	out.WriteString(g.importBlock())
+	out.Write(body.Bytes())
+	return out.Bytes()
+}
+
+func (g *generator) importBlock() string {
+	var std, third []string
+	for imp := range g.imports {
+		if strings.Contains(imp, ".") {
+			third = append(third, imp)
+		} else {
+			std = append(std, imp)
+		}
+	}
+	sort.Strings(std)
+	sort.Strings(third)
+
+	var b strings.Builder
+	b.WriteString("import (\n")
+	for _, imp := range std {
+		fmt.Fprintf(&b, "%q\n", imp)
+	}
+	if len(third) > 0 {
+		b.WriteString("\n")
+		for _, imp := range third {
+			if imp == "github.com/go-ozzo/ozzo-validation/v4" {
+				fmt.Fprintf(&b, "validation %q\n", imp)
+				continue
+			}
+			fmt.Fprintf(&b, "%q\n", imp)
+		}
+	}
+	b.WriteString(")\n\n")
+	return b.String()
+}
+
+func (g *generator) renderValidate(b *bytes.Buffer, e endpoint) {
+	var required []param
+	for _, p := range append(append([]param{}, e.pathParams...), e.queryParams...) {
+		if p.required && p.typ != "bool" {
+			required = append(required, p)
+		}
+	}
+
+	fmt.Fprintf(b, "\n// Validate validates %s\nfunc (r %s) Validate() error {\n", e.request, e.request)
+	if len(required) == 0 {
+		b.WriteString("return nil\n}\n")
+		return
+	}
+	g.imports["github.com/go-ozzo/ozzo-validation/v4"] = true
+	b.WriteString("return validation.Errors{\n")
+	for _, p := range required {
+		fmt.Fprintf(b, "%q: validation.Validate(r.%s, validation.Required),\n", p.field, p.field)
+	}
+	b.WriteString("}.Filter()\n}\n")
+}
+
+func (g *generator) renderMethod(b *bytes.Buffer, e endpoint) {
+	r := g.cfg.Receiver
+	errName := "Err" + e.name
+	fail := "return "
+	if e.response != "" {
+		fail = "return nil, "
+	}
+
+	var params string
+	if e.request != "" {
+		params = ", params " + e.request
+	}
+	fmt.Fprintf(b, "\nfunc (%s *%s) %s(ctx context.Context%s) %s {\n", r, g.cfg.Client, e.name, params, e.resultSignature())
+	fmt.Fprintf(b, "logger := %s.Log(ctx)\nlogger.Debug(%q)\n\n", r, e.name)
+
+	if e.request != "" {
+		fmt.Fprintf(b, "if err := params.Validate(); err != nil {\n%sfmt.Errorf(\"%%s: %%w: %%s\", %s, ErrStructValidation, err)\n}\n\n", fail, errName)
+	}
+
+	format, args := g.pathFormat(e)
+	if len(e.queryParams) == 0 {
+		fmt.Fprintf(b, "uri := %s\n", sprintf(format, args))
+	} else {
+		g.imports["github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/tools"] = true
+		fmt.Fprintf(b, "uri, err := tools.AppendQuery(%s, params)\nif err != nil {\n%sfmt.Errorf(\"%%w: failed to create request: %%s\", %s, err)\n}\n\n", sprintf(format, args), fail, errName)
+	}
+
+	fmt.Fprintf(b, "req, err := http.NewRequestWithContext(ctx, http.Method%s, uri, nil)\nif err != nil {\n%sfmt.Errorf(\"%%w: failed to create request: %%s\", %s, err)\n}\n\n",
+		strings.ToUpper(e.method[:1])+e.method[1:], fail, errName)
+
+	execArgs := "req, nil"
+	if e.response != "" {
+		fmt.Fprintf(b, "var result %s\n", e.response)
+		execArgs = "req, &result"
+	}
+	if e.body != "" {
+		execArgs += ", params.Body"
+	}
+	fmt.Fprintf(b, "resp, err := %s.Exec(%s)\nif err != nil {\n%sfmt.Errorf(\"%%w: request failed: %%s\", %s, err)\n}\n\n", r, execArgs, fail, errName)
+
+	fmt.Fprintf(b, "if resp.StatusCode != http.%s {\n%sfmt.Errorf(\"%%s: %%w\", %s, %s.Error(resp))\n}\n\n", statusConst(e.status), fail, errName, r)
+
+	if e.response != "" {
+		b.WriteString("return &result, nil\n}\n")
+	} else {
+		b.WriteString("return nil\n}\n")
+	}
+}
+
+// pathFormat converts an OpenAPI path template into a fmt format string and its arguments
+func (g *generator) pathFormat(e endpoint) (string, []string) {
+	types := make(map[string]param, len(e.pathParams))
+	for _, p := range e.pathParams {
+		types[p.name] = p
+	}
+	var args []string
+	format := pathParamRegexp.ReplaceAllStringFunc(e.path, func(m string) string {
+		p, ok := types[m[1:len(m)-1]]
+		if !ok {
+			p = param{field: goName(m[1 : len(m)-1]), typ: "string"}
+		}
+		switch p.typ {
+		case "int", "int64":
+			args = append(args, "params."+p.field)
+			return "%d"
+		}
+		g.imports["net/url"] = true
+		args = append(args, fmt.Sprintf("url.PathEscape(params.%s)", p.field))
+		return "%s"
+	})
+	return format, args
+}
+
+func sprintf(format string, args []string) string {
+	if len(args) == 0 {
+		return fmt.Sprintf("%q", format)
+	}
+	return fmt.Sprintf("fmt.Sprintf(%q, %s)", format, strings.Join(args, ", "))
+}
+
+func (e endpoint) paramSignature() string {
+	if e.request == "" {
+		return ""
+	}
+	return ", " + e.request
+}
+
+func (e endpoint) resultSignature() string {
+	if e.response == "" {
+		return "error"
+	}
+	return fmt.Sprintf("(*%s, error)", e.response)
+}
+
+func statusConst(status int) string {
+	switch status {
+	case http.StatusCreated:
+		return "StatusCreated"
+	case http.StatusAccepted:
+		return "StatusAccepted"
+	case http.StatusNoContent:
+		return "StatusNoContent"
+	}
+	return "StatusOK"
+}
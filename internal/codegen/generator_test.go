@@ -0,0 +1,161 @@
+package codegen
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func loadSpec(t *testing.T, name string) *Spec {
+	f, err := os.Open(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, f.Close())
+	}()
+	spec, err := Load(f)
+	require.NoError(t, err)
+	return spec
+}
+
+func TestGenerate(t *testing.T) {
+	tests := map[string]struct {
+		config    Config
+		golden    string
+		withError error
+	}{
+		"all operations": {
+			config: Config{
+				Package:   "appsec",
+				Interface: "ReputationProfiles",
+				Client:    "appsec",
+				Receiver:  "p",
+				Source:    "reputation.json",
+			},
+			golden: "reputation.gen.go.golden",
+		},
+		"selected operations": {
+			config: Config{
+				Package:    "appsec",
+				Interface:  "ReputationProfiles",
+				Client:     "appsec",
+				Receiver:   "p",
+				Operations: []string{"delete-reputation-profile"},
+			},
+			golden: "reputation_delete.gen.go.golden",
+		},
+		"unknown operation": {
+			config: Config{
+				Package:    "appsec",
+				Interface:  "ReputationProfiles",
+				Client:     "appsec",
+				Operations: []string{"get-something-else"},
+			},
+			withError: ErrNoOperations,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			src, err := Generate(loadSpec(t, "reputation.json"), test.config)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+
+			_, err = parser.ParseFile(token.NewFileSet(), "", src, parser.AllErrors)
+			require.NoError(t, err)
+
+			golden := filepath.Join("testdata", test.golden)
+			if *update {
+				require.NoError(t, os.WriteFile(golden, src, 0644))
+			}
+			expected, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			assert.Equal(t, string(expected), string(src))
+		})
+	}
+}
+
+func TestGenerateMock(t *testing.T) {
+	tests := map[string]struct {
+		config    Config
+		golden    string
+		withError error
+	}{
+		"all operations": {
+			config: Config{
+				Package:   "appsec",
+				Interface: "ReputationProfiles",
+				Client:    "appsec",
+				Source:    "reputation.json",
+			},
+			golden: "reputation_mocks.gen.go.golden",
+		},
+		"unknown operation": {
+			config: Config{
+				Package:    "appsec",
+				Interface:  "ReputationProfiles",
+				Client:     "appsec",
+				Operations: []string{"get-something-else"},
+			},
+			withError: ErrNoOperations,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			src, err := GenerateMock(loadSpec(t, "reputation.json"), test.config)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+
+			golden := filepath.Join("testdata", test.golden)
+			if *update {
+				require.NoError(t, os.WriteFile(golden, src, 0644))
+			}
+			expected, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			assert.Equal(t, string(expected), string(src))
+		})
+	}
+}
+
+func TestGenerateDeterministic(t *testing.T) {
+	cfg := Config{Package: "appsec", Interface: "ReputationProfiles", Client: "appsec", Receiver: "p"}
+	first, err := Generate(loadSpec(t, "reputation.json"), cfg)
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		next, err := Generate(loadSpec(t, "reputation.json"), cfg)
+		require.NoError(t, err)
+		assert.True(t, bytes.Equal(first, next))
+	}
+}
+
+func TestGoName(t *testing.T) {
+	tests := map[string]string{
+		"configId":                "ConfigID",
+		"get-reputation-profiles": "GetReputationProfiles",
+		"sharedIpHandling":        "SharedIPHandling",
+		"rate_policy":             "RatePolicy",
+		"hostnameIds":             "HostnameIDs",
+		"URLPattern":              "URLPattern",
+	}
+	for in, expected := range tests {
+		t.Run(in, func(t *testing.T) {
+			assert.Equal(t, expected, goName(in))
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package codegen
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+// GenerateMock renders gofmt-ed Go source of methods implementing the interface generated by Generate
+// for the same config on the Mock type of the target package, which every service package declares
+// in its mocks.go file on top of testify's mock.Mock
+func GenerateMock(spec *Spec, cfg Config) ([]byte, error) {
+	if cfg.Package == "" || cfg.Interface == "" {
+		return nil, errors.New("package and interface are required")
+	}
+
+	g := newGenerator(spec, cfg)
+	endpoints, err := g.endpoints()
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by internal/codegen")
+	if cfg.Source != "" {
+		fmt.Fprintf(&b, " from %s", cfg.Source)
+	}
+	fmt.Fprintf(&b, ". DO NOT EDIT.\n\n//revive:disable:exported\n\npackage %s\n\n", cfg.Package)
+	b.WriteString("import (\n\"context\"\n)\n\n")
+	fmt.Fprintf(&b, "var _ %s = &Mock{}\n", cfg.Interface)
+
+	for _, e := range endpoints {
+		called := "m.Called(ctx)"
+		params := "ctx context.Context"
+		if e.request != "" {
+			called = "m.Called(ctx, req)"
+			params += ", req " + e.request
+		}
+		fmt.Fprintf(&b, "\nfunc (m *Mock) %s(%s) %s {\n", e.name, params, e.resultSignature())
+		fmt.Fprintf(&b, "args := %s\n", called)
+		if e.response == "" {
+			b.WriteString("return args.Error(0)\n}\n")
+			continue
+		}
+		b.WriteString("if args.Get(0) == nil {\nreturn nil, args.Error(1)\n}\n")
+		fmt.Fprintf(&b, "return args.Get(0).(*%s), args.Error(1)\n}\n", e.response)
+	}
+
+	return formatSource(b.Bytes())
+}
@@ -0,0 +1,101 @@
+package codegen
+
+import (
+	"strings"
+	"unicode"
+)
+
+// initialisms are upper-cased in Go identifiers, following the naming used across the pkg tree
+var initialisms = map[string]bool{
+	"ACL": true, "API": true, "CP": true, "DNS": true, "HTTP": true, "HTTPS": true, "ICP": true,
+	"ID": true, "IP": true, "JSON": true, "SLA": true, "SSL": true, "TLS": true,
+	"TTL": true, "URI": true, "URL": true, "UUID": true, "WAF": true,
+}
+
+// words splits identifiers such as "configId", "get-reputation-profiles" or "rate_policy" into lower-case words
+func words(s string) []string {
+	var (
+		result []string
+		cur    []rune
+	)
+	flush := func() {
+		if len(cur) > 0 {
+			result = append(result, strings.ToLower(string(cur)))
+			cur = cur[:0]
+		}
+	}
+	runes := []rune(s)
+	for i, r := range runes {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+			continue
+		case unicode.IsUpper(r) && len(cur) > 0:
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				flush()
+			}
+		}
+		cur = append(cur, r)
+	}
+	flush()
+	return result
+}
+
+// goName converts an OpenAPI identifier into an exported Go identifier, e.g. "configId" -> "ConfigID"
+func goName(s string) string {
+	var b strings.Builder
+	for _, w := range words(s) {
+		if up := strings.ToUpper(w); initialisms[up] {
+			b.WriteString(up)
+			continue
+		}
+		if w == "ids" {
+			b.WriteString("IDs")
+			continue
+		}
+		b.WriteString(strings.ToUpper(w[:1]) + w[1:])
+	}
+	return b.String()
+}
+
+// errorText converts an operation name into the text of its sentinel error, e.g. "GetConfig" -> "get config"
+func errorText(name string) string {
+	return strings.Join(words(name), " ")
+}
+
+// singular strips a plural suffix from a type name used for array items
+func singular(s string) string {
+	switch {
+	case strings.HasSuffix(s, "ies"):
+		return strings.TrimSuffix(s, "ies") + "y"
+	case strings.HasSuffix(s, "ses"):
+		return strings.TrimSuffix(s, "es")
+	case strings.HasSuffix(s, "s") && !strings.HasSuffix(s, "ss"):
+		return strings.TrimSuffix(s, "s")
+	}
+	return s + "Item"
+}
+
+// thirdPerson turns an imperative summary ("List configurations") into a doc comment predicate ("lists configurations")
+func thirdPerson(summary string) string {
+	summary = strings.TrimSuffix(strings.TrimSpace(summary), ".")
+	if summary == "" {
+		return ""
+	}
+	parts := strings.SplitN(summary, " ", 2)
+	verb := strings.ToLower(parts[0])
+	switch {
+	case strings.HasSuffix(verb, "s"), strings.HasSuffix(verb, "sh"), strings.HasSuffix(verb, "ch"), strings.HasSuffix(verb, "x"):
+		verb += "es"
+	case strings.HasSuffix(verb, "y") && len(verb) > 1 && !strings.ContainsRune("aeiou", rune(verb[len(verb)-2])):
+		verb = verb[:len(verb)-1] + "ies"
+	default:
+		verb += "s"
+	}
+	if len(parts) == 1 {
+		return verb
+	}
+	return verb + " " + parts[1]
+}
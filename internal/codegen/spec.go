@@ -0,0 +1,194 @@
+package codegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+type (
+	// Spec is the subset of an OpenAPI 3 document used by the generator
+	Spec struct {
+		Servers    []Server            `json:"servers"`
+		Paths      map[string]PathItem `json:"paths"`
+		Components Components          `json:"components"`
+	}
+
+	// Server describes an API server; only the path part of its URL is used as the endpoint prefix
+	Server struct {
+		URL string `json:"url"`
+	}
+
+	// Components holds reusable schemas and parameters
+	Components struct {
+		Schemas    map[string]*Schema    `json:"schemas"`
+		Parameters map[string]*Parameter `json:"parameters"`
+	}
+
+	// PathItem maps lower-case HTTP methods to operations, with parameters shared by all of them
+	PathItem struct {
+		Parameters []*Parameter
+		Operations map[string]*Operation
+	}
+
+	// Operation describes a single API operation
+	Operation struct {
+		OperationID  string              `json:"operationId"`
+		Summary      string              `json:"summary"`
+		Description  string              `json:"description"`
+		ExternalDocs *ExternalDocs       `json:"externalDocs"`
+		Parameters   []*Parameter        `json:"parameters"`
+		RequestBody  *RequestBody        `json:"requestBody"`
+		Responses    map[string]Response `json:"responses"`
+	}
+
+	// ExternalDocs points at the techdocs page of an operation
+	ExternalDocs struct {
+		URL string `json:"url"`
+	}
+
+	// Parameter describes a path, query or header parameter
+	Parameter struct {
+		Ref         string  `json:"$ref"`
+		Name        string  `json:"name"`
+		In          string  `json:"in"`
+		Description string  `json:"description"`
+		Required    bool    `json:"required"`
+		Schema      *Schema `json:"schema"`
+	}
+
+	// RequestBody describes the body of an operation
+	RequestBody struct {
+		Required bool                 `json:"required"`
+		Content  map[string]MediaType `json:"content"`
+	}
+
+	// Response describes a single operation response
+	Response struct {
+		Description string               `json:"description"`
+		Content     map[string]MediaType `json:"content"`
+	}
+
+	// MediaType holds the schema for a given content type
+	MediaType struct {
+		Schema *Schema `json:"schema"`
+	}
+
+	// Schema is the subset of JSON schema supported by the generator
+	Schema struct {
+		Ref         string             `json:"$ref"`
+		Type        string             `json:"type"`
+		Format      string             `json:"format"`
+		Description string             `json:"description"`
+		Properties  map[string]*Schema `json:"properties"`
+		Items       *Schema            `json:"items"`
+		Required    []string           `json:"required"`
+		Enum        []interface{}      `json:"enum"`
+	}
+)
+
+var methods = []string{"get", "post", "put", "patch", "delete"}
+
+// Load reads an OpenAPI 3 document in JSON format
+func Load(r io.Reader) (*Spec, error) {
+	var spec Spec
+	if err := json.NewDecoder(r).Decode(&spec); err != nil {
+		return nil, fmt.Errorf("decoding spec: %w", err)
+	}
+	return &spec, nil
+}
+
+// UnmarshalJSON splits a path item into shared parameters and per-method operations
+func (p *PathItem) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	p.Operations = make(map[string]*Operation)
+	for key, val := range raw {
+		switch {
+		case key == "parameters":
+			if err := json.Unmarshal(val, &p.Parameters); err != nil {
+				return fmt.Errorf("parameters: %w", err)
+			}
+		case isMethod(key):
+			var op Operation
+			if err := json.Unmarshal(val, &op); err != nil {
+				return fmt.Errorf("%s: %w", key, err)
+			}
+			p.Operations[key] = &op
+		}
+	}
+	return nil
+}
+
+func isMethod(s string) bool {
+	for _, m := range methods {
+		if s == m {
+			return true
+		}
+	}
+	return false
+}
+
+// basePath returns the path part of the first server URL, e.g. "/appsec/v1"
+func (s *Spec) basePath() string {
+	if len(s.Servers) == 0 {
+		return ""
+	}
+	raw := s.Servers[0].URL
+	// server URLs are usually templated, e.g. https://{hostname}/appsec/v1
+	raw = strings.NewReplacer("{", "", "}", "").Replace(raw)
+	u, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSuffix(u.Path, "/")
+}
+
+// resolveParameter follows a parameter $ref into components
+func (s *Spec) resolveParameter(p *Parameter) (*Parameter, error) {
+	if p.Ref == "" {
+		return p, nil
+	}
+	name := refName(p.Ref)
+	resolved, ok := s.Components.Parameters[name]
+	if !ok {
+		return nil, fmt.Errorf("unresolved parameter reference %q", p.Ref)
+	}
+	return resolved, nil
+}
+
+// operations returns all operations sorted by path and method for deterministic output
+func (s *Spec) operations() []operationRef {
+	var paths []string
+	for p := range s.Paths {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	var ops []operationRef
+	for _, path := range paths {
+		item := s.Paths[path]
+		for _, m := range methods {
+			if op, ok := item.Operations[m]; ok {
+				ops = append(ops, operationRef{path: path, method: m, shared: item.Parameters, op: op})
+			}
+		}
+	}
+	return ops
+}
+
+type operationRef struct {
+	path   string
+	method string
+	shared []*Parameter
+	op     *Operation
+}
+
+func refName(ref string) string {
+	return ref[strings.LastIndex(ref, "/")+1:]
+}
@@ -0,0 +1,151 @@
+// Package akamai provides a single entry point to all Akamai API clients of the SDK.
+// It creates service clients on first use from a shared session, so applications
+// don't need to construct every service package client themselves.
+package akamai
+
+import (
+	"sync"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/apikey"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/appsec"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/botman"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/chinacdn"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/cloudlets"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/cps"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/datastream"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/dns"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/edgeworkers"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/gtm"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/hapi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/iam"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/imaging"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/networklists"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/papi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/session"
+)
+
+type (
+	// Client exposes the clients of all Akamai APIs supported by the SDK, e.g.:
+	//
+	//	client := akamai.New(sess)
+	//	configs, err := client.AppSec().GetConfigurations(ctx, appsec.GetConfigurationsRequest{})
+	//
+	// Service clients are created with default options on first use and reused afterwards.
+	// Client is safe for concurrent use.
+	Client struct {
+		sess session.Session
+
+		apiKey       lazy[apikey.APIKey]
+		appSec       lazy[appsec.APPSEC]
+		botMan       lazy[botman.BotMan]
+		chinaCDN     lazy[chinacdn.ChinaCDN]
+		cloudlets    lazy[cloudlets.Cloudlets]
+		cps          lazy[cps.CPS]
+		dataStream   lazy[datastream.DS]
+		dns          lazy[dns.DNS]
+		edgeWorkers  lazy[edgeworkers.Edgeworkers]
+		gtm          lazy[gtm.GTM]
+		hapi         lazy[hapi.HAPI]
+		iam          lazy[iam.IAM]
+		imaging      lazy[imaging.Imaging]
+		networkLists lazy[networklists.NTWRKLISTS]
+		papi         lazy[papi.PAPI]
+	}
+
+	lazy[T any] struct {
+		once   sync.Once
+		client T
+	}
+)
+
+// New returns a Client using given session for all service clients
+func New(sess session.Session) *Client {
+	return &Client{sess: sess}
+}
+
+// Session returns the session shared by all service clients
+func (c *Client) Session() session.Session {
+	return c.sess
+}
+
+// APIKey returns the API Keys and Traffic Management client
+func (c *Client) APIKey() apikey.APIKey {
+	return c.apiKey.get(func() apikey.APIKey { return apikey.Client(c.sess) })
+}
+
+// AppSec returns the Application Security client
+func (c *Client) AppSec() appsec.APPSEC {
+	return c.appSec.get(func() appsec.APPSEC { return appsec.Client(c.sess) })
+}
+
+// BotMan returns the Bot Manager client
+func (c *Client) BotMan() botman.BotMan {
+	return c.botMan.get(func() botman.BotMan { return botman.Client(c.sess) })
+}
+
+// ChinaCDN returns the China CDN client
+func (c *Client) ChinaCDN() chinacdn.ChinaCDN {
+	return c.chinaCDN.get(func() chinacdn.ChinaCDN { return chinacdn.Client(c.sess) })
+}
+
+// Cloudlets returns the Cloudlets client
+func (c *Client) Cloudlets() cloudlets.Cloudlets {
+	return c.cloudlets.get(func() cloudlets.Cloudlets { return cloudlets.Client(c.sess) })
+}
+
+// CPS returns the Certificate Provisioning System client
+func (c *Client) CPS() cps.CPS {
+	return c.cps.get(func() cps.CPS { return cps.Client(c.sess) })
+}
+
+// DataStream returns the DataStream client
+func (c *Client) DataStream() datastream.DS {
+	return c.dataStream.get(func() datastream.DS { return datastream.Client(c.sess) })
+}
+
+// DNS returns the Edge DNS client
+func (c *Client) DNS() dns.DNS {
+	return c.dns.get(func() dns.DNS { return dns.Client(c.sess) })
+}
+
+// EdgeWorkers returns the EdgeWorkers client
+func (c *Client) EdgeWorkers() edgeworkers.Edgeworkers {
+	return c.edgeWorkers.get(func() edgeworkers.Edgeworkers { return edgeworkers.Client(c.sess) })
+}
+
+// GTM returns the Global Traffic Management client
+func (c *Client) GTM() gtm.GTM {
+	return c.gtm.get(func() gtm.GTM { return gtm.Client(c.sess) })
+}
+
+// HAPI returns the Edge Hostnames client
+func (c *Client) HAPI() hapi.HAPI {
+	return c.hapi.get(func() hapi.HAPI { return hapi.Client(c.sess) })
+}
+
+// IAM returns the Identity and Access Management client
+func (c *Client) IAM() iam.IAM {
+	return c.iam.get(func() iam.IAM { return iam.Client(c.sess) })
+}
+
+// Imaging returns the Image and Video Manager client
+func (c *Client) Imaging() imaging.Imaging {
+	return c.imaging.get(func() imaging.Imaging { return imaging.Client(c.sess) })
+}
+
+// NetworkLists returns the Network Lists client
+func (c *Client) NetworkLists() networklists.NTWRKLISTS {
+	return c.networkLists.get(func() networklists.NTWRKLISTS { return networklists.Client(c.sess) })
+}
+
+// PAPI returns the Property Manager client
+func (c *Client) PAPI() papi.PAPI {
+	return c.papi.get(func() papi.PAPI { return papi.Client(c.sess) })
+}
+
+func (l *lazy[T]) get(create func() T) T {
+	l.once.Do(func() {
+		l.client = create()
+	})
+	return l.client
+}
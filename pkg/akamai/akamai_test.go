@@ -0,0 +1,81 @@
+package akamai
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/edgegridtest"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	sess, err := session.New()
+	require.NoError(t, err)
+	client := New(sess)
+
+	assert.Equal(t, sess, client.Session())
+
+	accessors := map[string]func() interface{}{
+		"APIKey":       func() interface{} { return client.APIKey() },
+		"AppSec":       func() interface{} { return client.AppSec() },
+		"BotMan":       func() interface{} { return client.BotMan() },
+		"ChinaCDN":     func() interface{} { return client.ChinaCDN() },
+		"Cloudlets":    func() interface{} { return client.Cloudlets() },
+		"CPS":          func() interface{} { return client.CPS() },
+		"DataStream":   func() interface{} { return client.DataStream() },
+		"DNS":          func() interface{} { return client.DNS() },
+		"EdgeWorkers":  func() interface{} { return client.EdgeWorkers() },
+		"GTM":          func() interface{} { return client.GTM() },
+		"HAPI":         func() interface{} { return client.HAPI() },
+		"IAM":          func() interface{} { return client.IAM() },
+		"Imaging":      func() interface{} { return client.Imaging() },
+		"NetworkLists": func() interface{} { return client.NetworkLists() },
+		"PAPI":         func() interface{} { return client.PAPI() },
+	}
+	for name, get := range accessors {
+		t.Run(name, func(t *testing.T) {
+			first := get()
+			require.NotNil(t, first)
+			assert.Same(t, first, get())
+		})
+	}
+}
+
+func TestClientConcurrentAccess(t *testing.T) {
+	sess, err := session.New()
+	require.NoError(t, err)
+	client := New(sess)
+
+	clients := make([]interface{}, 10)
+	var wg sync.WaitGroup
+	for i := range clients {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			clients[i] = client.PAPI()
+		}(i)
+	}
+	wg.Wait()
+	for _, c := range clients {
+		assert.Same(t, clients[0], c)
+	}
+}
+
+func TestClientRequests(t *testing.T) {
+	srv := edgegridtest.NewServer(edgegridtest.All())
+	defer srv.Close()
+	sess, err := srv.Session()
+	require.NoError(t, err)
+	client := New(sess)
+
+	contracts, err := client.PAPI().GetContracts(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, contracts.Contracts.Items, 1)
+
+	zones, err := client.DNS().ListZones(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, zones.Zones, 2)
+}
@@ -0,0 +1,100 @@
+// Package apikey provides access to the Akamai API Keys and Traffic Management APIs
+//
+// See: https://techdocs.akamai.com/key-traffic-mgmt/reference/api
+package apikey
+
+import (
+	"errors"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/session"
+	"github.com/apex/log"
+)
+
+var (
+	// ErrStructValidation is returned when given struct validation failed
+	ErrStructValidation = errors.New("struct validation")
+)
+
+type (
+	// APIKey is the api key and traffic management api interface
+	APIKey interface {
+		Collections
+		Counters
+		Keys
+		Quota
+	}
+
+	apikey struct {
+		session.Session
+		options session.ClientOptions
+	}
+
+	// Option defines an APIKey option
+	Option func(*apikey)
+
+	// ClientFunc is an apikey client new method, this can be used for mocking
+	ClientFunc func(sess session.Session, opts ...Option) APIKey
+)
+
+// Client returns a new apikey Client instance with the specified controller
+func Client(sess session.Session, opts ...Option) APIKey {
+	a := &apikey{
+		Session: sess,
+	}
+
+	for _, opt := range opts {
+		opt(a)
+	}
+	a.Session = a.options.Apply(a.Session)
+	return a
+}
+
+// WithLogger sets the logger used by the API Keys client instead of the session logger
+func WithLogger(l log.Interface) Option {
+	return func(a *apikey) {
+		a.options.Logger = l
+	}
+}
+
+// WithRetries sets how many times idempotent API Keys requests are retried after transient failures
+func WithRetries(retries int) Option {
+	return func(a *apikey) {
+		a.options.Retries = retries
+	}
+}
+
+// WithRetryClassifier sets the callback deciding whether failed API Keys requests are retried, see session.RetryClassifier
+func WithRetryClassifier(classifier session.RetryClassifier) Option {
+	return func(a *apikey) {
+		a.options.RetryClassifier = classifier
+	}
+}
+
+// WithBackoff sets the strategy computing delays between retries of failed API Keys requests, see session.Backoff
+func WithBackoff(backoff session.Backoff) Option {
+	return func(a *apikey) {
+		a.options.Backoff = backoff
+	}
+}
+
+// WithIdempotencyKeys adds an idempotency key to API Keys POST and PATCH requests, kept when they are
+// retried; it does not make them retried by itself, see session.WithIdempotencyKeys
+func WithIdempotencyKeys(enabled bool) Option {
+	return func(a *apikey) {
+		a.options.IdempotencyKeys = enabled
+	}
+}
+
+// WithBaseURL overrides the scheme and host API Keys requests are sent to
+func WithBaseURL(baseURL string) Option {
+	return func(a *apikey) {
+		a.options.BaseURL = baseURL
+	}
+}
+
+// WithAccountSwitchKey makes API Keys requests act on the account with given switch key
+func WithAccountSwitchKey(key string) Option {
+	return func(a *apikey) {
+		a.options.AccountSwitchKey = key
+	}
+}
@@ -0,0 +1,333 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type (
+	// Collections contains operations available on key collection resource
+	Collections interface {
+		// ListCollections lists all key collections available to the user
+		//
+		// See: https://techdocs.akamai.com/key-traffic-mgmt/reference/get-collections
+		ListCollections(context.Context, ListCollectionsRequest) (ListCollectionsResponse, error)
+
+		// GetCollection returns details of a single key collection
+		//
+		// See: https://techdocs.akamai.com/key-traffic-mgmt/reference/get-collection
+		GetCollection(context.Context, GetCollectionRequest) (*Collection, error)
+
+		// CreateCollection creates a new key collection
+		//
+		// See: https://techdocs.akamai.com/key-traffic-mgmt/reference/post-collections
+		CreateCollection(context.Context, CreateCollectionRequest) (*Collection, error)
+
+		// UpdateCollection updates the name and description of a key collection
+		//
+		// See: https://techdocs.akamai.com/key-traffic-mgmt/reference/put-collection
+		UpdateCollection(context.Context, UpdateCollectionRequest) (*Collection, error)
+
+		// DeleteCollection deletes a key collection
+		//
+		// See: https://techdocs.akamai.com/key-traffic-mgmt/reference/delete-collection
+		DeleteCollection(context.Context, DeleteCollectionRequest) error
+
+		// UpdateCollectionACL sets the list of API endpoints, resources and methods the keys of a collection can access
+		//
+		// See: https://techdocs.akamai.com/key-traffic-mgmt/reference/put-collection-acl
+		UpdateCollectionACL(context.Context, UpdateCollectionACLRequest) (*Collection, error)
+	}
+
+	// Collection represents a key collection
+	Collection struct {
+		CollectionID          int64          `json:"collectionId"`
+		CollectionName        string         `json:"collectionName"`
+		CollectionDescription string         `json:"collectionDescription,omitempty"`
+		ContractID            string         `json:"contractId"`
+		GroupID               int64          `json:"groupId"`
+		GrantedACL            []string       `json:"grantedACL,omitempty"`
+		DirtyACL              []string       `json:"dirtyACL,omitempty"`
+		Quota                 *QuotaSettings `json:"quota,omitempty"`
+		KeyCount              int64          `json:"keyCount"`
+		DirtyKeyCount         int64          `json:"dirtyKeyCount"`
+		CreatedBy             string         `json:"createdBy,omitempty"`
+		CreateDate            string         `json:"createDate,omitempty"`
+		UpdatedBy             string         `json:"updatedBy,omitempty"`
+		UpdateDate            string         `json:"updateDate,omitempty"`
+	}
+
+	// ListCollectionsRequest contains request parameters for ListCollections
+	ListCollectionsRequest struct {
+		ContractID string
+		GroupID    int64
+	}
+
+	// ListCollectionsResponse represents a response from ListCollections
+	ListCollectionsResponse []Collection
+
+	// GetCollectionRequest contains request parameters for GetCollection
+	GetCollectionRequest struct {
+		CollectionID int64
+	}
+
+	// CreateCollectionRequest contains request parameters for CreateCollection
+	CreateCollectionRequest struct {
+		ContractID            string `json:"contractId"`
+		GroupID               int64  `json:"groupId"`
+		CollectionName        string `json:"collectionName"`
+		CollectionDescription string `json:"collectionDescription,omitempty"`
+	}
+
+	// UpdateCollectionRequest contains request parameters for UpdateCollection
+	UpdateCollectionRequest struct {
+		CollectionID int64
+		Body         UpdateCollectionRequestBody
+	}
+
+	// UpdateCollectionRequestBody contains request body parameters for UpdateCollection
+	UpdateCollectionRequestBody struct {
+		CollectionName        string `json:"collectionName"`
+		CollectionDescription string `json:"collectionDescription,omitempty"`
+	}
+
+	// DeleteCollectionRequest contains request parameters for DeleteCollection
+	DeleteCollectionRequest struct {
+		CollectionID int64
+	}
+
+	// UpdateCollectionACLRequest contains request parameters for UpdateCollectionACL
+	UpdateCollectionACLRequest struct {
+		CollectionID int64
+		ACL          []string
+	}
+)
+
+var (
+	// ErrListCollections is returned in case an error occurs on ListCollections operation
+	ErrListCollections = errors.New("list collections")
+	// ErrGetCollection is returned in case an error occurs on GetCollection operation
+	ErrGetCollection = errors.New("get collection")
+	// ErrCreateCollection is returned in case an error occurs on CreateCollection operation
+	ErrCreateCollection = errors.New("create collection")
+	// ErrUpdateCollection is returned in case an error occurs on UpdateCollection operation
+	ErrUpdateCollection = errors.New("update collection")
+	// ErrDeleteCollection is returned in case an error occurs on DeleteCollection operation
+	ErrDeleteCollection = errors.New("delete collection")
+	// ErrUpdateCollectionACL is returned in case an error occurs on UpdateCollectionACL operation
+	ErrUpdateCollectionACL = errors.New("update collection acl")
+)
+
+// Validate validates GetCollectionRequest
+func (r GetCollectionRequest) Validate() error {
+	return validation.Errors{
+		"CollectionID": validation.Validate(r.CollectionID, validation.Required),
+	}.Filter()
+}
+
+// Validate validates CreateCollectionRequest
+func (r CreateCollectionRequest) Validate() error {
+	return validation.Errors{
+		"ContractID":     validation.Validate(r.ContractID, validation.Required),
+		"GroupID":        validation.Validate(r.GroupID, validation.Required),
+		"CollectionName": validation.Validate(r.CollectionName, validation.Required),
+	}.Filter()
+}
+
+// Validate validates UpdateCollectionRequest
+func (r UpdateCollectionRequest) Validate() error {
+	return validation.Errors{
+		"CollectionID":        validation.Validate(r.CollectionID, validation.Required),
+		"Body.CollectionName": validation.Validate(r.Body.CollectionName, validation.Required),
+	}.Filter()
+}
+
+// Validate validates DeleteCollectionRequest
+func (r DeleteCollectionRequest) Validate() error {
+	return validation.Errors{
+		"CollectionID": validation.Validate(r.CollectionID, validation.Required),
+	}.Filter()
+}
+
+// Validate validates UpdateCollectionACLRequest
+func (r UpdateCollectionACLRequest) Validate() error {
+	return validation.Errors{
+		"CollectionID": validation.Validate(r.CollectionID, validation.Required),
+		"ACL":          validation.Validate(r.ACL, validation.NotNil),
+	}.Filter()
+}
+
+func (a *apikey) ListCollections(ctx context.Context, params ListCollectionsRequest) (ListCollectionsResponse, error) {
+	logger := a.Log(ctx)
+	logger.Debug("ListCollections")
+
+	uri, err := url.Parse("/apikey-manager-api/v1/collections")
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to parse url: %s", ErrListCollections, err)
+	}
+
+	q := uri.Query()
+	if params.ContractID != "" {
+		q.Add("contractId", params.ContractID)
+	}
+	if params.GroupID != 0 {
+		q.Add("groupId", strconv.FormatInt(params.GroupID, 10))
+	}
+	uri.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrListCollections, err)
+	}
+
+	var result ListCollectionsResponse
+	resp, err := a.Exec(req, &result)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrListCollections, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrListCollections, a.Error(resp))
+	}
+
+	return result, nil
+}
+
+func (a *apikey) GetCollection(ctx context.Context, params GetCollectionRequest) (*Collection, error) {
+	logger := a.Log(ctx)
+	logger.Debug("GetCollection")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetCollection, ErrStructValidation, err)
+	}
+
+	uri := fmt.Sprintf("/apikey-manager-api/v1/collections/%d", params.CollectionID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetCollection, err)
+	}
+
+	var result Collection
+	resp, err := a.Exec(req, &result)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrGetCollection, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrGetCollection, a.Error(resp))
+	}
+
+	return &result, nil
+}
+
+func (a *apikey) CreateCollection(ctx context.Context, params CreateCollectionRequest) (*Collection, error) {
+	logger := a.Log(ctx)
+	logger.Debug("CreateCollection")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrCreateCollection, ErrStructValidation, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/apikey-manager-api/v1/collections", nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrCreateCollection, err)
+	}
+
+	var result Collection
+	resp, err := a.Exec(req, &result, params)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrCreateCollection, err)
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("%s: %w", ErrCreateCollection, a.Error(resp))
+	}
+
+	return &result, nil
+}
+
+func (a *apikey) UpdateCollection(ctx context.Context, params UpdateCollectionRequest) (*Collection, error) {
+	logger := a.Log(ctx)
+	logger.Debug("UpdateCollection")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrUpdateCollection, ErrStructValidation, err)
+	}
+
+	uri := fmt.Sprintf("/apikey-manager-api/v1/collections/%d", params.CollectionID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrUpdateCollection, err)
+	}
+
+	var result Collection
+	resp, err := a.Exec(req, &result, params.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrUpdateCollection, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrUpdateCollection, a.Error(resp))
+	}
+
+	return &result, nil
+}
+
+func (a *apikey) DeleteCollection(ctx context.Context, params DeleteCollectionRequest) error {
+	logger := a.Log(ctx)
+	logger.Debug("DeleteCollection")
+
+	if err := params.Validate(); err != nil {
+		return fmt.Errorf("%s: %w: %s", ErrDeleteCollection, ErrStructValidation, err)
+	}
+
+	uri := fmt.Sprintf("/apikey-manager-api/v1/collections/%d", params.CollectionID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, uri, nil)
+	if err != nil {
+		return fmt.Errorf("%w: failed to create request: %s", ErrDeleteCollection, err)
+	}
+
+	resp, err := a.Exec(req, nil)
+	if err != nil {
+		return fmt.Errorf("%w: request failed: %s", ErrDeleteCollection, err)
+	}
+
+	if resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("%s: %w", ErrDeleteCollection, a.Error(resp))
+	}
+
+	return nil
+}
+
+func (a *apikey) UpdateCollectionACL(ctx context.Context, params UpdateCollectionACLRequest) (*Collection, error) {
+	logger := a.Log(ctx)
+	logger.Debug("UpdateCollectionACL")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrUpdateCollectionACL, ErrStructValidation, err)
+	}
+
+	uri := fmt.Sprintf("/apikey-manager-api/v1/collections/%d/acl", params.CollectionID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrUpdateCollectionACL, err)
+	}
+
+	var result Collection
+	resp, err := a.Exec(req, &result, params.ACL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrUpdateCollectionACL, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrUpdateCollectionACL, a.Error(resp))
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,394 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListCollections(t *testing.T) {
+	tests := map[string]struct {
+		params           ListCollectionsRequest
+		responseStatus   int
+		responseBody     string
+		expectedPath     string
+		expectedResponse ListCollectionsResponse
+		withError        error
+	}{
+		"200 OK": {
+			params: ListCollectionsRequest{
+				ContractID: "C-0N7RAC7",
+				GroupID:    12345,
+			},
+			responseStatus: http.StatusOK,
+			responseBody: `
+[
+    {
+        "collectionId": 1001,
+        "collectionName": "mobile-clients",
+        "collectionDescription": "keys for mobile clients",
+        "contractId": "C-0N7RAC7",
+        "groupId": 12345,
+        "grantedACL": ["ENDPOINT-1"],
+        "dirtyACL": [],
+        "keyCount": 3,
+        "dirtyKeyCount": 0
+    }
+]`,
+			expectedPath: "/apikey-manager-api/v1/collections?contractId=C-0N7RAC7&groupId=12345",
+			expectedResponse: ListCollectionsResponse{
+				{
+					CollectionID:          1001,
+					CollectionName:        "mobile-clients",
+					CollectionDescription: "keys for mobile clients",
+					ContractID:            "C-0N7RAC7",
+					GroupID:               12345,
+					GrantedACL:            []string{"ENDPOINT-1"},
+					DirtyACL:              []string{},
+					KeyCount:              3,
+				},
+			},
+		},
+		"500 internal server error": {
+			responseStatus: http.StatusInternalServerError,
+			responseBody: `
+{
+	"type": "internal_error",
+	"title": "Internal Server Error",
+	"detail": "Error listing collections",
+	"status": 500
+}`,
+			expectedPath: "/apikey-manager-api/v1/collections",
+			withError: &Error{
+				Type:   "internal_error",
+				Title:  "Internal Server Error",
+				Detail: "Error listing collections",
+				Status: http.StatusInternalServerError,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.ListCollections(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestGetCollection(t *testing.T) {
+	tests := map[string]struct {
+		params           GetCollectionRequest
+		responseStatus   int
+		responseBody     string
+		expectedPath     string
+		expectedResponse *Collection
+		withError        error
+	}{
+		"200 OK": {
+			params:         GetCollectionRequest{CollectionID: 1001},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+    "collectionId": 1001,
+    "collectionName": "mobile-clients",
+    "contractId": "C-0N7RAC7",
+    "groupId": 12345,
+    "quota": {
+        "enabled": true,
+        "value": 1000,
+        "interval": "HOUR_1"
+    },
+    "keyCount": 3
+}`,
+			expectedPath: "/apikey-manager-api/v1/collections/1001",
+			expectedResponse: &Collection{
+				CollectionID:   1001,
+				CollectionName: "mobile-clients",
+				ContractID:     "C-0N7RAC7",
+				GroupID:        12345,
+				Quota: &QuotaSettings{
+					Enabled:  true,
+					Value:    1000,
+					Interval: QuotaIntervalHour1,
+				},
+				KeyCount: 3,
+			},
+		},
+		"404 not found": {
+			params:         GetCollectionRequest{CollectionID: 1002},
+			responseStatus: http.StatusNotFound,
+			responseBody: `
+{
+	"type": "not_found",
+	"title": "Not Found",
+	"detail": "Collection 1002 not found",
+	"status": 404
+}`,
+			expectedPath: "/apikey-manager-api/v1/collections/1002",
+			withError: &Error{
+				Type:   "not_found",
+				Title:  "Not Found",
+				Detail: "Collection 1002 not found",
+				Status: http.StatusNotFound,
+			},
+		},
+		"validation error": {
+			params:    GetCollectionRequest{},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.GetCollection(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestCreateCollection(t *testing.T) {
+	tests := map[string]struct {
+		params           CreateCollectionRequest
+		responseStatus   int
+		responseBody     string
+		expectedRequest  string
+		expectedResponse *Collection
+		withError        error
+	}{
+		"201 Created": {
+			params: CreateCollectionRequest{
+				ContractID:     "C-0N7RAC7",
+				GroupID:        12345,
+				CollectionName: "mobile-clients",
+			},
+			responseStatus:  http.StatusCreated,
+			responseBody:    `{"collectionId": 1001, "collectionName": "mobile-clients", "contractId": "C-0N7RAC7", "groupId": 12345}`,
+			expectedRequest: `{"contractId":"C-0N7RAC7","groupId":12345,"collectionName":"mobile-clients"}`,
+			expectedResponse: &Collection{
+				CollectionID:   1001,
+				CollectionName: "mobile-clients",
+				ContractID:     "C-0N7RAC7",
+				GroupID:        12345,
+			},
+		},
+		"validation error": {
+			params: CreateCollectionRequest{
+				ContractID: "C-0N7RAC7",
+			},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/apikey-manager-api/v1/collections", r.URL.String())
+				assert.Equal(t, http.MethodPost, r.Method)
+				body, err := ioutil.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.JSONEq(t, test.expectedRequest, string(body))
+				w.WriteHeader(test.responseStatus)
+				_, err = w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.CreateCollection(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestUpdateCollection(t *testing.T) {
+	tests := map[string]struct {
+		params           UpdateCollectionRequest
+		responseStatus   int
+		responseBody     string
+		expectedPath     string
+		expectedResponse *Collection
+		withError        error
+	}{
+		"200 OK": {
+			params: UpdateCollectionRequest{
+				CollectionID: 1001,
+				Body: UpdateCollectionRequestBody{
+					CollectionName:        "mobile-clients-v2",
+					CollectionDescription: "updated",
+				},
+			},
+			responseStatus: http.StatusOK,
+			responseBody:   `{"collectionId": 1001, "collectionName": "mobile-clients-v2", "collectionDescription": "updated"}`,
+			expectedPath:   "/apikey-manager-api/v1/collections/1001",
+			expectedResponse: &Collection{
+				CollectionID:          1001,
+				CollectionName:        "mobile-clients-v2",
+				CollectionDescription: "updated",
+			},
+		},
+		"validation error": {
+			params:    UpdateCollectionRequest{CollectionID: 1001},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodPut, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.UpdateCollection(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestDeleteCollection(t *testing.T) {
+	tests := map[string]struct {
+		params         DeleteCollectionRequest
+		responseStatus int
+		responseBody   string
+		expectedPath   string
+		withError      error
+	}{
+		"204 No Content": {
+			params:         DeleteCollectionRequest{CollectionID: 1001},
+			responseStatus: http.StatusNoContent,
+			expectedPath:   "/apikey-manager-api/v1/collections/1001",
+		},
+		"409 conflict": {
+			params:         DeleteCollectionRequest{CollectionID: 1001},
+			responseStatus: http.StatusConflict,
+			responseBody:   `{"type": "conflict", "title": "Conflict", "detail": "Collection has active keys", "status": 409}`,
+			expectedPath:   "/apikey-manager-api/v1/collections/1001",
+			withError: &Error{
+				Type:   "conflict",
+				Title:  "Conflict",
+				Detail: "Collection has active keys",
+				Status: http.StatusConflict,
+			},
+		},
+		"validation error": {
+			params:    DeleteCollectionRequest{},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodDelete, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			err := client.DeleteCollection(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestUpdateCollectionACL(t *testing.T) {
+	tests := map[string]struct {
+		params           UpdateCollectionACLRequest
+		responseStatus   int
+		responseBody     string
+		expectedRequest  string
+		expectedResponse *Collection
+		withError        error
+	}{
+		"200 OK": {
+			params: UpdateCollectionACLRequest{
+				CollectionID: 1001,
+				ACL:          []string{"ENDPOINT-1", "RESOURCE-22"},
+			},
+			responseStatus:  http.StatusOK,
+			responseBody:    `{"collectionId": 1001, "collectionName": "mobile-clients", "grantedACL": ["ENDPOINT-1", "RESOURCE-22"]}`,
+			expectedRequest: `["ENDPOINT-1","RESOURCE-22"]`,
+			expectedResponse: &Collection{
+				CollectionID:   1001,
+				CollectionName: "mobile-clients",
+				GrantedACL:     []string{"ENDPOINT-1", "RESOURCE-22"},
+			},
+		},
+		"validation error": {
+			params:    UpdateCollectionACLRequest{CollectionID: 1001},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/apikey-manager-api/v1/collections/1001/acl", r.URL.String())
+				assert.Equal(t, http.MethodPut, r.Method)
+				body, err := ioutil.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.JSONEq(t, test.expectedRequest, string(body))
+				w.WriteHeader(test.responseStatus)
+				_, err = w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.UpdateCollectionACL(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
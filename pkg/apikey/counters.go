@@ -0,0 +1,170 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type (
+	// Counters contains operations available on throttling counter resource
+	Counters interface {
+		// ListCounters lists all throttling counters available to the user
+		//
+		// See: https://techdocs.akamai.com/key-traffic-mgmt/reference/get-counters
+		ListCounters(context.Context) (ListCountersResponse, error)
+
+		// GetCounter returns details of a single throttling counter
+		//
+		// See: https://techdocs.akamai.com/key-traffic-mgmt/reference/get-counter
+		GetCounter(context.Context, GetCounterRequest) (*Counter, error)
+
+		// UpdateCounter updates the limit and behavior of a throttling counter
+		//
+		// See: https://techdocs.akamai.com/key-traffic-mgmt/reference/put-counter
+		UpdateCounter(context.Context, UpdateCounterRequest) (*Counter, error)
+	}
+
+	// Counter represents a throttling counter
+	Counter struct {
+		CounterID          int64  `json:"counterId"`
+		CounterName        string `json:"counterName"`
+		CounterDescription string `json:"counterDescription,omitempty"`
+		Enabled            bool   `json:"enabled"`
+		Limit              int64  `json:"limit"`
+		OnOverLimit        string `json:"onOverLimit,omitempty"`
+		KeyCount           int64  `json:"keyCount"`
+		EndpointCount      int64  `json:"endpointCount"`
+		CreatedBy          string `json:"createdBy,omitempty"`
+		CreateDate         string `json:"createDate,omitempty"`
+		UpdatedBy          string `json:"updatedBy,omitempty"`
+		UpdateDate         string `json:"updateDate,omitempty"`
+	}
+
+	// ListCountersResponse represents a response from ListCounters
+	ListCountersResponse []Counter
+
+	// GetCounterRequest contains request parameters for GetCounter
+	GetCounterRequest struct {
+		CounterID int64
+	}
+
+	// UpdateCounterRequest contains request parameters for UpdateCounter
+	UpdateCounterRequest struct {
+		CounterID int64
+		Body      UpdateCounterRequestBody
+	}
+
+	// UpdateCounterRequestBody contains request body parameters for UpdateCounter
+	UpdateCounterRequestBody struct {
+		CounterName        string `json:"counterName"`
+		CounterDescription string `json:"counterDescription,omitempty"`
+		Enabled            bool   `json:"enabled"`
+		Limit              int64  `json:"limit"`
+		OnOverLimit        string `json:"onOverLimit,omitempty"`
+	}
+)
+
+var (
+	// ErrListCounters is returned in case an error occurs on ListCounters operation
+	ErrListCounters = errors.New("list counters")
+	// ErrGetCounter is returned in case an error occurs on GetCounter operation
+	ErrGetCounter = errors.New("get counter")
+	// ErrUpdateCounter is returned in case an error occurs on UpdateCounter operation
+	ErrUpdateCounter = errors.New("update counter")
+)
+
+// Validate validates GetCounterRequest
+func (r GetCounterRequest) Validate() error {
+	return validation.Errors{
+		"CounterID": validation.Validate(r.CounterID, validation.Required),
+	}.Filter()
+}
+
+// Validate validates UpdateCounterRequest
+func (r UpdateCounterRequest) Validate() error {
+	return validation.Errors{
+		"CounterID":        validation.Validate(r.CounterID, validation.Required),
+		"Body.CounterName": validation.Validate(r.Body.CounterName, validation.Required),
+		"Body.Limit":       validation.Validate(r.Body.Limit, validation.Min(0)),
+	}.Filter()
+}
+
+func (a *apikey) ListCounters(ctx context.Context) (ListCountersResponse, error) {
+	logger := a.Log(ctx)
+	logger.Debug("ListCounters")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "/apikey-manager-api/v1/counters", nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrListCounters, err)
+	}
+
+	var result ListCountersResponse
+	resp, err := a.Exec(req, &result)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrListCounters, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrListCounters, a.Error(resp))
+	}
+
+	return result, nil
+}
+
+func (a *apikey) GetCounter(ctx context.Context, params GetCounterRequest) (*Counter, error) {
+	logger := a.Log(ctx)
+	logger.Debug("GetCounter")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetCounter, ErrStructValidation, err)
+	}
+
+	uri := fmt.Sprintf("/apikey-manager-api/v1/counters/%d", params.CounterID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetCounter, err)
+	}
+
+	var result Counter
+	resp, err := a.Exec(req, &result)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrGetCounter, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrGetCounter, a.Error(resp))
+	}
+
+	return &result, nil
+}
+
+func (a *apikey) UpdateCounter(ctx context.Context, params UpdateCounterRequest) (*Counter, error) {
+	logger := a.Log(ctx)
+	logger.Debug("UpdateCounter")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrUpdateCounter, ErrStructValidation, err)
+	}
+
+	uri := fmt.Sprintf("/apikey-manager-api/v1/counters/%d", params.CounterID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrUpdateCounter, err)
+	}
+
+	var result Counter
+	resp, err := a.Exec(req, &result, params.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrUpdateCounter, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrUpdateCounter, a.Error(resp))
+	}
+
+	return &result, nil
+}
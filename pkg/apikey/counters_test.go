@@ -0,0 +1,167 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListCounters(t *testing.T) {
+	tests := map[string]struct {
+		responseStatus   int
+		responseBody     string
+		expectedResponse ListCountersResponse
+		withError        error
+	}{
+		"200 OK": {
+			responseStatus: http.StatusOK,
+			responseBody: `
+[
+    {
+        "counterId": 7,
+        "counterName": "default",
+        "enabled": true,
+        "limit": 100,
+        "onOverLimit": "DENY",
+        "keyCount": 12,
+        "endpointCount": 2
+    }
+]`,
+			expectedResponse: ListCountersResponse{
+				{
+					CounterID:     7,
+					CounterName:   "default",
+					Enabled:       true,
+					Limit:         100,
+					OnOverLimit:   "DENY",
+					KeyCount:      12,
+					EndpointCount: 2,
+				},
+			},
+		},
+		"500 internal server error": {
+			responseStatus: http.StatusInternalServerError,
+			responseBody:   `{"type": "internal_error", "title": "Internal Server Error", "detail": "Error listing counters", "status": 500}`,
+			withError: &Error{
+				Type:   "internal_error",
+				Title:  "Internal Server Error",
+				Detail: "Error listing counters",
+				Status: http.StatusInternalServerError,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/apikey-manager-api/v1/counters", r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.ListCounters(context.Background())
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestGetCounter(t *testing.T) {
+	tests := map[string]struct {
+		params           GetCounterRequest
+		responseStatus   int
+		responseBody     string
+		expectedResponse *Counter
+		withError        error
+	}{
+		"200 OK": {
+			params:           GetCounterRequest{CounterID: 7},
+			responseStatus:   http.StatusOK,
+			responseBody:     `{"counterId": 7, "counterName": "default", "enabled": true, "limit": 100}`,
+			expectedResponse: &Counter{CounterID: 7, CounterName: "default", Enabled: true, Limit: 100},
+		},
+		"validation error": {
+			params:    GetCounterRequest{},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/apikey-manager-api/v1/counters/7", r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.GetCounter(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestUpdateCounter(t *testing.T) {
+	tests := map[string]struct {
+		params           UpdateCounterRequest
+		responseStatus   int
+		responseBody     string
+		expectedResponse *Counter
+		withError        error
+	}{
+		"200 OK": {
+			params: UpdateCounterRequest{
+				CounterID: 7,
+				Body: UpdateCounterRequestBody{
+					CounterName: "default",
+					Enabled:     true,
+					Limit:       250,
+				},
+			},
+			responseStatus:   http.StatusOK,
+			responseBody:     `{"counterId": 7, "counterName": "default", "enabled": true, "limit": 250}`,
+			expectedResponse: &Counter{CounterID: 7, CounterName: "default", Enabled: true, Limit: 250},
+		},
+		"validation error": {
+			params:    UpdateCounterRequest{CounterID: 7, Body: UpdateCounterRequestBody{Limit: -1}},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/apikey-manager-api/v1/counters/7", r.URL.String())
+				assert.Equal(t, http.MethodPut, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.UpdateCounter(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
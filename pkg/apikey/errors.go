@@ -0,0 +1,91 @@
+package apikey
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/session"
+)
+
+type (
+	// Error is an apikey error interface
+	Error struct {
+		Type     string      `json:"type"`
+		Title    string      `json:"title"`
+		Detail   string      `json:"detail"`
+		Instance string      `json:"instance,omitempty"`
+		Status   int         `json:"status,omitempty"`
+		Errors   []ErrorItem `json:"errors,omitempty"`
+	}
+
+	// ErrorItem represents a single error item
+	ErrorItem struct {
+		Type   string `json:"type,omitempty"`
+		Title  string `json:"title,omitempty"`
+		Detail string `json:"detail,omitempty"`
+	}
+)
+
+// Error parses an error from the response
+func (a *apikey) Error(r *http.Response) error {
+	var e Error
+
+	var body []byte
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.Log(r.Request.Context()).Errorf("reading error response body: %s", err)
+		e.Status = r.StatusCode
+		e.Title = "Failed to read error body"
+		e.Detail = err.Error()
+		return &e
+	}
+
+	if err := json.Unmarshal(body, &e); err != nil {
+		a.Log(r.Request.Context()).Errorf("could not unmarshal API error: %s", err)
+		e.Title = "Failed to unmarshal error body"
+		e.Detail = err.Error()
+	}
+
+	e.Status = r.StatusCode
+
+	return &e
+}
+
+func (e *Error) Error() string {
+	msg, err := json.MarshalIndent(e, "", "\t")
+	if err != nil {
+		return fmt.Sprintf("error marshaling API error: %s", err)
+	}
+	return fmt.Sprintf("API error: \n%s", msg)
+}
+
+// Is handles error comparisons
+func (e *Error) Is(target error) bool {
+	if session.MatchStatus(e.Status, target) {
+		return true
+	}
+
+	var t *Error
+	if !errors.As(target, &t) {
+		return false
+	}
+
+	if e == t {
+		return true
+	}
+
+	if e.Status != t.Status {
+		return false
+	}
+
+	return e.Error() == t.Error()
+}
+
+// As converts the error into a *session.Problem, shared by the errors of all packages.
+func (e *Error) As(target interface{}) bool {
+	return session.AsProblem(e, e.Status, target)
+}
@@ -0,0 +1,275 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type (
+	// Keys contains operations available on API key resource
+	Keys interface {
+		// ListKeys lists all keys of a key collection
+		//
+		// See: https://techdocs.akamai.com/key-traffic-mgmt/reference/get-collection-keys
+		ListKeys(context.Context, ListKeysRequest) (ListKeysResponse, error)
+
+		// GetKey returns details of a single key
+		//
+		// See: https://techdocs.akamai.com/key-traffic-mgmt/reference/get-key
+		GetKey(context.Context, GetKeyRequest) (*Key, error)
+
+		// CreateKey creates a new key in a key collection
+		//
+		// See: https://techdocs.akamai.com/key-traffic-mgmt/reference/post-keys
+		CreateKey(context.Context, CreateKeyRequest) (*Key, error)
+
+		// ImportKeys imports keys from a CSV file into a key collection
+		//
+		// See: https://techdocs.akamai.com/key-traffic-mgmt/reference/post-keys-import
+		ImportKeys(context.Context, ImportKeysRequest) (ListKeysResponse, error)
+
+		// RevokeKeys revokes the keys with given ids
+		//
+		// See: https://techdocs.akamai.com/key-traffic-mgmt/reference/post-keys-revoke
+		RevokeKeys(context.Context, RevokeKeysRequest) (ListKeysResponse, error)
+	}
+
+	// Key represents an API key
+	Key struct {
+		KeyID               int64    `json:"keyId"`
+		Value               string   `json:"value"`
+		Label               string   `json:"label,omitempty"`
+		Description         string   `json:"description,omitempty"`
+		Tags                []string `json:"tags,omitempty"`
+		CollectionID        int64    `json:"collectionId"`
+		CollectionName      string   `json:"collectionName,omitempty"`
+		Revoked             bool     `json:"revoked"`
+		Dirty               bool     `json:"dirty"`
+		CreatedAt           string   `json:"createdAt,omitempty"`
+		RevokedAt           string   `json:"revokedAt,omitempty"`
+		TerminationAt       string   `json:"terminationAt,omitempty"`
+		QuotaUsage          int64    `json:"quotaUsage"`
+		QuotaUsageTimestamp string   `json:"quotaUsageTimestamp,omitempty"`
+		QuotaUpdateState    string   `json:"quotaUpdateState,omitempty"`
+	}
+
+	// ListKeysRequest contains request parameters for ListKeys
+	ListKeysRequest struct {
+		CollectionID int64
+	}
+
+	// ListKeysResponse represents a list of keys
+	ListKeysResponse []Key
+
+	// GetKeyRequest contains request parameters for GetKey
+	GetKeyRequest struct {
+		KeyID int64
+	}
+
+	// CreateKeyRequest contains request parameters for CreateKey
+	CreateKeyRequest struct {
+		CollectionID int64    `json:"collectionId"`
+		Value        string   `json:"value,omitempty"`
+		Label        string   `json:"label,omitempty"`
+		Description  string   `json:"description,omitempty"`
+		Tags         []string `json:"tags,omitempty"`
+	}
+
+	// ImportKeysRequest contains request parameters for ImportKeys
+	ImportKeysRequest struct {
+		CollectionID int64  `json:"collectionId"`
+		Filename     string `json:"filename"`
+		Content      string `json:"content"`
+	}
+
+	// RevokeKeysRequest contains request parameters for RevokeKeys
+	RevokeKeysRequest struct {
+		Keys []int64 `json:"keys"`
+	}
+)
+
+var (
+	// ErrListKeys is returned in case an error occurs on ListKeys operation
+	ErrListKeys = errors.New("list keys")
+	// ErrGetKey is returned in case an error occurs on GetKey operation
+	ErrGetKey = errors.New("get key")
+	// ErrCreateKey is returned in case an error occurs on CreateKey operation
+	ErrCreateKey = errors.New("create key")
+	// ErrImportKeys is returned in case an error occurs on ImportKeys operation
+	ErrImportKeys = errors.New("import keys")
+	// ErrRevokeKeys is returned in case an error occurs on RevokeKeys operation
+	ErrRevokeKeys = errors.New("revoke keys")
+)
+
+// Validate validates ListKeysRequest
+func (r ListKeysRequest) Validate() error {
+	return validation.Errors{
+		"CollectionID": validation.Validate(r.CollectionID, validation.Required),
+	}.Filter()
+}
+
+// Validate validates GetKeyRequest
+func (r GetKeyRequest) Validate() error {
+	return validation.Errors{
+		"KeyID": validation.Validate(r.KeyID, validation.Required),
+	}.Filter()
+}
+
+// Validate validates CreateKeyRequest
+func (r CreateKeyRequest) Validate() error {
+	return validation.Errors{
+		"CollectionID": validation.Validate(r.CollectionID, validation.Required),
+	}.Filter()
+}
+
+// Validate validates ImportKeysRequest
+func (r ImportKeysRequest) Validate() error {
+	return validation.Errors{
+		"CollectionID": validation.Validate(r.CollectionID, validation.Required),
+		"Filename":     validation.Validate(r.Filename, validation.Required),
+		"Content":      validation.Validate(r.Content, validation.Required),
+	}.Filter()
+}
+
+// Validate validates RevokeKeysRequest
+func (r RevokeKeysRequest) Validate() error {
+	return validation.Errors{
+		"Keys": validation.Validate(r.Keys, validation.Required),
+	}.Filter()
+}
+
+func (a *apikey) ListKeys(ctx context.Context, params ListKeysRequest) (ListKeysResponse, error) {
+	logger := a.Log(ctx)
+	logger.Debug("ListKeys")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrListKeys, ErrStructValidation, err)
+	}
+
+	uri := fmt.Sprintf("/apikey-manager-api/v1/collections/%d/keys", params.CollectionID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrListKeys, err)
+	}
+
+	var result ListKeysResponse
+	resp, err := a.Exec(req, &result)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrListKeys, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrListKeys, a.Error(resp))
+	}
+
+	return result, nil
+}
+
+func (a *apikey) GetKey(ctx context.Context, params GetKeyRequest) (*Key, error) {
+	logger := a.Log(ctx)
+	logger.Debug("GetKey")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetKey, ErrStructValidation, err)
+	}
+
+	uri := fmt.Sprintf("/apikey-manager-api/v1/keys/%d", params.KeyID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetKey, err)
+	}
+
+	var result Key
+	resp, err := a.Exec(req, &result)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrGetKey, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrGetKey, a.Error(resp))
+	}
+
+	return &result, nil
+}
+
+func (a *apikey) CreateKey(ctx context.Context, params CreateKeyRequest) (*Key, error) {
+	logger := a.Log(ctx)
+	logger.Debug("CreateKey")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrCreateKey, ErrStructValidation, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/apikey-manager-api/v1/keys", nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrCreateKey, err)
+	}
+
+	var result Key
+	resp, err := a.Exec(req, &result, params)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrCreateKey, err)
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("%s: %w", ErrCreateKey, a.Error(resp))
+	}
+
+	return &result, nil
+}
+
+func (a *apikey) ImportKeys(ctx context.Context, params ImportKeysRequest) (ListKeysResponse, error) {
+	logger := a.Log(ctx)
+	logger.Debug("ImportKeys")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrImportKeys, ErrStructValidation, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/apikey-manager-api/v1/keys/import", nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrImportKeys, err)
+	}
+
+	var result ListKeysResponse
+	resp, err := a.Exec(req, &result, params)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrImportKeys, err)
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("%s: %w", ErrImportKeys, a.Error(resp))
+	}
+
+	return result, nil
+}
+
+func (a *apikey) RevokeKeys(ctx context.Context, params RevokeKeysRequest) (ListKeysResponse, error) {
+	logger := a.Log(ctx)
+	logger.Debug("RevokeKeys")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrRevokeKeys, ErrStructValidation, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/apikey-manager-api/v1/keys/revoke", nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrRevokeKeys, err)
+	}
+
+	var result ListKeysResponse
+	resp, err := a.Exec(req, &result, params)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrRevokeKeys, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrRevokeKeys, a.Error(resp))
+	}
+
+	return result, nil
+}
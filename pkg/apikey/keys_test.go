@@ -0,0 +1,287 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListKeys(t *testing.T) {
+	tests := map[string]struct {
+		params           ListKeysRequest
+		responseStatus   int
+		responseBody     string
+		expectedPath     string
+		expectedResponse ListKeysResponse
+		withError        error
+	}{
+		"200 OK": {
+			params:         ListKeysRequest{CollectionID: 1001},
+			responseStatus: http.StatusOK,
+			responseBody: `
+[
+    {
+        "keyId": 501,
+        "value": "d2f1b5e0-key",
+        "label": "ios-app",
+        "tags": ["mobile"],
+        "collectionId": 1001,
+        "collectionName": "mobile-clients",
+        "revoked": false,
+        "dirty": false,
+        "createdAt": "2023-05-01T10:00:00Z",
+        "quotaUsage": 10
+    }
+]`,
+			expectedPath: "/apikey-manager-api/v1/collections/1001/keys",
+			expectedResponse: ListKeysResponse{
+				{
+					KeyID:          501,
+					Value:          "d2f1b5e0-key",
+					Label:          "ios-app",
+					Tags:           []string{"mobile"},
+					CollectionID:   1001,
+					CollectionName: "mobile-clients",
+					CreatedAt:      "2023-05-01T10:00:00Z",
+					QuotaUsage:     10,
+				},
+			},
+		},
+		"500 internal server error": {
+			params:         ListKeysRequest{CollectionID: 1001},
+			responseStatus: http.StatusInternalServerError,
+			responseBody:   `{"type": "internal_error", "title": "Internal Server Error", "detail": "Error listing keys", "status": 500}`,
+			expectedPath:   "/apikey-manager-api/v1/collections/1001/keys",
+			withError: &Error{
+				Type:   "internal_error",
+				Title:  "Internal Server Error",
+				Detail: "Error listing keys",
+				Status: http.StatusInternalServerError,
+			},
+		},
+		"validation error": {
+			params:    ListKeysRequest{},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.ListKeys(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestGetKey(t *testing.T) {
+	tests := map[string]struct {
+		params           GetKeyRequest
+		responseStatus   int
+		responseBody     string
+		expectedResponse *Key
+		withError        error
+	}{
+		"200 OK": {
+			params:           GetKeyRequest{KeyID: 501},
+			responseStatus:   http.StatusOK,
+			responseBody:     `{"keyId": 501, "value": "d2f1b5e0-key", "collectionId": 1001, "revoked": true, "revokedAt": "2023-05-02T10:00:00Z"}`,
+			expectedResponse: &Key{KeyID: 501, Value: "d2f1b5e0-key", CollectionID: 1001, Revoked: true, RevokedAt: "2023-05-02T10:00:00Z"},
+		},
+		"validation error": {
+			params:    GetKeyRequest{},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/apikey-manager-api/v1/keys/501", r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.GetKey(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestCreateKey(t *testing.T) {
+	tests := map[string]struct {
+		params           CreateKeyRequest
+		responseStatus   int
+		responseBody     string
+		expectedRequest  string
+		expectedResponse *Key
+		withError        error
+	}{
+		"201 Created": {
+			params: CreateKeyRequest{
+				CollectionID: 1001,
+				Label:        "android-app",
+				Tags:         []string{"mobile"},
+			},
+			responseStatus:   http.StatusCreated,
+			responseBody:     `{"keyId": 502, "value": "generated-value", "label": "android-app", "tags": ["mobile"], "collectionId": 1001}`,
+			expectedRequest:  `{"collectionId":1001,"label":"android-app","tags":["mobile"]}`,
+			expectedResponse: &Key{KeyID: 502, Value: "generated-value", Label: "android-app", Tags: []string{"mobile"}, CollectionID: 1001},
+		},
+		"400 bad request": {
+			params:          CreateKeyRequest{CollectionID: 1001, Value: "short"},
+			responseStatus:  http.StatusBadRequest,
+			responseBody:    `{"type": "bad_request", "title": "Bad Request", "detail": "Key value is too short", "status": 400}`,
+			expectedRequest: `{"collectionId":1001,"value":"short"}`,
+			withError: &Error{
+				Type:   "bad_request",
+				Title:  "Bad Request",
+				Detail: "Key value is too short",
+				Status: http.StatusBadRequest,
+			},
+		},
+		"validation error": {
+			params:    CreateKeyRequest{Label: "android-app"},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/apikey-manager-api/v1/keys", r.URL.String())
+				assert.Equal(t, http.MethodPost, r.Method)
+				body, err := ioutil.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.JSONEq(t, test.expectedRequest, string(body))
+				w.WriteHeader(test.responseStatus)
+				_, err = w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.CreateKey(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestImportKeys(t *testing.T) {
+	tests := map[string]struct {
+		params           ImportKeysRequest
+		responseStatus   int
+		responseBody     string
+		expectedResponse ListKeysResponse
+		withError        error
+	}{
+		"201 Created": {
+			params: ImportKeysRequest{
+				CollectionID: 1001,
+				Filename:     "keys.csv",
+				Content:      "value,label\nabc,first\n",
+			},
+			responseStatus:   http.StatusCreated,
+			responseBody:     `[{"keyId": 503, "value": "abc", "label": "first", "collectionId": 1001}]`,
+			expectedResponse: ListKeysResponse{{KeyID: 503, Value: "abc", Label: "first", CollectionID: 1001}},
+		},
+		"validation error": {
+			params:    ImportKeysRequest{CollectionID: 1001},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/apikey-manager-api/v1/keys/import", r.URL.String())
+				assert.Equal(t, http.MethodPost, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.ImportKeys(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestRevokeKeys(t *testing.T) {
+	tests := map[string]struct {
+		params           RevokeKeysRequest
+		responseStatus   int
+		responseBody     string
+		expectedRequest  string
+		expectedResponse ListKeysResponse
+		withError        error
+	}{
+		"200 OK": {
+			params:           RevokeKeysRequest{Keys: []int64{501, 502}},
+			responseStatus:   http.StatusOK,
+			responseBody:     `[{"keyId": 501, "revoked": true}, {"keyId": 502, "revoked": true}]`,
+			expectedRequest:  `{"keys":[501,502]}`,
+			expectedResponse: ListKeysResponse{{KeyID: 501, Revoked: true}, {KeyID: 502, Revoked: true}},
+		},
+		"validation error": {
+			params:    RevokeKeysRequest{},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/apikey-manager-api/v1/keys/revoke", r.URL.String())
+				assert.Equal(t, http.MethodPost, r.Method)
+				body, err := ioutil.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.JSONEq(t, test.expectedRequest, string(body))
+				w.WriteHeader(test.responseStatus)
+				_, err = w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.RevokeKeys(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
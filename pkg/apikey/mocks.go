@@ -0,0 +1,171 @@
+//revive:disable:exported
+
+package apikey
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type Mock struct {
+	mock.Mock
+}
+
+var _ APIKey = &Mock{}
+
+func (m *Mock) ListCollections(ctx context.Context, params ListCollectionsRequest) (ListCollectionsResponse, error) {
+	args := m.Called(ctx, params)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(ListCollectionsResponse), nil
+}
+
+func (m *Mock) GetCollection(ctx context.Context, params GetCollectionRequest) (*Collection, error) {
+	args := m.Called(ctx, params)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*Collection), nil
+}
+
+func (m *Mock) CreateCollection(ctx context.Context, params CreateCollectionRequest) (*Collection, error) {
+	args := m.Called(ctx, params)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*Collection), nil
+}
+
+func (m *Mock) UpdateCollection(ctx context.Context, params UpdateCollectionRequest) (*Collection, error) {
+	args := m.Called(ctx, params)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*Collection), nil
+}
+
+func (m *Mock) DeleteCollection(ctx context.Context, params DeleteCollectionRequest) error {
+	args := m.Called(ctx, params)
+
+	return args.Error(0)
+}
+
+func (m *Mock) UpdateCollectionACL(ctx context.Context, params UpdateCollectionACLRequest) (*Collection, error) {
+	args := m.Called(ctx, params)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*Collection), nil
+}
+
+func (m *Mock) ListCounters(ctx context.Context) (ListCountersResponse, error) {
+	args := m.Called(ctx)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(ListCountersResponse), nil
+}
+
+func (m *Mock) GetCounter(ctx context.Context, params GetCounterRequest) (*Counter, error) {
+	args := m.Called(ctx, params)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*Counter), nil
+}
+
+func (m *Mock) UpdateCounter(ctx context.Context, params UpdateCounterRequest) (*Counter, error) {
+	args := m.Called(ctx, params)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*Counter), nil
+}
+
+func (m *Mock) ListKeys(ctx context.Context, params ListKeysRequest) (ListKeysResponse, error) {
+	args := m.Called(ctx, params)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(ListKeysResponse), nil
+}
+
+func (m *Mock) GetKey(ctx context.Context, params GetKeyRequest) (*Key, error) {
+	args := m.Called(ctx, params)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*Key), nil
+}
+
+func (m *Mock) CreateKey(ctx context.Context, params CreateKeyRequest) (*Key, error) {
+	args := m.Called(ctx, params)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*Key), nil
+}
+
+func (m *Mock) ImportKeys(ctx context.Context, params ImportKeysRequest) (ListKeysResponse, error) {
+	args := m.Called(ctx, params)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(ListKeysResponse), nil
+}
+
+func (m *Mock) RevokeKeys(ctx context.Context, params RevokeKeysRequest) (ListKeysResponse, error) {
+	args := m.Called(ctx, params)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(ListKeysResponse), nil
+}
+
+func (m *Mock) GetCollectionQuota(ctx context.Context, params GetCollectionQuotaRequest) (*QuotaSettings, error) {
+	args := m.Called(ctx, params)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*QuotaSettings), nil
+}
+
+func (m *Mock) UpdateCollectionQuota(ctx context.Context, params UpdateCollectionQuotaRequest) (*QuotaSettings, error) {
+	args := m.Called(ctx, params)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*QuotaSettings), nil
+}
@@ -0,0 +1,161 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/edgegriderr"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type (
+	// Quota contains operations available on key collection quota resource
+	Quota interface {
+		// GetCollectionQuota returns quota settings of a key collection
+		//
+		// See: https://techdocs.akamai.com/key-traffic-mgmt/reference/get-collection-quota
+		GetCollectionQuota(context.Context, GetCollectionQuotaRequest) (*QuotaSettings, error)
+
+		// UpdateCollectionQuota updates quota settings of a key collection
+		//
+		// See: https://techdocs.akamai.com/key-traffic-mgmt/reference/put-collection-quota
+		UpdateCollectionQuota(context.Context, UpdateCollectionQuotaRequest) (*QuotaSettings, error)
+	}
+
+	// QuotaSettings represents the quota applied to all keys in a collection
+	QuotaSettings struct {
+		Enabled  bool          `json:"enabled"`
+		Value    int64         `json:"value,omitempty"`
+		Interval QuotaInterval `json:"interval,omitempty"`
+		Headers  *QuotaHeaders `json:"headers,omitempty"`
+	}
+
+	// QuotaHeaders controls which quota headers are returned to API consumers
+	QuotaHeaders struct {
+		DenyLimitHeaderShown      bool `json:"denyLimitHeaderShown"`
+		DenyRemainingHeaderShown  bool `json:"denyRemainingHeaderShown"`
+		DenyNextHeaderShown       bool `json:"denyNextHeaderShown"`
+		AllowLimitHeaderShown     bool `json:"allowLimitHeaderShown"`
+		AllowRemainingHeaderShown bool `json:"allowRemainingHeaderShown"`
+		AllowResetHeaderShown     bool `json:"allowResetHeaderShown"`
+	}
+
+	// QuotaInterval is the period after which quota usage is reset
+	QuotaInterval string
+
+	// GetCollectionQuotaRequest contains request parameters for GetCollectionQuota
+	GetCollectionQuotaRequest struct {
+		CollectionID int64
+	}
+
+	// UpdateCollectionQuotaRequest contains request parameters for UpdateCollectionQuota
+	UpdateCollectionQuotaRequest struct {
+		CollectionID int64
+		Quota        QuotaSettings
+	}
+)
+
+const (
+	// QuotaIntervalHour1 resets quota every hour
+	QuotaIntervalHour1 QuotaInterval = "HOUR_1"
+	// QuotaIntervalHour6 resets quota every 6 hours
+	QuotaIntervalHour6 QuotaInterval = "HOUR_6"
+	// QuotaIntervalHour12 resets quota every 12 hours
+	QuotaIntervalHour12 QuotaInterval = "HOUR_12"
+	// QuotaIntervalDay1 resets quota every day
+	QuotaIntervalDay1 QuotaInterval = "DAY_1"
+	// QuotaIntervalWeek1 resets quota every week
+	QuotaIntervalWeek1 QuotaInterval = "WEEK_1"
+	// QuotaIntervalMonth1 resets quota every month
+	QuotaIntervalMonth1 QuotaInterval = "MONTH_1"
+)
+
+var (
+	// ErrGetCollectionQuota is returned in case an error occurs on GetCollectionQuota operation
+	ErrGetCollectionQuota = errors.New("get collection quota")
+	// ErrUpdateCollectionQuota is returned in case an error occurs on UpdateCollectionQuota operation
+	ErrUpdateCollectionQuota = errors.New("update collection quota")
+)
+
+// Validate validates GetCollectionQuotaRequest
+func (r GetCollectionQuotaRequest) Validate() error {
+	return validation.Errors{
+		"CollectionID": validation.Validate(r.CollectionID, validation.Required),
+	}.Filter()
+}
+
+// Validate validates UpdateCollectionQuotaRequest
+func (r UpdateCollectionQuotaRequest) Validate() error {
+	return edgegriderr.ParseValidationErrors(validation.Errors{
+		"CollectionID": validation.Validate(r.CollectionID, validation.Required),
+		"Quota":        validation.Validate(r.Quota),
+	})
+}
+
+// Validate validates QuotaSettings
+func (q QuotaSettings) Validate() error {
+	return validation.Errors{
+		"Value": validation.Validate(q.Value, validation.When(q.Enabled, validation.Required)),
+		"Interval": validation.Validate(q.Interval, validation.When(q.Enabled, validation.Required),
+			validation.In(QuotaIntervalHour1, QuotaIntervalHour6, QuotaIntervalHour12, QuotaIntervalDay1, QuotaIntervalWeek1, QuotaIntervalMonth1).
+				Error(fmt.Sprintf("value '%s' is invalid. Must be one of: '%s', '%s', '%s', '%s', '%s' or '%s'", q.Interval,
+					QuotaIntervalHour1, QuotaIntervalHour6, QuotaIntervalHour12, QuotaIntervalDay1, QuotaIntervalWeek1, QuotaIntervalMonth1))),
+	}.Filter()
+}
+
+func (a *apikey) GetCollectionQuota(ctx context.Context, params GetCollectionQuotaRequest) (*QuotaSettings, error) {
+	logger := a.Log(ctx)
+	logger.Debug("GetCollectionQuota")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetCollectionQuota, ErrStructValidation, err)
+	}
+
+	uri := fmt.Sprintf("/apikey-manager-api/v1/collections/%d/quota", params.CollectionID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetCollectionQuota, err)
+	}
+
+	var result QuotaSettings
+	resp, err := a.Exec(req, &result)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrGetCollectionQuota, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrGetCollectionQuota, a.Error(resp))
+	}
+
+	return &result, nil
+}
+
+func (a *apikey) UpdateCollectionQuota(ctx context.Context, params UpdateCollectionQuotaRequest) (*QuotaSettings, error) {
+	logger := a.Log(ctx)
+	logger.Debug("UpdateCollectionQuota")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w:\n%s", ErrUpdateCollectionQuota, ErrStructValidation, err)
+	}
+
+	uri := fmt.Sprintf("/apikey-manager-api/v1/collections/%d/quota", params.CollectionID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrUpdateCollectionQuota, err)
+	}
+
+	var result QuotaSettings
+	resp, err := a.Exec(req, &result, params.Quota)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrUpdateCollectionQuota, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrUpdateCollectionQuota, a.Error(resp))
+	}
+
+	return &result, nil
+}
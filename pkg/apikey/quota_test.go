@@ -0,0 +1,162 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCollectionQuota(t *testing.T) {
+	tests := map[string]struct {
+		params           GetCollectionQuotaRequest
+		responseStatus   int
+		responseBody     string
+		expectedResponse *QuotaSettings
+		withError        error
+	}{
+		"200 OK": {
+			params:         GetCollectionQuotaRequest{CollectionID: 1001},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+    "enabled": true,
+    "value": 5000,
+    "interval": "DAY_1",
+    "headers": {
+        "denyLimitHeaderShown": true,
+        "denyRemainingHeaderShown": true,
+        "denyNextHeaderShown": false,
+        "allowLimitHeaderShown": false,
+        "allowRemainingHeaderShown": true,
+        "allowResetHeaderShown": false
+    }
+}`,
+			expectedResponse: &QuotaSettings{
+				Enabled:  true,
+				Value:    5000,
+				Interval: QuotaIntervalDay1,
+				Headers: &QuotaHeaders{
+					DenyLimitHeaderShown:      true,
+					DenyRemainingHeaderShown:  true,
+					AllowRemainingHeaderShown: true,
+				},
+			},
+		},
+		"validation error": {
+			params:    GetCollectionQuotaRequest{},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/apikey-manager-api/v1/collections/1001/quota", r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.GetCollectionQuota(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestUpdateCollectionQuota(t *testing.T) {
+	tests := map[string]struct {
+		params           UpdateCollectionQuotaRequest
+		responseStatus   int
+		responseBody     string
+		expectedRequest  string
+		expectedResponse *QuotaSettings
+		withError        func(*testing.T, error)
+	}{
+		"200 OK": {
+			params: UpdateCollectionQuotaRequest{
+				CollectionID: 1001,
+				Quota: QuotaSettings{
+					Enabled:  true,
+					Value:    100,
+					Interval: QuotaIntervalHour6,
+				},
+			},
+			responseStatus:   http.StatusOK,
+			responseBody:     `{"enabled": true, "value": 100, "interval": "HOUR_6"}`,
+			expectedRequest:  `{"enabled":true,"value":100,"interval":"HOUR_6"}`,
+			expectedResponse: &QuotaSettings{Enabled: true, Value: 100, Interval: QuotaIntervalHour6},
+		},
+		"disable quota": {
+			params: UpdateCollectionQuotaRequest{
+				CollectionID: 1001,
+				Quota:        QuotaSettings{Enabled: false},
+			},
+			responseStatus:   http.StatusOK,
+			responseBody:     `{"enabled": false}`,
+			expectedRequest:  `{"enabled":false}`,
+			expectedResponse: &QuotaSettings{},
+		},
+		"validation error - invalid interval": {
+			params: UpdateCollectionQuotaRequest{
+				CollectionID: 1001,
+				Quota: QuotaSettings{
+					Enabled:  true,
+					Value:    100,
+					Interval: "HOUR_2",
+				},
+			},
+			withError: func(t *testing.T, err error) {
+				assert.True(t, errors.Is(err, ErrStructValidation), "want: %s; got: %s", ErrStructValidation, err)
+				assert.Contains(t, err.Error(), "value 'HOUR_2' is invalid")
+			},
+		},
+		"validation error - missing value": {
+			params: UpdateCollectionQuotaRequest{
+				CollectionID: 1001,
+				Quota: QuotaSettings{
+					Enabled:  true,
+					Interval: QuotaIntervalDay1,
+				},
+			},
+			withError: func(t *testing.T, err error) {
+				assert.True(t, errors.Is(err, ErrStructValidation), "want: %s; got: %s", ErrStructValidation, err)
+				assert.Contains(t, err.Error(), "Value: cannot be blank")
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/apikey-manager-api/v1/collections/1001/quota", r.URL.String())
+				assert.Equal(t, http.MethodPut, r.Method)
+				body, err := ioutil.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.JSONEq(t, test.expectedRequest, string(body))
+				w.WriteHeader(test.responseStatus)
+				_, err = w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.UpdateCollectionQuota(context.Background(), test.params)
+			if test.withError != nil {
+				test.withError(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
@@ -2,10 +2,12 @@ package appsec
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
 
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/tools"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 )
 
@@ -39,14 +41,14 @@ type (
 
 	// GetActivationsResponse is returned from a call to GetActivations.
 	GetActivationsResponse struct {
-		DispatchCount     int          `json:"dispatchCount"`
-		ActivationID      int          `json:"activationId"`
-		Action            string       `json:"action"`
-		Status            StatusValue  `json:"status"`
-		Network           NetworkValue `json:"network"`
-		Estimate          string       `json:"estimate"`
-		CreatedBy         string       `json:"createdBy"`
-		CreateDate        time.Time    `json:"createDate"`
+		DispatchCount     int             `json:"dispatchCount"`
+		ActivationID      int             `json:"activationId"`
+		Action            ActivationValue `json:"action"`
+		Status            StatusValue     `json:"status"`
+		Network           NetworkValue    `json:"network"`
+		Estimate          string          `json:"estimate"`
+		CreatedBy         string          `json:"createdBy"`
+		CreateDate        time.Time       `json:"createDate"`
 		ActivationConfigs []struct {
 			ConfigID              int    `json:"configId"`
 			ConfigName            string `json:"configName"`
@@ -68,22 +70,22 @@ type (
 
 	// Activation represents the status of a configuration activation.
 	Activation struct {
-		ActivationID       int       `json:"activationId"`
-		Version            int       `json:"version"`
-		Status             string    `json:"status"`
-		Network            string    `json:"Network"`
-		ActivatedBy        string    `json:"activatedBy"`
-		ActivationDate     time.Time `json:"activationDate"`
-		Notes              string    `json:"notes"`
-		NotificationEmails []string  `json:"notificationEmails"`
+		ActivationID       int          `json:"activationId"`
+		Version            int          `json:"version"`
+		Status             StatusValue  `json:"status"`
+		Network            NetworkValue `json:"Network"`
+		ActivatedBy        string       `json:"activatedBy"`
+		ActivationDate     time.Time    `json:"activationDate"`
+		Notes              string       `json:"notes"`
+		NotificationEmails []string     `json:"notificationEmails"`
 	}
 
 	// CreateActivationsRequest is used to request activation or deactivation of a configuration.
 	CreateActivationsRequest struct {
-		Action             string   `json:"action"`
-		Network            string   `json:"network"`
-		Note               string   `json:"note"`
-		NotificationEmails []string `json:"notificationEmails"`
+		Action             ActivationValue `json:"action"`
+		Network            NetworkValue    `json:"network"`
+		Note               string          `json:"note"`
+		NotificationEmails []string        `json:"notificationEmails"`
 		ActivationConfigs  []struct {
 			ConfigID      int `json:"configId"`
 			ConfigVersion int `json:"configVersion"`
@@ -92,14 +94,14 @@ type (
 
 	// CreateActivationsResponse is returned from a call to CreateActivations.
 	CreateActivationsResponse struct {
-		DispatchCount     int          `json:"dispatchCount"`
-		ActivationID      int          `json:"activationId"`
-		Action            string       `json:"action"`
-		Status            StatusValue  `json:"status"`
-		Network           NetworkValue `json:"network"`
-		Estimate          string       `json:"estimate"`
-		CreatedBy         string       `json:"createdBy"`
-		CreateDate        time.Time    `json:"createDate"`
+		DispatchCount     int             `json:"dispatchCount"`
+		ActivationID      int             `json:"activationId"`
+		Action            ActivationValue `json:"action"`
+		Status            StatusValue     `json:"status"`
+		Network           NetworkValue    `json:"network"`
+		Estimate          string          `json:"estimate"`
+		CreatedBy         string          `json:"createdBy"`
+		CreateDate        time.Time       `json:"createDate"`
 		ActivationConfigs []struct {
 			ConfigID              int    `json:"configId"`
 			ConfigName            string `json:"configName"`
@@ -116,11 +118,11 @@ type (
 
 	// RemoveActivationsRequest is used to request deactivation of one or more configurations.
 	RemoveActivationsRequest struct {
-		ActivationID       int      `json:"-"`
-		Action             string   `json:"action"`
-		Network            string   `json:"network"`
-		Note               string   `json:"note"`
-		NotificationEmails []string `json:"notificationEmails"`
+		ActivationID       int             `json:"-"`
+		Action             ActivationValue `json:"action"`
+		Network            NetworkValue    `json:"network"`
+		Note               string          `json:"note"`
+		NotificationEmails []string        `json:"notificationEmails"`
 		ActivationConfigs  []struct {
 			ConfigID      int `json:"configId"`
 			ConfigVersion int `json:"configVersion"`
@@ -129,14 +131,14 @@ type (
 
 	// RemoveActivationsResponse is returned from a call to RemoveActivations.
 	RemoveActivationsResponse struct {
-		DispatchCount     int          `json:"dispatchCount"`
-		ActivationID      int          `json:"activationId"`
-		Action            string       `json:"action"`
-		Status            StatusValue  `json:"status"`
-		Network           NetworkValue `json:"network"`
-		Estimate          string       `json:"estimate"`
-		CreatedBy         string       `json:"createdBy"`
-		CreateDate        time.Time    `json:"createDate"`
+		DispatchCount     int             `json:"dispatchCount"`
+		ActivationID      int             `json:"activationId"`
+		Action            ActivationValue `json:"action"`
+		Status            StatusValue     `json:"status"`
+		Network           NetworkValue    `json:"network"`
+		Estimate          string          `json:"estimate"`
+		CreatedBy         string          `json:"createdBy"`
+		CreateDate        time.Time       `json:"createDate"`
 		ActivationConfigs []struct {
 			ConfigID              int    `json:"configId"`
 			ConfigName            string `json:"configName"`
@@ -160,6 +162,24 @@ func (v GetActivationHistoryRequest) Validate() error {
 	}.Filter()
 }
 
+// Validate validates a CreateActivationsRequest.
+func (v CreateActivationsRequest) Validate() error {
+	return validation.Errors{
+		"action":            validation.Validate(v.Action, validation.Required, validation.In(ActivationTypeActivate, ActivationTypeDeactivate)),
+		"network":           validation.Validate(v.Network, validation.Required, validation.In(NetworkStaging, NetworkProduction)),
+		"activationConfigs": validation.Validate(v.ActivationConfigs, validation.Required),
+	}.Filter()
+}
+
+// Validate validates a RemoveActivationsRequest.
+func (v RemoveActivationsRequest) Validate() error {
+	return validation.Errors{
+		"action":            validation.Validate(v.Action, validation.Required, validation.In(ActivationTypeActivate, ActivationTypeDeactivate)),
+		"network":           validation.Validate(v.Network, validation.Required, validation.In(NetworkStaging, NetworkProduction)),
+		"activationConfigs": validation.Validate(v.ActivationConfigs, validation.Required),
+	}.Filter()
+}
+
 func (p *appsec) GetActivations(ctx context.Context, params GetActivationsRequest) (*GetActivationsResponse, error) {
 	logger := p.Log(ctx)
 	logger.Debug("GetActivations")
@@ -224,6 +244,10 @@ func (p *appsec) CreateActivations(ctx context.Context, params CreateActivations
 	logger := p.Log(ctx)
 	logger.Debug("CreateActivations")
 
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrStructValidation, err.Error())
+	}
+
 	uri := "/appsec/v1/activations"
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri, nil)
@@ -265,6 +289,10 @@ func (p *appsec) RemoveActivations(ctx context.Context, params RemoveActivations
 	logger := p.Log(ctx)
 	logger.Debug("RemoveActivations")
 
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrStructValidation, err.Error())
+	}
+
 	uri := "/appsec/v1/activations"
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri, nil)
@@ -331,3 +359,41 @@ const (
 	// StatusNew indicates that a deactivation request is new.
 	StatusNew StatusValue = "NEW"
 )
+
+// ErrActivationFailed is returned by WaitForActivation when the activation ends with FAILED or ABORTED status.
+var ErrActivationFailed = errors.New("activation failed")
+
+// WaitForActivation polls GetActivations until the activation is activated or deactivated and returns its final state.
+func WaitForActivation(ctx context.Context, client Activations, params GetActivationsRequest, opts tools.WaitOptions) (*GetActivationsResponse, error) {
+	var result *GetActivationsResponse
+	if err := tools.WaitFor(ctx, activationCheck(client, params, &result), opts); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// WatchActivation polls GetActivations like WaitForActivation, delivering an event on the returned channel
+// whenever the activation status changes, see tools.WatchChanges.
+func WatchActivation(ctx context.Context, client Activations, params GetActivationsRequest, opts tools.WaitOptions) <-chan tools.Event {
+	return tools.WatchChanges(ctx, activationCheck(client, params, nil), opts)
+}
+
+// activationCheck returns a check of the activation state, storing the last response in result if it is not nil
+func activationCheck(client Activations, params GetActivationsRequest, result **GetActivationsResponse) tools.CheckFunc {
+	return func(ctx context.Context) (string, bool, error) {
+		resp, err := client.GetActivations(ctx, params)
+		if err != nil {
+			return "", false, err
+		}
+		if result != nil {
+			*result = resp
+		}
+		switch resp.Status {
+		case StatusActive, StatusDeactivated:
+			return string(resp.Status), true, nil
+		case StatusFailed, StatusAborted:
+			return string(resp.Status), false, fmt.Errorf("%w: activation %d status %s", ErrActivationFailed, resp.ActivationID, resp.Status)
+		}
+		return string(resp.Status), false, nil
+	}
+}
@@ -7,9 +7,12 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/session"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/tools"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -90,3 +93,97 @@ func TestAppSec_ListActivations(t *testing.T) {
 		})
 	}
 }
+
+func TestAppSec_WaitForActivation(t *testing.T) {
+	opts := tools.WaitOptions{Interval: time.Millisecond, Jitter: -1}
+	params := GetActivationsRequest{ActivationID: 1234}
+
+	t.Run("activated", func(t *testing.T) {
+		client := &Mock{}
+		client.On("GetActivations", mock.Anything, params).Return(&GetActivationsResponse{ActivationID: 1234, Status: StatusPending}, nil).Once()
+		client.On("GetActivations", mock.Anything, params).Return(&GetActivationsResponse{ActivationID: 1234, Status: StatusActive}, nil).Once()
+
+		result, err := WaitForActivation(context.Background(), client, params, opts)
+		require.NoError(t, err)
+		assert.Equal(t, StatusActive, result.Status)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		client := &Mock{}
+		client.On("GetActivations", mock.Anything, params).Return(&GetActivationsResponse{ActivationID: 1234, Status: StatusFailed}, nil).Once()
+
+		_, err := WaitForActivation(context.Background(), client, params, opts)
+		assert.True(t, errors.Is(err, ErrActivationFailed), "want: %s; got: %s", ErrActivationFailed, err)
+	})
+}
+
+func TestAppSec_CreateActivationsValidation(t *testing.T) {
+	activationConfigs := []struct {
+		ConfigID      int `json:"configId"`
+		ConfigVersion int `json:"configVersion"`
+	}{{ConfigID: 43253, ConfigVersion: 7}}
+
+	tests := map[string]struct {
+		params    CreateActivationsRequest
+		withError bool
+	}{
+		"valid": {
+			params: CreateActivationsRequest{Action: ActivationTypeActivate, Network: NetworkStaging, ActivationConfigs: activationConfigs},
+		},
+		"invalid network": {
+			params:    CreateActivationsRequest{Action: ActivationTypeActivate, Network: "STAGNG", ActivationConfigs: activationConfigs},
+			withError: true,
+		},
+		"invalid action": {
+			params:    CreateActivationsRequest{Action: "activate", Network: NetworkProduction, ActivationConfigs: activationConfigs},
+			withError: true,
+		},
+		"missing configs": {
+			params:    CreateActivationsRequest{Action: ActivationTypeActivate, Network: NetworkProduction},
+			withError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				_, err := w.Write([]byte(`{"activationId": 1234, "status": "RECEIVED"}`))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := mockAPIClient(t, mockServer)
+			_, err := client.CreateActivations(context.Background(), test.params, false)
+			if test.withError {
+				assert.True(t, errors.Is(err, ErrStructValidation), "want: %s; got: %s", ErrStructValidation, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestAppSec_WatchActivation(t *testing.T) {
+	opts := tools.WaitOptions{Interval: time.Millisecond, Jitter: -1}
+	params := GetActivationsRequest{ActivationID: 1234}
+	activation := func(status StatusValue) *GetActivationsResponse {
+		return &GetActivationsResponse{ActivationID: 1234, Status: status}
+	}
+
+	client := &Mock{}
+	client.On("GetActivations", mock.Anything, params).Return(activation(StatusNew), nil).Once()
+	client.On("GetActivations", mock.Anything, params).Return(activation(StatusPending), nil).Twice()
+	client.On("GetActivations", mock.Anything, params).Return(activation(StatusActive), nil).Once()
+
+	var transitions [][2]string
+	var last tools.Event
+	for event := range WatchActivation(context.Background(), client, params, opts) {
+		transitions = append(transitions, [2]string{event.PreviousStatus, event.Status})
+		last = event
+	}
+	assert.Equal(t, [][2]string{{"", "NEW"}, {"NEW", "RECEIVED"}, {"RECEIVED", "ACTIVATED"}}, transitions)
+	assert.True(t, last.Done)
+	assert.NoError(t, last.Err)
+	client.AssertExpectations(t)
+}
@@ -177,6 +177,7 @@ func (p *appsec) UpdateAdvancedSettingsEvasivePathMatch(ctx context.Context, par
 func (p *appsec) RemoveAdvancedSettingsEvasivePathMatch(ctx context.Context, params RemoveAdvancedSettingsEvasivePathMatchRequest) (*RemoveAdvancedSettingsEvasivePathMatchResponse, error) {
 	logger := p.Log(ctx)
 	logger.Debug("RemoveAdvancedSettingsEvasivePathMatch")
+	p.warnDeprecated(ctx, "RemoveAdvancedSettingsEvasivePathMatch")
 
 	request := UpdateAdvancedSettingsEvasivePathMatchRequest{
 		ConfigID:        params.ConfigID,
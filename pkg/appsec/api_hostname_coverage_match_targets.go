@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/tools"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 )
 
@@ -22,7 +23,7 @@ type (
 	GetApiHostnameCoverageMatchTargetsRequest struct {
 		ConfigID int    `json:"-"`
 		Version  int    `json:"-"`
-		Hostname string `json:"-"`
+		Hostname string `json:"-" query:"hostname"`
 	}
 
 	// GetApiHostnameCoverageMatchTargetsResponse is returned from a call to GetApiHostnameCoverageMatchTargets.
@@ -82,11 +83,14 @@ func (p *appsec) GetApiHostnameCoverageMatchTargets(ctx context.Context, params
 		return nil, fmt.Errorf("%w: %s", ErrStructValidation, err.Error())
 	}
 
-	uri := fmt.Sprintf(
-		"/appsec/v1/configs/%d/versions/%d/hostname-coverage/match-targets?hostname=%s",
+	uri, err := tools.AppendQuery(fmt.Sprintf(
+		"/appsec/v1/configs/%d/versions/%d/hostname-coverage/match-targets",
 		params.ConfigID,
 		params.Version,
-		params.Hostname)
+	), params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GetApiHostnameCoverageMatchTargets request: %w", err)
+	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
 	if err != nil {
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/tools"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 )
 
@@ -22,7 +23,7 @@ type (
 	GetApiHostnameCoverageOverlappingRequest struct {
 		ConfigID int    `json:"-"`
 		Version  int    `json:"-"`
-		Hostname string `json:"-"`
+		Hostname string `json:"-" query:"hostname"`
 	}
 
 	// GetApiHostnameCoverageOverlappingResponse is returned from a call to GetApiHostnameCoverageOverlapping.
@@ -54,12 +55,14 @@ func (p *appsec) GetApiHostnameCoverageOverlapping(ctx context.Context, params G
 		return nil, fmt.Errorf("%w: %s", ErrStructValidation, err.Error())
 	}
 
-	uri := fmt.Sprintf(
-		"/appsec/v1/configs/%d/versions/%d/hostname-coverage/overlapping?hostname=%s",
+	uri, err := tools.AppendQuery(fmt.Sprintf(
+		"/appsec/v1/configs/%d/versions/%d/hostname-coverage/overlapping",
 		params.ConfigID,
 		params.Version,
-		params.Hostname,
-	)
+	), params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GetApiHostnameCoverageOverlapping request: %w", err)
+	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
 	if err != nil {
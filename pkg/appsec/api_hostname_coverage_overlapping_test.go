@@ -43,6 +43,20 @@ func TestAppSec_ListApiHostnameCoverageOverlapping(t *testing.T) {
 			expectedPath:     "/appsec/v1/configs/43253/versions/15/hostname-coverage/overlapping?hostname=",
 			expectedResponse: &result,
 		},
+		"200 OK with escaped hostname": {
+			params: GetApiHostnameCoverageOverlappingRequest{
+				ConfigID: 43253,
+				Version:  15,
+				Hostname: "a&b=c.example.com",
+			},
+			headers: http.Header{
+				"Content-Type": []string{"application/json"},
+			},
+			responseStatus:   http.StatusOK,
+			responseBody:     string(respData),
+			expectedPath:     "/appsec/v1/configs/43253/versions/15/hostname-coverage/overlapping?hostname=a%26b%3Dc.example.com",
+			expectedResponse: &result,
+		},
 		"500 internal server error": {
 			params: GetApiHostnameCoverageOverlappingRequest{
 				ConfigID: 43253,
@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/session"
+	"github.com/apex/log"
 )
 
 var (
@@ -82,6 +83,7 @@ type (
 
 	appsec struct {
 		session.Session
+		options session.ClientOptions
 	}
 
 	// Option defines a PAPI option
@@ -100,5 +102,56 @@ func Client(sess session.Session, opts ...Option) APPSEC {
 	for _, opt := range opts {
 		opt(p)
 	}
+	p.Session = p.options.Apply(p.Session)
 	return p
 }
+
+// WithLogger sets the logger used by the Application Security client instead of the session logger
+func WithLogger(l log.Interface) Option {
+	return func(p *appsec) {
+		p.options.Logger = l
+	}
+}
+
+// WithRetries sets how many times idempotent Application Security requests are retried after transient failures
+func WithRetries(retries int) Option {
+	return func(p *appsec) {
+		p.options.Retries = retries
+	}
+}
+
+// WithRetryClassifier sets the callback deciding whether failed Application Security requests are retried, see session.RetryClassifier
+func WithRetryClassifier(classifier session.RetryClassifier) Option {
+	return func(p *appsec) {
+		p.options.RetryClassifier = classifier
+	}
+}
+
+// WithBackoff sets the strategy computing delays between retries of failed Application Security requests, see session.Backoff
+func WithBackoff(backoff session.Backoff) Option {
+	return func(p *appsec) {
+		p.options.Backoff = backoff
+	}
+}
+
+// WithIdempotencyKeys adds an idempotency key to Application Security POST and PATCH requests, kept when they are
+// retried; it does not make them retried by itself, see session.WithIdempotencyKeys
+func WithIdempotencyKeys(enabled bool) Option {
+	return func(p *appsec) {
+		p.options.IdempotencyKeys = enabled
+	}
+}
+
+// WithBaseURL overrides the scheme and host Application Security requests are sent to
+func WithBaseURL(baseURL string) Option {
+	return func(p *appsec) {
+		p.options.BaseURL = baseURL
+	}
+}
+
+// WithAccountSwitchKey makes Application Security requests act on the account with given switch key
+func WithAccountSwitchKey(key string) Option {
+	return func(p *appsec) {
+		p.options.AccountSwitchKey = key
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/session"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 )
 
@@ -45,7 +46,10 @@ type (
 	}
 
 	// AttackGroupConditions describes an attack group's condition information.
-	AttackGroupConditions []struct {
+	AttackGroupConditions []AttackGroupCondition
+
+	// AttackGroupCondition describes a condition of an attack group's advanced exceptions, see NewAttackGroupHostCondition and the other condition constructors.
+	AttackGroupCondition struct {
 		Type          string   `json:"type,omitempty"`
 		Extensions    []string `json:"extensions,omitempty"`
 		Filenames     []string `json:"filenames,omitempty"`
@@ -66,7 +70,10 @@ type (
 	}
 
 	// AttackGroupAdvancedCriteria describes the hostname and path criteria used to limit the scope of an exception.
-	AttackGroupAdvancedCriteria []struct {
+	AttackGroupAdvancedCriteria []AttackGroupAdvancedCriterion
+
+	// AttackGroupAdvancedCriterion describes the hostnames and paths an advanced exception applies to.
+	AttackGroupAdvancedCriterion struct {
 		Hostnames []string `json:"hostnames,omitempty"`
 		Names     []string `json:"names,omitempty"`
 		Paths     []string `json:"paths,omitempty"`
@@ -74,19 +81,28 @@ type (
 	}
 
 	// AttackGroupSpecificHeaderCookieOrParamNameValAdvanced describes the excepted name-value pairs in a request.
-	AttackGroupSpecificHeaderCookieOrParamNameValAdvanced []struct {
-		Criteria    *AttackGroupAdvancedCriteria `json:"criteria,omitempty"`
-		NamesValues []struct {
-			Names  []string `json:"names"`
-			Values []string `json:"values"`
-		} `json:"namesValues"`
-		Selector      string `json:"selector"`
-		ValueWildcard bool   `json:"valueWildcard"`
-		Wildcard      bool   `json:"wildcard"`
+	AttackGroupSpecificHeaderCookieOrParamNameValAdvanced []AttackGroupSpecificHeaderCookieOrParamNameValueAdvanced
+
+	// AttackGroupSpecificHeaderCookieOrParamNameValueAdvanced describes excepted name-value pairs of a selector.
+	AttackGroupSpecificHeaderCookieOrParamNameValueAdvanced struct {
+		Criteria      *AttackGroupAdvancedCriteria `json:"criteria,omitempty"`
+		NamesValues   []AttackGroupNamesValues     `json:"namesValues"`
+		Selector      string                       `json:"selector"`
+		ValueWildcard bool                         `json:"valueWildcard"`
+		Wildcard      bool                         `json:"wildcard"`
+	}
+
+	// AttackGroupNamesValues describes excepted names and their values.
+	AttackGroupNamesValues struct {
+		Names  []string `json:"names"`
+		Values []string `json:"values"`
 	}
 
 	// AttackGroupSpecificHeaderCookieParamXMLOrJSONNamesAdvanced describes the advanced exception members that allow you to conditionally exclude requests from inspection.
-	AttackGroupSpecificHeaderCookieParamXMLOrJSONNamesAdvanced []struct {
+	AttackGroupSpecificHeaderCookieParamXMLOrJSONNamesAdvanced []AttackGroupSpecificHeaderCookieParamXMLOrJSONNameAdvanced
+
+	// AttackGroupSpecificHeaderCookieParamXMLOrJSONNameAdvanced describes excepted names of a selector.
+	AttackGroupSpecificHeaderCookieParamXMLOrJSONNameAdvanced struct {
 		Criteria *AttackGroupAdvancedCriteria `json:"criteria,omitempty"`
 		Names    []string                     `json:"names,omitempty"`
 		Selector string                       `json:"selector,omitempty"`
@@ -94,7 +110,10 @@ type (
 	}
 
 	// AttackGroupHeaderCookieOrParamValuesAdvanced describes the list of excepted values in headers, cookies, or query parameters.
-	AttackGroupHeaderCookieOrParamValuesAdvanced []struct {
+	AttackGroupHeaderCookieOrParamValuesAdvanced []AttackGroupHeaderCookieOrParamValueAdvanced
+
+	// AttackGroupHeaderCookieOrParamValueAdvanced describes excepted values in headers, cookies, or query parameters.
+	AttackGroupHeaderCookieOrParamValueAdvanced struct {
 		Criteria      *AttackGroupAdvancedCriteria `json:"criteria,omitempty"`
 		ValueWildcard bool                         `json:"valueWildcard"`
 		Values        []string                     `json:"values,omitempty"`
@@ -106,7 +125,10 @@ type (
 	}
 
 	// AttackGroupSpecificHeaderCookieParamXMLOrJSONNames describes the advanced exception members that can be used to conditionally exclude requests from inspection.
-	AttackGroupSpecificHeaderCookieParamXMLOrJSONNames []struct {
+	AttackGroupSpecificHeaderCookieParamXMLOrJSONNames []AttackGroupSpecificHeaderCookieParamXMLOrJSONName
+
+	// AttackGroupSpecificHeaderCookieParamXMLOrJSONName describes excepted names of a selector.
+	AttackGroupSpecificHeaderCookieParamXMLOrJSONName struct {
 		Names    []string `json:"names,omitempty"`
 		Selector string   `json:"selector,omitempty"`
 		Wildcard bool     `json:"wildcard,omitempty"`
@@ -122,11 +144,14 @@ type (
 
 	// GetAttackGroupsResponse is returned from a call to GetAttackGroups.
 	GetAttackGroupsResponse struct {
-		AttackGroups []struct {
-			Group              string                         `json:"group,omitempty"`
-			Action             string                         `json:"action,omitempty"`
-			ConditionException *AttackGroupConditionException `json:"conditionException,omitempty"`
-		} `json:"attackGroupActions,omitempty"`
+		AttackGroups []AttackGroupItem `json:"attackGroupActions,omitempty"`
+	}
+
+	// AttackGroupItem describes an attack group and its action returned by GetAttackGroups.
+	AttackGroupItem struct {
+		Group              string                         `json:"group,omitempty"`
+		Action             string                         `json:"action,omitempty"`
+		ConditionException *AttackGroupConditionException `json:"conditionException,omitempty"`
 	}
 
 	// GetAttackGroupRequest is used to retrieve a list of attack groups with their associated actions.
@@ -144,13 +169,16 @@ type (
 	}
 
 	// UpdateAttackGroupRequest is used to modify what action to take when an attack group’s rule triggers.
+	// The condition exception is given either by ConditionException, see NewAttackGroupConditionException,
+	// or by JsonPayloadRaw.
 	UpdateAttackGroupRequest struct {
-		ConfigID       int             `json:"-"`
-		Version        int             `json:"-"`
-		PolicyID       string          `json:"-"`
-		Group          string          `json:"-"`
-		Action         string          `json:"action"`
-		JsonPayloadRaw json.RawMessage `json:"conditionException,omitempty"`
+		ConfigID           int                            `json:"-"`
+		Version            int                            `json:"-"`
+		PolicyID           string                         `json:"-"`
+		Group              string                         `json:"-"`
+		Action             string                         `json:"action"`
+		ConditionException *AttackGroupConditionException `json:"-"`
+		JsonPayloadRaw     json.RawMessage                `json:"conditionException,omitempty"`
 	}
 
 	// UpdateAttackGroupResponse is returned from a call to UpdateAttackGroup.
@@ -186,9 +214,12 @@ func (v GetAttackGroupsRequest) Validate() error {
 // Validate validates an UpdateAttackGroupConditionExceptionRequest.
 func (v UpdateAttackGroupRequest) Validate() error {
 	return validation.Errors{
-		"ConfigID": validation.Validate(v.ConfigID, validation.Required),
-		"Version":  validation.Validate(v.Version, validation.Required),
-		"PolicyID": validation.Validate(v.PolicyID, validation.Required),
+		"ConfigID":           validation.Validate(v.ConfigID, validation.Required),
+		"Version":            validation.Validate(v.Version, validation.Required),
+		"PolicyID":           validation.Validate(v.PolicyID, validation.Required),
+		"Action":             validation.Validate(ActionType(v.Action)),
+		"ConditionException": validation.Validate(v.ConditionException),
+		"JsonPayloadRaw":     validation.Validate(v.JsonPayloadRaw, validation.When(v.ConditionException != nil, validation.Empty.Error("must be blank when ConditionException is set"))),
 	}.Filter()
 }
 
@@ -286,6 +317,12 @@ func (p *appsec) UpdateAttackGroup(ctx context.Context, params UpdateAttackGroup
 		return nil, fmt.Errorf("failed to create UpdateAttackGroup request: %w", err)
 	}
 
+	if params.ConditionException != nil {
+		if params.JsonPayloadRaw, err = json.Marshal(params.ConditionException); err != nil {
+			return nil, fmt.Errorf("%w: %s", session.ErrMarshaling, err)
+		}
+	}
+
 	var result UpdateAttackGroupResponse
 	req.Header.Set("Content-Type", "application/json")
 	resp, err := p.Exec(req, &result, params)
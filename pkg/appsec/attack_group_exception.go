@@ -0,0 +1,317 @@
+package appsec
+
+import (
+	"fmt"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// AttackGroupConditionExceptionBuilder builds an AttackGroupConditionException, see NewAttackGroupConditionException.
+type AttackGroupConditionExceptionBuilder struct {
+	exception AttackGroupConditionException
+}
+
+const (
+	// AttackGroupConditionHostMatch matches requests for the hostnames of the condition.
+	AttackGroupConditionHostMatch = "hostMatch"
+
+	// AttackGroupConditionPathMatch matches requests for the paths of the condition.
+	AttackGroupConditionPathMatch = "pathMatch"
+
+	// AttackGroupConditionIPMatch matches requests from the IP addresses or CIDR blocks of the condition.
+	AttackGroupConditionIPMatch = "ipMatch"
+
+	// AttackGroupConditionRequestMethodMatch matches requests with the HTTP methods of the condition.
+	AttackGroupConditionRequestMethodMatch = "requestMethodMatch"
+
+	// AttackGroupConditionRequestHeaderMatch matches requests with the header and value of the condition.
+	AttackGroupConditionRequestHeaderMatch = "requestHeaderMatch"
+
+	// AttackGroupConditionExtensionMatch matches requests for files with the extensions of the condition.
+	AttackGroupConditionExtensionMatch = "extensionMatch"
+
+	// AttackGroupConditionFilenameMatch matches requests for the filenames of the condition.
+	AttackGroupConditionFilenameMatch = "filenameMatch"
+
+	// AttackGroupConditionURIQueryMatch matches requests with the query parameter and value of the condition.
+	AttackGroupConditionURIQueryMatch = "uriQueryMatch"
+
+	// AttackGroupConditionOperatorAnd requires all conditions of an advanced exception to match.
+	AttackGroupConditionOperatorAnd = "AND"
+
+	// AttackGroupConditionOperatorOr requires any condition of an advanced exception to match.
+	AttackGroupConditionOperatorOr = "OR"
+
+	// AttackGroupSelectorRequestHeaders selects request headers.
+	AttackGroupSelectorRequestHeaders = "REQUEST_HEADERS"
+
+	// AttackGroupSelectorRequestCookies selects request cookies.
+	AttackGroupSelectorRequestCookies = "REQUEST_COOKIES"
+
+	// AttackGroupSelectorArgs selects query and body parameters.
+	AttackGroupSelectorArgs = "ARGS"
+
+	// AttackGroupSelectorJSONPairs selects the members of JSON bodies.
+	AttackGroupSelectorJSONPairs = "JSON_PAIRS"
+
+	// AttackGroupSelectorXMLPairs selects the elements of XML bodies.
+	AttackGroupSelectorXMLPairs = "XML_PAIRS"
+)
+
+// NewAttackGroupConditionException returns a builder of an attack group condition exception, e.g.:
+//
+//	exception, err := appsec.NewAttackGroupConditionException().
+//		ConditionOperator(appsec.AttackGroupConditionOperatorAnd).
+//		Conditions(appsec.NewAttackGroupHostCondition("www.example.com"), appsec.NewAttackGroupPathCondition("/search")).
+//		HeaderCookieOrParamValues(appsec.AttackGroupHeaderCookieOrParamValueAdvanced{
+//			Values:   []string{"select"},
+//			Criteria: appsec.NewAttackGroupCriteria([]string{"www.example.com"}, nil),
+//		}).
+//		Build()
+func NewAttackGroupConditionException() *AttackGroupConditionExceptionBuilder {
+	return &AttackGroupConditionExceptionBuilder{}
+}
+
+// ConditionOperator sets whether all or any of the conditions must match, see the AttackGroupConditionOperator constants.
+func (b *AttackGroupConditionExceptionBuilder) ConditionOperator(operator string) *AttackGroupConditionExceptionBuilder {
+	b.advanced().ConditionOperator = operator
+	return b
+}
+
+// Conditions adds conditions of the advanced exceptions.
+func (b *AttackGroupConditionExceptionBuilder) Conditions(conditions ...AttackGroupCondition) *AttackGroupConditionExceptionBuilder {
+	appendAttackGroupList(&b.advanced().Conditions, conditions...)
+	return b
+}
+
+// HeaderCookieOrParamValues adds values excepted in headers, cookies or parameters.
+func (b *AttackGroupConditionExceptionBuilder) HeaderCookieOrParamValues(values ...AttackGroupHeaderCookieOrParamValueAdvanced) *AttackGroupConditionExceptionBuilder {
+	appendAttackGroupList(&b.advanced().HeaderCookieOrParamValues, values...)
+	return b
+}
+
+// SpecificHeaderCookieOrParamNameValue adds name-value pairs excepted in headers, cookies or parameters.
+func (b *AttackGroupConditionExceptionBuilder) SpecificHeaderCookieOrParamNameValue(namesValues ...AttackGroupSpecificHeaderCookieOrParamNameValueAdvanced) *AttackGroupConditionExceptionBuilder {
+	appendAttackGroupList(&b.advanced().SpecificHeaderCookieOrParamNameValue, namesValues...)
+	return b
+}
+
+// SpecificHeaderCookieParamXMLOrJSONNames adds names excepted in headers, cookies, parameters, XML or JSON bodies.
+func (b *AttackGroupConditionExceptionBuilder) SpecificHeaderCookieParamXMLOrJSONNames(names ...AttackGroupSpecificHeaderCookieParamXMLOrJSONNameAdvanced) *AttackGroupConditionExceptionBuilder {
+	appendAttackGroupList(&b.advanced().SpecificHeaderCookieParamXMLOrJSONNames, names...)
+	return b
+}
+
+// Exception adds names excepted in headers, cookies, parameters, XML or JSON bodies regardless of any condition.
+func (b *AttackGroupConditionExceptionBuilder) Exception(names ...AttackGroupSpecificHeaderCookieParamXMLOrJSONName) *AttackGroupConditionExceptionBuilder {
+	if b.exception.Exception == nil {
+		b.exception.Exception = &AttackGroupException{}
+	}
+	appendAttackGroupList(&b.exception.Exception.SpecificHeaderCookieParamXMLOrJSONNames, names...)
+	return b
+}
+
+// Build validates and returns the condition exception.
+func (b *AttackGroupConditionExceptionBuilder) Build() (*AttackGroupConditionException, error) {
+	exception := AttackGroupConditionException{}.Merge(b.exception)
+	if err := exception.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrStructValidation, err)
+	}
+	return &exception, nil
+}
+
+func (b *AttackGroupConditionExceptionBuilder) advanced() *AttackGroupAdvancedExceptions {
+	if b.exception.AdvancedExceptionsList == nil {
+		b.exception.AdvancedExceptionsList = &AttackGroupAdvancedExceptions{}
+	}
+	return b.exception.AdvancedExceptionsList
+}
+
+// NewAttackGroupHostCondition returns a condition matching requests for given hostnames.
+func NewAttackGroupHostCondition(hosts ...string) AttackGroupCondition {
+	return AttackGroupCondition{Type: AttackGroupConditionHostMatch, Hosts: hosts, PositiveMatch: true}
+}
+
+// NewAttackGroupPathCondition returns a condition matching requests for given paths.
+func NewAttackGroupPathCondition(paths ...string) AttackGroupCondition {
+	return AttackGroupCondition{Type: AttackGroupConditionPathMatch, Paths: paths, PositiveMatch: true}
+}
+
+// NewAttackGroupIPCondition returns a condition matching requests from given IP addresses or CIDR blocks.
+func NewAttackGroupIPCondition(ips ...string) AttackGroupCondition {
+	return AttackGroupCondition{Type: AttackGroupConditionIPMatch, Ips: ips, PositiveMatch: true}
+}
+
+// NewAttackGroupMethodCondition returns a condition matching requests with given HTTP methods.
+func NewAttackGroupMethodCondition(methods ...string) AttackGroupCondition {
+	return AttackGroupCondition{Type: AttackGroupConditionRequestMethodMatch, Methods: methods, PositiveMatch: true}
+}
+
+// NewAttackGroupExtensionCondition returns a condition matching requests for files with given extensions.
+func NewAttackGroupExtensionCondition(extensions ...string) AttackGroupCondition {
+	return AttackGroupCondition{Type: AttackGroupConditionExtensionMatch, Extensions: extensions, PositiveMatch: true}
+}
+
+// NewAttackGroupFilenameCondition returns a condition matching requests for given filenames.
+func NewAttackGroupFilenameCondition(filenames ...string) AttackGroupCondition {
+	return AttackGroupCondition{Type: AttackGroupConditionFilenameMatch, Filenames: filenames, PositiveMatch: true}
+}
+
+// NewAttackGroupHeaderCondition returns a condition matching requests with given header, and value unless empty.
+func NewAttackGroupHeaderCondition(header, value string) AttackGroupCondition {
+	return AttackGroupCondition{Type: AttackGroupConditionRequestHeaderMatch, Header: header, Value: value, PositiveMatch: true}
+}
+
+// NewAttackGroupURIQueryCondition returns a condition matching requests with given query parameter, and value unless empty.
+func NewAttackGroupURIQueryCondition(name, value string) AttackGroupCondition {
+	return AttackGroupCondition{Type: AttackGroupConditionURIQueryMatch, Name: name, Value: value, PositiveMatch: true}
+}
+
+// Negate returns the condition matching the requests the condition does not match.
+func (v AttackGroupCondition) Negate() AttackGroupCondition {
+	v.PositiveMatch = !v.PositiveMatch
+	return v
+}
+
+// NewAttackGroupCriteria returns criteria limiting an advanced exception to given hostnames and paths.
+func NewAttackGroupCriteria(hostnames, paths []string) *AttackGroupAdvancedCriteria {
+	return &AttackGroupAdvancedCriteria{{Hostnames: hostnames, Paths: paths}}
+}
+
+// Merge returns the condition exception with the conditions and exceptions of other appended,
+// leaving both unchanged. The condition operator is the one of v unless it is not set.
+func (v AttackGroupConditionException) Merge(other AttackGroupConditionException) AttackGroupConditionException {
+	var merged AttackGroupConditionException
+	for _, advanced := range []*AttackGroupAdvancedExceptions{v.AdvancedExceptionsList, other.AdvancedExceptionsList} {
+		if advanced == nil {
+			continue
+		}
+		if merged.AdvancedExceptionsList == nil {
+			merged.AdvancedExceptionsList = &AttackGroupAdvancedExceptions{}
+		}
+		m := merged.AdvancedExceptionsList
+		if m.ConditionOperator == "" {
+			m.ConditionOperator = advanced.ConditionOperator
+		}
+		if advanced.Conditions != nil {
+			appendAttackGroupList(&m.Conditions, *advanced.Conditions...)
+		}
+		if advanced.HeaderCookieOrParamValues != nil {
+			appendAttackGroupList(&m.HeaderCookieOrParamValues, *advanced.HeaderCookieOrParamValues...)
+		}
+		if advanced.SpecificHeaderCookieOrParamNameValue != nil {
+			appendAttackGroupList(&m.SpecificHeaderCookieOrParamNameValue, *advanced.SpecificHeaderCookieOrParamNameValue...)
+		}
+		if advanced.SpecificHeaderCookieParamXMLOrJSONNames != nil {
+			appendAttackGroupList(&m.SpecificHeaderCookieParamXMLOrJSONNames, *advanced.SpecificHeaderCookieParamXMLOrJSONNames...)
+		}
+	}
+	for _, exception := range []*AttackGroupException{v.Exception, other.Exception} {
+		if exception == nil {
+			continue
+		}
+		if merged.Exception == nil {
+			merged.Exception = &AttackGroupException{}
+		}
+		if exception.SpecificHeaderCookieParamXMLOrJSONNames != nil {
+			appendAttackGroupList(&merged.Exception.SpecificHeaderCookieParamXMLOrJSONNames, *exception.SpecificHeaderCookieParamXMLOrJSONNames...)
+		}
+	}
+	return merged
+}
+
+// MergeConditionException returns the condition exception of the attack group with the conditions and exceptions
+// of exception appended, to be set as ConditionException of an UpdateAttackGroupRequest.
+func (v GetAttackGroupResponse) MergeConditionException(exception AttackGroupConditionException) AttackGroupConditionException {
+	if v.ConditionException == nil {
+		return AttackGroupConditionException{}.Merge(exception)
+	}
+	return v.ConditionException.Merge(exception)
+}
+
+// Validate validates an AttackGroupConditionException.
+func (v AttackGroupConditionException) Validate() error {
+	return validation.Errors{
+		"AdvancedExceptionsList": validation.Validate(v.AdvancedExceptionsList),
+		"Exception":              validation.Validate(v.Exception),
+	}.Filter()
+}
+
+// Validate validates an AttackGroupAdvancedExceptions.
+func (v AttackGroupAdvancedExceptions) Validate() error {
+	return validation.Errors{
+		"ConditionOperator":                       validation.Validate(v.ConditionOperator, validation.In(AttackGroupConditionOperatorAnd, AttackGroupConditionOperatorOr)),
+		"Conditions":                              validation.Validate(v.Conditions),
+		"HeaderCookieOrParamValues":               validation.Validate(v.HeaderCookieOrParamValues),
+		"SpecificHeaderCookieOrParamNameValue":    validation.Validate(v.SpecificHeaderCookieOrParamNameValue),
+		"SpecificHeaderCookieParamXMLOrJSONNames": validation.Validate(v.SpecificHeaderCookieParamXMLOrJSONNames),
+	}.Filter()
+}
+
+// Validate validates an AttackGroupException.
+func (v AttackGroupException) Validate() error {
+	return validation.Errors{
+		"SpecificHeaderCookieParamXMLOrJSONNames": validation.Validate(v.SpecificHeaderCookieParamXMLOrJSONNames),
+	}.Filter()
+}
+
+// Validate validates an AttackGroupCondition.
+func (v AttackGroupCondition) Validate() error {
+	return validation.Errors{
+		"Type": validation.Validate(v.Type, validation.Required, validation.In(
+			AttackGroupConditionHostMatch, AttackGroupConditionPathMatch, AttackGroupConditionIPMatch,
+			AttackGroupConditionRequestMethodMatch, AttackGroupConditionRequestHeaderMatch, AttackGroupConditionExtensionMatch,
+			AttackGroupConditionFilenameMatch, AttackGroupConditionURIQueryMatch,
+		)),
+		"Hosts":      validation.Validate(v.Hosts, validation.When(v.Type == AttackGroupConditionHostMatch, validation.Required)),
+		"Paths":      validation.Validate(v.Paths, validation.When(v.Type == AttackGroupConditionPathMatch, validation.Required)),
+		"Ips":        validation.Validate(v.Ips, validation.When(v.Type == AttackGroupConditionIPMatch, validation.Required)),
+		"Methods":    validation.Validate(v.Methods, validation.When(v.Type == AttackGroupConditionRequestMethodMatch, validation.Required)),
+		"Extensions": validation.Validate(v.Extensions, validation.When(v.Type == AttackGroupConditionExtensionMatch, validation.Required)),
+		"Filenames":  validation.Validate(v.Filenames, validation.When(v.Type == AttackGroupConditionFilenameMatch, validation.Required)),
+		"Header":     validation.Validate(v.Header, validation.When(v.Type == AttackGroupConditionRequestHeaderMatch, validation.Required)),
+		"Name":       validation.Validate(v.Name, validation.When(v.Type == AttackGroupConditionURIQueryMatch, validation.Required)),
+	}.Filter()
+}
+
+// Validate validates an AttackGroupHeaderCookieOrParamValueAdvanced.
+func (v AttackGroupHeaderCookieOrParamValueAdvanced) Validate() error {
+	return validation.Errors{
+		"Values": validation.Validate(v.Values, validation.Required),
+	}.Filter()
+}
+
+// Validate validates an AttackGroupSpecificHeaderCookieOrParamNameValueAdvanced.
+func (v AttackGroupSpecificHeaderCookieOrParamNameValueAdvanced) Validate() error {
+	return validation.Errors{
+		"NamesValues": validation.Validate(v.NamesValues, validation.Required),
+		"Selector":    validation.Validate(v.Selector, validation.Required),
+	}.Filter()
+}
+
+// Validate validates an AttackGroupSpecificHeaderCookieParamXMLOrJSONNameAdvanced.
+func (v AttackGroupSpecificHeaderCookieParamXMLOrJSONNameAdvanced) Validate() error {
+	return validation.Errors{
+		"Names":    validation.Validate(v.Names, validation.Required),
+		"Selector": validation.Validate(v.Selector, validation.Required),
+	}.Filter()
+}
+
+// Validate validates an AttackGroupSpecificHeaderCookieParamXMLOrJSONName.
+func (v AttackGroupSpecificHeaderCookieParamXMLOrJSONName) Validate() error {
+	return validation.Errors{
+		"Names":    validation.Validate(v.Names, validation.Required),
+		"Selector": validation.Validate(v.Selector, validation.Required),
+	}.Filter()
+}
+
+// appendAttackGroupList appends items to a copy of the list list points to, allocating it if needed,
+// so that lists shared with other condition exceptions are left unchanged
+func appendAttackGroupList[S ~[]E, E any](list **S, items ...E) {
+	var appended S
+	if *list != nil {
+		appended = append(appended, **list...)
+	}
+	appended = append(appended, items...)
+	*list = &appended
+}
@@ -0,0 +1,99 @@
+package appsec
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttackGroupConditionExceptionBuilder(t *testing.T) {
+	tests := map[string]struct {
+		builder      *AttackGroupConditionExceptionBuilder
+		expectedJSON string
+		withError    error
+	}{
+		"advanced exceptions": {
+			builder: NewAttackGroupConditionException().
+				ConditionOperator(AttackGroupConditionOperatorOr).
+				Conditions(NewAttackGroupHostCondition("www.example.com"), NewAttackGroupMethodCondition("POST").Negate()).
+				HeaderCookieOrParamValues(AttackGroupHeaderCookieOrParamValueAdvanced{
+					Values:   []string{"select"},
+					Criteria: NewAttackGroupCriteria([]string{"www.example.com"}, []string{"/search"}),
+				}).
+				SpecificHeaderCookieOrParamNameValue(AttackGroupSpecificHeaderCookieOrParamNameValueAdvanced{
+					Selector:    AttackGroupSelectorArgs,
+					NamesValues: []AttackGroupNamesValues{{Names: []string{"q"}, Values: []string{"*"}}},
+				}),
+			expectedJSON: `{"advancedExceptions":{
+				"conditionOperator":"OR",
+				"conditions":[
+					{"type":"hostMatch","hosts":["www.example.com"],"positiveMatch":true},
+					{"type":"requestMethodMatch","methods":["POST"],"positiveMatch":false}
+				],
+				"headerCookieOrParamValues":[{"criteria":[{"hostnames":["www.example.com"],"paths":["/search"]}],"valueWildcard":false,"values":["select"]}],
+				"specificHeaderCookieOrParamNameValue":[{"namesValues":[{"names":["q"],"values":["*"]}],"selector":"ARGS","valueWildcard":false,"wildcard":false}]
+			}}`,
+		},
+		"exception": {
+			builder: NewAttackGroupConditionException().
+				Exception(AttackGroupSpecificHeaderCookieParamXMLOrJSONName{Selector: AttackGroupSelectorRequestCookies, Names: []string{"session"}}),
+			expectedJSON: `{"exception":{"specificHeaderCookieParamXmlOrJsonNames":[{"names":["session"],"selector":"REQUEST_COOKIES"}]}}`,
+		},
+		"invalid operator": {
+			builder:   NewAttackGroupConditionException().ConditionOperator("XOR").Conditions(NewAttackGroupPathCondition("/")),
+			withError: ErrStructValidation,
+		},
+		"condition without values": {
+			builder:   NewAttackGroupConditionException().Conditions(NewAttackGroupIPCondition()),
+			withError: ErrStructValidation,
+		},
+		"exception without selector": {
+			builder:   NewAttackGroupConditionException().Exception(AttackGroupSpecificHeaderCookieParamXMLOrJSONName{Names: []string{"session"}}),
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			exception, err := test.builder.Build()
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			body, err := json.Marshal(exception)
+			require.NoError(t, err)
+			assert.JSONEq(t, test.expectedJSON, string(body))
+		})
+	}
+}
+
+func TestGetAttackGroupResponse_MergeConditionException(t *testing.T) {
+	existing := &AttackGroupConditionException{
+		AdvancedExceptionsList: &AttackGroupAdvancedExceptions{
+			ConditionOperator: AttackGroupConditionOperatorAnd,
+			Conditions:        &AttackGroupConditions{NewAttackGroupHostCondition("www.example.com")},
+		},
+	}
+	group := GetAttackGroupResponse{Action: "deny", ConditionException: existing}
+
+	added, err := NewAttackGroupConditionException().
+		ConditionOperator(AttackGroupConditionOperatorOr).
+		Conditions(NewAttackGroupPathCondition("/login")).
+		Exception(AttackGroupSpecificHeaderCookieParamXMLOrJSONName{Selector: AttackGroupSelectorArgs, Names: []string{"password"}}).
+		Build()
+	require.NoError(t, err)
+
+	merged := group.MergeConditionException(*added)
+	assert.Equal(t, AttackGroupConditionOperatorAnd, merged.AdvancedExceptionsList.ConditionOperator)
+	assert.Equal(t, AttackGroupConditions{NewAttackGroupHostCondition("www.example.com"), NewAttackGroupPathCondition("/login")}, *merged.AdvancedExceptionsList.Conditions)
+	assert.Equal(t, AttackGroupSpecificHeaderCookieParamXMLOrJSONNames{{Selector: AttackGroupSelectorArgs, Names: []string{"password"}}}, *merged.Exception.SpecificHeaderCookieParamXMLOrJSONNames)
+	assert.Len(t, *existing.AdvancedExceptionsList.Conditions, 1)
+
+	merged = GetAttackGroupResponse{}.MergeConditionException(*added)
+	assert.Equal(t, AttackGroupConditionOperatorOr, merged.AdvancedExceptionsList.ConditionOperator)
+	assert.Equal(t, *added.AdvancedExceptionsList.Conditions, *merged.AdvancedExceptionsList.Conditions)
+}
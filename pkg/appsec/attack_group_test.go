@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -184,13 +185,14 @@ func TestAppSec_UpdateAttackGroup(t *testing.T) {
 	require.NoError(t, err)
 
 	tests := map[string]struct {
-		params           UpdateAttackGroupRequest
-		responseStatus   int
-		responseBody     string
-		expectedPath     string
-		expectedResponse *UpdateAttackGroupResponse
-		withError        error
-		headers          http.Header
+		params              UpdateAttackGroupRequest
+		responseStatus      int
+		responseBody        string
+		expectedPath        string
+		expectedResponse    *UpdateAttackGroupResponse
+		expectedRequestBody string
+		withError           error
+		headers             http.Header
 	}{
 		"200 Success": {
 			params: UpdateAttackGroupRequest{
@@ -207,6 +209,50 @@ func TestAppSec_UpdateAttackGroup(t *testing.T) {
 			expectedResponse: &result,
 			expectedPath:     "/appsec/v1/configs/43253/versions/15/security-policies/AAAA_81230/attack-groups/SQL/action-condition-exception",
 		},
+		"200 Success with condition exception": {
+			params: UpdateAttackGroupRequest{
+				ConfigID: 43253,
+				Version:  15,
+				PolicyID: "AAAA_81230",
+				Group:    "SQL",
+				Action:   "deny",
+				ConditionException: &AttackGroupConditionException{
+					AdvancedExceptionsList: &AttackGroupAdvancedExceptions{
+						ConditionOperator: AttackGroupConditionOperatorAnd,
+						Conditions:        &AttackGroupConditions{NewAttackGroupHostCondition("www.example.com")},
+					},
+				},
+			},
+			responseStatus:      http.StatusOK,
+			responseBody:        respData,
+			expectedResponse:    &result,
+			expectedPath:        "/appsec/v1/configs/43253/versions/15/security-policies/AAAA_81230/attack-groups/SQL/action-condition-exception",
+			expectedRequestBody: `{"action":"deny","conditionException":{"advancedExceptions":{"conditionOperator":"AND","conditions":[{"type":"hostMatch","hosts":["www.example.com"],"positiveMatch":true}]}}}`,
+		},
+		"invalid action": {
+			params: UpdateAttackGroupRequest{
+				ConfigID: 43253,
+				Version:  15,
+				PolicyID: "AAAA_81230",
+				Group:    "SQL",
+				Action:   "block",
+			},
+			withError: ErrStructValidation,
+		},
+		"invalid condition exception": {
+			params: UpdateAttackGroupRequest{
+				ConfigID: 43253,
+				Version:  15,
+				PolicyID: "AAAA_81230",
+				Group:    "SQL",
+				ConditionException: &AttackGroupConditionException{
+					AdvancedExceptionsList: &AttackGroupAdvancedExceptions{
+						Conditions: &AttackGroupConditions{{Type: "unknownMatch"}},
+					},
+				},
+			},
+			withError: ErrStructValidation,
+		},
 		"500 internal server error": {
 			params: UpdateAttackGroupRequest{
 				ConfigID: 43253,
@@ -235,6 +281,11 @@ func TestAppSec_UpdateAttackGroup(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				assert.Equal(t, http.MethodPut, r.Method)
+				if test.expectedRequestBody != "" {
+					body, err := ioutil.ReadAll(r.Body)
+					require.NoError(t, err)
+					assert.JSONEq(t, test.expectedRequestBody, string(body))
+				}
 				w.WriteHeader(test.responseStatus)
 				if len(test.responseBody) > 0 {
 					_, err := w.Write([]byte(test.responseBody))
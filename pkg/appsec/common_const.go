@@ -1,5 +1,10 @@
 package appsec
 
+import (
+	"fmt"
+	"strings"
+)
+
 type (
 	// RulesetType is a ruleset type value.
 	RulesetType string
@@ -20,4 +25,23 @@ const (
 	ActionTypeAlert ActionType = "alert"
 	// ActionTypeNone firewall no action.
 	ActionTypeNone ActionType = "none"
+
+	// ActionTypeDenyCustomPrefix prefixes the IDs of custom deny actions, see ActionTypeDenyCustom.
+	ActionTypeDenyCustomPrefix = "deny_custom_"
 )
+
+// ActionTypeDenyCustom returns the action denying requests with the custom deny action with given ID, e.g. deny_custom_622918.
+func ActionTypeDenyCustom(id string) ActionType {
+	return ActionType(ActionTypeDenyCustomPrefix + id)
+}
+
+// Validate validates an ActionType, allowing it to be empty for requests which keep the current action.
+func (t ActionType) Validate() error {
+	switch {
+	case t == "", t == ActionTypeAlert, t == ActionTypeDeny, t == ActionTypeNone:
+		return nil
+	case strings.HasPrefix(string(t), ActionTypeDenyCustomPrefix) && len(t) > len(ActionTypeDenyCustomPrefix):
+		return nil
+	}
+	return fmt.Errorf("value '%s' is invalid. Must be one of: 'alert', 'deny', 'deny_custom_{ID}' or 'none'", t)
+}
@@ -0,0 +1,16 @@
+package appsec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActionType_Validate(t *testing.T) {
+	for _, action := range []ActionType{"", ActionTypeAlert, ActionTypeDeny, ActionTypeNone, ActionTypeDenyCustom("622918")} {
+		assert.NoError(t, action.Validate(), action)
+	}
+	for _, action := range []ActionType{"block", "Deny", ActionTypeDenyCustomPrefix, "deny_custom"} {
+		assert.Error(t, action.Validate(), action)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/session"
 )
 
 type (
@@ -19,30 +21,41 @@ type (
 	GetConfigurationVersionsRequest struct {
 		ConfigID      int `json:"configId"`
 		ConfigVersion int `json:"configVersion"`
+		// Page is the one-based index of the page to return; all versions are returned when it is not set
+		Page int `json:"-"`
+		// PageSize is the number of versions per page, used with Page; DefaultConfigurationVersionsPageSize when not set
+		PageSize int `json:"-"`
 	}
 
 	// GetConfigurationVersionsResponse is returned from a call to GetConfigurationVersions.
 	GetConfigurationVersionsResponse struct {
-		ConfigID           int    `json:"configId,omitempty"`
-		ConfigName         string `json:"configName,omitempty"`
-		LastCreatedVersion int    `json:"lastCreatedVersion,omitempty"`
-		Page               int    `json:"page,omitempty"`
-		PageSize           int    `json:"pageSize,omitempty"`
-		TotalSize          int    `json:"totalSize,omitempty"`
-		VersionList        []struct {
-			ConfigID   int `json:"configId,omitempty"`
-			Production struct {
-				Status string `json:"status,omitempty"`
-			} `json:"production,omitempty"`
-			Staging struct {
-				Status string `json:"status,omitempty"`
-			} `json:"staging,omitempty"`
-			Version int `json:"version,omitempty"`
-			BasedOn int `json:"basedOn,omitempty"`
-		} `json:"versionList,omitempty"`
+		ConfigID           int                        `json:"configId,omitempty"`
+		ConfigName         string                     `json:"configName,omitempty"`
+		LastCreatedVersion int                        `json:"lastCreatedVersion,omitempty"`
+		Page               int                        `json:"page,omitempty"`
+		PageSize           int                        `json:"pageSize,omitempty"`
+		TotalSize          int                        `json:"totalSize,omitempty"`
+		VersionList        []ConfigurationVersionItem `json:"versionList,omitempty"`
+	}
+
+	// ConfigurationVersionItem is a version of a security configuration.
+	ConfigurationVersionItem struct {
+		ConfigID   int `json:"configId,omitempty"`
+		Production struct {
+			Status string `json:"status,omitempty"`
+		} `json:"production,omitempty"`
+		Staging struct {
+			Status string `json:"status,omitempty"`
+		} `json:"staging,omitempty"`
+		Version int `json:"version,omitempty"`
+		BasedOn int `json:"basedOn,omitempty"`
 	}
 )
 
+// DefaultConfigurationVersionsPageSize is the page size of GetConfigurationVersions and NewConfigurationVersionsPager
+// when none is given
+const DefaultConfigurationVersionsPageSize = 25
+
 func (p *appsec) GetConfigurationVersions(ctx context.Context, params GetConfigurationVersionsRequest) (*GetConfigurationVersionsResponse, error) {
 	logger := p.Log(ctx)
 	logger.Debug("GetConfigurationVersions")
@@ -50,6 +63,14 @@ func (p *appsec) GetConfigurationVersions(ctx context.Context, params GetConfigu
 	uri := fmt.Sprintf(
 		"/appsec/v1/configs/%d/versions?page=-1&detail=false",
 		params.ConfigID)
+	if params.Page > 0 {
+		if params.PageSize <= 0 {
+			params.PageSize = DefaultConfigurationVersionsPageSize
+		}
+		uri = fmt.Sprintf(
+			"/appsec/v1/configs/%d/versions?page=%d&pageSize=%d&detail=false",
+			params.ConfigID, params.Page, params.PageSize)
+	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
 	if err != nil {
@@ -67,3 +88,25 @@ func (p *appsec) GetConfigurationVersions(ctx context.Context, params GetConfigu
 
 	return &result, nil
 }
+
+// NewConfigurationVersionsPager returns a pager listing the versions of a security configuration page by page
+// with GetConfigurationVersions, each page holding up to params.PageSize versions, or DefaultConfigurationVersionsPageSize
+// when not set. The Page field of params is ignored.
+func NewConfigurationVersionsPager(client ConfigurationVersion, params GetConfigurationVersionsRequest) session.Pager[ConfigurationVersionItem] {
+	if params.PageSize <= 0 {
+		params.PageSize = DefaultConfigurationVersionsPageSize
+	}
+	return session.NewPager(func(ctx context.Context, page int) ([]ConfigurationVersionItem, bool, error) {
+		req := params
+		req.Page = page + 1
+		resp, err := client.GetConfigurationVersions(ctx, req)
+		if err != nil {
+			return nil, false, err
+		}
+		items := resp.VersionList
+		if resp.TotalSize > 0 {
+			return items, req.Page*req.PageSize < resp.TotalSize, nil
+		}
+		return items, len(items) == req.PageSize, nil
+	})
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/session"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -42,6 +43,29 @@ func TestAppSec_ListConfigurationVersions(t *testing.T) {
 			expectedPath:     "/appsec/v1/configs/43253/versions?detail=false&page=-1",
 			expectedResponse: &result,
 		},
+		"200 OK, single page": {
+			params: GetConfigurationVersionsRequest{
+				ConfigID: 43253,
+				Page:     2,
+				PageSize: 10,
+			},
+			headers:          http.Header{},
+			responseStatus:   http.StatusOK,
+			responseBody:     string(respData),
+			expectedPath:     "/appsec/v1/configs/43253/versions?detail=false&page=2&pageSize=10",
+			expectedResponse: &result,
+		},
+		"200 OK, single page with default page size": {
+			params: GetConfigurationVersionsRequest{
+				ConfigID: 43253,
+				Page:     2,
+			},
+			headers:          http.Header{},
+			responseStatus:   http.StatusOK,
+			responseBody:     string(respData),
+			expectedPath:     "/appsec/v1/configs/43253/versions?detail=false&page=2&pageSize=25",
+			expectedResponse: &result,
+		},
 		"500 internal server error": {
 			params: GetConfigurationVersionsRequest{
 				ConfigID: 43253,
@@ -90,3 +114,30 @@ func TestAppSec_ListConfigurationVersions(t *testing.T) {
 		})
 	}
 }
+
+func TestAppSec_NewConfigurationVersionsPager(t *testing.T) {
+	params := GetConfigurationVersionsRequest{ConfigID: 43253, PageSize: 2}
+	page := func(page int) GetConfigurationVersionsRequest {
+		p := params
+		p.Page = page
+		return p
+	}
+	client := &Mock{}
+	client.On("GetConfigurationVersions", mock.Anything, page(1)).Return(&GetConfigurationVersionsResponse{
+		Page: 1, PageSize: 2, TotalSize: 3,
+		VersionList: []ConfigurationVersionItem{{ConfigID: 43253, Version: 3}, {ConfigID: 43253, Version: 2}},
+	}, nil).Once()
+	client.On("GetConfigurationVersions", mock.Anything, page(2)).Return(&GetConfigurationVersionsResponse{
+		Page: 2, PageSize: 2, TotalSize: 3,
+		VersionList: []ConfigurationVersionItem{{ConfigID: 43253, Version: 1}},
+	}, nil).Once()
+
+	versions := session.NewItemIterator(NewConfigurationVersionsPager(client, params))
+	var numbers []int
+	for versions.Next(context.Background()) {
+		numbers = append(numbers, versions.Item().Version)
+	}
+	require.NoError(t, versions.Err())
+	assert.Equal(t, []int{3, 2, 1}, numbers)
+	client.AssertExpectations(t)
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"reflect"
 	"strconv"
 
@@ -44,6 +45,7 @@ type (
 	customDenyID string
 
 	// GetCustomDenyListRequest is used to retrieve the custom deny actions for a configuration.
+	// If ID is set, only that action is retrieved, and an error is returned if it does not exist.
 	GetCustomDenyListRequest struct {
 		ConfigID int    `json:"configId"`
 		Version  int    `json:"version"`
@@ -52,16 +54,22 @@ type (
 
 	// GetCustomDenyListResponse is returned from a call to GetCustomDenyList.
 	GetCustomDenyListResponse struct {
-		CustomDenyList []struct {
-			Description string       `json:"description,omitempty"`
-			Name        string       `json:"name"`
-			ID          customDenyID `json:"id"`
-			Parameters  []struct {
-				DisplayName string `json:"-"`
-				Name        string `json:"name"`
-				Value       string `json:"value"`
-			} `json:"parameters"`
-		} `json:"customDenyList"`
+		CustomDenyList []CustomDenyItem `json:"customDenyList"`
+	}
+
+	// CustomDenyItem describes a custom deny action returned by GetCustomDenyList.
+	CustomDenyItem struct {
+		Description string                        `json:"description,omitempty"`
+		Name        string                        `json:"name"`
+		ID          customDenyID                  `json:"id"`
+		Parameters  []CustomDenyResponseParameter `json:"parameters"`
+	}
+
+	// CustomDenyResponseParameter describes a parameter of a custom deny action returned by the API.
+	CustomDenyResponseParameter struct {
+		DisplayName string `json:"-"`
+		Name        string `json:"name"`
+		Value       string `json:"value"`
 	}
 
 	// GetCustomDenyRequest is used to retrieve a specific custom deny action.
@@ -73,53 +81,45 @@ type (
 
 	// GetCustomDenyResponse is returned from a call to GetCustomDeny.
 	GetCustomDenyResponse struct {
-		Description string       `json:"description,omitempty"`
-		Name        string       `json:"name"`
-		ID          customDenyID `json:"-"`
-		Parameters  []struct {
-			DisplayName string `json:"-"`
-			Name        string `json:"name"`
-			Value       string `json:"value"`
-		} `json:"parameters"`
+		Description string                        `json:"description,omitempty"`
+		Name        string                        `json:"name"`
+		ID          customDenyID                  `json:"-"`
+		Parameters  []CustomDenyResponseParameter `json:"parameters"`
 	}
 
 	// CreateCustomDenyRequest is used to create a new custom deny action for a specific configuration.
+	// The action is described either by Payload or by JsonPayloadRaw.
 	CreateCustomDenyRequest struct {
-		ConfigID       int             `json:"-"`
-		Version        int             `json:"-"`
-		JsonPayloadRaw json.RawMessage `json:"-"`
+		ConfigID       int                `json:"-"`
+		Version        int                `json:"-"`
+		Payload        *CustomDenyPayload `json:"-"`
+		JsonPayloadRaw json.RawMessage    `json:"-"`
 	}
 
 	// CreateCustomDenyResponse is returned from a call to CreateCustomDeny.
 	CreateCustomDenyResponse struct {
-		Description string       `json:"description,omitempty"`
-		Name        string       `json:"name"`
-		ID          customDenyID `json:"id"`
-		Parameters  []struct {
-			DisplayName string `json:"-"`
-			Name        string `json:"name"`
-			Value       string `json:"value"`
-		} `json:"parameters"`
+		Description string                        `json:"description,omitempty"`
+		Name        string                        `json:"name"`
+		ID          customDenyID                  `json:"id"`
+		Parameters  []CustomDenyResponseParameter `json:"parameters"`
 	}
 
 	// UpdateCustomDenyRequest is used to details for a specific custom deny action.
+	// The action is described either by Payload or by JsonPayloadRaw.
 	UpdateCustomDenyRequest struct {
-		ConfigID       int             `json:"-"`
-		Version        int             `json:"-"`
-		ID             string          `json:"id"`
-		JsonPayloadRaw json.RawMessage `json:"-"`
+		ConfigID       int                `json:"-"`
+		Version        int                `json:"-"`
+		ID             string             `json:"id"`
+		Payload        *CustomDenyPayload `json:"-"`
+		JsonPayloadRaw json.RawMessage    `json:"-"`
 	}
 
 	// UpdateCustomDenyResponse is returned from a call to UpdateCustomDeny.
 	UpdateCustomDenyResponse struct {
-		Description string       `json:"description,omitempty"`
-		Name        string       `json:"name"`
-		ID          customDenyID `json:"-"`
-		Parameters  []struct {
-			DisplayName string `json:"-"`
-			Name        string `json:"name"`
-			Value       string `json:"value"`
-		} `json:"parameters"`
+		Description string                        `json:"description,omitempty"`
+		Name        string                        `json:"name"`
+		ID          customDenyID                  `json:"-"`
+		Parameters  []CustomDenyResponseParameter `json:"parameters"`
 	}
 
 	// RemoveCustomDenyRequest is used to remove an existing custom deny action.
@@ -133,6 +133,78 @@ type (
 	RemoveCustomDenyResponse struct {
 		Empty string `json:"-"`
 	}
+
+	// CustomDenyPayload describes a custom deny action sent to CreateCustomDeny or UpdateCustomDeny.
+	CustomDenyPayload struct {
+		Name        string               `json:"name"`
+		Description string               `json:"description,omitempty"`
+		Parameters  CustomDenyParameters `json:"parameters"`
+	}
+
+	// CustomDenyParameters holds the parameters of a custom deny action, either a custom response with
+	// a status code, content type and body, or a redirect to CustomDenyHostname and CustomDenyPath.
+	// It is encoded as the list of name and value pairs expected by the API; unset parameters are omitted.
+	CustomDenyParameters struct {
+		ResponseStatusCode  int
+		ResponseContentType string
+		ResponseBodyContent string
+		ResponseHeaderName  string
+		ResponseHeaderValue string
+		PreventBrowserCache *bool
+		CustomDenyHostname  string
+		CustomDenyPath      string
+		IncludeReferenceID  *bool
+		IncludeTrueIP       *bool
+	}
+
+	// CustomDenyParameter is a parameter of a custom deny action, as sent to the API.
+	CustomDenyParameter struct {
+		Name  CustomDenyParameterName `json:"name"`
+		Value string                  `json:"value"`
+	}
+
+	// CustomDenyParameterName is the name of a custom deny action parameter.
+	CustomDenyParameterName string
+)
+
+const (
+	// CustomDenyResponseStatusCode is the status code of the custom response.
+	CustomDenyResponseStatusCode CustomDenyParameterName = "response_status_code"
+
+	// CustomDenyResponseContentType is the content type of the custom response.
+	CustomDenyResponseContentType CustomDenyParameterName = "response_content_type"
+
+	// CustomDenyResponseBodyContent is the body of the custom response.
+	CustomDenyResponseBodyContent CustomDenyParameterName = "response_body_content"
+
+	// CustomDenyResponseHeaderName is the name of a header added to the custom response.
+	CustomDenyResponseHeaderName CustomDenyParameterName = "response_header_name"
+
+	// CustomDenyResponseHeaderValue is the value of the header added to the custom response.
+	CustomDenyResponseHeaderValue CustomDenyParameterName = "response_header_value"
+
+	// CustomDenyPreventBrowserCache tells whether browsers are prevented from caching the response.
+	CustomDenyPreventBrowserCache CustomDenyParameterName = "prevent_browser_cache"
+
+	// CustomDenyHostname is the hostname denied requests are redirected to.
+	CustomDenyHostname CustomDenyParameterName = "custom_deny_hostname"
+
+	// CustomDenyPath is the path denied requests are redirected to.
+	CustomDenyPath CustomDenyParameterName = "custom_deny_path"
+
+	// CustomDenyIncludeReferenceID tells whether the reference ID of the request is included in the redirect.
+	CustomDenyIncludeReferenceID CustomDenyParameterName = "include_reference_id"
+
+	// CustomDenyIncludeTrueIP tells whether the client IP address is included in the redirect.
+	CustomDenyIncludeTrueIP CustomDenyParameterName = "include_true_ip"
+)
+
+var (
+	// CustomDenyStatusCodes are the status codes allowed in custom deny responses.
+	CustomDenyStatusCodes = []int{200, 201, 202, 203, 204, 205, 206, 400, 401, 403, 404, 405, 406, 407, 408, 409, 410, 500, 501, 502, 503, 504}
+
+	// CustomDenyContentTypes are the content types allowed in custom deny responses.
+	CustomDenyContentTypes = []string{"application/json", "text/html", "text/xml"}
 )
 
 // UnmarshalJSON reads a customDenyID struct from its data argument.
@@ -175,20 +247,90 @@ func (v GetCustomDenyListRequest) Validate() error {
 // Validate validates a CreateCustomDenyRequest.
 func (v CreateCustomDenyRequest) Validate() error {
 	return validation.Errors{
-		"ConfigID": validation.Validate(v.ConfigID, validation.Required),
-		"Version":  validation.Validate(v.Version, validation.Required),
+		"ConfigID":       validation.Validate(v.ConfigID, validation.Required),
+		"Version":        validation.Validate(v.Version, validation.Required),
+		"Payload":        validation.Validate(v.Payload),
+		"JsonPayloadRaw": validation.Validate(v.JsonPayloadRaw, validation.When(v.Payload != nil, validation.Empty.Error("must be blank when Payload is set"))),
 	}.Filter()
 }
 
 // Validate validates an UpdateCustomDenyRequest.
 func (v UpdateCustomDenyRequest) Validate() error {
 	return validation.Errors{
-		"ConfigID": validation.Validate(v.ConfigID, validation.Required),
-		"Version":  validation.Validate(v.Version, validation.Required),
-		"ID":       validation.Validate(v.ID, validation.Required),
+		"ConfigID":       validation.Validate(v.ConfigID, validation.Required),
+		"Version":        validation.Validate(v.Version, validation.Required),
+		"ID":             validation.Validate(v.ID, validation.Required),
+		"Payload":        validation.Validate(v.Payload),
+		"JsonPayloadRaw": validation.Validate(v.JsonPayloadRaw, validation.When(v.Payload != nil, validation.Empty.Error("must be blank when Payload is set"))),
 	}.Filter()
 }
 
+// Validate validates a CustomDenyPayload.
+func (v CustomDenyPayload) Validate() error {
+	return validation.Errors{
+		"Name":       validation.Validate(v.Name, validation.Required),
+		"Parameters": validation.Validate(v.Parameters),
+	}.Filter()
+}
+
+// Validate validates CustomDenyParameters.
+func (v CustomDenyParameters) Validate() error {
+	statusCodes := make([]interface{}, 0, len(CustomDenyStatusCodes))
+	for _, code := range CustomDenyStatusCodes {
+		statusCodes = append(statusCodes, code)
+	}
+	contentTypes := make([]interface{}, 0, len(CustomDenyContentTypes))
+	for _, contentType := range CustomDenyContentTypes {
+		contentTypes = append(contentTypes, contentType)
+	}
+	redirect := v.CustomDenyHostname != "" || v.CustomDenyPath != ""
+	return validation.Errors{
+		"ResponseStatusCode":  validation.Validate(v.ResponseStatusCode, validation.When(!redirect, validation.Required), validation.In(statusCodes...)),
+		"ResponseContentType": validation.Validate(v.ResponseContentType, validation.In(contentTypes...)),
+		"ResponseHeaderName":  validation.Validate(v.ResponseHeaderName, validation.When(v.ResponseHeaderValue != "", validation.Required)),
+		"ResponseHeaderValue": validation.Validate(v.ResponseHeaderValue, validation.When(v.ResponseHeaderName != "", validation.Required)),
+		"CustomDenyHostname":  validation.Validate(v.CustomDenyHostname, validation.When(redirect, validation.Required)),
+		"CustomDenyPath":      validation.Validate(v.CustomDenyPath, validation.When(redirect, validation.Required)),
+	}.Filter()
+}
+
+// List returns the parameters as name and value pairs, in the order of the CustomDenyParameterName constants.
+func (v CustomDenyParameters) List() []CustomDenyParameter {
+	var params []CustomDenyParameter
+	add := func(name CustomDenyParameterName, value string) {
+		if value != "" {
+			params = append(params, CustomDenyParameter{Name: name, Value: value})
+		}
+	}
+	addBool := func(name CustomDenyParameterName, value *bool) {
+		if value != nil {
+			add(name, strconv.FormatBool(*value))
+		}
+	}
+	if v.ResponseStatusCode != 0 {
+		add(CustomDenyResponseStatusCode, strconv.Itoa(v.ResponseStatusCode))
+	}
+	add(CustomDenyResponseContentType, v.ResponseContentType)
+	add(CustomDenyResponseBodyContent, v.ResponseBodyContent)
+	add(CustomDenyResponseHeaderName, v.ResponseHeaderName)
+	add(CustomDenyResponseHeaderValue, v.ResponseHeaderValue)
+	addBool(CustomDenyPreventBrowserCache, v.PreventBrowserCache)
+	add(CustomDenyHostname, v.CustomDenyHostname)
+	add(CustomDenyPath, v.CustomDenyPath)
+	addBool(CustomDenyIncludeReferenceID, v.IncludeReferenceID)
+	addBool(CustomDenyIncludeTrueIP, v.IncludeTrueIP)
+	return params
+}
+
+// MarshalJSON encodes the parameters as the list of name and value pairs expected by the API.
+func (v CustomDenyParameters) MarshalJSON() ([]byte, error) {
+	params := v.List()
+	if params == nil {
+		params = []CustomDenyParameter{}
+	}
+	return json.Marshal(params)
+}
+
 // Validate validates a RemoveCustomDenyRequest.
 func (v RemoveCustomDenyRequest) Validate() error {
 	return validation.Errors{
@@ -242,6 +384,10 @@ func (p *appsec) GetCustomDenyList(ctx context.Context, params GetCustomDenyList
 		params.ConfigID,
 		params.Version,
 	)
+	if params.ID != "" {
+		// a single action is retrieved by itself rather than filtered out of the whole collection
+		uri = fmt.Sprintf("%s/%s", uri, url.PathEscape(params.ID))
+	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
 	if err != nil {
@@ -249,22 +395,20 @@ func (p *appsec) GetCustomDenyList(ctx context.Context, params GetCustomDenyList
 	}
 
 	var result GetCustomDenyListResponse
-	resp, err := p.Exec(req, &result)
+	var out interface{} = &result
+	if params.ID != "" {
+		result.CustomDenyList = make([]CustomDenyItem, 1)
+		out = &result.CustomDenyList[0]
+	}
+	resp, err := p.Exec(req, out)
 	if err != nil {
 		return nil, fmt.Errorf("get custom deny list request failed: %w", err)
 	}
 	if resp.StatusCode != http.StatusOK {
 		return nil, p.Error(resp)
 	}
-
 	if params.ID != "" {
-		var filteredResult GetCustomDenyListResponse
-		for _, val := range result.CustomDenyList {
-			if string(val.ID) == params.ID {
-				filteredResult.CustomDenyList = append(filteredResult.CustomDenyList, val)
-			}
-		}
-		return &filteredResult, nil
+		result.CustomDenyList[0].ID = customDenyID(params.ID)
 	}
 
 	return &result, nil
@@ -290,9 +434,14 @@ func (p *appsec) UpdateCustomDeny(ctx context.Context, params UpdateCustomDenyRe
 		return nil, fmt.Errorf("failed to create UpdateCustomDeny request: %w", err)
 	}
 
+	var payload interface{} = params.JsonPayloadRaw
+	if params.Payload != nil {
+		payload = params.Payload
+	}
+
 	var result UpdateCustomDenyResponse
 	req.Header.Set("Content-Type", "application/json")
-	resp, err := p.Exec(req, &result, params.JsonPayloadRaw)
+	resp, err := p.Exec(req, &result, payload)
 	if err != nil {
 		return nil, fmt.Errorf("update custom deny request failed: %w", err)
 	}
@@ -322,9 +471,14 @@ func (p *appsec) CreateCustomDeny(ctx context.Context, params CreateCustomDenyRe
 		return nil, fmt.Errorf("failed to create CreateCustomDeny request: %w", err)
 	}
 
+	var payload interface{} = params.JsonPayloadRaw
+	if params.Payload != nil {
+		payload = params.Payload
+	}
+
 	var result CreateCustomDenyResponse
 	req.Header.Set("Content-Type", "application/json")
-	resp, err := p.Exec(req, &result, params.JsonPayloadRaw)
+	resp, err := p.Exec(req, &result, payload)
 	if err != nil {
 		return nil, fmt.Errorf("create custom deny request failed: %w", err)
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -43,6 +44,36 @@ func TestAppSec_ListCustomDeny(t *testing.T) {
 			expectedPath:     "/appsec/v1/configs/43253/versions/15/custom-deny",
 			expectedResponse: &result,
 		},
+		"200 OK single action": {
+			params: GetCustomDenyListRequest{
+				ConfigID: 43253,
+				Version:  15,
+				ID:       "deny_custom_622918",
+			},
+			responseStatus: http.StatusOK,
+			responseBody:   `{"name":"Custom Deny","parameters":[{"displayName":"Response status code","name":"response_status_code","value":"403"}]}`,
+			expectedPath:   "/appsec/v1/configs/43253/versions/15/custom-deny/deny_custom_622918",
+			expectedResponse: &GetCustomDenyListResponse{CustomDenyList: []CustomDenyItem{{
+				Name:       "Custom Deny",
+				ID:         "deny_custom_622918",
+				Parameters: []CustomDenyResponseParameter{{Name: "response_status_code", Value: "403"}},
+			}}},
+		},
+		"404 single action": {
+			params: GetCustomDenyListRequest{
+				ConfigID: 43253,
+				Version:  15,
+				ID:       "deny_custom_622918",
+			},
+			responseStatus: http.StatusNotFound,
+			responseBody:   `{"type":"not_found","title":"Not Found","status":404}`,
+			expectedPath:   "/appsec/v1/configs/43253/versions/15/custom-deny/deny_custom_622918",
+			withError: &Error{
+				Type:       "not_found",
+				Title:      "Not Found",
+				StatusCode: http.StatusNotFound,
+			},
+		},
 		"500 internal server error": {
 			params: GetCustomDenyListRequest{
 				ConfigID: 43253,
@@ -180,16 +211,49 @@ func TestAppSec_CreateCustomDeny(t *testing.T) {
 	err = json.Unmarshal([]byte(reqData), &req)
 	require.NoError(t, err)
 
+	preventBrowserCache := true
 	tests := map[string]struct {
-		params           CreateCustomDenyRequest
-		prop             *CreateCustomDenyRequest
-		responseStatus   int
-		responseBody     string
-		expectedPath     string
-		expectedResponse *CreateCustomDenyResponse
-		withError        error
-		headers          http.Header
+		params              CreateCustomDenyRequest
+		prop                *CreateCustomDenyRequest
+		responseStatus      int
+		responseBody        string
+		expectedPath        string
+		expectedRequestBody string
+		expectedResponse    *CreateCustomDenyResponse
+		withError           error
+		headers             http.Header
 	}{
+		"201 Created with typed payload": {
+			params: CreateCustomDenyRequest{
+				ConfigID: 43253,
+				Version:  15,
+				Payload: &CustomDenyPayload{
+					Name: "Blocked",
+					Parameters: CustomDenyParameters{
+						ResponseStatusCode:  403,
+						ResponseContentType: "application/json",
+						ResponseBodyContent: `{"blocked":true}`,
+						PreventBrowserCache: &preventBrowserCache,
+					},
+				},
+			},
+			responseStatus:      http.StatusCreated,
+			responseBody:        respData,
+			expectedResponse:    &result,
+			expectedPath:        "/appsec/v1/configs/43253/versions/15/custom-deny",
+			expectedRequestBody: `{"name":"Blocked","parameters":[{"name":"response_status_code","value":"403"},{"name":"response_content_type","value":"application/json"},{"name":"response_body_content","value":"{\"blocked\":true}"},{"name":"prevent_browser_cache","value":"true"}]}`,
+		},
+		"invalid typed payload": {
+			params: CreateCustomDenyRequest{
+				ConfigID: 43253,
+				Version:  15,
+				Payload: &CustomDenyPayload{
+					Name:       "Blocked",
+					Parameters: CustomDenyParameters{ResponseStatusCode: 302},
+				},
+			},
+			withError: ErrStructValidation,
+		},
 		"201 Created": {
 			params: CreateCustomDenyRequest{
 				ConfigID: 43253,
@@ -229,6 +293,11 @@ func TestAppSec_CreateCustomDeny(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				assert.Equal(t, http.MethodPost, r.Method)
+				if test.expectedRequestBody != "" {
+					body, err := ioutil.ReadAll(r.Body)
+					assert.NoError(t, err)
+					assert.JSONEq(t, test.expectedRequestBody, string(body))
+				}
 				w.WriteHeader(test.responseStatus)
 				if len(test.responseBody) > 0 {
 					_, err := w.Write([]byte(test.responseBody))
@@ -420,3 +489,71 @@ func TestAppSec_RemoveCustomDeny(t *testing.T) {
 		})
 	}
 }
+
+func TestCustomDenyParameters(t *testing.T) {
+	includeReferenceID := false
+	tests := map[string]struct {
+		params    CustomDenyParameters
+		expected  string
+		withError bool
+	}{
+		"custom response with header": {
+			params: CustomDenyParameters{
+				ResponseStatusCode:  200,
+				ResponseContentType: "text/html",
+				ResponseBodyContent: "<html>Denied</html>",
+				ResponseHeaderName:  "X-Denied",
+				ResponseHeaderValue: "true",
+			},
+			expected: `[
+				{"name": "response_status_code", "value": "200"},
+				{"name": "response_content_type", "value": "text/html"},
+				{"name": "response_body_content", "value": "<html>Denied</html>"},
+				{"name": "response_header_name", "value": "X-Denied"},
+				{"name": "response_header_value", "value": "true"}
+			]`,
+		},
+		"redirect": {
+			params: CustomDenyParameters{
+				CustomDenyHostname: "www.example.com",
+				CustomDenyPath:     "/denied",
+				IncludeReferenceID: &includeReferenceID,
+			},
+			expected: `[
+				{"name": "custom_deny_hostname", "value": "www.example.com"},
+				{"name": "custom_deny_path", "value": "/denied"},
+				{"name": "include_reference_id", "value": "false"}
+			]`,
+		},
+		"missing status code": {
+			params:    CustomDenyParameters{ResponseBodyContent: "Denied"},
+			withError: true,
+		},
+		"unknown content type": {
+			params:    CustomDenyParameters{ResponseStatusCode: 403, ResponseContentType: "image/png"},
+			withError: true,
+		},
+		"header without value": {
+			params:    CustomDenyParameters{ResponseStatusCode: 403, ResponseHeaderName: "X-Denied"},
+			withError: true,
+		},
+		"redirect without path": {
+			params:    CustomDenyParameters{CustomDenyHostname: "www.example.com"},
+			withError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := test.params.Validate()
+			if test.withError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			data, err := json.Marshal(test.params)
+			require.NoError(t, err)
+			assert.JSONEq(t, test.expected, string(data))
+		})
+	}
+}
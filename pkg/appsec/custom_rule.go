@@ -54,15 +54,18 @@ type (
 
 	// GetCustomRulesResponse is returned from a call to GetCustomRules.
 	GetCustomRulesResponse struct {
-		CustomRules []struct {
-			ID                  int                        `json:"id"`
-			Link                string                     `json:"link"`
-			Name                string                     `json:"name"`
-			Status              string                     `json:"status"`
-			Version             int                        `json:"version"`
-			EffectiveTimePeriod *CustomRuleEffectivePeriod `json:"effectiveTimePeriod,omitempty"`
-			SamplingRate        *int                       `json:"samplingRate,omitempty"`
-		} `json:"customRules"`
+		CustomRules []CustomRuleItem `json:"customRules"`
+	}
+
+	// CustomRuleItem describes a custom rule returned by GetCustomRules.
+	CustomRuleItem struct {
+		ID                  int                        `json:"id"`
+		Link                string                     `json:"link"`
+		Name                string                     `json:"name"`
+		Status              string                     `json:"status"`
+		Version             int                        `json:"version"`
+		EffectiveTimePeriod *CustomRuleEffectivePeriod `json:"effectiveTimePeriod,omitempty"`
+		SamplingRate        *int                       `json:"samplingRate,omitempty"`
 	}
 
 	// GetCustomRuleRequest is used to retrieve the details of a custom rule.
@@ -0,0 +1,46 @@
+package appsec
+
+import (
+	"context"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/session"
+)
+
+// deprecations lists the deprecated methods of the APPSEC interface
+var deprecations = []session.Deprecation{
+	{Method: "appsec.GetExportConfigurations", Replacement: "appsec.GetExportConfiguration"},
+	{Method: "appsec.GetIPGeoProtections", Replacement: "appsec.GetIPGeoProtection"},
+	{Method: "appsec.GetNetworkLayerProtections", Replacement: "appsec.GetNetworkLayerProtection"},
+	{Method: "appsec.GetPenaltyBoxes", Replacement: "appsec.GetPenaltyBox"},
+	{Method: "appsec.GetRatePolicyAction", Replacement: "appsec.GetRatePolicyActions"},
+	{Method: "appsec.GetRateProtections", Replacement: "appsec.GetRateProtection"},
+	{Method: "appsec.GetReputationProtections", Replacement: "appsec.GetReputationProtection"},
+	{Method: "appsec.GetSelectedHostname", Replacement: "appsec.GetWAPSelectedHostnames"},
+	{Method: "appsec.GetSelectedHostnames", Replacement: "appsec.GetWAPSelectedHostnames"},
+	{Method: "appsec.GetSlowPostProtectionSetting", Replacement: "appsec.GetSlowPostProtectionSettings"},
+	{Method: "appsec.GetSlowPostProtections", Replacement: "appsec.GetSlowPostProtection"},
+	{Method: "appsec.GetWAFModes", Replacement: "appsec.GetWAFMode"},
+	{Method: "appsec.GetWAFProtections", Replacement: "appsec.GetWAFProtection"},
+	{Method: "appsec.RemoveAdvancedSettingsEvasivePathMatch", Replacement: "appsec.UpdateAdvancedSettingsEvasivePathMatch"},
+	{Method: "appsec.RemoveNetworkLayerProtection", Replacement: "appsec.UpdateNetworkLayerProtection"},
+	{Method: "appsec.RemovePolicyProtections", Replacement: "appsec.UpdatePolicyProtections"},
+	{Method: "appsec.RemoveReputationProtection", Replacement: "appsec.UpdateReputationProtection"},
+	{Method: "appsec.RemoveSiemSettings"},
+	{Method: "appsec.UpdateSelectedHostname", Replacement: "appsec.UpdateWAPSelectedHostnames"},
+	{Method: "appsec.UpdateSelectedHostnames", Replacement: "appsec.UpdateWAPSelectedHostnames"},
+}
+
+// Deprecations returns the deprecated methods of the APPSEC interface with their replacements.
+// Calling a deprecated method logs a warning once per session, see session.WarnDeprecated.
+func Deprecations() []session.Deprecation {
+	return append([]session.Deprecation(nil), deprecations...)
+}
+
+func (p *appsec) warnDeprecated(ctx context.Context, method string) {
+	for _, d := range deprecations {
+		if d.Method == "appsec."+method {
+			session.WarnDeprecated(ctx, p, d)
+			return
+		}
+	}
+}
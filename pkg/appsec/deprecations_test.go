@@ -0,0 +1,23 @@
+package appsec
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeprecations(t *testing.T) {
+	api := reflect.TypeOf((*APPSEC)(nil)).Elem()
+	for _, d := range Deprecations() {
+		t.Run(d.Method, func(t *testing.T) {
+			_, ok := api.MethodByName(strings.TrimPrefix(d.Method, "appsec."))
+			assert.True(t, ok, "deprecated method %s does not exist", d.Method)
+			if d.Replacement != "" {
+				_, ok = api.MethodByName(strings.TrimPrefix(d.Replacement, "appsec."))
+				assert.True(t, ok, "replacement method %s does not exist", d.Replacement)
+			}
+		})
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/session"
 )
 
 var (
@@ -23,11 +25,23 @@ type (
 		BehaviorName  string `json:"behaviorName,omitempty"`
 		ErrorLocation string `json:"errorLocation,omitempty"`
 		StatusCode    int    `json:"-"`
+		// RequestID is the ID Akamai assigned to the failed request, to be referenced in support tickets
+		RequestID string `json:"-"`
+		// ClientRequestID is the ID the session sent with the failed request
+		ClientRequestID string `json:"-"`
+		// Method is the HTTP method of the failed request
+		Method string `json:"-"`
+		// URL is the URL of the failed request, including the query
+		URL string `json:"-"`
 	}
 )
 
 func (p *appsec) Error(r *http.Response) error {
-	var e Error
+	metadata := session.Metadata(r)
+	e := Error{RequestID: metadata.RequestID, ClientRequestID: metadata.ClientRequestID}
+	if r.Request != nil && r.Request.URL != nil {
+		e.Method, e.URL = r.Request.Method, r.Request.URL.String()
+	}
 
 	var body []byte
 
@@ -51,13 +65,32 @@ func (p *appsec) Error(r *http.Response) error {
 	return &e
 }
 
-// Error returns a string formatted using a given title, type, and detail information.
+// Error returns a string formatted using a given title, type, and detail information,
+// followed by the failed request, its status code and the request IDs if known.
 func (e *Error) Error() string {
+	msg := e.message()
+	if e.Method != "" {
+		msg += fmt.Sprintf("; Request: %s %s; Status: %d", e.Method, e.URL, e.StatusCode)
+	}
+	if e.RequestID != "" {
+		msg += fmt.Sprintf("; Request ID: %s", e.RequestID)
+	}
+	if e.ClientRequestID != "" {
+		msg += fmt.Sprintf("; Client Request ID: %s", e.ClientRequestID)
+	}
+	return msg
+}
+
+func (e *Error) message() string {
 	return fmt.Sprintf("Title: %s; Type: %s; Detail: %s", e.Title, e.Type, e.Detail)
 }
 
 // Is handles error comparisons.
 func (e *Error) Is(target error) bool {
+	if session.MatchStatus(e.StatusCode, target) {
+		return true
+	}
+
 	var t *Error
 	if !errors.As(target, &t) {
 		return false
@@ -71,5 +104,19 @@ func (e *Error) Is(target error) bool {
 		return false
 	}
 
-	return e.Error() == t.Error()
+	// request IDs differ for every request, and the request is only context, so they are not compared
+	return e.message() == t.message()
+}
+
+// As converts the error into a *session.Problem, shared by the errors of all packages.
+func (e *Error) As(target interface{}) bool {
+	if !session.AsProblem(e, e.StatusCode, target) {
+		return false
+	}
+	problem := *target.(**session.Problem)
+	problem.RequestID = e.RequestID
+	problem.ClientRequestID = e.ClientRequestID
+	problem.Method = e.Method
+	problem.URL = e.URL
+	return true
 }
@@ -2,6 +2,9 @@ package appsec
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"strings"
@@ -41,6 +44,27 @@ func TestNewError(t *testing.T) {
 				Title:      "b",
 				Detail:     "c",
 				StatusCode: http.StatusInternalServerError,
+				Method:     http.MethodHead,
+				URL:        "/",
+			},
+		},
+		"request IDs": {
+			response: &http.Response{
+				Status:     "Internal Server Error",
+				StatusCode: http.StatusInternalServerError,
+				Header:     http.Header{"X-Trace-Id": []string{"trace-123"}},
+				Body: ioutil.NopCloser(strings.NewReader(
+					`{"type":"a","title":"b","detail":"c"}`),
+				),
+				Request: &http.Request{Header: http.Header{session.ClientRequestIDHeader: []string{"client-456"}}},
+			},
+			expected: &Error{
+				Type:            "a",
+				Title:           "b",
+				Detail:          "c",
+				StatusCode:      http.StatusInternalServerError,
+				RequestID:       "trace-123",
+				ClientRequestID: "client-456",
 			},
 		},
 		"invalid response body, assign status code": {
@@ -56,6 +80,8 @@ func TestNewError(t *testing.T) {
 				Title:      "Failed to unmarshal error body",
 				Detail:     "invalid character 'e' in literal true (expecting 'r')",
 				StatusCode: http.StatusInternalServerError,
+				Method:     http.MethodHead,
+				URL:        "/",
 			},
 		},
 	}
@@ -66,3 +92,64 @@ func TestNewError(t *testing.T) {
 		})
 	}
 }
+
+func TestError_RequestIDs(t *testing.T) {
+	err := &Error{Type: "a", Title: "b", Detail: "c", StatusCode: http.StatusInternalServerError, RequestID: "trace-123", ClientRequestID: "client-456"}
+	assert.Equal(t, "Title: b; Type: a; Detail: c; Request ID: trace-123; Client Request ID: client-456", err.Error())
+	assert.True(t, errors.Is(err, &Error{Type: "a", Title: "b", Detail: "c", StatusCode: http.StatusInternalServerError}))
+}
+
+func TestError_RequestContext(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "https://akab-host.luna.akamaiapis.net/appsec/v1/configs/43253/versions/7/match-targets/2971336", nil)
+	require.NoError(t, err)
+	sess, err := session.New()
+	require.NoError(t, err)
+
+	res := Client(sess).(*appsec).Error(&http.Response{
+		StatusCode: http.StatusNotFound,
+		Header:     http.Header{"X-Trace-Id": []string{"trace-123"}},
+		Body:       ioutil.NopCloser(strings.NewReader(`{"type":"a","title":"b","detail":"c"}`)),
+		Request:    req,
+	})
+	err = fmt.Errorf("get match target request failed: %w", res)
+
+	var e *Error
+	require.True(t, errors.As(err, &e))
+	assert.Equal(t, http.MethodGet, e.Method)
+	assert.Equal(t, req.URL.String(), e.URL)
+	assert.Equal(t, http.StatusNotFound, e.StatusCode)
+	assert.Equal(t, "trace-123", e.RequestID)
+	assert.Equal(t, "Title: b; Type: a; Detail: c; Request: GET "+req.URL.String()+"; Status: 404; Request ID: trace-123", e.Error())
+	assert.True(t, errors.Is(err, &Error{Type: "a", Title: "b", Detail: "c", StatusCode: http.StatusNotFound}))
+
+	var problem *session.Problem
+	require.True(t, errors.As(err, &problem))
+	assert.Equal(t, http.MethodGet, problem.Method)
+	assert.Equal(t, req.URL.String(), problem.URL)
+	assert.Equal(t, e.Error(), problem.Error())
+}
+
+func TestError_AsProblem(t *testing.T) {
+	err := fmt.Errorf("update match target request failed: %w", &Error{
+		Type:            "a",
+		Title:           "b",
+		Detail:          "c",
+		BehaviorName:    "origin",
+		StatusCode:      http.StatusBadRequest,
+		RequestID:       "trace-123",
+		ClientRequestID: "client-456",
+	})
+
+	var problem *session.Problem
+	require.True(t, errors.As(err, &problem))
+	assert.Equal(t, &session.Problem{
+		Type:            "a",
+		Title:           "b",
+		Detail:          "c",
+		Status:          http.StatusBadRequest,
+		Extensions:      map[string]json.RawMessage{"behaviorName": json.RawMessage(`"origin"`)},
+		RequestID:       "trace-123",
+		ClientRequestID: "client-456",
+	}, problem)
+	assert.True(t, errors.Is(problem, session.ErrValidation))
+}
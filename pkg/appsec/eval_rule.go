@@ -39,11 +39,14 @@ type (
 
 	// GetEvalRulesResponse is returned from a call to GetEvalRules.
 	GetEvalRulesResponse struct {
-		Rules []struct {
-			ID                 int                     `json:"id,omitempty"`
-			Action             string                  `json:"action,omitempty"`
-			ConditionException *RuleConditionException `json:"conditionException,omitempty"`
-		} `json:"evalRuleActions,omitempty"`
+		Rules []EvalRuleItem `json:"evalRuleActions,omitempty"`
+	}
+
+	// EvalRuleItem describes an evaluation rule and its action returned by GetEvalRules.
+	EvalRuleItem struct {
+		ID                 int                     `json:"id,omitempty"`
+		Action             string                  `json:"action,omitempty"`
+		ConditionException *RuleConditionException `json:"conditionException,omitempty"`
 	}
 
 	// GetEvalRuleRequest is used to retrieve a rule available for evaluation and its action.
@@ -108,6 +111,7 @@ func (v UpdateEvalRuleRequest) Validate() error {
 		"Version":  validation.Validate(v.Version, validation.Required),
 		"PolicyID": validation.Validate(v.PolicyID, validation.Required),
 		"RuleID":   validation.Validate(v.RuleID, validation.Required),
+		"Action":   validation.Validate(ActionType(v.Action)),
 	}.Filter()
 }
 
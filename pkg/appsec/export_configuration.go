@@ -845,6 +845,7 @@ func (p *appsec) GetExportConfiguration(ctx context.Context, params GetExportCon
 func (p *appsec) GetExportConfigurations(ctx context.Context, params GetExportConfigurationsRequest) (*GetExportConfigurationsResponse, error) {
 	logger := p.Log(ctx)
 	logger.Debug("GetExportConfigurations")
+	p.warnDeprecated(ctx, "GetExportConfigurations")
 
 	uri := fmt.Sprintf(
 		"/appsec/v1/export/configs/%d/versions/%d",
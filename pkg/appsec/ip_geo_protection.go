@@ -125,6 +125,7 @@ func (p *appsec) GetIPGeoProtection(ctx context.Context, params GetIPGeoProtecti
 func (p *appsec) GetIPGeoProtections(ctx context.Context, params GetIPGeoProtectionsRequest) (*GetIPGeoProtectionsResponse, error) {
 	logger := p.Log(ctx)
 	logger.Debug("GetIPGeoProtections")
+	p.warnDeprecated(ctx, "GetIPGeoProtections")
 
 	if err := params.Validate(); err != nil {
 		return nil, fmt.Errorf("%w: %s", ErrStructValidation, err.Error())
@@ -48,49 +48,40 @@ type (
 	// GetMatchTargetsResponse is returned from a call to GetMatchTargets.
 	GetMatchTargetsResponse struct {
 		MatchTargets struct {
-			APITargets []struct {
-				Type string `json:"type,omitempty"`
-				Apis []struct {
-					ID   int    `json:"id"`
-					Name string `json:"name"`
-				} `json:"apis"`
-				Sequence      int `json:"sequence"`
-				TargetID      int `json:"targetId"`
-				ConfigID      int `json:"configId,omitempty"`
-				ConfigVersion int `json:"configVersion,omitempty"`
-
-				SecurityPolicy struct {
-					PolicyID string `json:"policyId,omitempty"`
-				} `json:"securityPolicy,omitempty"`
-
-				BypassNetworkLists []struct {
-					Name string `json:"name,omitempty"`
-					ID   string `json:"id,omitempty"`
-				} `json:"bypassNetworkLists,omitempty"`
-			} `json:"apiTargets,omitempty"`
-			WebsiteTargets []struct {
-				ConfigID                     int              `json:"configId,omitempty"`
-				ConfigVersion                int              `json:"configVersion,omitempty"`
-				DefaultFile                  string           `json:"defaultFile,omitempty"`
-				IsNegativeFileExtensionMatch bool             `json:"isNegativeFileExtensionMatch,omitempty"`
-				IsNegativePathMatch          *json.RawMessage `json:"isNegativePathMatch,omitempty"`
-				Sequence                     int              `json:"-"`
-				TargetID                     int              `json:"targetId,omitempty"`
-				Type                         string           `json:"type,omitempty"`
-				FileExtensions               []string         `json:"fileExtensions,omitempty"`
-				FilePaths                    []string         `json:"filePaths,omitempty"`
-				Hostnames                    []string         `json:"hostnames,omitempty"`
-				SecurityPolicy               struct {
-					PolicyID string `json:"policyId,omitempty"`
-				} `json:"securityPolicy,omitempty"`
-				BypassNetworkLists []struct {
-					Name string `json:"name,omitempty"`
-					ID   string `json:"id,omitempty"`
-				} `json:"bypassNetworkLists,omitempty"`
-			} `json:"websiteTargets,omitempty"`
+			APITargets     []APIMatchTarget     `json:"apiTargets,omitempty"`
+			WebsiteTargets []WebsiteMatchTarget `json:"websiteTargets,omitempty"`
 		} `json:"matchTargets,omitempty"`
 	}
 
+	// APIMatchTarget describes an API match target returned by GetMatchTargets.
+	APIMatchTarget struct {
+		Type               string                    `json:"type,omitempty"`
+		Apis               []MatchTargetAPI          `json:"apis"`
+		Sequence           int                       `json:"sequence"`
+		TargetID           int                       `json:"targetId"`
+		ConfigID           int                       `json:"configId,omitempty"`
+		ConfigVersion      int                       `json:"configVersion,omitempty"`
+		SecurityPolicy     MatchTargetSecurityPolicy `json:"securityPolicy,omitempty"`
+		BypassNetworkLists []BypassNetworkList       `json:"bypassNetworkLists,omitempty"`
+	}
+
+	// WebsiteMatchTarget describes a website match target returned by GetMatchTargets.
+	WebsiteMatchTarget struct {
+		ConfigID                     int                       `json:"configId,omitempty"`
+		ConfigVersion                int                       `json:"configVersion,omitempty"`
+		DefaultFile                  string                    `json:"defaultFile,omitempty"`
+		IsNegativeFileExtensionMatch bool                      `json:"isNegativeFileExtensionMatch,omitempty"`
+		IsNegativePathMatch          *json.RawMessage          `json:"isNegativePathMatch,omitempty"`
+		Sequence                     int                       `json:"-"`
+		TargetID                     int                       `json:"targetId,omitempty"`
+		Type                         string                    `json:"type,omitempty"`
+		FileExtensions               []string                  `json:"fileExtensions,omitempty"`
+		FilePaths                    []string                  `json:"filePaths,omitempty"`
+		Hostnames                    []string                  `json:"hostnames,omitempty"`
+		SecurityPolicy               MatchTargetSecurityPolicy `json:"securityPolicy,omitempty"`
+		BypassNetworkLists           []BypassNetworkList       `json:"bypassNetworkLists,omitempty"`
+	}
+
 	// GetMatchTargetRequest is used to retrieve a match target.
 	GetMatchTargetRequest struct {
 		ConfigID      int `json:"configId"`
@@ -100,88 +91,73 @@ type (
 
 	// GetMatchTargetResponse is returned from a call to GetMatchTarget.
 	GetMatchTargetResponse struct {
-		Type string `json:"type,omitempty"`
-		Apis []struct {
-			ID   int    `json:"id"`
-			Name string `json:"name"`
-		} `json:"apis,omitempty"`
-		DefaultFile                  string           `json:"defaultFile,omitempty"`
-		Hostnames                    []string         `json:"hostnames,omitempty"`
-		IsNegativeFileExtensionMatch bool             `json:"isNegativeFileExtensionMatch,omitempty"`
-		IsNegativePathMatch          *json.RawMessage `json:"isNegativePathMatch,omitempty"`
-		FilePaths                    []string         `json:"filePaths,omitempty"`
-		FileExtensions               []string         `json:"fileExtensions,omitempty"`
-		SecurityPolicy               struct {
-			PolicyID string `json:"policyId,omitempty"`
-		} `json:"securityPolicy,omitempty"`
-		Sequence           int `json:"-"`
-		TargetID           int `json:"targetId"`
-		BypassNetworkLists []struct {
-			Name string `json:"name,omitempty"`
-			ID   string `json:"id,omitempty"`
-		} `json:"bypassNetworkLists,omitempty"`
+		Type                         string                    `json:"type,omitempty"`
+		Apis                         []MatchTargetAPI          `json:"apis,omitempty"`
+		DefaultFile                  string                    `json:"defaultFile,omitempty"`
+		Hostnames                    []string                  `json:"hostnames,omitempty"`
+		IsNegativeFileExtensionMatch bool                      `json:"isNegativeFileExtensionMatch,omitempty"`
+		IsNegativePathMatch          *json.RawMessage          `json:"isNegativePathMatch,omitempty"`
+		FilePaths                    []string                  `json:"filePaths,omitempty"`
+		FileExtensions               []string                  `json:"fileExtensions,omitempty"`
+		SecurityPolicy               MatchTargetSecurityPolicy `json:"securityPolicy,omitempty"`
+		Sequence                     int                       `json:"-"`
+		TargetID                     int                       `json:"targetId"`
+		BypassNetworkLists           []BypassNetworkList       `json:"bypassNetworkLists,omitempty"`
 	}
 
 	// CreateMatchTargetRequest is used to create a match target.
+	// The target is described either by Payload, a WebsiteMatchTargetPayload or an APIMatchTargetPayload,
+	// or by JsonPayloadRaw.
 	CreateMatchTargetRequest struct {
-		Type           string          `json:"type"`
-		ConfigID       int             `json:"configId"`
-		ConfigVersion  int             `json:"configVersion"`
-		JsonPayloadRaw json.RawMessage `json:"-"`
+		Type           string             `json:"type"`
+		ConfigID       int                `json:"configId"`
+		ConfigVersion  int                `json:"configVersion"`
+		Payload        MatchTargetPayload `json:"-"`
+		JsonPayloadRaw json.RawMessage    `json:"-"`
 	}
 
 	// CreateMatchTargetResponse is returned from a call to CreateMatchTarget.
 	CreateMatchTargetResponse struct {
-		MType string `json:"type"`
-		Apis  []struct {
-			ID   int    `json:"id"`
-			Name string `json:"name"`
-		} `json:"apis,omitempty"`
-		DefaultFile                  string           `json:"defaultFile"`
-		Hostnames                    []string         `json:"hostnames"`
-		IsNegativeFileExtensionMatch bool             `json:"isNegativeFileExtensionMatch"`
-		IsNegativePathMatch          *json.RawMessage `json:"isNegativePathMatch,omitempty"`
-		FilePaths                    []string         `json:"filePaths"`
-		FileExtensions               []string         `json:"fileExtensions"`
-		SecurityPolicy               struct {
-			PolicyID string `json:"policyId"`
-		} `json:"securityPolicy"`
-		Sequence           int `json:"-"`
-		TargetID           int `json:"targetId"`
-		BypassNetworkLists []struct {
-			Name string `json:"name"`
-			ID   string `json:"id"`
-		} `json:"bypassNetworkLists"`
+		MType                        string                    `json:"type"`
+		Apis                         []MatchTargetAPI          `json:"apis,omitempty"`
+		DefaultFile                  string                    `json:"defaultFile"`
+		Hostnames                    []string                  `json:"hostnames"`
+		IsNegativeFileExtensionMatch bool                      `json:"isNegativeFileExtensionMatch"`
+		IsNegativePathMatch          *json.RawMessage          `json:"isNegativePathMatch,omitempty"`
+		FilePaths                    []string                  `json:"filePaths"`
+		FileExtensions               []string                  `json:"fileExtensions"`
+		SecurityPolicy               MatchTargetSecurityPolicy `json:"securityPolicy"`
+		Sequence                     int                       `json:"-"`
+		TargetID                     int                       `json:"targetId"`
+		BypassNetworkLists           []BypassNetworkList       `json:"bypassNetworkLists"`
 	}
 
 	// UpdateMatchTargetRequest is used to modify an existing match target.
+	// The target is described either by Payload, a WebsiteMatchTargetPayload or an APIMatchTargetPayload,
+	// or by JsonPayloadRaw.
 	UpdateMatchTargetRequest struct {
-		ConfigID       int             `json:"configId"`
-		ConfigVersion  int             `json:"configVersion"`
-		JsonPayloadRaw json.RawMessage `json:"-"`
-		TargetID       int             `json:"targetId"`
+		ConfigID       int                `json:"configId"`
+		ConfigVersion  int                `json:"configVersion"`
+		Payload        MatchTargetPayload `json:"-"`
+		JsonPayloadRaw json.RawMessage    `json:"-"`
+		TargetID       int                `json:"targetId"`
 	}
 
 	// UpdateMatchTargetResponse is returned from a call to UpdateMatchTarget.
 	UpdateMatchTargetResponse struct {
-		Type                         string           `json:"type"`
-		ConfigID                     int              `json:"configId"`
-		ConfigVersion                int              `json:"configVersion"`
-		DefaultFile                  string           `json:"defaultFile"`
-		Hostnames                    []string         `json:"hostnames"`
-		IsNegativeFileExtensionMatch bool             `json:"isNegativeFileExtensionMatch"`
-		IsNegativePathMatch          *json.RawMessage `json:"isNegativePathMatch,omitempty"`
-		FilePaths                    []string         `json:"filePaths"`
-		FileExtensions               []string         `json:"fileExtensions"`
-		SecurityPolicy               struct {
-			PolicyID string `json:"policyId"`
-		} `json:"securityPolicy"`
-		Sequence           int `json:"-"`
-		TargetID           int `json:"targetId"`
-		BypassNetworkLists []struct {
-			Name string `json:"name"`
-			ID   string `json:"id"`
-		} `json:"bypassNetworkLists"`
+		Type                         string                    `json:"type"`
+		ConfigID                     int                       `json:"configId"`
+		ConfigVersion                int                       `json:"configVersion"`
+		DefaultFile                  string                    `json:"defaultFile"`
+		Hostnames                    []string                  `json:"hostnames"`
+		IsNegativeFileExtensionMatch bool                      `json:"isNegativeFileExtensionMatch"`
+		IsNegativePathMatch          *json.RawMessage          `json:"isNegativePathMatch,omitempty"`
+		FilePaths                    []string                  `json:"filePaths"`
+		FileExtensions               []string                  `json:"fileExtensions"`
+		SecurityPolicy               MatchTargetSecurityPolicy `json:"securityPolicy"`
+		Sequence                     int                       `json:"-"`
+		TargetID                     int                       `json:"targetId"`
+		BypassNetworkLists           []BypassNetworkList       `json:"bypassNetworkLists"`
 	}
 
 	// RemoveMatchTargetRequest is used to remove a match target.
@@ -193,24 +169,19 @@ type (
 
 	// RemoveMatchTargetResponse is returned from a call to RemoveMatchTarget.
 	RemoveMatchTargetResponse struct {
-		Type                         string   `json:"type"`
-		ConfigID                     int      `json:"configId"`
-		ConfigVersion                int      `json:"configVersion"`
-		DefaultFile                  string   `json:"defaultFile"`
-		Hostnames                    []string `json:"hostnames"`
-		IsNegativeFileExtensionMatch bool     `json:"isNegativeFileExtensionMatch"`
-		IsNegativePathMatch          bool     `json:"isNegativePathMatch"`
-		FilePaths                    []string `json:"filePaths"`
-		FileExtensions               []string `json:"fileExtensions"`
-		SecurityPolicy               struct {
-			PolicyID string `json:"policyId"`
-		} `json:"securityPolicy"`
-		Sequence           int `json:"sequence"`
-		TargetID           int `json:"targetId"`
-		BypassNetworkLists []struct {
-			Name string `json:"name"`
-			ID   string `json:"id"`
-		} `json:"bypassNetworkLists"`
+		Type                         string                    `json:"type"`
+		ConfigID                     int                       `json:"configId"`
+		ConfigVersion                int                       `json:"configVersion"`
+		DefaultFile                  string                    `json:"defaultFile"`
+		Hostnames                    []string                  `json:"hostnames"`
+		IsNegativeFileExtensionMatch bool                      `json:"isNegativeFileExtensionMatch"`
+		IsNegativePathMatch          bool                      `json:"isNegativePathMatch"`
+		FilePaths                    []string                  `json:"filePaths"`
+		FileExtensions               []string                  `json:"fileExtensions"`
+		SecurityPolicy               MatchTargetSecurityPolicy `json:"securityPolicy"`
+		Sequence                     int                       `json:"sequence"`
+		TargetID                     int                       `json:"targetId"`
+		BypassNetworkLists           []BypassNetworkList       `json:"bypassNetworkLists"`
 	}
 
 	// BypassNetworkList describes a network list used in the bypass network lists for the specified configuration.
@@ -226,22 +197,14 @@ type (
 
 	// AutoGenerated is currently unused.
 	AutoGenerated struct {
-		Type string `json:"type"`
-		Apis []struct {
-			ID   int    `json:"id"`
-			Name string `json:"name"`
-		} `json:"apis"`
-		BypassNetworkLists []struct {
-			ID   string `json:"id"`
-			Name string `json:"name"`
-		} `json:"bypassNetworkLists"`
-		ConfigID       int `json:"configId"`
-		ConfigVersion  int `json:"configVersion"`
-		SecurityPolicy struct {
-			PolicyID string `json:"policyId"`
-		} `json:"securityPolicy"`
-		Sequence int `json:"-"`
-		TargetID int `json:"targetId"`
+		Type               string                    `json:"type"`
+		Apis               []MatchTargetAPI          `json:"apis"`
+		BypassNetworkLists []BypassNetworkList       `json:"bypassNetworkLists"`
+		ConfigID           int                       `json:"configId"`
+		ConfigVersion      int                       `json:"configVersion"`
+		SecurityPolicy     MatchTargetSecurityPolicy `json:"securityPolicy"`
+		Sequence           int                       `json:"-"`
+		TargetID           int                       `json:"targetId"`
 	}
 )
 
@@ -265,17 +228,21 @@ func (v GetMatchTargetsRequest) Validate() error {
 // Validate validates a CreateMatchTargetRequest.
 func (v CreateMatchTargetRequest) Validate() error {
 	return validation.Errors{
-		"ConfigID":      validation.Validate(v.ConfigID, validation.Required),
-		"ConfigVersion": validation.Validate(v.ConfigVersion, validation.Required),
+		"ConfigID":       validation.Validate(v.ConfigID, validation.Required),
+		"ConfigVersion":  validation.Validate(v.ConfigVersion, validation.Required),
+		"Payload":        validation.Validate(v.Payload),
+		"JsonPayloadRaw": validation.Validate(v.JsonPayloadRaw, validation.When(v.Payload != nil, validation.Empty.Error("must be blank when Payload is set"))),
 	}.Filter()
 }
 
 // Validate validates an UpdateMatchTargetRequest.
 func (v UpdateMatchTargetRequest) Validate() error {
 	return validation.Errors{
-		"ConfigID":      validation.Validate(v.ConfigID, validation.Required),
-		"ConfigVersion": validation.Validate(v.ConfigVersion, validation.Required),
-		"TargetID":      validation.Validate(v.TargetID, validation.Required),
+		"ConfigID":       validation.Validate(v.ConfigID, validation.Required),
+		"ConfigVersion":  validation.Validate(v.ConfigVersion, validation.Required),
+		"TargetID":       validation.Validate(v.TargetID, validation.Required),
+		"Payload":        validation.Validate(v.Payload),
+		"JsonPayloadRaw": validation.Validate(v.JsonPayloadRaw, validation.When(v.Payload != nil, validation.Empty.Error("must be blank when Payload is set"))),
 	}.Filter()
 }
 
@@ -386,9 +353,14 @@ func (p *appsec) UpdateMatchTarget(ctx context.Context, params UpdateMatchTarget
 		return nil, fmt.Errorf("failed to create UpdateMatchTarget request: %w", err)
 	}
 
+	var payload interface{} = params.JsonPayloadRaw
+	if params.Payload != nil {
+		payload = params.Payload
+	}
+
 	var result UpdateMatchTargetResponse
 	req.Header.Set("Content-Type", "application/json")
-	resp, err := p.Exec(req, &result, params.JsonPayloadRaw)
+	resp, err := p.Exec(req, &result, payload)
 	if err != nil {
 		return nil, fmt.Errorf("update match target request failed: %w", err)
 	}
@@ -418,9 +390,14 @@ func (p *appsec) CreateMatchTarget(ctx context.Context, params CreateMatchTarget
 		return nil, fmt.Errorf("failed to create CreateMatchTarget request: %w", err)
 	}
 
+	var payload interface{} = params.JsonPayloadRaw
+	if params.Payload != nil {
+		payload = params.Payload
+	}
+
 	var result CreateMatchTargetResponse
 	req.Header.Set("Content-Type", "application/json")
-	resp, err := p.Exec(req, &result, params.JsonPayloadRaw)
+	resp, err := p.Exec(req, &result, payload)
 	if err != nil {
 		return nil, fmt.Errorf("create match target request failed: %w", err)
 	}
@@ -0,0 +1,241 @@
+package appsec
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type (
+	// MatchTargetPayload describes a match target sent to CreateMatchTarget or UpdateMatchTarget.
+	// It is implemented by WebsiteMatchTargetPayload and APIMatchTargetPayload.
+	MatchTargetPayload interface {
+		validation.Validatable
+		matchTargetType() string
+	}
+
+	// WebsiteMatchTargetPayload describes a website match target, applying a security policy to requests
+	// for the hostnames, paths and file extensions it matches.
+	WebsiteMatchTargetPayload struct {
+		Hostnames                    []string                  `json:"hostnames,omitempty"`
+		FilePaths                    []string                  `json:"filePaths,omitempty"`
+		FileExtensions               []string                  `json:"fileExtensions,omitempty"`
+		IsNegativePathMatch          bool                      `json:"isNegativePathMatch"`
+		IsNegativeFileExtensionMatch bool                      `json:"isNegativeFileExtensionMatch"`
+		DefaultFile                  MatchTargetDefaultFile    `json:"defaultFile,omitempty"`
+		SecurityPolicy               MatchTargetSecurityPolicy `json:"securityPolicy"`
+		BypassNetworkLists           []BypassNetworkList       `json:"bypassNetworkLists,omitempty"`
+	}
+
+	// APIMatchTargetPayload describes an API match target, applying a security policy to requests
+	// for the API endpoints it matches.
+	APIMatchTargetPayload struct {
+		Apis               []MatchTargetAPI          `json:"apis"`
+		SecurityPolicy     MatchTargetSecurityPolicy `json:"securityPolicy"`
+		BypassNetworkLists []BypassNetworkList       `json:"bypassNetworkLists,omitempty"`
+	}
+
+	// MatchTargetAPI identifies an API endpoint matched by an API match target.
+	MatchTargetAPI struct {
+		ID   int    `json:"id"`
+		Name string `json:"name,omitempty"`
+	}
+
+	// MatchTargetSecurityPolicy identifies the security policy applied by a match target.
+	MatchTargetSecurityPolicy struct {
+		PolicyID string `json:"policyId"`
+	}
+
+	// MatchTargetDefaultFile defines how a website match target matches requests for the default file of a path.
+	MatchTargetDefaultFile string
+
+	// WebsiteMatchTargetBuilder builds a WebsiteMatchTargetPayload, see NewWebsiteMatchTarget.
+	WebsiteMatchTargetBuilder struct {
+		payload WebsiteMatchTargetPayload
+	}
+
+	// APIMatchTargetBuilder builds an APIMatchTargetPayload, see NewAPIMatchTarget.
+	APIMatchTargetBuilder struct {
+		payload APIMatchTargetPayload
+	}
+)
+
+const (
+	// MatchTargetTypeWebsite is the type of website match targets.
+	MatchTargetTypeWebsite = "website"
+
+	// MatchTargetTypeAPI is the type of API match targets.
+	MatchTargetTypeAPI = "api"
+
+	// DefaultFileNoMatch does not match requests for the default file of a path.
+	DefaultFileNoMatch MatchTargetDefaultFile = "NO_MATCH"
+
+	// DefaultFileBaseMatch matches requests for the default file of the base path only.
+	DefaultFileBaseMatch MatchTargetDefaultFile = "BASE_MATCH"
+
+	// DefaultFileRecursiveMatch matches requests for the default file of all matched paths.
+	DefaultFileRecursiveMatch MatchTargetDefaultFile = "RECURSIVE_MATCH"
+)
+
+// NewWebsiteMatchTarget returns a builder of a website match target applying the security policy with given ID, e.g.:
+//
+//	payload, err := appsec.NewWebsiteMatchTarget("AAAA_81230").
+//		Hostnames("www.example.com", "shop.example.com").
+//		FilePaths("/*").
+//		BypassNetworkList("12345_ALLOWLIST", "Allowlist").
+//		Build()
+func NewWebsiteMatchTarget(policyID string) *WebsiteMatchTargetBuilder {
+	return &WebsiteMatchTargetBuilder{payload: WebsiteMatchTargetPayload{SecurityPolicy: MatchTargetSecurityPolicy{PolicyID: policyID}}}
+}
+
+// Hostnames adds hostnames matched by the target.
+func (b *WebsiteMatchTargetBuilder) Hostnames(hostnames ...string) *WebsiteMatchTargetBuilder {
+	b.payload.Hostnames = append(b.payload.Hostnames, hostnames...)
+	return b
+}
+
+// FilePaths adds paths matched by the target, e.g. /* or /login/*.
+func (b *WebsiteMatchTargetBuilder) FilePaths(paths ...string) *WebsiteMatchTargetBuilder {
+	b.payload.FilePaths = append(b.payload.FilePaths, paths...)
+	return b
+}
+
+// FileExtensions adds file extensions matched by the target, without the leading dot.
+func (b *WebsiteMatchTargetBuilder) FileExtensions(extensions ...string) *WebsiteMatchTargetBuilder {
+	b.payload.FileExtensions = append(b.payload.FileExtensions, extensions...)
+	return b
+}
+
+// NegativePathMatch makes the target match requests for paths other than its file paths.
+func (b *WebsiteMatchTargetBuilder) NegativePathMatch() *WebsiteMatchTargetBuilder {
+	b.payload.IsNegativePathMatch = true
+	return b
+}
+
+// NegativeFileExtensionMatch makes the target match requests for files with extensions other than its file extensions.
+func (b *WebsiteMatchTargetBuilder) NegativeFileExtensionMatch() *WebsiteMatchTargetBuilder {
+	b.payload.IsNegativeFileExtensionMatch = true
+	return b
+}
+
+// DefaultFile sets how the target matches requests for the default file of a path.
+func (b *WebsiteMatchTargetBuilder) DefaultFile(defaultFile MatchTargetDefaultFile) *WebsiteMatchTargetBuilder {
+	b.payload.DefaultFile = defaultFile
+	return b
+}
+
+// BypassNetworkList adds a network list whose clients bypass the target.
+func (b *WebsiteMatchTargetBuilder) BypassNetworkList(id, name string) *WebsiteMatchTargetBuilder {
+	b.payload.BypassNetworkLists = append(b.payload.BypassNetworkLists, BypassNetworkList{ID: id, Name: name})
+	return b
+}
+
+// Build validates and returns the payload.
+func (b *WebsiteMatchTargetBuilder) Build() (*WebsiteMatchTargetPayload, error) {
+	payload := b.payload
+	if err := payload.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrStructValidation, err)
+	}
+	return &payload, nil
+}
+
+// NewAPIMatchTarget returns a builder of an API match target applying the security policy with given ID, e.g.:
+//
+//	payload, err := appsec.NewAPIMatchTarget("AAAA_81230").
+//		API(493, "Orders API").
+//		Build()
+func NewAPIMatchTarget(policyID string) *APIMatchTargetBuilder {
+	return &APIMatchTargetBuilder{payload: APIMatchTargetPayload{SecurityPolicy: MatchTargetSecurityPolicy{PolicyID: policyID}}}
+}
+
+// API adds an API endpoint matched by the target.
+func (b *APIMatchTargetBuilder) API(id int, name string) *APIMatchTargetBuilder {
+	b.payload.Apis = append(b.payload.Apis, MatchTargetAPI{ID: id, Name: name})
+	return b
+}
+
+// BypassNetworkList adds a network list whose clients bypass the target.
+func (b *APIMatchTargetBuilder) BypassNetworkList(id, name string) *APIMatchTargetBuilder {
+	b.payload.BypassNetworkLists = append(b.payload.BypassNetworkLists, BypassNetworkList{ID: id, Name: name})
+	return b
+}
+
+// Build validates and returns the payload.
+func (b *APIMatchTargetBuilder) Build() (*APIMatchTargetPayload, error) {
+	payload := b.payload
+	if err := payload.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrStructValidation, err)
+	}
+	return &payload, nil
+}
+
+func (WebsiteMatchTargetPayload) matchTargetType() string {
+	return MatchTargetTypeWebsite
+}
+
+func (APIMatchTargetPayload) matchTargetType() string {
+	return MatchTargetTypeAPI
+}
+
+// MarshalJSON encodes the payload with its type.
+func (v WebsiteMatchTargetPayload) MarshalJSON() ([]byte, error) {
+	type payload WebsiteMatchTargetPayload
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		payload
+	}{Type: v.matchTargetType(), payload: payload(v)})
+}
+
+// MarshalJSON encodes the payload with its type.
+func (v APIMatchTargetPayload) MarshalJSON() ([]byte, error) {
+	type payload APIMatchTargetPayload
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		payload
+	}{Type: v.matchTargetType(), payload: payload(v)})
+}
+
+// Validate validates a WebsiteMatchTargetPayload.
+func (v WebsiteMatchTargetPayload) Validate() error {
+	return validation.Errors{
+		"Hostnames":          validation.Validate(v.Hostnames, validation.Each(validation.Required)),
+		"FilePaths":          validation.Validate(v.FilePaths, validation.Required, validation.Each(validation.Required, validation.By(validateFilePath))),
+		"FileExtensions":     validation.Validate(v.FileExtensions, validation.Each(validation.Required)),
+		"DefaultFile":        validation.Validate(v.DefaultFile, validation.In(DefaultFileNoMatch, DefaultFileBaseMatch, DefaultFileRecursiveMatch)),
+		"SecurityPolicy":     validation.Validate(v.SecurityPolicy.PolicyID, validation.Required),
+		"BypassNetworkLists": validation.Validate(v.BypassNetworkLists),
+	}.Filter()
+}
+
+// Validate validates an APIMatchTargetPayload.
+func (v APIMatchTargetPayload) Validate() error {
+	return validation.Errors{
+		"Apis":               validation.Validate(v.Apis, validation.Required),
+		"SecurityPolicy":     validation.Validate(v.SecurityPolicy.PolicyID, validation.Required),
+		"BypassNetworkLists": validation.Validate(v.BypassNetworkLists),
+	}.Filter()
+}
+
+// Validate validates a MatchTargetAPI.
+func (v MatchTargetAPI) Validate() error {
+	return validation.Errors{
+		"ID": validation.Validate(v.ID, validation.Required),
+	}.Filter()
+}
+
+// Validate validates a BypassNetworkList.
+func (v BypassNetworkList) Validate() error {
+	return validation.Errors{
+		"ID": validation.Validate(v.ID, validation.Required),
+	}.Filter()
+}
+
+func validateFilePath(value interface{}) error {
+	if path, ok := value.(string); ok && !strings.HasPrefix(path, "/") {
+		return errors.New("must start with /")
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package appsec
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebsiteMatchTargetBuilder(t *testing.T) {
+	tests := map[string]struct {
+		builder   *WebsiteMatchTargetBuilder
+		expected  string
+		withError bool
+	}{
+		"all fields": {
+			builder: NewWebsiteMatchTarget("AAAA_81230").
+				Hostnames("www.example.com", "shop.example.com").
+				FilePaths("/login/*").
+				NegativePathMatch().
+				FileExtensions("php").
+				NegativeFileExtensionMatch().
+				DefaultFile(DefaultFileBaseMatch).
+				BypassNetworkList("12345_ALLOWLIST", "Allowlist"),
+			expected: `{
+				"type": "website",
+				"hostnames": ["www.example.com", "shop.example.com"],
+				"filePaths": ["/login/*"],
+				"fileExtensions": ["php"],
+				"isNegativePathMatch": true,
+				"isNegativeFileExtensionMatch": true,
+				"defaultFile": "BASE_MATCH",
+				"securityPolicy": {"policyId": "AAAA_81230"},
+				"bypassNetworkLists": [{"id": "12345_ALLOWLIST", "name": "Allowlist"}]
+			}`,
+		},
+		"missing security policy": {
+			builder:   NewWebsiteMatchTarget("").FilePaths("/*"),
+			withError: true,
+		},
+		"missing file paths": {
+			builder:   NewWebsiteMatchTarget("AAAA_81230").Hostnames("www.example.com"),
+			withError: true,
+		},
+		"relative file path": {
+			builder:   NewWebsiteMatchTarget("AAAA_81230").FilePaths("login/*"),
+			withError: true,
+		},
+		"invalid default file": {
+			builder:   NewWebsiteMatchTarget("AAAA_81230").FilePaths("/*").DefaultFile("ANY"),
+			withError: true,
+		},
+		"bypass network list without ID": {
+			builder:   NewWebsiteMatchTarget("AAAA_81230").FilePaths("/*").BypassNetworkList("", "Allowlist"),
+			withError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			payload, err := test.builder.Build()
+			if test.withError {
+				assert.True(t, errors.Is(err, ErrStructValidation), "want: %s; got: %s", ErrStructValidation, err)
+				return
+			}
+			require.NoError(t, err)
+			data, err := json.Marshal(payload)
+			require.NoError(t, err)
+			assert.JSONEq(t, test.expected, string(data))
+		})
+	}
+}
+
+func TestAPIMatchTargetBuilder(t *testing.T) {
+	tests := map[string]struct {
+		builder   *APIMatchTargetBuilder
+		expected  string
+		withError bool
+	}{
+		"all fields": {
+			builder: NewAPIMatchTarget("AAAA_81230").
+				API(493, "Orders API").
+				API(494, "").
+				BypassNetworkList("12345_ALLOWLIST", "Allowlist"),
+			expected: `{
+				"type": "api",
+				"apis": [{"id": 493, "name": "Orders API"}, {"id": 494}],
+				"securityPolicy": {"policyId": "AAAA_81230"},
+				"bypassNetworkLists": [{"id": "12345_ALLOWLIST", "name": "Allowlist"}]
+			}`,
+		},
+		"missing APIs": {
+			builder:   NewAPIMatchTarget("AAAA_81230"),
+			withError: true,
+		},
+		"API without ID": {
+			builder:   NewAPIMatchTarget("AAAA_81230").API(0, "Orders API"),
+			withError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			payload, err := test.builder.Build()
+			if test.withError {
+				assert.True(t, errors.Is(err, ErrStructValidation), "want: %s; got: %s", ErrStructValidation, err)
+				return
+			}
+			require.NoError(t, err)
+			data, err := json.Marshal(payload)
+			require.NoError(t, err)
+			assert.JSONEq(t, test.expected, string(data))
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/tools"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 )
 
@@ -26,7 +27,7 @@ type (
 	GetMatchTargetSequenceRequest struct {
 		ConfigID      int    `json:"configId"`
 		ConfigVersion int    `json:"configVersion"`
-		Type          string `json:"type"`
+		Type          string `json:"type" query:"type"`
 	}
 
 	// GetMatchTargetSequenceResponse is returned from a call to GetMatchTargetSequence.
@@ -35,7 +36,8 @@ type (
 		Type           string            `json:"type"`
 	}
 
-	// UpdateMatchTargetSequenceRequest UpdateMatchTargetSequenceRequest is used to modify an existing match target sequence.
+	// UpdateMatchTargetSequenceRequest is used to reorder the match targets of a type, website or api, in a configuration
+	// version. Targets are matched in the order of their sequence numbers, see NewMatchTargetSequence.
 	UpdateMatchTargetSequenceRequest struct {
 		ConfigID       int               `json:"-"`
 		ConfigVersion  int               `json:"-"`
@@ -68,12 +70,47 @@ func (v GetMatchTargetSequenceRequest) Validate() error {
 // Validate validates an UpdateMatchTargetSequenceRequest.
 func (v UpdateMatchTargetSequenceRequest) Validate() error {
 	return validation.Errors{
-		"ConfigID":      validation.Validate(v.ConfigID, validation.Required),
-		"ConfigVersion": validation.Validate(v.ConfigVersion, validation.Required),
-		"Type":          validation.Validate(v.ConfigVersion, validation.Required),
+		"ConfigID":       validation.Validate(v.ConfigID, validation.Required),
+		"ConfigVersion":  validation.Validate(v.ConfigVersion, validation.Required),
+		"Type":           validation.Validate(v.Type, validation.Required, validation.In(MatchTargetTypeWebsite, MatchTargetTypeAPI)),
+		"TargetSequence": validation.Validate(v.TargetSequence, validation.By(validateMatchTargetSequence)),
 	}.Filter()
 }
 
+// Validate validates a MatchTargetItem.
+func (v MatchTargetItem) Validate() error {
+	return validation.Errors{
+		"TargetID": validation.Validate(v.TargetID, validation.Required),
+		"Sequence": validation.Validate(v.Sequence, validation.Required, validation.Min(1)),
+	}.Filter()
+}
+
+// NewMatchTargetSequence returns the sequence of match targets matched in the order of given target IDs.
+func NewMatchTargetSequence(targetIDs ...int) []MatchTargetItem {
+	sequence := make([]MatchTargetItem, 0, len(targetIDs))
+	for i, id := range targetIDs {
+		sequence = append(sequence, MatchTargetItem{Sequence: i + 1, TargetID: id})
+	}
+	return sequence
+}
+
+// validateMatchTargetSequence checks that targets and sequence numbers are not repeated
+func validateMatchTargetSequence(value interface{}) error {
+	items, _ := value.([]MatchTargetItem)
+	targets := make(map[int]bool, len(items))
+	sequences := make(map[int]bool, len(items))
+	for _, item := range items {
+		if targets[item.TargetID] {
+			return fmt.Errorf("target %d is repeated", item.TargetID)
+		}
+		if sequences[item.Sequence] {
+			return fmt.Errorf("sequence %d is repeated", item.Sequence)
+		}
+		targets[item.TargetID], sequences[item.Sequence] = true, true
+	}
+	return nil
+}
+
 func (p *appsec) GetMatchTargetSequence(ctx context.Context, params GetMatchTargetSequenceRequest) (*GetMatchTargetSequenceResponse, error) {
 	logger := p.Log(ctx)
 	logger.Debug("GetMatchTargetSequence")
@@ -82,12 +119,14 @@ func (p *appsec) GetMatchTargetSequence(ctx context.Context, params GetMatchTarg
 		return nil, fmt.Errorf("%w: %s", ErrStructValidation, err.Error())
 	}
 
-	uri := fmt.Sprintf(
-		"/appsec/v1/configs/%d/versions/%d/match-targets/sequence?type=%s",
+	uri, err := tools.AppendQuery(fmt.Sprintf(
+		"/appsec/v1/configs/%d/versions/%d/match-targets/sequence",
 		params.ConfigID,
 		params.ConfigVersion,
-		params.Type,
-	)
+	), params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GetMatchTargetSequence request: %w", err)
+	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
 	if err != nil {
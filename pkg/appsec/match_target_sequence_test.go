@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -182,14 +183,54 @@ func TestAppSec_UpdateMatchTargetSequence(t *testing.T) {
 	require.NoError(t, err)
 
 	tests := map[string]struct {
-		params           UpdateMatchTargetSequenceRequest
-		responseStatus   int
-		responseBody     string
-		expectedPath     string
-		expectedResponse *UpdateMatchTargetSequenceResponse
-		withError        error
-		headers          http.Header
+		params              UpdateMatchTargetSequenceRequest
+		responseStatus      int
+		responseBody        string
+		expectedPath        string
+		expectedRequestBody string
+		expectedResponse    *UpdateMatchTargetSequenceResponse
+		withError           error
+		headers             http.Header
 	}{
+		"200 Success with reordered targets": {
+			params: UpdateMatchTargetSequenceRequest{
+				ConfigID:       43253,
+				ConfigVersion:  15,
+				Type:           MatchTargetTypeWebsite,
+				TargetSequence: NewMatchTargetSequence(3954, 2052813),
+			},
+			responseStatus:      http.StatusOK,
+			responseBody:        respData,
+			expectedResponse:    &result,
+			expectedPath:        "/appsec/v1/configs/43253/versions/15/match-targets/sequence",
+			expectedRequestBody: `{"type":"website","targetSequence":[{"sequence":1,"targetId":3954},{"sequence":2,"targetId":2052813}]}`,
+		},
+		"invalid type": {
+			params: UpdateMatchTargetSequenceRequest{
+				ConfigID:      43253,
+				ConfigVersion: 15,
+				Type:          "mobile",
+			},
+			withError: ErrStructValidation,
+		},
+		"repeated target": {
+			params: UpdateMatchTargetSequenceRequest{
+				ConfigID:       43253,
+				ConfigVersion:  15,
+				Type:           MatchTargetTypeAPI,
+				TargetSequence: []MatchTargetItem{{Sequence: 1, TargetID: 3954}, {Sequence: 2, TargetID: 3954}},
+			},
+			withError: ErrStructValidation,
+		},
+		"invalid sequence number": {
+			params: UpdateMatchTargetSequenceRequest{
+				ConfigID:       43253,
+				ConfigVersion:  15,
+				Type:           MatchTargetTypeAPI,
+				TargetSequence: []MatchTargetItem{{Sequence: 0, TargetID: 3954}},
+			},
+			withError: ErrStructValidation,
+		},
 		"200 Success": {
 			params: UpdateMatchTargetSequenceRequest{
 				ConfigID:      43253,
@@ -231,6 +272,12 @@ func TestAppSec_UpdateMatchTargetSequence(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				assert.Equal(t, http.MethodPut, r.Method)
+				if test.expectedRequestBody != "" {
+					assert.Equal(t, test.expectedPath, r.URL.Path)
+					body, err := ioutil.ReadAll(r.Body)
+					assert.NoError(t, err)
+					assert.JSONEq(t, test.expectedRequestBody, string(body))
+				}
 				w.WriteHeader(test.responseStatus)
 				if len(test.responseBody) > 0 {
 					_, err := w.Write([]byte(test.responseBody))
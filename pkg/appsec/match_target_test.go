@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -181,15 +182,40 @@ func TestAppSec_CreateMatchTarget(t *testing.T) {
 	require.NoError(t, err)
 
 	tests := map[string]struct {
-		params           CreateMatchTargetRequest
-		prop             *CreateMatchTargetRequest
-		responseStatus   int
-		responseBody     string
-		expectedPath     string
-		expectedResponse *CreateMatchTargetResponse
-		withError        error
-		headers          http.Header
+		params              CreateMatchTargetRequest
+		prop                *CreateMatchTargetRequest
+		responseStatus      int
+		responseBody        string
+		expectedPath        string
+		expectedRequestBody string
+		expectedResponse    *CreateMatchTargetResponse
+		withError           error
+		headers             http.Header
 	}{
+		"201 Created with website payload": {
+			params: CreateMatchTargetRequest{
+				ConfigID:      43253,
+				ConfigVersion: 15,
+				Payload: &WebsiteMatchTargetPayload{
+					Hostnames:      []string{"www.example.com"},
+					FilePaths:      []string{"/*"},
+					SecurityPolicy: MatchTargetSecurityPolicy{PolicyID: "AAAA_81230"},
+				},
+			},
+			responseStatus:      http.StatusCreated,
+			responseBody:        respData,
+			expectedResponse:    &result,
+			expectedPath:        "/appsec/v1/configs/43253/versions/15/match-targets",
+			expectedRequestBody: `{"type":"website","hostnames":["www.example.com"],"filePaths":["/*"],"isNegativePathMatch":false,"isNegativeFileExtensionMatch":false,"securityPolicy":{"policyId":"AAAA_81230"}}`,
+		},
+		"invalid API payload": {
+			params: CreateMatchTargetRequest{
+				ConfigID:      43253,
+				ConfigVersion: 15,
+				Payload:       &APIMatchTargetPayload{SecurityPolicy: MatchTargetSecurityPolicy{PolicyID: "AAAA_81230"}},
+			},
+			withError: ErrStructValidation,
+		},
 		"201 Created": {
 			params: CreateMatchTargetRequest{
 				ConfigID:      43253,
@@ -229,6 +255,11 @@ func TestAppSec_CreateMatchTarget(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				assert.Equal(t, http.MethodPost, r.Method)
+				if test.expectedRequestBody != "" {
+					body, err := ioutil.ReadAll(r.Body)
+					assert.NoError(t, err)
+					assert.JSONEq(t, test.expectedRequestBody, string(body))
+				}
 				w.WriteHeader(test.responseStatus)
 				if len(test.responseBody) > 0 {
 					_, err := w.Write([]byte(test.responseBody))
@@ -153,6 +153,7 @@ func (p *appsec) GetNetworkLayerProtection(ctx context.Context, params GetNetwor
 func (p *appsec) GetNetworkLayerProtections(ctx context.Context, params GetNetworkLayerProtectionsRequest) (*GetNetworkLayerProtectionsResponse, error) {
 	logger := p.Log(ctx)
 	logger.Debug("GetNetworkLayerProtections")
+	p.warnDeprecated(ctx, "GetNetworkLayerProtections")
 
 	if err := params.Validate(); err != nil {
 		return nil, fmt.Errorf("%w: %s", ErrStructValidation, err.Error())
@@ -216,6 +217,7 @@ func (p *appsec) UpdateNetworkLayerProtection(ctx context.Context, params Update
 func (p *appsec) RemoveNetworkLayerProtection(ctx context.Context, params RemoveNetworkLayerProtectionRequest) (*RemoveNetworkLayerProtectionResponse, error) {
 	logger := p.Log(ctx)
 	logger.Debug("RemoveNetworkLayerProtection")
+	p.warnDeprecated(ctx, "RemoveNetworkLayerProtection")
 
 	if err := params.Validate(); err != nil {
 		return nil, fmt.Errorf("%w: %s", ErrStructValidation, err.Error())
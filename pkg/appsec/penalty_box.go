@@ -143,6 +143,7 @@ func (p *appsec) GetPenaltyBox(ctx context.Context, params GetPenaltyBoxRequest)
 func (p *appsec) GetPenaltyBoxes(ctx context.Context, params GetPenaltyBoxesRequest) (*GetPenaltyBoxesResponse, error) {
 	logger := p.Log(ctx)
 	logger.Debug("GetPenaltyBoxes")
+	p.warnDeprecated(ctx, "GetPenaltyBoxes")
 
 	if err := params.Validate(); err != nil {
 		return nil, fmt.Errorf("%w: %s", ErrStructValidation, err.Error())
@@ -193,37 +193,40 @@ type (
 
 	// GetRatePoliciesResponse is returned from a call to GetRatePolicies.
 	GetRatePoliciesResponse struct {
-		RatePolicies []struct {
-			ID                     int                        `json:"id"`
-			ConfigID               int                        `json:"-"`
-			ConfigVersion          int                        `json:"-"`
-			MatchType              string                     `json:"matchType,omitempty"`
-			Type                   string                     `json:"type,omitempty"`
-			Name                   string                     `json:"name,omitempty"`
-			Description            string                     `json:"description,omitempty"`
-			AverageThreshold       int                        `json:"averageThreshold,omitempty"`
-			BurstThreshold         int                        `json:"burstThreshold,omitempty"`
-			BurstWindow            int                        `json:"burstWindow,omitempty"`
-			ClientIdentifier       string                     `json:"clientIdentifier,omitempty"`
-			UseXForwardForHeaders  bool                       `json:"useXForwardForHeaders"`
-			RequestType            string                     `json:"requestType,omitempty"`
-			SameActionOnIpv6       bool                       `json:"sameActionOnIpv6"`
-			Path                   *RatePolicyPath            `json:"path,omitempty"`
-			PathMatchType          string                     `json:"pathMatchType,omitempty"`
-			PathURIPositiveMatch   bool                       `json:"pathUriPositiveMatch"`
-			FileExtensions         *RatePolicyFileExtensions  `json:"fileExtensions,omitempty"`
-			Hosts                  *RatePoliciesHosts         `json:"hosts,omitempty"`
-			Hostnames              []string                   `json:"hostnames,omitempty"`
-			AdditionalMatchOptions []RatePolicyMatchOption    `json:"additionalMatchOptions,omitempty"`
-			Condition              *RatePolicyCondition       `json:"condition,omitempty"`
-			QueryParameters        *RatePolicyQueryParameters `json:"queryParameters,omitempty"`
-			CreateDate             string                     `json:"-"`
-			UpdateDate             string                     `json:"-"`
-			Used                   json.RawMessage            `json:"used"`
-			SameActionOnIpv        bool                       `json:"sameActionOnIpv"`
-			APISelectors           *RatePolicyAPISelectors    `json:"apiSelectors,omitempty"`
-			BodyParameters         *RatePolicyBodyParameters  `json:"bodyParameters,omitempty"`
-		} `json:"ratePolicies,omitempty"`
+		RatePolicies []RatePolicyItem `json:"ratePolicies,omitempty"`
+	}
+
+	// RatePolicyItem describes a rate policy returned by GetRatePolicies.
+	RatePolicyItem struct {
+		ID                     int                        `json:"id"`
+		ConfigID               int                        `json:"-"`
+		ConfigVersion          int                        `json:"-"`
+		MatchType              string                     `json:"matchType,omitempty"`
+		Type                   string                     `json:"type,omitempty"`
+		Name                   string                     `json:"name,omitempty"`
+		Description            string                     `json:"description,omitempty"`
+		AverageThreshold       int                        `json:"averageThreshold,omitempty"`
+		BurstThreshold         int                        `json:"burstThreshold,omitempty"`
+		BurstWindow            int                        `json:"burstWindow,omitempty"`
+		ClientIdentifier       string                     `json:"clientIdentifier,omitempty"`
+		UseXForwardForHeaders  bool                       `json:"useXForwardForHeaders"`
+		RequestType            string                     `json:"requestType,omitempty"`
+		SameActionOnIpv6       bool                       `json:"sameActionOnIpv6"`
+		Path                   *RatePolicyPath            `json:"path,omitempty"`
+		PathMatchType          string                     `json:"pathMatchType,omitempty"`
+		PathURIPositiveMatch   bool                       `json:"pathUriPositiveMatch"`
+		FileExtensions         *RatePolicyFileExtensions  `json:"fileExtensions,omitempty"`
+		Hosts                  *RatePoliciesHosts         `json:"hosts,omitempty"`
+		Hostnames              []string                   `json:"hostnames,omitempty"`
+		AdditionalMatchOptions []RatePolicyMatchOption    `json:"additionalMatchOptions,omitempty"`
+		Condition              *RatePolicyCondition       `json:"condition,omitempty"`
+		QueryParameters        *RatePolicyQueryParameters `json:"queryParameters,omitempty"`
+		CreateDate             string                     `json:"-"`
+		UpdateDate             string                     `json:"-"`
+		Used                   json.RawMessage            `json:"used"`
+		SameActionOnIpv        bool                       `json:"sameActionOnIpv"`
+		APISelectors           *RatePolicyAPISelectors    `json:"apiSelectors,omitempty"`
+		BodyParameters         *RatePolicyBodyParameters  `json:"bodyParameters,omitempty"`
 	}
 
 	// GetRatePolicyRequest is used to retrieve information about a specific rate policy.
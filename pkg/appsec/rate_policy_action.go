@@ -41,11 +41,14 @@ type (
 
 	// GetRatePolicyActionsResponse is returned from a call to GetRatePolicyActions.
 	GetRatePolicyActionsResponse struct {
-		RatePolicyActions []struct {
-			ID         int    `json:"id"`
-			Ipv4Action string `json:"ipv4Action,omitempty"`
-			Ipv6Action string `json:"ipv6Action,omitempty"`
-		} `json:"ratePolicyActions,omitempty"`
+		RatePolicyActions []RatePolicyActionItem `json:"ratePolicyActions,omitempty"`
+	}
+
+	// RatePolicyActionItem describes a rate policy action returned by GetRatePolicyActions.
+	RatePolicyActionItem struct {
+		ID         int    `json:"id"`
+		Ipv4Action string `json:"ipv4Action,omitempty"`
+		Ipv6Action string `json:"ipv6Action,omitempty"`
 	}
 
 	// GetRatePolicyActionRequest is used to retrieve a configuration's rate policies and their associated actions.
@@ -120,6 +123,8 @@ func (v UpdateRatePolicyActionRequest) Validate() error {
 		"Version":      validation.Validate(v.Version, validation.Required),
 		"PolicyID":     validation.Validate(v.PolicyID, validation.Required),
 		"RatePolicyID": validation.Validate(v.RatePolicyID, validation.Required),
+		"Ipv4Action":   validation.Validate(ActionType(v.Ipv4Action)),
+		"Ipv6Action":   validation.Validate(ActionType(v.Ipv6Action)),
 	}.Filter()
 }
 
@@ -127,6 +132,7 @@ func (v UpdateRatePolicyActionRequest) Validate() error {
 func (p *appsec) GetRatePolicyAction(ctx context.Context, params GetRatePolicyActionRequest) (*GetRatePolicyActionResponse, error) {
 	logger := p.Log(ctx)
 	logger.Debug("GetRatePolicyAction")
+	p.warnDeprecated(ctx, "GetRatePolicyAction")
 
 	if err := params.Validate(); err != nil {
 		return nil, fmt.Errorf("%w: %s", ErrStructValidation, err.Error())
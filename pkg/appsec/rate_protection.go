@@ -125,6 +125,7 @@ func (p *appsec) GetRateProtection(ctx context.Context, params GetRateProtection
 func (p *appsec) GetRateProtections(ctx context.Context, params GetRateProtectionsRequest) (*GetRateProtectionsResponse, error) {
 	logger := p.Log(ctx)
 	logger.Debug("GetRateProtections")
+	p.warnDeprecated(ctx, "GetRateProtections")
 
 	if err := params.Validate(); err != nil {
 		return nil, fmt.Errorf("%w: %s", ErrStructValidation, err.Error())
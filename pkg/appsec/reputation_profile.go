@@ -43,6 +43,7 @@ type (
 	atomicConditionsName []string
 
 	// GetReputationProfilesRequest is used to retrieve the reputation profiles for a configuration.
+	// If ReputationProfileId is set, only that profile is retrieved, and an error is returned if it does not exist.
 	GetReputationProfilesRequest struct {
 		ConfigID            int `json:"configId"`
 		ConfigVersion       int `json:"configVersion"`
@@ -51,16 +52,19 @@ type (
 
 	// GetReputationProfilesResponse is returned from a call to GetReputationProfiles.
 	GetReputationProfilesResponse struct {
-		ReputationProfiles []struct {
-			Condition        *ReputationProfileCondition `json:"condition,omitempty"`
-			Context          string                      `json:"context,omitempty"`
-			ContextReadable  string                      `json:"-"`
-			Enabled          bool                        `json:"-"`
-			ID               int                         `json:"id,omitempty"`
-			Name             string                      `json:"name,omitempty"`
-			SharedIPHandling string                      `json:"sharedIpHandling,omitempty"`
-			Threshold        int                         `json:"threshold,omitempty"`
-		} `json:"reputationProfiles,omitempty"`
+		ReputationProfiles []ReputationProfileItem `json:"reputationProfiles,omitempty"`
+	}
+
+	// ReputationProfileItem describes a reputation profile returned by GetReputationProfiles.
+	ReputationProfileItem struct {
+		Condition        *ReputationProfileCondition `json:"condition,omitempty"`
+		Context          string                      `json:"context,omitempty"`
+		ContextReadable  string                      `json:"-"`
+		Enabled          bool                        `json:"-"`
+		ID               int                         `json:"id,omitempty"`
+		Name             string                      `json:"name,omitempty"`
+		SharedIPHandling string                      `json:"sharedIpHandling,omitempty"`
+		Threshold        int                         `json:"threshold,omitempty"`
 	}
 
 	// GetReputationProfileRequest is used to retrieve the details for a specific reputation profile.
@@ -83,12 +87,51 @@ type (
 	}
 
 	// CreateReputationProfileRequest is used to create a reputation profile.
+	// The profile is described either by Payload or, as an escape hatch for fields it does not cover, by JsonPayloadRaw.
 	CreateReputationProfileRequest struct {
-		ConfigID       int             `json:"-"`
-		ConfigVersion  int             `json:"-"`
-		JsonPayloadRaw json.RawMessage `json:"-"`
+		ConfigID       int                       `json:"-"`
+		ConfigVersion  int                       `json:"-"`
+		Payload        *ReputationProfilePayload `json:"-"`
+		JsonPayloadRaw json.RawMessage           `json:"-"`
+	}
+
+	// ReputationProfilePayload describes a reputation profile sent to CreateReputationProfile or UpdateReputationProfile.
+	ReputationProfilePayload struct {
+		Name             string                             `json:"name"`
+		Description      string                             `json:"description,omitempty"`
+		Context          ReputationProfileContext           `json:"context"`
+		Threshold        int                                `json:"threshold"`
+		SharedIPHandling SharedIPHandling                   `json:"sharedIpHandling"`
+		Condition        *ReputationProfilePayloadCondition `json:"condition,omitempty"`
+	}
+
+	// ReputationProfilePayloadCondition limits the requests a reputation profile applies to.
+	ReputationProfilePayloadCondition struct {
+		AtomicConditions []ReputationProfileAtomicCondition `json:"atomicConditions,omitempty"`
+		PositiveMatch    *bool                              `json:"positiveMatch,omitempty"`
+	}
+
+	// ReputationProfileAtomicCondition is a single condition of a reputation profile, such as a match
+	// on a request header or the AS number of the client.
+	ReputationProfileAtomicCondition struct {
+		ClassName     string   `json:"className"`
+		PositiveMatch bool     `json:"positiveMatch"`
+		CheckIPs      string   `json:"checkIps,omitempty"`
+		Value         []string `json:"value,omitempty"`
+		Name          []string `json:"name,omitempty"`
+		NameCase      bool     `json:"nameCase,omitempty"`
+		NameWildcard  bool     `json:"nameWildcard,omitempty"`
+		ValueCase     bool     `json:"valueCase,omitempty"`
+		ValueWildcard bool     `json:"valueWildcard,omitempty"`
+		Host          []string `json:"host,omitempty"`
 	}
 
+	// ReputationProfileContext is the category of threats a reputation profile scores clients for.
+	ReputationProfileContext string
+
+	// SharedIPHandling defines whether a reputation profile applies to clients with shared IP addresses, such as proxies.
+	SharedIPHandling string
+
 	// CreateReputationProfileResponse is returned from a call to CreateReputationProfile.
 	CreateReputationProfileResponse struct {
 		ID               int    `json:"id"`
@@ -117,11 +160,14 @@ type (
 	}
 
 	// UpdateReputationProfileRequest is used to modify an existing reputation profile.
+	// The profile is described either by Payload, which can be obtained from GetReputationProfileResponse.Payload
+	// to modify a retrieved profile, or by JsonPayloadRaw.
 	UpdateReputationProfileRequest struct {
-		ConfigID            int             `json:"-"`
-		ConfigVersion       int             `json:"-"`
-		ReputationProfileId int             `json:"-"`
-		JsonPayloadRaw      json.RawMessage `json:"-"`
+		ConfigID            int                       `json:"-"`
+		ConfigVersion       int                       `json:"-"`
+		ReputationProfileId int                       `json:"-"`
+		Payload             *ReputationProfilePayload `json:"-"`
+		JsonPayloadRaw      json.RawMessage           `json:"-"`
 	}
 
 	// UpdateReputationProfileResponse is returned from a call to UpdateReputationProfile.
@@ -254,6 +300,32 @@ type (
 	}
 )
 
+const (
+	// ReputationProfileContextWebAttackers scores clients for web application attacks.
+	ReputationProfileContextWebAttackers ReputationProfileContext = "WEBATCK"
+
+	// ReputationProfileContextDoSAttackers scores clients for denial of service attacks.
+	ReputationProfileContextDoSAttackers ReputationProfileContext = "DOSATCK"
+
+	// ReputationProfileContextWebScrapers scores clients for web scraping.
+	ReputationProfileContextWebScrapers ReputationProfileContext = "WEBSCRP"
+
+	// ReputationProfileContextScanningTools scores clients for vulnerability scanning.
+	ReputationProfileContextScanningTools ReputationProfileContext = "SCANTL"
+
+	// SharedIPHandlingNonShared applies the profile to clients with non-shared IP addresses only.
+	SharedIPHandlingNonShared SharedIPHandling = "NON_SHARED"
+
+	// SharedIPHandlingSharedOnly applies the profile to clients with shared IP addresses only.
+	SharedIPHandlingSharedOnly SharedIPHandling = "SHARED_ONLY"
+
+	// SharedIPHandlingBoth applies the profile to all clients.
+	SharedIPHandlingBoth SharedIPHandling = "BOTH"
+
+	// ReputationProfileMaxThreshold is the highest reputation score threshold of a profile.
+	ReputationProfileMaxThreshold = 10
+)
+
 func (c *atomicConditionsName) UnmarshalJSON(data []byte) error {
 	var nums interface{}
 	err := json.Unmarshal(data, &nums)
@@ -281,6 +353,76 @@ func (c *atomicConditionsName) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// Payload returns the reputation profile as a payload for UpdateReputationProfile, so that a retrieved profile
+// can be modified and sent back, e.g.:
+//
+//	profile, err := client.GetReputationProfile(ctx, getReq)
+//	...
+//	payload, err := profile.Payload()
+//	...
+//	payload.Threshold = 8
+//	_, err = client.UpdateReputationProfile(ctx, appsec.UpdateReputationProfileRequest{
+//		ConfigID:            getReq.ConfigID,
+//		ConfigVersion:       getReq.ConfigVersion,
+//		ReputationProfileId: getReq.ReputationProfileId,
+//		Payload:             payload,
+//	})
+func (v GetReputationProfileResponse) Payload() (*ReputationProfilePayload, error) {
+	payload := ReputationProfilePayload{
+		Name:             v.Name,
+		Context:          ReputationProfileContext(v.Context),
+		Threshold:        v.Threshold,
+		SharedIPHandling: SharedIPHandling(v.SharedIPHandling),
+	}
+	if v.Condition == nil {
+		return &payload, nil
+	}
+
+	payload.Condition = &ReputationProfilePayloadCondition{}
+	if err := decodeRawField(v.Condition.PositiveMatch, &payload.Condition.PositiveMatch); err != nil {
+		return nil, fmt.Errorf("condition positiveMatch: %w", err)
+	}
+	for i, c := range v.Condition.AtomicConditions {
+		condition := ReputationProfileAtomicCondition{
+			ClassName: c.ClassName,
+			Value:     c.Value,
+			NameCase:  c.NameCase,
+			ValueCase: c.ValueCase,
+			Host:      c.Host,
+		}
+		var name atomicConditionsName
+		positiveMatch := c.PositiveMatch
+		fields := map[string]struct {
+			raw *json.RawMessage
+			out interface{}
+		}{
+			"positiveMatch": {&positiveMatch, &condition.PositiveMatch},
+			"checkIps":      {c.CheckIps, &condition.CheckIPs},
+			"name":          {c.Name, &name},
+			"nameWildcard":  {c.NameWildcard, &condition.NameWildcard},
+			"valueWildcard": {c.ValueWildcard, &condition.ValueWildcard},
+		}
+		for field, f := range fields {
+			if err := decodeRawField(f.raw, f.out); err != nil {
+				return nil, fmt.Errorf("atomic condition %d %s: %w", i, field, err)
+			}
+		}
+		if len(name) > 0 {
+			condition.Name = name
+		}
+		payload.Condition.AtomicConditions = append(payload.Condition.AtomicConditions, condition)
+	}
+	return &payload, nil
+}
+
+// decodeRawField decodes a raw JSON field into out, leaving it unchanged if the field is missing or null
+func decodeRawField(raw *json.RawMessage, out interface{}) error {
+	if raw == nil || len(*raw) == 0 || string(*raw) == "null" {
+		return nil
+	}
+	return json.Unmarshal(*raw, out)
+}
+
 // Validate validates a GetReputationProfileRequest.
 func (v GetReputationProfileRequest) Validate() error {
 	return validation.Errors{
@@ -301,8 +443,36 @@ func (v GetReputationProfilesRequest) Validate() error {
 // Validate validates a CreateReputationProfileRequest.
 func (v CreateReputationProfileRequest) Validate() error {
 	return validation.Errors{
-		"ConfigID":      validation.Validate(v.ConfigID, validation.Required),
-		"ConfigVersion": validation.Validate(v.ConfigVersion, validation.Required),
+		"ConfigID":       validation.Validate(v.ConfigID, validation.Required),
+		"ConfigVersion":  validation.Validate(v.ConfigVersion, validation.Required),
+		"Payload":        validation.Validate(v.Payload),
+		"JsonPayloadRaw": validation.Validate(v.JsonPayloadRaw, validation.When(v.Payload != nil, validation.Empty.Error("must be blank when Payload is set"))),
+	}.Filter()
+}
+
+// Validate validates a ReputationProfilePayload.
+func (v ReputationProfilePayload) Validate() error {
+	return validation.Errors{
+		"Name":      validation.Validate(v.Name, validation.Required),
+		"Context":   validation.Validate(v.Context, validation.Required, validation.In(ReputationProfileContextWebAttackers, ReputationProfileContextDoSAttackers, ReputationProfileContextWebScrapers, ReputationProfileContextScanningTools)),
+		"Threshold": validation.Validate(v.Threshold, validation.Required, validation.Min(0), validation.Max(ReputationProfileMaxThreshold)),
+		"SharedIPHandling": validation.Validate(v.SharedIPHandling, validation.Required,
+			validation.In(SharedIPHandlingNonShared, SharedIPHandlingSharedOnly, SharedIPHandlingBoth)),
+		"Condition": validation.Validate(v.Condition),
+	}.Filter()
+}
+
+// Validate validates a ReputationProfilePayloadCondition.
+func (v ReputationProfilePayloadCondition) Validate() error {
+	return validation.Errors{
+		"AtomicConditions": validation.Validate(v.AtomicConditions),
+	}.Filter()
+}
+
+// Validate validates a ReputationProfileAtomicCondition.
+func (v ReputationProfileAtomicCondition) Validate() error {
+	return validation.Errors{
+		"ClassName": validation.Validate(v.ClassName, validation.Required),
 	}.Filter()
 }
 
@@ -312,6 +482,8 @@ func (v UpdateReputationProfileRequest) Validate() error {
 		"ConfigID":            validation.Validate(v.ConfigID, validation.Required),
 		"ConfigVersion":       validation.Validate(v.ConfigVersion, validation.Required),
 		"ReputationProfileId": validation.Validate(v.ReputationProfileId, validation.Required),
+		"Payload":             validation.Validate(v.Payload),
+		"JsonPayloadRaw":      validation.Validate(v.JsonPayloadRaw, validation.When(v.Payload != nil, validation.Empty.Error("must be blank when Payload is set"))),
 	}.Filter()
 }
 
@@ -368,6 +540,10 @@ func (p *appsec) GetReputationProfiles(ctx context.Context, params GetReputation
 		params.ConfigID,
 		params.ConfigVersion,
 	)
+	if params.ReputationProfileId != 0 {
+		// a single profile is retrieved by itself rather than filtered out of the whole collection
+		uri = fmt.Sprintf("%s/%d", uri, params.ReputationProfileId)
+	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
 	if err != nil {
@@ -375,22 +551,20 @@ func (p *appsec) GetReputationProfiles(ctx context.Context, params GetReputation
 	}
 
 	var result GetReputationProfilesResponse
-	resp, err := p.Exec(req, &result)
+	var out interface{} = &result
+	if params.ReputationProfileId != 0 {
+		result.ReputationProfiles = make([]ReputationProfileItem, 1)
+		out = &result.ReputationProfiles[0]
+	}
+	resp, err := p.Exec(req, out)
 	if err != nil {
 		return nil, fmt.Errorf("get reputation profiles request failed: %w", err)
 	}
 	if resp.StatusCode != http.StatusOK {
 		return nil, p.Error(resp)
 	}
-
 	if params.ReputationProfileId != 0 {
-		var filteredResult GetReputationProfilesResponse
-		for _, val := range result.ReputationProfiles {
-			if val.ID == params.ReputationProfileId {
-				filteredResult.ReputationProfiles = append(filteredResult.ReputationProfiles, val)
-			}
-		}
-		return &filteredResult, nil
+		result.ReputationProfiles[0].ID = params.ReputationProfileId
 	}
 
 	return &result, nil
@@ -417,8 +591,13 @@ func (p *appsec) UpdateReputationProfile(ctx context.Context, params UpdateReput
 	}
 	req.Header.Set("Content-Type", "application/json")
 
+	var payload interface{} = params.JsonPayloadRaw
+	if params.Payload != nil {
+		payload = params.Payload
+	}
+
 	var result UpdateReputationProfileResponse
-	resp, err := p.Exec(req, &result, params.JsonPayloadRaw)
+	resp, err := p.Exec(req, &result, payload)
 	if err != nil {
 		return nil, fmt.Errorf("update reputation profile request failed: %w", err)
 	}
@@ -448,9 +627,14 @@ func (p *appsec) CreateReputationProfile(ctx context.Context, params CreateReput
 		return nil, fmt.Errorf("failed to create CreateReputationProfile request: %w", err)
 	}
 
+	var payload interface{} = params.JsonPayloadRaw
+	if params.Payload != nil {
+		payload = params.Payload
+	}
+
 	var result CreateReputationProfileResponse
 	req.Header.Set("Content-Type", "application/json")
-	resp, err := p.Exec(req, &result, params.JsonPayloadRaw)
+	resp, err := p.Exec(req, &result, payload)
 	if err != nil {
 		return nil, fmt.Errorf("create reputation profile request failed: %w", err)
 	}
@@ -39,10 +39,13 @@ type (
 
 	// GetReputationProfileActionsResponse is returned from a call to GetReputationProfileActions.
 	GetReputationProfileActionsResponse struct {
-		ReputationProfiles []struct {
-			Action string `json:"action,omitempty"`
-			ID     int    `json:"id,omitempty"`
-		} `json:"reputationProfiles,omitempty"`
+		ReputationProfiles []ReputationProfileActionItem `json:"reputationProfiles,omitempty"`
+	}
+
+	// ReputationProfileActionItem describes a reputation profile action returned by GetReputationProfileActions.
+	ReputationProfileActionItem struct {
+		Action string `json:"action,omitempty"`
+		ID     int    `json:"id,omitempty"`
 	}
 
 	// GetReputationProfileActionRequest is used to retrieve the details for a specific reputation profile.
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -43,6 +44,38 @@ func TestAppSec_ListReputationProfile(t *testing.T) {
 			expectedPath:     "/appsec/v1/configs/43253/versions/15/reputation-profiles",
 			expectedResponse: &result,
 		},
+		"200 OK single profile": {
+			params: GetReputationProfilesRequest{
+				ConfigID:            43253,
+				ConfigVersion:       15,
+				ReputationProfileId: 12345,
+			},
+			responseStatus: http.StatusOK,
+			responseBody:   `{"id":12345,"name":"Web Attackers (High Threat)","context":"WEBATCK","sharedIpHandling":"NON_SHARED","threshold":9}`,
+			expectedPath:   "/appsec/v1/configs/43253/versions/15/reputation-profiles/12345",
+			expectedResponse: &GetReputationProfilesResponse{ReputationProfiles: []ReputationProfileItem{{
+				ID:               12345,
+				Name:             "Web Attackers (High Threat)",
+				Context:          "WEBATCK",
+				SharedIPHandling: "NON_SHARED",
+				Threshold:        9,
+			}}},
+		},
+		"404 single profile": {
+			params: GetReputationProfilesRequest{
+				ConfigID:            43253,
+				ConfigVersion:       15,
+				ReputationProfileId: 12345,
+			},
+			responseStatus: http.StatusNotFound,
+			responseBody:   `{"type":"not_found","title":"Not Found","status":404}`,
+			expectedPath:   "/appsec/v1/configs/43253/versions/15/reputation-profiles/12345",
+			withError: &Error{
+				Type:       "not_found",
+				Title:      "Not Found",
+				StatusCode: http.StatusNotFound,
+			},
+		},
 		"500 internal server error": {
 			params: GetReputationProfilesRequest{
 				ConfigID:      43253,
@@ -180,16 +213,89 @@ func TestAppSec_CreateReputationProfile(t *testing.T) {
 	err = json.Unmarshal([]byte(reqData), &req)
 	require.NoError(t, err)
 
+	positiveMatch := true
 	tests := map[string]struct {
-		params           CreateReputationProfileRequest
-		prop             *CreateReputationProfileRequest
-		responseStatus   int
-		responseBody     string
-		expectedPath     string
-		expectedResponse *CreateReputationProfileResponse
-		withError        error
-		headers          http.Header
+		params              CreateReputationProfileRequest
+		prop                *CreateReputationProfileRequest
+		responseStatus      int
+		responseBody        string
+		expectedPath        string
+		expectedRequestBody string
+		expectedResponse    *CreateReputationProfileResponse
+		withError           error
+		headers             http.Header
 	}{
+		"201 Created with typed payload": {
+			params: CreateReputationProfileRequest{
+				ConfigID:      43253,
+				ConfigVersion: 15,
+				Payload: &ReputationProfilePayload{
+					Name:             "Web Attackers (High Threat)",
+					Context:          ReputationProfileContextWebAttackers,
+					Threshold:        9,
+					SharedIPHandling: SharedIPHandlingNonShared,
+					Condition: &ReputationProfilePayloadCondition{
+						AtomicConditions: []ReputationProfileAtomicCondition{
+							{
+								ClassName:     "RequestHeaderCondition",
+								PositiveMatch: true,
+								Name:          []string{"x-header"},
+								Value:         []string{"foo"},
+							},
+						},
+						PositiveMatch: &positiveMatch,
+					},
+				},
+			},
+			responseStatus:      http.StatusCreated,
+			responseBody:        respData,
+			expectedResponse:    &result,
+			expectedPath:        "/appsec/v1/configs/43253/versions/15/reputation-profiles",
+			expectedRequestBody: `{"name":"Web Attackers (High Threat)","context":"WEBATCK","threshold":9,"sharedIpHandling":"NON_SHARED","condition":{"atomicConditions":[{"className":"RequestHeaderCondition","positiveMatch":true,"value":["foo"],"name":["x-header"]}],"positiveMatch":true}}`,
+		},
+		"invalid typed payload": {
+			params: CreateReputationProfileRequest{
+				ConfigID:      43253,
+				ConfigVersion: 15,
+				Payload: &ReputationProfilePayload{
+					Name:             "Web Attackers",
+					Context:          "UNKNOWN",
+					Threshold:        11,
+					SharedIPHandling: SharedIPHandlingBoth,
+					Condition: &ReputationProfilePayloadCondition{
+						AtomicConditions: []ReputationProfileAtomicCondition{{PositiveMatch: true}},
+					},
+				},
+			},
+			withError: ErrStructValidation,
+		},
+		"negative threshold": {
+			params: CreateReputationProfileRequest{
+				ConfigID:      43253,
+				ConfigVersion: 15,
+				Payload: &ReputationProfilePayload{
+					Name:             "Web Attackers",
+					Context:          ReputationProfileContextWebAttackers,
+					Threshold:        -1,
+					SharedIPHandling: SharedIPHandlingBoth,
+				},
+			},
+			withError: ErrStructValidation,
+		},
+		"typed and raw payload": {
+			params: CreateReputationProfileRequest{
+				ConfigID:      43253,
+				ConfigVersion: 15,
+				Payload: &ReputationProfilePayload{
+					Name:             "Web Attackers",
+					Context:          ReputationProfileContextWebAttackers,
+					Threshold:        5,
+					SharedIPHandling: SharedIPHandlingBoth,
+				},
+				JsonPayloadRaw: json.RawMessage(`{"name":"Web Attackers"}`),
+			},
+			withError: ErrStructValidation,
+		},
 		"201 Created": {
 			params: CreateReputationProfileRequest{
 				ConfigID:      43253,
@@ -229,6 +335,11 @@ func TestAppSec_CreateReputationProfile(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				assert.Equal(t, http.MethodPost, r.Method)
+				if test.expectedRequestBody != "" {
+					body, err := ioutil.ReadAll(r.Body)
+					assert.NoError(t, err)
+					assert.JSONEq(t, test.expectedRequestBody, string(body))
+				}
 				w.WriteHeader(test.responseStatus)
 				if len(test.responseBody) > 0 {
 					_, err := w.Write([]byte(test.responseBody))
@@ -264,15 +375,36 @@ func TestAppSec_UpdateReputationProfile(t *testing.T) {
 	err = json.Unmarshal([]byte(reqData), &req)
 	require.NoError(t, err)
 
+	var profile GetReputationProfileResponse
+	err = json.Unmarshal([]byte(`{"name":"Web Scrapers","context":"WEBSCRP","threshold":5,"sharedIpHandling":"BOTH"}`), &profile)
+	require.NoError(t, err)
+	payload, err := profile.Payload()
+	require.NoError(t, err)
+	payload.Threshold = 8
+
 	tests := map[string]struct {
-		params           UpdateReputationProfileRequest
-		responseStatus   int
-		responseBody     string
-		expectedPath     string
-		expectedResponse *UpdateReputationProfileResponse
-		withError        error
-		headers          http.Header
+		params              UpdateReputationProfileRequest
+		responseStatus      int
+		responseBody        string
+		expectedPath        string
+		expectedRequestBody string
+		expectedResponse    *UpdateReputationProfileResponse
+		withError           error
+		headers             http.Header
 	}{
+		"200 Success with payload of retrieved profile": {
+			params: UpdateReputationProfileRequest{
+				ConfigID:            43253,
+				ConfigVersion:       15,
+				ReputationProfileId: 134644,
+				Payload:             payload,
+			},
+			responseStatus:      http.StatusOK,
+			responseBody:        respData,
+			expectedResponse:    &result,
+			expectedPath:        "/appsec/v1/configs/43253/versions/15/reputation-profiles/134644",
+			expectedRequestBody: `{"name":"Web Scrapers","context":"WEBSCRP","threshold":8,"sharedIpHandling":"BOTH"}`,
+		},
 		"200 Success": {
 			params: UpdateReputationProfileRequest{
 				ConfigID:            43253,
@@ -314,6 +446,11 @@ func TestAppSec_UpdateReputationProfile(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				assert.Equal(t, http.MethodPut, r.Method)
+				if test.expectedRequestBody != "" {
+					body, err := ioutil.ReadAll(r.Body)
+					assert.NoError(t, err)
+					assert.JSONEq(t, test.expectedRequestBody, string(body))
+				}
 				w.WriteHeader(test.responseStatus)
 				if len(test.responseBody) > 0 {
 					_, err := w.Write([]byte(test.responseBody))
@@ -420,3 +557,75 @@ func TestAppSec_RemoveReputationProfile(t *testing.T) {
 		})
 	}
 }
+
+func TestGetReputationProfileResponse_Payload(t *testing.T) {
+	tests := map[string]struct {
+		response  string
+		expected  string
+		withError bool
+	}{
+		"profile with conditions": {
+			response: `{
+				"id": 134644,
+				"name": "Web Attackers (High Threat)",
+				"context": "WEBATCK",
+				"contextReadable": "Web Attackers",
+				"enabled": true,
+				"sharedIpHandling": "NON_SHARED",
+				"threshold": 9,
+				"condition": {
+					"positiveMatch": true,
+					"atomicConditions": [
+						{"className": "AsNumberCondition", "index": 1, "positiveMatch": true, "value": ["1"]},
+						{"className": "RequestHeaderCondition", "index": 2, "positiveMatch": false, "name": "x-header",
+							"nameWildcard": true, "value": ["foo"], "valueWildcard": false},
+						{"className": "IpAddressCondition", "index": 3, "positiveMatch": true, "checkIps": "connecting",
+							"value": ["1.1.1.1"]}
+					]
+				}
+			}`,
+			expected: `{
+				"name": "Web Attackers (High Threat)",
+				"context": "WEBATCK",
+				"threshold": 9,
+				"sharedIpHandling": "NON_SHARED",
+				"condition": {
+					"positiveMatch": true,
+					"atomicConditions": [
+						{"className": "AsNumberCondition", "positiveMatch": true, "value": ["1"]},
+						{"className": "RequestHeaderCondition", "positiveMatch": false, "name": ["x-header"],
+							"nameWildcard": true, "value": ["foo"]},
+						{"className": "IpAddressCondition", "positiveMatch": true, "checkIps": "connecting",
+							"value": ["1.1.1.1"]}
+					]
+				}
+			}`,
+		},
+		"profile without condition": {
+			response: `{"name": "DoS Attackers", "context": "DOSATCK", "sharedIpHandling": "BOTH", "threshold": 5}`,
+			expected: `{"name": "DoS Attackers", "context": "DOSATCK", "sharedIpHandling": "BOTH", "threshold": 5}`,
+		},
+		"invalid condition": {
+			response:  `{"name": "DoS Attackers", "condition": {"atomicConditions": [{"className": "AsNumberCondition", "nameWildcard": "yes"}]}}`,
+			withError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var response GetReputationProfileResponse
+			require.NoError(t, json.Unmarshal([]byte(test.response), &response))
+
+			payload, err := response.Payload()
+			if test.withError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.NoError(t, payload.Validate())
+			data, err := json.Marshal(payload)
+			require.NoError(t, err)
+			assert.JSONEq(t, test.expected, string(data))
+		})
+	}
+}
@@ -153,6 +153,7 @@ func (p *appsec) GetReputationProtection(ctx context.Context, params GetReputati
 func (p *appsec) GetReputationProtections(ctx context.Context, params GetReputationProtectionsRequest) (*GetReputationProtectionsResponse, error) {
 	logger := p.Log(ctx)
 	logger.Debug("GetReputationProtections")
+	p.warnDeprecated(ctx, "GetReputationProtections")
 
 	if err := params.Validate(); err != nil {
 		return nil, fmt.Errorf("%w: %s", ErrStructValidation, err.Error())
@@ -216,6 +217,7 @@ func (p *appsec) UpdateReputationProtection(ctx context.Context, params UpdateRe
 func (p *appsec) RemoveReputationProtection(ctx context.Context, params RemoveReputationProtectionRequest) (*RemoveReputationProtectionResponse, error) {
 	logger := p.Log(ctx)
 	logger.Debug("RemoveReputationProtection")
+	p.warnDeprecated(ctx, "RemoveReputationProtection")
 
 	if err := params.Validate(); err != nil {
 		return nil, fmt.Errorf("%w: %s", ErrStructValidation, err.Error())
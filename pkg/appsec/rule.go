@@ -45,11 +45,14 @@ type (
 
 	// GetRulesResponse is returned from a call to GetRules.
 	GetRulesResponse struct {
-		Rules []struct {
-			ID                 int                     `json:"id,omitempty"`
-			Action             string                  `json:"action,omitempty"`
-			ConditionException *RuleConditionException `json:"conditionException,omitempty"`
-		} `json:"ruleActions,omitempty"`
+		Rules []RuleItem `json:"ruleActions,omitempty"`
+	}
+
+	// RuleItem describes a rule and its action returned by GetRules.
+	RuleItem struct {
+		ID                 int                     `json:"id,omitempty"`
+		Action             string                  `json:"action,omitempty"`
+		ConditionException *RuleConditionException `json:"conditionException,omitempty"`
 	}
 
 	// GetRuleRequest is used to retrieve a rule together with its action and its condition and exception information.
@@ -191,6 +194,7 @@ func (v UpdateRuleRequest) Validate() error {
 		"Version":  validation.Validate(v.Version, validation.Required),
 		"PolicyID": validation.Validate(v.PolicyID, validation.Required),
 		"RuleID":   validation.Validate(v.RuleID, validation.Required),
+		"Action":   validation.Validate(ActionType(v.Action)),
 	}.Filter()
 }
 
@@ -47,14 +47,17 @@ type (
 
 	// GetSecurityPoliciesResponse is returned from a call to GetSecurityPolicies.
 	GetSecurityPoliciesResponse struct {
-		ConfigID int `json:"configId,omitempty"`
-		Version  int `json:"version,omitempty"`
-		Policies []struct {
-			PolicyID                string            `json:"policyId,omitempty"`
-			PolicyName              string            `json:"policyName,omitempty"`
-			HasRatePolicyWithAPIKey bool              `json:"hasRatePolicyWithApiKey,omitempty"`
-			PolicySecurityControls  *SecurityControls `json:"policySecurityControls,omitempty"`
-		} `json:"policies,omitempty"`
+		ConfigID int                  `json:"configId,omitempty"`
+		Version  int                  `json:"version,omitempty"`
+		Policies []SecurityPolicyItem `json:"policies,omitempty"`
+	}
+
+	// SecurityPolicyItem describes a security policy returned by GetSecurityPolicies.
+	SecurityPolicyItem struct {
+		PolicyID                string            `json:"policyId,omitempty"`
+		PolicyName              string            `json:"policyName,omitempty"`
+		HasRatePolicyWithAPIKey bool              `json:"hasRatePolicyWithApiKey,omitempty"`
+		PolicySecurityControls  *SecurityControls `json:"policySecurityControls,omitempty"`
 	}
 
 	// GetSecurityPolicyRequest is used to retrieve information about a security policy.
@@ -172,6 +172,7 @@ func (p *appsec) UpdatePolicyProtections(ctx context.Context, params UpdatePolic
 func (p *appsec) RemovePolicyProtections(ctx context.Context, params UpdatePolicyProtectionsRequest) (*PolicyProtectionsResponse, error) {
 	logger := p.Log(ctx)
 	logger.Debug("RemovePolicyProtections")
+	p.warnDeprecated(ctx, "RemovePolicyProtections")
 
 	if err := params.Validate(); err != nil {
 		return nil, fmt.Errorf("%w: %s", ErrStructValidation, err.Error())
@@ -136,6 +136,7 @@ func (v UpdateSelectedHostnameRequest) Validate() error {
 func (p *appsec) GetSelectedHostname(ctx context.Context, params GetSelectedHostnameRequest) (*GetSelectedHostnameResponse, error) {
 	logger := p.Log(ctx)
 	logger.Debug("GetSelectedHostname")
+	p.warnDeprecated(ctx, "GetSelectedHostname")
 
 	if err := params.Validate(); err != nil {
 		return nil, fmt.Errorf("%w: %s", ErrStructValidation, err.Error())
@@ -166,6 +167,7 @@ func (p *appsec) GetSelectedHostname(ctx context.Context, params GetSelectedHost
 func (p *appsec) GetSelectedHostnames(ctx context.Context, params GetSelectedHostnamesRequest) (*GetSelectedHostnamesResponse, error) {
 	logger := p.Log(ctx)
 	logger.Debug("GetSelectedHostnames")
+	p.warnDeprecated(ctx, "GetSelectedHostnames")
 
 	if err := params.Validate(); err != nil {
 		return nil, fmt.Errorf("%w: %s", ErrStructValidation, err.Error())
@@ -196,6 +198,7 @@ func (p *appsec) GetSelectedHostnames(ctx context.Context, params GetSelectedHos
 func (p *appsec) UpdateSelectedHostnames(ctx context.Context, params UpdateSelectedHostnamesRequest) (*UpdateSelectedHostnamesResponse, error) {
 	logger := p.Log(ctx)
 	logger.Debug("UpdateSelectedHostnames")
+	p.warnDeprecated(ctx, "UpdateSelectedHostnames")
 
 	if err := params.Validate(); err != nil {
 		return nil, fmt.Errorf("%w: %s", ErrStructValidation, err.Error())
@@ -228,6 +231,7 @@ func (p *appsec) UpdateSelectedHostnames(ctx context.Context, params UpdateSelec
 func (p *appsec) UpdateSelectedHostname(ctx context.Context, params UpdateSelectedHostnameRequest) (*UpdateSelectedHostnameResponse, error) {
 	logger := p.Log(ctx)
 	logger.Debug("UpdateSelectedHostname")
+	p.warnDeprecated(ctx, "UpdateSelectedHostname")
 
 	if err := params.Validate(); err != nil {
 		return nil, fmt.Errorf("%w: %s", ErrStructValidation, err.Error())
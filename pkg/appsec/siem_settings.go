@@ -192,6 +192,7 @@ func (p *appsec) UpdateSiemSettings(ctx context.Context, params UpdateSiemSettin
 func (p *appsec) RemoveSiemSettings(ctx context.Context, params RemoveSiemSettingsRequest) (*RemoveSiemSettingsResponse, error) {
 	logger := p.Log(ctx)
 	logger.Debug("RemoveSiemSettings")
+	p.warnDeprecated(ctx, "RemoveSiemSettings")
 
 	if err := params.Validate(); err != nil {
 		return nil, fmt.Errorf("%w: %s", ErrStructValidation, err.Error())
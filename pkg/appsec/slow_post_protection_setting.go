@@ -142,6 +142,7 @@ func (v UpdateSlowPostProtectionSettingRequest) Validate() error {
 func (p *appsec) GetSlowPostProtectionSetting(ctx context.Context, params GetSlowPostProtectionSettingRequest) (*GetSlowPostProtectionSettingResponse, error) {
 	logger := p.Log(ctx)
 	logger.Debug("GetSlowPostProtectionSetting")
+	p.warnDeprecated(ctx, "GetSlowPostProtectionSetting")
 
 	if err := params.Validate(); err != nil {
 		return nil, fmt.Errorf("%w: %s", ErrStructValidation, err.Error())
@@ -125,6 +125,7 @@ func (p *appsec) GetSlowPostProtection(ctx context.Context, params GetSlowPostPr
 func (p *appsec) GetSlowPostProtections(ctx context.Context, params GetSlowPostProtectionsRequest) (*GetSlowPostProtectionsResponse, error) {
 	logger := p.Log(ctx)
 	logger.Debug("GetSlowPostProtections")
+	p.warnDeprecated(ctx, "GetSlowPostProtections")
 
 	if err := params.Validate(); err != nil {
 		return nil, fmt.Errorf("%w: %s", ErrStructValidation, err.Error())
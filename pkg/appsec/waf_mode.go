@@ -149,6 +149,7 @@ func (p *appsec) GetWAFMode(ctx context.Context, params GetWAFModeRequest) (*Get
 func (p *appsec) GetWAFModes(ctx context.Context, params GetWAFModesRequest) (*GetWAFModesResponse, error) {
 	logger := p.Log(ctx)
 	logger.Debug("GetWAFModes")
+	p.warnDeprecated(ctx, "GetWAFModes")
 
 	if err := params.Validate(); err != nil {
 		return nil, fmt.Errorf("%w: %s", ErrStructValidation, err.Error())
@@ -125,6 +125,7 @@ func (p *appsec) GetWAFProtection(ctx context.Context, params GetWAFProtectionRe
 func (p *appsec) GetWAFProtections(ctx context.Context, params GetWAFProtectionsRequest) (*GetWAFProtectionsResponse, error) {
 	logger := p.Log(ctx)
 	logger.Debug("GetWAFProtections")
+	p.warnDeprecated(ctx, "GetWAFProtections")
 
 	if err := params.Validate(); err != nil {
 		return nil, fmt.Errorf("%w: %s", ErrStructValidation, err.Error())
@@ -9,7 +9,7 @@ import (
 type (
 	// The BotDetection interface supports retrieving bot detections
 	BotDetection interface {
-		// GetBotDetectionList todo: add link
+		// GetBotDetectionList https://techdocs.akamai.com/bot-manager/reference/get-bot-detections
 		GetBotDetectionList(ctx context.Context, params GetBotDetectionListRequest) (*GetBotDetectionListResponse, error)
 	}
 
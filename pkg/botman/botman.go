@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/session"
+	"github.com/apex/log"
 )
 
 var (
@@ -33,6 +34,7 @@ type (
 		CustomBotCategoryAction
 		CustomBotCategorySequence
 		CustomClient
+		CustomClientSequence
 		CustomDefinedBot
 		CustomDenyAction
 		JavascriptInjection
@@ -45,6 +47,7 @@ type (
 
 	botman struct {
 		session.Session
+		options session.ClientOptions
 	}
 
 	// Option defines a BotMan option
@@ -63,5 +66,56 @@ func Client(sess session.Session, opts ...Option) BotMan {
 	for _, opt := range opts {
 		opt(p)
 	}
+	p.Session = p.options.Apply(p.Session)
 	return p
 }
+
+// WithLogger sets the logger used by the Bot Manager client instead of the session logger
+func WithLogger(l log.Interface) Option {
+	return func(p *botman) {
+		p.options.Logger = l
+	}
+}
+
+// WithRetries sets how many times idempotent Bot Manager requests are retried after transient failures
+func WithRetries(retries int) Option {
+	return func(p *botman) {
+		p.options.Retries = retries
+	}
+}
+
+// WithRetryClassifier sets the callback deciding whether failed Bot Manager requests are retried, see session.RetryClassifier
+func WithRetryClassifier(classifier session.RetryClassifier) Option {
+	return func(p *botman) {
+		p.options.RetryClassifier = classifier
+	}
+}
+
+// WithBackoff sets the strategy computing delays between retries of failed Bot Manager requests, see session.Backoff
+func WithBackoff(backoff session.Backoff) Option {
+	return func(p *botman) {
+		p.options.Backoff = backoff
+	}
+}
+
+// WithIdempotencyKeys adds an idempotency key to Bot Manager POST and PATCH requests, kept when they are
+// retried; it does not make them retried by itself, see session.WithIdempotencyKeys
+func WithIdempotencyKeys(enabled bool) Option {
+	return func(p *botman) {
+		p.options.IdempotencyKeys = enabled
+	}
+}
+
+// WithBaseURL overrides the scheme and host Bot Manager requests are sent to
+func WithBaseURL(baseURL string) Option {
+	return func(p *botman) {
+		p.options.BaseURL = baseURL
+	}
+}
+
+// WithAccountSwitchKey makes Bot Manager requests act on the account with given switch key
+func WithAccountSwitchKey(key string) Option {
+	return func(p *botman) {
+		p.options.AccountSwitchKey = key
+	}
+}
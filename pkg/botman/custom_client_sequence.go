@@ -0,0 +1,117 @@
+package botman
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type (
+	// The CustomClientSequence interface supports retrieving and updating custom client sequence
+	CustomClientSequence interface {
+		// GetCustomClientSequence https://techdocs.akamai.com/bot-manager/reference/get-custom-client-sequence
+		GetCustomClientSequence(ctx context.Context, params GetCustomClientSequenceRequest) (*CustomClientSequenceResponse, error)
+
+		// UpdateCustomClientSequence https://techdocs.akamai.com/bot-manager/reference/put-custom-client-sequence
+		UpdateCustomClientSequence(ctx context.Context, params UpdateCustomClientSequenceRequest) (*CustomClientSequenceResponse, error)
+	}
+
+	// GetCustomClientSequenceRequest is used to retrieve custom client sequence
+	GetCustomClientSequenceRequest struct {
+		ConfigID int64
+		Version  int64
+	}
+
+	// CustomClientSequenceResponse is used to retrieve custom client sequence
+	CustomClientSequenceResponse struct {
+		Sequence []string `json:"sequence"`
+	}
+
+	// UpdateCustomClientSequenceRequest is used to update custom client sequence
+	UpdateCustomClientSequenceRequest struct {
+		ConfigID int64    `json:"-"`
+		Version  int64    `json:"-"`
+		Sequence []string `json:"sequence"`
+	}
+)
+
+// Validate validates a GetCustomClientSequenceRequest.
+func (v GetCustomClientSequenceRequest) Validate() error {
+	return validation.Errors{
+		"ConfigID": validation.Validate(v.ConfigID, validation.Required),
+		"Version":  validation.Validate(v.Version, validation.Required),
+	}.Filter()
+}
+
+// Validate validates an UpdateCustomClientSequenceRequest.
+func (v UpdateCustomClientSequenceRequest) Validate() error {
+	return validation.Errors{
+		"ConfigID": validation.Validate(v.ConfigID, validation.Required),
+		"Version":  validation.Validate(v.Version, validation.Required),
+		"Sequence": validation.Validate(v.Sequence, validation.Required),
+	}.Filter()
+}
+
+func (b *botman) GetCustomClientSequence(ctx context.Context, params GetCustomClientSequenceRequest) (*CustomClientSequenceResponse, error) {
+	logger := b.Log(ctx)
+	logger.Debug("GetCustomClientSequence")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrStructValidation, err.Error())
+	}
+
+	uri := fmt.Sprintf(
+		"/appsec/v1/configs/%d/versions/%d/custom-client-sequence",
+		params.ConfigID,
+		params.Version)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GetCustomClientSequence request: %w", err)
+	}
+
+	var result CustomClientSequenceResponse
+	resp, err := b.Exec(req, &result)
+	if err != nil {
+		return nil, fmt.Errorf("GetCustomClientSequence request failed: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, b.Error(resp)
+	}
+
+	return &result, nil
+}
+
+func (b *botman) UpdateCustomClientSequence(ctx context.Context, params UpdateCustomClientSequenceRequest) (*CustomClientSequenceResponse, error) {
+	logger := b.Log(ctx)
+	logger.Debug("UpdateCustomClientSequence")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrStructValidation, err.Error())
+	}
+
+	putURL := fmt.Sprintf(
+		"/appsec/v1/configs/%d/versions/%d/custom-client-sequence",
+		params.ConfigID,
+		params.Version)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, putURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create UpdateCustomClientSequence request: %w", err)
+	}
+
+	var result CustomClientSequenceResponse
+	resp, err := b.Exec(req, &result, params)
+	if err != nil {
+		return nil, fmt.Errorf("UpdateCustomClientSequence request failed: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, b.Error(resp)
+	}
+
+	return &result, nil
+}
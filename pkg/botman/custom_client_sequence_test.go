@@ -0,0 +1,208 @@
+package botman
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test Get CustomClientSequence
+func TestBotman_GetCustomClientSequence(t *testing.T) {
+	tests := map[string]struct {
+		params           GetCustomClientSequenceRequest
+		responseStatus   int
+		responseBody     string
+		expectedPath     string
+		expectedResponse *CustomClientSequenceResponse
+		withError        func(*testing.T, error)
+	}{
+		"200 OK": {
+			params: GetCustomClientSequenceRequest{
+				ConfigID: 43253,
+				Version:  15,
+			},
+			responseStatus: http.StatusOK,
+			responseBody:   `{"sequence":["cc9c3f89-e179-4892-89cf-d5e623ba9dc7","d79285df-e399-43e8-bb0f-c0d980a88e4f","afa309b8-4fd5-430e-a061-1c61df1d2ac2"]}`,
+			expectedPath:   "/appsec/v1/configs/43253/versions/15/custom-client-sequence",
+			expectedResponse: &CustomClientSequenceResponse{
+				Sequence: []string{"cc9c3f89-e179-4892-89cf-d5e623ba9dc7", "d79285df-e399-43e8-bb0f-c0d980a88e4f", "afa309b8-4fd5-430e-a061-1c61df1d2ac2"},
+			},
+		},
+		"500 internal server error": {
+			params: GetCustomClientSequenceRequest{
+				ConfigID: 43253,
+				Version:  15,
+			},
+			responseStatus: http.StatusInternalServerError,
+			responseBody: `
+			{
+				"type": "internal_error",
+				"title": "Internal Server Error",
+				"detail": "Error fetching data"
+			}`,
+			expectedPath: "/appsec/v1/configs/43253/versions/15/custom-client-sequence",
+			withError: func(t *testing.T, err error) {
+				want := &Error{
+					Type:       "internal_error",
+					Title:      "Internal Server Error",
+					Detail:     "Error fetching data",
+					StatusCode: http.StatusInternalServerError,
+				}
+				assert.True(t, errors.Is(err, want), "want: %s; got: %s", want, err)
+			},
+		},
+		"Missing ConfigID": {
+			params: GetCustomClientSequenceRequest{
+				Version: 15,
+			},
+			withError: func(t *testing.T, err error) {
+				want := ErrStructValidation
+				assert.True(t, errors.Is(err, want), "want: %s; got: %s", want, err)
+				assert.Contains(t, err.Error(), "ConfigID")
+			},
+		},
+		"Missing Version": {
+			params: GetCustomClientSequenceRequest{
+				ConfigID: 43253,
+			},
+			withError: func(t *testing.T, err error) {
+				want := ErrStructValidation
+				assert.True(t, errors.Is(err, want), "want: %s; got: %s", want, err)
+				assert.Contains(t, err.Error(), "Version")
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.GetCustomClientSequence(context.Background(), test.params)
+			if test.withError != nil {
+				test.withError(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+// Test Update CustomClientSequence.
+func TestBotman_UpdateCustomClientSequence(t *testing.T) {
+	tests := map[string]struct {
+		params           UpdateCustomClientSequenceRequest
+		responseStatus   int
+		responseBody     string
+		expectedPath     string
+		expectedResponse *CustomClientSequenceResponse
+		withError        func(*testing.T, error)
+	}{
+		"200 Success": {
+			params: UpdateCustomClientSequenceRequest{
+				ConfigID: 43253,
+				Version:  15,
+				Sequence: []string{"cc9c3f89-e179-4892-89cf-d5e623ba9dc7", "d79285df-e399-43e8-bb0f-c0d980a88e4f", "afa309b8-4fd5-430e-a061-1c61df1d2ac2"},
+			},
+			responseStatus: http.StatusOK,
+			responseBody:   `{"sequence":["cc9c3f89-e179-4892-89cf-d5e623ba9dc7", "d79285df-e399-43e8-bb0f-c0d980a88e4f", "afa309b8-4fd5-430e-a061-1c61df1d2ac2"]}`,
+			expectedResponse: &CustomClientSequenceResponse{
+				Sequence: []string{"cc9c3f89-e179-4892-89cf-d5e623ba9dc7", "d79285df-e399-43e8-bb0f-c0d980a88e4f", "afa309b8-4fd5-430e-a061-1c61df1d2ac2"},
+			},
+			expectedPath: "/appsec/v1/configs/43253/versions/15/custom-client-sequence",
+		},
+		"500 internal server error": {
+			params: UpdateCustomClientSequenceRequest{
+				ConfigID: 43253,
+				Version:  15,
+				Sequence: []string{"cc9c3f89-e179-4892-89cf-d5e623ba9dc7", "d79285df-e399-43e8-bb0f-c0d980a88e4f", "afa309b8-4fd5-430e-a061-1c61df1d2ac2"},
+			},
+			responseStatus: http.StatusInternalServerError,
+			responseBody: `
+			{
+				"type": "internal_error",
+				"title": "Internal Server Error",
+				"detail": "Error updating data"
+			}`,
+			expectedPath: "/appsec/v1/configs/43253/versions/15/custom-client-sequence",
+			withError: func(t *testing.T, err error) {
+				want := &Error{
+					Type:       "internal_error",
+					Title:      "Internal Server Error",
+					Detail:     "Error updating data",
+					StatusCode: http.StatusInternalServerError,
+				}
+				assert.True(t, errors.Is(err, want), "want: %s; got: %s", want, err)
+			},
+		},
+		"Missing ConfigID": {
+			params: UpdateCustomClientSequenceRequest{
+				Version:  15,
+				Sequence: []string{"cc9c3f89-e179-4892-89cf-d5e623ba9dc7", "d79285df-e399-43e8-bb0f-c0d980a88e4f", "afa309b8-4fd5-430e-a061-1c61df1d2ac2"},
+			},
+			withError: func(t *testing.T, err error) {
+				want := ErrStructValidation
+				assert.True(t, errors.Is(err, want), "want: %s; got: %s", want, err)
+				assert.Contains(t, err.Error(), "ConfigID")
+			},
+		},
+		"Missing Version": {
+			params: UpdateCustomClientSequenceRequest{
+				ConfigID: 43253,
+				Sequence: []string{"cc9c3f89-e179-4892-89cf-d5e623ba9dc7", "d79285df-e399-43e8-bb0f-c0d980a88e4f", "afa309b8-4fd5-430e-a061-1c61df1d2ac2"},
+			},
+			withError: func(t *testing.T, err error) {
+				want := ErrStructValidation
+				assert.True(t, errors.Is(err, want), "want: %s; got: %s", want, err)
+				assert.Contains(t, err.Error(), "Version")
+			},
+		},
+		"Missing Sequence": {
+			params: UpdateCustomClientSequenceRequest{
+				ConfigID: 43253,
+				Version:  15,
+			},
+			withError: func(t *testing.T, err error) {
+				want := ErrStructValidation
+				assert.True(t, errors.Is(err, want), "want: %s; got: %s", want, err)
+				assert.Contains(t, err.Error(), "Sequence")
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodPut, r.Method)
+				w.WriteHeader(test.responseStatus)
+				if len(test.responseBody) > 0 {
+					_, err := w.Write([]byte(test.responseBody))
+					assert.NoError(t, err)
+				}
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.UpdateCustomClientSequence(
+				session.ContextWithOptions(
+					context.Background()), test.params)
+			if test.withError != nil {
+				test.withError(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
@@ -296,6 +296,20 @@ func (p *Mock) UpdateCustomBotCategorySequence(ctx context.Context, params Updat
 	}
 	return args.Get(0).(*CustomBotCategorySequenceResponse), nil
 }
+func (p *Mock) GetCustomClientSequence(ctx context.Context, params GetCustomClientSequenceRequest) (*CustomClientSequenceResponse, error) {
+	args := p.Called(ctx, params)
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*CustomClientSequenceResponse), nil
+}
+func (p *Mock) UpdateCustomClientSequence(ctx context.Context, params UpdateCustomClientSequenceRequest) (*CustomClientSequenceResponse, error) {
+	args := p.Called(ctx, params)
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*CustomClientSequenceResponse), nil
+}
 func (p *Mock) GetCustomClientList(ctx context.Context, params GetCustomClientListRequest) (*GetCustomClientListResponse, error) {
 	args := p.Called(ctx, params)
 	if args.Error(1) != nil {
@@ -0,0 +1,260 @@
+// Package bulk provides a scheduler executing large queues of SDK operations, such as updating hundreds of
+// match targets or zones, while respecting API rate limits and resuming interrupted jobs
+package bulk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type (
+	// Operation is a single unit of work of a bulk job
+	Operation struct {
+		// ID identifies the operation within the job; operations recorded as completed in the Store are skipped
+		ID string
+		// Endpoint groups operations limited by the same concurrency rule, e.g. "appsec/match-targets"
+		Endpoint string
+		// Run executes the operation
+		Run func(ctx context.Context) error
+	}
+
+	// Result is the outcome of an operation
+	Result struct {
+		ID  string
+		Err error
+		// Skipped is set for operations completed by a previous run of the job
+		Skipped bool
+	}
+
+	// Scheduler executes operations with bounded concurrency, pausing when the API reports exhausted rate limits.
+	// Rate limits are observed on responses passing through the Transport of the scheduler, e.g.:
+	//
+	//	scheduler := bulk.NewScheduler(bulk.WithConcurrency(8), bulk.WithEndpointConcurrency("appsec", 2))
+	//	sess, err := session.New(session.WithClient(&http.Client{Transport: scheduler.Transport(nil)}))
+	Scheduler struct {
+		concurrency int
+		endpoints   map[string]int
+		store       Store
+		now         func() time.Time
+
+		mu          sync.Mutex
+		pausedUntil time.Time
+	}
+
+	// Option configures the Scheduler
+	Option func(*Scheduler)
+
+	transport struct {
+		base      http.RoundTripper
+		scheduler *Scheduler
+	}
+)
+
+const (
+	defaultConcurrency = 4
+	// defaultPause is used when the API reports an exhausted rate limit without telling when it resets
+	defaultPause = time.Second
+)
+
+var (
+	// ErrJobFailed is returned by Run when at least one operation failed
+	ErrJobFailed = errors.New("bulk job failed")
+	// ErrStore is returned when the store fails to load or save the job progress
+	ErrStore = errors.New("job store")
+)
+
+// NewScheduler returns a new Scheduler
+func NewScheduler(opts ...Option) *Scheduler {
+	s := &Scheduler{
+		concurrency: defaultConcurrency,
+		endpoints:   make(map[string]int),
+		store:       NewMemoryStore(),
+		now:         time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// WithConcurrency sets the maximum number of operations running at the same time, defaults to 4
+func WithConcurrency(n int) Option {
+	return func(s *Scheduler) {
+		if n > 0 {
+			s.concurrency = n
+		}
+	}
+}
+
+// WithEndpointConcurrency limits the number of running operations of given endpoint
+func WithEndpointConcurrency(endpoint string, n int) Option {
+	return func(s *Scheduler) {
+		s.endpoints[endpoint] = n
+	}
+}
+
+// WithStore sets the store recording the job progress, which allows resuming interrupted jobs
+func WithStore(store Store) Option {
+	return func(s *Scheduler) {
+		s.store = store
+	}
+}
+
+// Run executes the operations and returns their results in the order of ops.
+// Operations completed by a previous run recorded in the store are skipped.
+// When any operation fails, the returned error wraps ErrJobFailed; all other operations are still executed.
+func (s *Scheduler) Run(ctx context.Context, ops []Operation) ([]Result, error) {
+	global := make(chan struct{}, s.concurrency)
+	endpoints := make(map[string]chan struct{})
+	for endpoint, n := range s.endpoints {
+		if n > 0 {
+			endpoints[endpoint] = make(chan struct{}, n)
+		}
+	}
+
+	results := make([]Result, len(ops))
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var storeErr error
+	for i, op := range ops {
+		results[i].ID = op.ID
+		done, err := s.store.Done(ctx, op.ID)
+		if err != nil {
+			wg.Wait()
+			return nil, fmt.Errorf("%w: %s", ErrStore, err)
+		}
+		if done {
+			results[i].Skipped = true
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, op Operation) {
+			defer wg.Done()
+			if err := s.run(ctx, op, global, endpoints[op.Endpoint]); err != nil {
+				results[i].Err = err
+			}
+			if ctx.Err() != nil && results[i].Err != nil {
+				// do not record operations which did not finish because the job was interrupted
+				return
+			}
+			if err := s.store.Save(ctx, results[i]); err != nil {
+				mu.Lock()
+				if storeErr == nil {
+					storeErr = err
+				}
+				mu.Unlock()
+			}
+		}(i, op)
+	}
+	wg.Wait()
+
+	if storeErr != nil {
+		return results, fmt.Errorf("%w: %s", ErrStore, storeErr)
+	}
+	var failed int
+	for _, r := range results {
+		if r.Err != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return results, fmt.Errorf("%w: %d of %d operations failed", ErrJobFailed, failed, len(ops))
+	}
+	return results, nil
+}
+
+func (s *Scheduler) run(ctx context.Context, op Operation, global, endpoint chan struct{}) error {
+	if endpoint != nil {
+		select {
+		case endpoint <- struct{}{}:
+			defer func() { <-endpoint }()
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	select {
+	case global <- struct{}{}:
+		defer func() { <-global }()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	if err := s.waitForRateLimit(ctx); err != nil {
+		return err
+	}
+	return op.Run(ctx)
+}
+
+// waitForRateLimit blocks while the scheduler is paused because of an exhausted rate limit
+func (s *Scheduler) waitForRateLimit(ctx context.Context) error {
+	for {
+		s.mu.Lock()
+		wait := s.pausedUntil.Sub(s.now())
+		s.mu.Unlock()
+		if wait <= 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Observe inspects rate limit headers of the response and pauses starting new operations when the limit is exhausted.
+// The X-RateLimit-Remaining, X-RateLimit-Next and Retry-After headers are taken into account.
+func (s *Scheduler) Observe(resp *http.Response) {
+	if resp == nil {
+		return
+	}
+	exhausted := resp.StatusCode == http.StatusTooManyRequests
+	if remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining")); err == nil && remaining <= 0 {
+		exhausted = true
+	}
+	if !exhausted {
+		return
+	}
+
+	now := s.now()
+	until := now.Add(defaultPause)
+	if next, err := time.Parse(time.RFC3339, resp.Header.Get("X-RateLimit-Next")); err == nil {
+		until = next
+	} else if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil {
+			until = now.Add(time.Duration(seconds) * time.Second)
+		} else if date, err := http.ParseTime(retryAfter); err == nil {
+			until = date
+		}
+	}
+
+	s.mu.Lock()
+	if until.After(s.pausedUntil) {
+		s.pausedUntil = until
+	}
+	s.mu.Unlock()
+}
+
+// Transport returns a RoundTripper passing responses of base to Observe; http.DefaultTransport is used when base is nil
+func (s *Scheduler) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{base: base, scheduler: s}
+}
+
+func (t *transport) RoundTrip(r *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(r)
+	if err == nil {
+		t.scheduler.Observe(resp)
+	}
+	return resp, err
+}
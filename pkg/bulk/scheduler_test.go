@@ -0,0 +1,184 @@
+package bulk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduler_Run(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	var running, maxRunning int32
+	op := func(id, endpoint string, err error) Operation {
+		return Operation{
+			ID:       id,
+			Endpoint: endpoint,
+			Run: func(ctx context.Context) error {
+				n := atomic.AddInt32(&running, 1)
+				defer atomic.AddInt32(&running, -1)
+				for {
+					m := atomic.LoadInt32(&maxRunning)
+					if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+						break
+					}
+				}
+				time.Sleep(5 * time.Millisecond)
+				return err
+			},
+		}
+	}
+
+	tests := map[string]struct {
+		opts               []Option
+		ops                []Operation
+		expectedResults    []Result
+		expectedMaxRunning int32
+		withError          error
+	}{
+		"all succeed": {
+			opts:               []Option{WithConcurrency(2)},
+			ops:                []Operation{op("1", "", nil), op("2", "", nil), op("3", "", nil)},
+			expectedResults:    []Result{{ID: "1"}, {ID: "2"}, {ID: "3"}},
+			expectedMaxRunning: 2,
+		},
+		"endpoint concurrency": {
+			opts:               []Option{WithConcurrency(4), WithEndpointConcurrency("appsec", 1)},
+			ops:                []Operation{op("1", "appsec", nil), op("2", "appsec", nil), op("3", "appsec", nil)},
+			expectedResults:    []Result{{ID: "1"}, {ID: "2"}, {ID: "3"}},
+			expectedMaxRunning: 1,
+		},
+		"some fail": {
+			opts:               []Option{WithConcurrency(1)},
+			ops:                []Operation{op("1", "", nil), op("2", "", errFailed)},
+			expectedResults:    []Result{{ID: "1"}, {ID: "2", Err: errFailed}},
+			expectedMaxRunning: 1,
+			withError:          ErrJobFailed,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			atomic.StoreInt32(&maxRunning, 0)
+			results, err := NewScheduler(test.opts...).Run(context.Background(), test.ops)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, test.expectedResults, results)
+			assert.Equal(t, test.expectedMaxRunning, atomic.LoadInt32(&maxRunning))
+		})
+	}
+}
+
+func TestScheduler_Resume(t *testing.T) {
+	store, err := OpenFileStore(filepath.Join(t.TempDir(), "job.jsonl"))
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	var calls []string
+	fail := true
+	ops := make([]Operation, 3)
+	for i := range ops {
+		id := fmt.Sprint(i)
+		ops[i] = Operation{ID: id, Run: func(ctx context.Context) error {
+			mu.Lock()
+			defer mu.Unlock()
+			calls = append(calls, id)
+			if id == "1" && fail {
+				return errors.New("failed")
+			}
+			return nil
+		}}
+	}
+
+	_, err = NewScheduler(WithStore(store), WithConcurrency(1)).Run(context.Background(), ops)
+	assert.True(t, errors.Is(err, ErrJobFailed))
+	require.NoError(t, store.Close())
+
+	store, err = OpenFileStore(store.file.Name())
+	require.NoError(t, err)
+	defer func() { require.NoError(t, store.Close()) }()
+	calls, fail = nil, false
+	results, err := NewScheduler(WithStore(store)).Run(context.Background(), ops)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1"}, calls)
+	assert.Equal(t, []Result{{ID: "0", Skipped: true}, {ID: "1"}, {ID: "2", Skipped: true}}, results)
+}
+
+func TestScheduler_Observe(t *testing.T) {
+	now := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		status        int
+		header        http.Header
+		expectedPause time.Time
+	}{
+		"remaining": {
+			status: http.StatusOK,
+			header: http.Header{"X-Ratelimit-Remaining": []string{"10"}},
+		},
+		"exhausted with next": {
+			status:        http.StatusOK,
+			header:        http.Header{"X-Ratelimit-Remaining": []string{"0"}, "X-Ratelimit-Next": []string{"2023-05-01T10:00:05Z"}},
+			expectedPause: now.Add(5 * time.Second),
+		},
+		"exhausted without next": {
+			status:        http.StatusOK,
+			header:        http.Header{"X-Ratelimit-Remaining": []string{"0"}},
+			expectedPause: now.Add(defaultPause),
+		},
+		"too many requests with retry after": {
+			status:        http.StatusTooManyRequests,
+			header:        http.Header{"Retry-After": []string{"3"}},
+			expectedPause: now.Add(3 * time.Second),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := NewScheduler()
+			s.now = func() time.Time { return now }
+			s.Observe(&http.Response{StatusCode: test.status, Header: test.header})
+			assert.Equal(t, test.expectedPause, s.pausedUntil)
+		})
+	}
+}
+
+func TestScheduler_Transport(t *testing.T) {
+	var calls int32
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Next", time.Now().Add(50*time.Millisecond).UTC().Format(time.RFC3339Nano))
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer mockServer.Close()
+
+	s := NewScheduler(WithConcurrency(1))
+	client := &http.Client{Transport: s.Transport(nil)}
+	get := func(ctx context.Context) error {
+		resp, err := client.Get(mockServer.URL)
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	}
+
+	start := time.Now()
+	_, err := s.Run(context.Background(), []Operation{{ID: "1", Run: get}, {ID: "2", Run: get}})
+	require.NoError(t, err)
+	assert.True(t, time.Since(start) >= 40*time.Millisecond, "second operation should wait for the rate limit reset")
+	assert.Equal(t, int32(2), calls)
+}
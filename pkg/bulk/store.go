@@ -0,0 +1,119 @@
+package bulk
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+)
+
+type (
+	// Store records the progress of a bulk job. Implementations have to be safe for concurrent use.
+	Store interface {
+		// Done reports whether the operation with given ID has already completed successfully
+		Done(ctx context.Context, id string) (bool, error)
+		// Save records the result of an operation
+		Save(ctx context.Context, result Result) error
+	}
+
+	// MemoryStore is an in-memory Store, allowing a job to be resumed within the same process
+	MemoryStore struct {
+		mu   sync.Mutex
+		done map[string]bool
+	}
+
+	// FileStore is a Store appending results to a file, allowing a job to be resumed after the process was restarted
+	FileStore struct {
+		mu   sync.Mutex
+		file *os.File
+		done map[string]bool
+	}
+
+	fileRecord struct {
+		ID    string `json:"id"`
+		Error string `json:"error,omitempty"`
+	}
+)
+
+// NewMemoryStore returns an empty MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{done: make(map[string]bool)}
+}
+
+// Done reports whether the operation with given ID has already completed successfully
+func (s *MemoryStore) Done(_ context.Context, id string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.done[id], nil
+}
+
+// Save records the result of an operation
+func (s *MemoryStore) Save(_ context.Context, result Result) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.done[result.ID] = result.Err == nil
+	return nil
+}
+
+// OpenFileStore opens the file at path, creating it if it does not exist, and loads the results recorded in it.
+// The file contains one JSON object per line and should be closed with Close once the job is finished.
+func OpenFileStore(path string) (*FileStore, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	done := make(map[string]bool)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var record fileRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			// a partially written last line of an interrupted job
+			continue
+		}
+		done[record.ID] = record.Error == ""
+	}
+	if err := scanner.Err(); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	return &FileStore{file: file, done: done}, nil
+}
+
+// Done reports whether the operation with given ID has already completed successfully
+func (s *FileStore) Done(_ context.Context, id string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.done[id], nil
+}
+
+// Save appends the result of an operation to the file
+func (s *FileStore) Save(_ context.Context, result Result) error {
+	record := fileRecord{ID: result.ID}
+	if result.Err != nil {
+		record.Error = result.Err.Error()
+	}
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	s.done[result.ID] = result.Err == nil
+	return nil
+}
+
+// Close closes the file
+func (s *FileStore) Close() error {
+	return s.file.Close()
+}
@@ -0,0 +1,99 @@
+// Package chinacdn provides access to the Akamai China CDN APIs
+//
+// See: https://techdocs.akamai.com/china-cdn/reference/api
+package chinacdn
+
+import (
+	"errors"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/session"
+	"github.com/apex/log"
+)
+
+var (
+	// ErrStructValidation is returned when given struct validation failed
+	ErrStructValidation = errors.New("struct validation")
+)
+
+type (
+	// ChinaCDN is the china cdn api interface
+	ChinaCDN interface {
+		ICPNumbers
+		PropertyHostnames
+		ProvisionStates
+	}
+
+	chinacdn struct {
+		session.Session
+		options session.ClientOptions
+	}
+
+	// Option defines a ChinaCDN option
+	Option func(*chinacdn)
+
+	// ClientFunc is a chinacdn client new method, this can be used for mocking
+	ClientFunc func(sess session.Session, opts ...Option) ChinaCDN
+)
+
+// Client returns a new chinacdn Client instance with the specified controller
+func Client(sess session.Session, opts ...Option) ChinaCDN {
+	c := &chinacdn{
+		Session: sess,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.Session = c.options.Apply(c.Session)
+	return c
+}
+
+// WithLogger sets the logger used by the China CDN client instead of the session logger
+func WithLogger(l log.Interface) Option {
+	return func(c *chinacdn) {
+		c.options.Logger = l
+	}
+}
+
+// WithRetries sets how many times idempotent China CDN requests are retried after transient failures
+func WithRetries(retries int) Option {
+	return func(c *chinacdn) {
+		c.options.Retries = retries
+	}
+}
+
+// WithRetryClassifier sets the callback deciding whether failed China CDN requests are retried, see session.RetryClassifier
+func WithRetryClassifier(classifier session.RetryClassifier) Option {
+	return func(c *chinacdn) {
+		c.options.RetryClassifier = classifier
+	}
+}
+
+// WithBackoff sets the strategy computing delays between retries of failed China CDN requests, see session.Backoff
+func WithBackoff(backoff session.Backoff) Option {
+	return func(c *chinacdn) {
+		c.options.Backoff = backoff
+	}
+}
+
+// WithIdempotencyKeys adds an idempotency key to China CDN POST and PATCH requests, kept when they are
+// retried; it does not make them retried by itself, see session.WithIdempotencyKeys
+func WithIdempotencyKeys(enabled bool) Option {
+	return func(c *chinacdn) {
+		c.options.IdempotencyKeys = enabled
+	}
+}
+
+// WithBaseURL overrides the scheme and host China CDN requests are sent to
+func WithBaseURL(baseURL string) Option {
+	return func(c *chinacdn) {
+		c.options.BaseURL = baseURL
+	}
+}
+
+// WithAccountSwitchKey makes China CDN requests act on the account with given switch key
+func WithAccountSwitchKey(key string) Option {
+	return func(c *chinacdn) {
+		c.options.AccountSwitchKey = key
+	}
+}
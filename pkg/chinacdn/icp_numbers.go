@@ -0,0 +1,115 @@
+package chinacdn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type (
+	// ICPNumbers contains operations available on ICP number resource
+	ICPNumbers interface {
+		// ListICPNumbers lists ICP numbers registered for the account
+		//
+		// See: https://techdocs.akamai.com/china-cdn/reference/get-icp-numbers
+		ListICPNumbers(context.Context) (*ListICPNumbersResponse, error)
+
+		// GetICPNumber returns details of a single ICP number, including its holder
+		//
+		// See: https://techdocs.akamai.com/china-cdn/reference/get-icp-number
+		GetICPNumber(context.Context, GetICPNumberRequest) (*ICPNumber, error)
+	}
+
+	// ICPNumber represents an Internet Content Provider registration issued to an ICP holder
+	ICPNumber struct {
+		ICPNumberID   int64    `json:"icpNumberId"`
+		ICPNumber     string   `json:"icpNumber"`
+		ICPHolderID   int64    `json:"icpHolderId"`
+		ICPHolderName string   `json:"icpHolderName"`
+		ICPTopDomains []string `json:"icpTopDomains,omitempty"`
+		Deleted       bool     `json:"deleted"`
+	}
+
+	// ListICPNumbersResponse represents a response from ListICPNumbers
+	ListICPNumbersResponse struct {
+		ICPNumbers []ICPNumber `json:"icpNumbers"`
+	}
+
+	// GetICPNumberRequest contains request parameters for GetICPNumber
+	GetICPNumberRequest struct {
+		ICPNumberID int64
+	}
+)
+
+const (
+	icpNumbersMediaType = "application/vnd.akamai.chinacdn.icp-numbers.v1+json"
+	icpNumberMediaType  = "application/vnd.akamai.chinacdn.icp-number.v1+json"
+)
+
+var (
+	// ErrListICPNumbers is returned in case an error occurs on ListICPNumbers operation
+	ErrListICPNumbers = errors.New("list icp numbers")
+	// ErrGetICPNumber is returned in case an error occurs on GetICPNumber operation
+	ErrGetICPNumber = errors.New("get icp number")
+)
+
+// Validate validates GetICPNumberRequest
+func (r GetICPNumberRequest) Validate() error {
+	return validation.Errors{
+		"ICPNumberID": validation.Validate(r.ICPNumberID, validation.Required),
+	}.Filter()
+}
+
+func (c *chinacdn) ListICPNumbers(ctx context.Context) (*ListICPNumbersResponse, error) {
+	logger := c.Log(ctx)
+	logger.Debug("ListICPNumbers")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "/chinacdn/v1/icp-numbers", nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrListICPNumbers, err)
+	}
+	req.Header.Set("Accept", icpNumbersMediaType)
+
+	var result ListICPNumbersResponse
+	resp, err := c.Exec(req, &result)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrListICPNumbers, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrListICPNumbers, c.Error(resp))
+	}
+
+	return &result, nil
+}
+
+func (c *chinacdn) GetICPNumber(ctx context.Context, params GetICPNumberRequest) (*ICPNumber, error) {
+	logger := c.Log(ctx)
+	logger.Debug("GetICPNumber")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetICPNumber, ErrStructValidation, err)
+	}
+
+	uri := fmt.Sprintf("/chinacdn/v1/icp-numbers/%d", params.ICPNumberID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetICPNumber, err)
+	}
+	req.Header.Set("Accept", icpNumberMediaType)
+
+	var result ICPNumber
+	resp, err := c.Exec(req, &result)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrGetICPNumber, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrGetICPNumber, c.Error(resp))
+	}
+
+	return &result, nil
+}
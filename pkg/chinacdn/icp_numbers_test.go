@@ -0,0 +1,121 @@
+package chinacdn
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListICPNumbers(t *testing.T) {
+	tests := map[string]struct {
+		responseStatus   int
+		responseBody     string
+		expectedResponse *ListICPNumbersResponse
+		withError        error
+	}{
+		"200 OK": {
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+    "icpNumbers": [
+        {
+            "icpNumberId": 1,
+            "icpNumber": "京ICP备12345678号",
+            "icpHolderId": 10,
+            "icpHolderName": "Example Holder",
+            "icpTopDomains": ["example.cn"],
+            "deleted": false
+        }
+    ]
+}`,
+			expectedResponse: &ListICPNumbersResponse{
+				ICPNumbers: []ICPNumber{
+					{
+						ICPNumberID:   1,
+						ICPNumber:     "京ICP备12345678号",
+						ICPHolderID:   10,
+						ICPHolderName: "Example Holder",
+						ICPTopDomains: []string{"example.cn"},
+					},
+				},
+			},
+		},
+		"500 internal server error": {
+			responseStatus: http.StatusInternalServerError,
+			responseBody:   `{"type": "internal_error", "title": "Internal Server Error", "detail": "Error listing ICP numbers", "status": 500}`,
+			withError: &Error{
+				Type:   "internal_error",
+				Title:  "Internal Server Error",
+				Detail: "Error listing ICP numbers",
+				Status: http.StatusInternalServerError,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/chinacdn/v1/icp-numbers", r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				assert.Equal(t, icpNumbersMediaType, r.Header.Get("Accept"))
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.ListICPNumbers(context.Background())
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestGetICPNumber(t *testing.T) {
+	tests := map[string]struct {
+		params           GetICPNumberRequest
+		responseStatus   int
+		responseBody     string
+		expectedResponse *ICPNumber
+		withError        error
+	}{
+		"200 OK": {
+			params:           GetICPNumberRequest{ICPNumberID: 1},
+			responseStatus:   http.StatusOK,
+			responseBody:     `{"icpNumberId": 1, "icpNumber": "京ICP备12345678号", "icpHolderId": 10, "icpHolderName": "Example Holder"}`,
+			expectedResponse: &ICPNumber{ICPNumberID: 1, ICPNumber: "京ICP备12345678号", ICPHolderID: 10, ICPHolderName: "Example Holder"},
+		},
+		"validation error": {
+			params:    GetICPNumberRequest{},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/chinacdn/v1/icp-numbers/1", r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.GetICPNumber(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
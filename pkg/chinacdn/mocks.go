@@ -0,0 +1,101 @@
+//revive:disable:exported
+
+package chinacdn
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type Mock struct {
+	mock.Mock
+}
+
+var _ ChinaCDN = &Mock{}
+
+func (m *Mock) ListICPNumbers(ctx context.Context) (*ListICPNumbersResponse, error) {
+	args := m.Called(ctx)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*ListICPNumbersResponse), nil
+}
+
+func (m *Mock) GetICPNumber(ctx context.Context, params GetICPNumberRequest) (*ICPNumber, error) {
+	args := m.Called(ctx, params)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*ICPNumber), nil
+}
+
+func (m *Mock) ListPropertyHostnames(ctx context.Context, params ListPropertyHostnamesRequest) (*ListPropertyHostnamesResponse, error) {
+	args := m.Called(ctx, params)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*ListPropertyHostnamesResponse), nil
+}
+
+func (m *Mock) GetPropertyHostname(ctx context.Context, params GetPropertyHostnameRequest) (*PropertyHostname, error) {
+	args := m.Called(ctx, params)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*PropertyHostname), nil
+}
+
+func (m *Mock) UpsertPropertyHostname(ctx context.Context, params UpsertPropertyHostnameRequest) (*PropertyHostname, error) {
+	args := m.Called(ctx, params)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*PropertyHostname), nil
+}
+
+func (m *Mock) DeletePropertyHostname(ctx context.Context, params DeletePropertyHostnameRequest) error {
+	args := m.Called(ctx, params)
+
+	return args.Error(0)
+}
+
+func (m *Mock) ListProvisionStateChanges(ctx context.Context, params ListProvisionStateChangesRequest) (*ListProvisionStateChangesResponse, error) {
+	args := m.Called(ctx, params)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*ListProvisionStateChangesResponse), nil
+}
+
+func (m *Mock) GetCurrentProvisionStateChange(ctx context.Context, params GetCurrentProvisionStateChangeRequest) (*ProvisionStateChange, error) {
+	args := m.Called(ctx, params)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*ProvisionStateChange), nil
+}
+
+func (m *Mock) CreateProvisionStateChange(ctx context.Context, params CreateProvisionStateChangeRequest) (*ProvisionStateChange, error) {
+	args := m.Called(ctx, params)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*ProvisionStateChange), nil
+}
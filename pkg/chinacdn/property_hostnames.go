@@ -0,0 +1,270 @@
+package chinacdn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type (
+	// PropertyHostnames contains operations available on property hostname resource
+	PropertyHostnames interface {
+		// ListPropertyHostnames lists property hostnames registered for China CDN
+		//
+		// See: https://techdocs.akamai.com/china-cdn/reference/get-property-hostnames
+		ListPropertyHostnames(context.Context, ListPropertyHostnamesRequest) (*ListPropertyHostnamesResponse, error)
+
+		// GetPropertyHostname returns details of a single property hostname
+		//
+		// See: https://techdocs.akamai.com/china-cdn/reference/get-property-hostname
+		GetPropertyHostname(context.Context, GetPropertyHostnameRequest) (*PropertyHostname, error)
+
+		// UpsertPropertyHostname creates or updates a property hostname and associates it with an ICP number
+		//
+		// See: https://techdocs.akamai.com/china-cdn/reference/put-property-hostname
+		UpsertPropertyHostname(context.Context, UpsertPropertyHostnameRequest) (*PropertyHostname, error)
+
+		// DeletePropertyHostname removes a property hostname from China CDN
+		//
+		// See: https://techdocs.akamai.com/china-cdn/reference/delete-property-hostname
+		DeletePropertyHostname(context.Context, DeletePropertyHostnameRequest) error
+	}
+
+	// PropertyHostname represents a hostname served through China CDN
+	PropertyHostname struct {
+		Hostname        string         `json:"hostname"`
+		ICPNumberID     int64          `json:"icpNumberId"`
+		ServiceCategory int64          `json:"serviceCategory"`
+		Comments        string         `json:"comments,omitempty"`
+		ProvisionState  ProvisionState `json:"provisionState,omitempty"`
+		LastModifiedBy  string         `json:"lastModifiedBy,omitempty"`
+		LastModifiedAt  string         `json:"lastModifiedAt,omitempty"`
+	}
+
+	// ListPropertyHostnamesRequest contains request parameters for ListPropertyHostnames
+	ListPropertyHostnamesRequest struct {
+		GroupID int64
+	}
+
+	// ListPropertyHostnamesResponse represents a response from ListPropertyHostnames
+	ListPropertyHostnamesResponse struct {
+		PropertyHostnames []PropertyHostname `json:"propertyHostnames"`
+	}
+
+	// GetPropertyHostnameRequest contains request parameters for GetPropertyHostname
+	GetPropertyHostnameRequest struct {
+		Hostname string
+		GroupID  int64
+	}
+
+	// UpsertPropertyHostnameRequest contains request parameters for UpsertPropertyHostname
+	UpsertPropertyHostnameRequest struct {
+		Hostname   string
+		GroupID    int64
+		ContractID string
+		Body       UpsertPropertyHostnameRequestBody
+	}
+
+	// UpsertPropertyHostnameRequestBody contains request body parameters for UpsertPropertyHostname
+	UpsertPropertyHostnameRequestBody struct {
+		Hostname        string `json:"hostname"`
+		ICPNumberID     int64  `json:"icpNumberId"`
+		ServiceCategory int64  `json:"serviceCategory"`
+		Comments        string `json:"comments,omitempty"`
+	}
+
+	// DeletePropertyHostnameRequest contains request parameters for DeletePropertyHostname
+	DeletePropertyHostnameRequest struct {
+		Hostname string
+		GroupID  int64
+	}
+)
+
+const (
+	propertyHostnamesMediaType = "application/vnd.akamai.chinacdn.property-hostnames.v1+json"
+	propertyHostnameMediaType  = "application/vnd.akamai.chinacdn.property-hostname.v1+json"
+)
+
+var (
+	// ErrListPropertyHostnames is returned in case an error occurs on ListPropertyHostnames operation
+	ErrListPropertyHostnames = errors.New("list property hostnames")
+	// ErrGetPropertyHostname is returned in case an error occurs on GetPropertyHostname operation
+	ErrGetPropertyHostname = errors.New("get property hostname")
+	// ErrUpsertPropertyHostname is returned in case an error occurs on UpsertPropertyHostname operation
+	ErrUpsertPropertyHostname = errors.New("upsert property hostname")
+	// ErrDeletePropertyHostname is returned in case an error occurs on DeletePropertyHostname operation
+	ErrDeletePropertyHostname = errors.New("delete property hostname")
+)
+
+// Validate validates GetPropertyHostnameRequest
+func (r GetPropertyHostnameRequest) Validate() error {
+	return validation.Errors{
+		"Hostname": validation.Validate(r.Hostname, validation.Required),
+	}.Filter()
+}
+
+// Validate validates UpsertPropertyHostnameRequest
+func (r UpsertPropertyHostnameRequest) Validate() error {
+	return validation.Errors{
+		"Hostname":             validation.Validate(r.Hostname, validation.Required),
+		"GroupID":              validation.Validate(r.GroupID, validation.Required),
+		"ContractID":           validation.Validate(r.ContractID, validation.Required),
+		"Body.Hostname":        validation.Validate(r.Body.Hostname, validation.Required, validation.In(r.Hostname).Error("must match Hostname")),
+		"Body.ICPNumberID":     validation.Validate(r.Body.ICPNumberID, validation.Required),
+		"Body.ServiceCategory": validation.Validate(r.Body.ServiceCategory, validation.Required),
+	}.Filter()
+}
+
+// Validate validates DeletePropertyHostnameRequest
+func (r DeletePropertyHostnameRequest) Validate() error {
+	return validation.Errors{
+		"Hostname": validation.Validate(r.Hostname, validation.Required),
+	}.Filter()
+}
+
+func (c *chinacdn) ListPropertyHostnames(ctx context.Context, params ListPropertyHostnamesRequest) (*ListPropertyHostnamesResponse, error) {
+	logger := c.Log(ctx)
+	logger.Debug("ListPropertyHostnames")
+
+	uri, err := url.Parse("/chinacdn/v1/property-hostnames")
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to parse url: %s", ErrListPropertyHostnames, err)
+	}
+	q := uri.Query()
+	if params.GroupID != 0 {
+		q.Add("groupId", strconv.FormatInt(params.GroupID, 10))
+	}
+	uri.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrListPropertyHostnames, err)
+	}
+	req.Header.Set("Accept", propertyHostnamesMediaType)
+
+	var result ListPropertyHostnamesResponse
+	resp, err := c.Exec(req, &result)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrListPropertyHostnames, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrListPropertyHostnames, c.Error(resp))
+	}
+
+	return &result, nil
+}
+
+func (c *chinacdn) GetPropertyHostname(ctx context.Context, params GetPropertyHostnameRequest) (*PropertyHostname, error) {
+	logger := c.Log(ctx)
+	logger.Debug("GetPropertyHostname")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetPropertyHostname, ErrStructValidation, err)
+	}
+
+	uri, err := url.Parse(fmt.Sprintf("/chinacdn/v1/property-hostnames/%s", url.PathEscape(params.Hostname)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to parse url: %s", ErrGetPropertyHostname, err)
+	}
+	q := uri.Query()
+	if params.GroupID != 0 {
+		q.Add("groupId", strconv.FormatInt(params.GroupID, 10))
+	}
+	uri.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetPropertyHostname, err)
+	}
+	req.Header.Set("Accept", propertyHostnameMediaType)
+
+	var result PropertyHostname
+	resp, err := c.Exec(req, &result)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrGetPropertyHostname, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrGetPropertyHostname, c.Error(resp))
+	}
+
+	return &result, nil
+}
+
+func (c *chinacdn) UpsertPropertyHostname(ctx context.Context, params UpsertPropertyHostnameRequest) (*PropertyHostname, error) {
+	logger := c.Log(ctx)
+	logger.Debug("UpsertPropertyHostname")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrUpsertPropertyHostname, ErrStructValidation, err)
+	}
+
+	uri, err := url.Parse(fmt.Sprintf("/chinacdn/v1/property-hostnames/%s", url.PathEscape(params.Hostname)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to parse url: %s", ErrUpsertPropertyHostname, err)
+	}
+	q := uri.Query()
+	q.Add("groupId", strconv.FormatInt(params.GroupID, 10))
+	q.Add("contractId", params.ContractID)
+	uri.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, uri.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrUpsertPropertyHostname, err)
+	}
+	req.Header.Set("Accept", propertyHostnameMediaType)
+	req.Header.Set("Content-Type", propertyHostnameMediaType)
+
+	var result PropertyHostname
+	resp, err := c.Exec(req, &result, params.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrUpsertPropertyHostname, err)
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("%s: %w", ErrUpsertPropertyHostname, c.Error(resp))
+	}
+
+	return &result, nil
+}
+
+func (c *chinacdn) DeletePropertyHostname(ctx context.Context, params DeletePropertyHostnameRequest) error {
+	logger := c.Log(ctx)
+	logger.Debug("DeletePropertyHostname")
+
+	if err := params.Validate(); err != nil {
+		return fmt.Errorf("%s: %w: %s", ErrDeletePropertyHostname, ErrStructValidation, err)
+	}
+
+	uri, err := url.Parse(fmt.Sprintf("/chinacdn/v1/property-hostnames/%s", url.PathEscape(params.Hostname)))
+	if err != nil {
+		return fmt.Errorf("%w: failed to parse url: %s", ErrDeletePropertyHostname, err)
+	}
+	q := uri.Query()
+	if params.GroupID != 0 {
+		q.Add("groupId", strconv.FormatInt(params.GroupID, 10))
+	}
+	uri.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, uri.String(), nil)
+	if err != nil {
+		return fmt.Errorf("%w: failed to create request: %s", ErrDeletePropertyHostname, err)
+	}
+
+	resp, err := c.Exec(req, nil)
+	if err != nil {
+		return fmt.Errorf("%w: request failed: %s", ErrDeletePropertyHostname, err)
+	}
+
+	if resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("%s: %w", ErrDeletePropertyHostname, c.Error(resp))
+	}
+
+	return nil
+}
@@ -0,0 +1,235 @@
+package chinacdn
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListPropertyHostnames(t *testing.T) {
+	tests := map[string]struct {
+		params           ListPropertyHostnamesRequest
+		responseStatus   int
+		responseBody     string
+		expectedPath     string
+		expectedResponse *ListPropertyHostnamesResponse
+		withError        error
+	}{
+		"200 OK": {
+			params:         ListPropertyHostnamesRequest{GroupID: 12345},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+    "propertyHostnames": [
+        {
+            "hostname": "www.example.cn",
+            "icpNumberId": 1,
+            "serviceCategory": 8,
+            "provisionState": "PROVISIONED"
+        }
+    ]
+}`,
+			expectedPath: "/chinacdn/v1/property-hostnames?groupId=12345",
+			expectedResponse: &ListPropertyHostnamesResponse{
+				PropertyHostnames: []PropertyHostname{
+					{
+						Hostname:        "www.example.cn",
+						ICPNumberID:     1,
+						ServiceCategory: 8,
+						ProvisionState:  ProvisionStateProvisioned,
+					},
+				},
+			},
+		},
+		"500 internal server error": {
+			responseStatus: http.StatusInternalServerError,
+			responseBody:   `{"type": "internal_error", "title": "Internal Server Error", "detail": "Error listing hostnames", "status": 500}`,
+			expectedPath:   "/chinacdn/v1/property-hostnames",
+			withError: &Error{
+				Type:   "internal_error",
+				Title:  "Internal Server Error",
+				Detail: "Error listing hostnames",
+				Status: http.StatusInternalServerError,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				assert.Equal(t, propertyHostnamesMediaType, r.Header.Get("Accept"))
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.ListPropertyHostnames(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestGetPropertyHostname(t *testing.T) {
+	tests := map[string]struct {
+		params           GetPropertyHostnameRequest
+		responseStatus   int
+		responseBody     string
+		expectedResponse *PropertyHostname
+		withError        error
+	}{
+		"200 OK": {
+			params:           GetPropertyHostnameRequest{Hostname: "www.example.cn"},
+			responseStatus:   http.StatusOK,
+			responseBody:     `{"hostname": "www.example.cn", "icpNumberId": 1, "serviceCategory": 8, "provisionState": "WHITELISTED"}`,
+			expectedResponse: &PropertyHostname{Hostname: "www.example.cn", ICPNumberID: 1, ServiceCategory: 8, ProvisionState: ProvisionStateWhitelisted},
+		},
+		"404 not found": {
+			params:         GetPropertyHostnameRequest{Hostname: "www.example.cn"},
+			responseStatus: http.StatusNotFound,
+			responseBody:   `{"type": "not_found", "title": "Not Found", "detail": "Hostname not found", "status": 404}`,
+			withError: &Error{
+				Type:   "not_found",
+				Title:  "Not Found",
+				Detail: "Hostname not found",
+				Status: http.StatusNotFound,
+			},
+		},
+		"validation error": {
+			params:    GetPropertyHostnameRequest{},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/chinacdn/v1/property-hostnames/www.example.cn", r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.GetPropertyHostname(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestUpsertPropertyHostname(t *testing.T) {
+	tests := map[string]struct {
+		params           UpsertPropertyHostnameRequest
+		responseStatus   int
+		responseBody     string
+		expectedRequest  string
+		expectedResponse *PropertyHostname
+		withError        error
+	}{
+		"201 Created": {
+			params: UpsertPropertyHostnameRequest{
+				Hostname:   "www.example.cn",
+				GroupID:    12345,
+				ContractID: "C-0N7RAC7",
+				Body: UpsertPropertyHostnameRequestBody{
+					Hostname:        "www.example.cn",
+					ICPNumberID:     1,
+					ServiceCategory: 8,
+				},
+			},
+			responseStatus:   http.StatusCreated,
+			responseBody:     `{"hostname": "www.example.cn", "icpNumberId": 1, "serviceCategory": 8, "provisionState": "PENDING"}`,
+			expectedRequest:  `{"hostname":"www.example.cn","icpNumberId":1,"serviceCategory":8}`,
+			expectedResponse: &PropertyHostname{Hostname: "www.example.cn", ICPNumberID: 1, ServiceCategory: 8, ProvisionState: ProvisionStatePending},
+		},
+		"validation error - hostname mismatch": {
+			params: UpsertPropertyHostnameRequest{
+				Hostname:   "www.example.cn",
+				GroupID:    12345,
+				ContractID: "C-0N7RAC7",
+				Body: UpsertPropertyHostnameRequestBody{
+					Hostname:        "api.example.cn",
+					ICPNumberID:     1,
+					ServiceCategory: 8,
+				},
+			},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/chinacdn/v1/property-hostnames/www.example.cn?contractId=C-0N7RAC7&groupId=12345", r.URL.String())
+				assert.Equal(t, http.MethodPut, r.Method)
+				assert.Equal(t, propertyHostnameMediaType, r.Header.Get("Content-Type"))
+				body, err := ioutil.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.JSONEq(t, test.expectedRequest, string(body))
+				w.WriteHeader(test.responseStatus)
+				_, err = w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.UpsertPropertyHostname(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestDeletePropertyHostname(t *testing.T) {
+	tests := map[string]struct {
+		params         DeletePropertyHostnameRequest
+		responseStatus int
+		responseBody   string
+		withError      error
+	}{
+		"204 No Content": {
+			params:         DeletePropertyHostnameRequest{Hostname: "www.example.cn", GroupID: 12345},
+			responseStatus: http.StatusNoContent,
+		},
+		"validation error": {
+			params:    DeletePropertyHostnameRequest{},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/chinacdn/v1/property-hostnames/www.example.cn?groupId=12345", r.URL.String())
+				assert.Equal(t, http.MethodDelete, r.Method)
+				w.WriteHeader(test.responseStatus)
+			}))
+			client := mockAPIClient(t, mockServer)
+			err := client.DeletePropertyHostname(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
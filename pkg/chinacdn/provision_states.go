@@ -0,0 +1,217 @@
+package chinacdn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type (
+	// ProvisionStates contains operations available on property hostname provision state resource
+	ProvisionStates interface {
+		// ListProvisionStateChanges lists the history of provision state changes of a property hostname
+		//
+		// See: https://techdocs.akamai.com/china-cdn/reference/get-provision-state-changes
+		ListProvisionStateChanges(context.Context, ListProvisionStateChangesRequest) (*ListProvisionStateChangesResponse, error)
+
+		// GetCurrentProvisionStateChange returns the provision state change currently in progress for a property hostname
+		//
+		// See: https://techdocs.akamai.com/china-cdn/reference/get-current-provision-state-change
+		GetCurrentProvisionStateChange(context.Context, GetCurrentProvisionStateChangeRequest) (*ProvisionStateChange, error)
+
+		// CreateProvisionStateChange requests a property hostname to be moved into a new provision state
+		//
+		// See: https://techdocs.akamai.com/china-cdn/reference/post-provision-state-changes
+		CreateProvisionStateChange(context.Context, CreateProvisionStateChangeRequest) (*ProvisionStateChange, error)
+	}
+
+	// ProvisionState is the provisioning state of a property hostname
+	ProvisionState string
+
+	// ProvisionStateChange represents a request to change the provision state of a property hostname
+	ProvisionStateChange struct {
+		Hostname       string         `json:"hostname"`
+		TargetState    ProvisionState `json:"targetState"`
+		Status         string         `json:"status,omitempty"`
+		SendEmail      bool           `json:"sendEmail,omitempty"`
+		StatusMessage  string         `json:"statusMessage,omitempty"`
+		CreatedBy      string         `json:"createdBy,omitempty"`
+		CreatedAt      string         `json:"createdAt,omitempty"`
+		LastModifiedAt string         `json:"lastModifiedAt,omitempty"`
+	}
+
+	// ListProvisionStateChangesRequest contains request parameters for ListProvisionStateChanges
+	ListProvisionStateChangesRequest struct {
+		Hostname string
+	}
+
+	// ListProvisionStateChangesResponse represents a response from ListProvisionStateChanges
+	ListProvisionStateChangesResponse struct {
+		ProvisionStateChanges []ProvisionStateChange `json:"provisionStateChanges"`
+	}
+
+	// GetCurrentProvisionStateChangeRequest contains request parameters for GetCurrentProvisionStateChange
+	GetCurrentProvisionStateChangeRequest struct {
+		Hostname string
+	}
+
+	// CreateProvisionStateChangeRequest contains request parameters for CreateProvisionStateChange
+	CreateProvisionStateChangeRequest struct {
+		Hostname    string
+		GroupID     int64
+		TargetState ProvisionState
+		SendEmail   bool
+	}
+)
+
+const (
+	// ProvisionStatePending is the state of a hostname awaiting ICP verification
+	ProvisionStatePending ProvisionState = "PENDING"
+	// ProvisionStateWhitelisted is the state of a hostname approved for provisioning in China
+	ProvisionStateWhitelisted ProvisionState = "WHITELISTED"
+	// ProvisionStateProvisioned is the state of a hostname served by China CDN
+	ProvisionStateProvisioned ProvisionState = "PROVISIONED"
+	// ProvisionStateDeprovisioned is the state of a hostname no longer served by China CDN
+	ProvisionStateDeprovisioned ProvisionState = "DEPROVISIONED"
+
+	provisionStateChangesMediaType = "application/vnd.akamai.chinacdn.provision-state-changes.v1+json"
+	provisionStateChangeMediaType  = "application/vnd.akamai.chinacdn.provision-state-change.v1+json"
+)
+
+var (
+	// ErrListProvisionStateChanges is returned in case an error occurs on ListProvisionStateChanges operation
+	ErrListProvisionStateChanges = errors.New("list provision state changes")
+	// ErrGetCurrentProvisionStateChange is returned in case an error occurs on GetCurrentProvisionStateChange operation
+	ErrGetCurrentProvisionStateChange = errors.New("get current provision state change")
+	// ErrCreateProvisionStateChange is returned in case an error occurs on CreateProvisionStateChange operation
+	ErrCreateProvisionStateChange = errors.New("create provision state change")
+)
+
+// Validate validates ListProvisionStateChangesRequest
+func (r ListProvisionStateChangesRequest) Validate() error {
+	return validation.Errors{
+		"Hostname": validation.Validate(r.Hostname, validation.Required),
+	}.Filter()
+}
+
+// Validate validates GetCurrentProvisionStateChangeRequest
+func (r GetCurrentProvisionStateChangeRequest) Validate() error {
+	return validation.Errors{
+		"Hostname": validation.Validate(r.Hostname, validation.Required),
+	}.Filter()
+}
+
+// Validate validates CreateProvisionStateChangeRequest
+func (r CreateProvisionStateChangeRequest) Validate() error {
+	return validation.Errors{
+		"Hostname": validation.Validate(r.Hostname, validation.Required),
+		"GroupID":  validation.Validate(r.GroupID, validation.Required),
+		"TargetState": validation.Validate(r.TargetState, validation.Required,
+			validation.In(ProvisionStateWhitelisted, ProvisionStateProvisioned, ProvisionStateDeprovisioned).
+				Error(fmt.Sprintf("value '%s' is invalid. Must be one of: '%s', '%s' or '%s'", r.TargetState,
+					ProvisionStateWhitelisted, ProvisionStateProvisioned, ProvisionStateDeprovisioned))),
+	}.Filter()
+}
+
+func (c *chinacdn) ListProvisionStateChanges(ctx context.Context, params ListProvisionStateChangesRequest) (*ListProvisionStateChangesResponse, error) {
+	logger := c.Log(ctx)
+	logger.Debug("ListProvisionStateChanges")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrListProvisionStateChanges, ErrStructValidation, err)
+	}
+
+	uri := fmt.Sprintf("/chinacdn/v1/property-hostnames/%s/provision-state-changes", url.PathEscape(params.Hostname))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrListProvisionStateChanges, err)
+	}
+	req.Header.Set("Accept", provisionStateChangesMediaType)
+
+	var result ListProvisionStateChangesResponse
+	resp, err := c.Exec(req, &result)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrListProvisionStateChanges, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrListProvisionStateChanges, c.Error(resp))
+	}
+
+	return &result, nil
+}
+
+func (c *chinacdn) GetCurrentProvisionStateChange(ctx context.Context, params GetCurrentProvisionStateChangeRequest) (*ProvisionStateChange, error) {
+	logger := c.Log(ctx)
+	logger.Debug("GetCurrentProvisionStateChange")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetCurrentProvisionStateChange, ErrStructValidation, err)
+	}
+
+	uri := fmt.Sprintf("/chinacdn/v1/property-hostnames/%s/current-provision-state-change", url.PathEscape(params.Hostname))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetCurrentProvisionStateChange, err)
+	}
+	req.Header.Set("Accept", provisionStateChangeMediaType)
+
+	var result ProvisionStateChange
+	resp, err := c.Exec(req, &result)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrGetCurrentProvisionStateChange, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrGetCurrentProvisionStateChange, c.Error(resp))
+	}
+
+	return &result, nil
+}
+
+func (c *chinacdn) CreateProvisionStateChange(ctx context.Context, params CreateProvisionStateChangeRequest) (*ProvisionStateChange, error) {
+	logger := c.Log(ctx)
+	logger.Debug("CreateProvisionStateChange")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrCreateProvisionStateChange, ErrStructValidation, err)
+	}
+
+	uri, err := url.Parse(fmt.Sprintf("/chinacdn/v1/property-hostnames/%s/provision-state-changes", url.PathEscape(params.Hostname)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to parse url: %s", ErrCreateProvisionStateChange, err)
+	}
+	q := uri.Query()
+	q.Add("groupId", strconv.FormatInt(params.GroupID, 10))
+	uri.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrCreateProvisionStateChange, err)
+	}
+	req.Header.Set("Accept", provisionStateChangeMediaType)
+	req.Header.Set("Content-Type", provisionStateChangeMediaType)
+
+	body := ProvisionStateChange{
+		Hostname:    params.Hostname,
+		TargetState: params.TargetState,
+		SendEmail:   params.SendEmail,
+	}
+
+	var result ProvisionStateChange
+	resp, err := c.Exec(req, &result, body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrCreateProvisionStateChange, err)
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("%s: %w", ErrCreateProvisionStateChange, c.Error(resp))
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,181 @@
+package chinacdn
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListProvisionStateChanges(t *testing.T) {
+	tests := map[string]struct {
+		params           ListProvisionStateChangesRequest
+		responseStatus   int
+		responseBody     string
+		expectedResponse *ListProvisionStateChangesResponse
+		withError        error
+	}{
+		"200 OK": {
+			params:         ListProvisionStateChangesRequest{Hostname: "www.example.cn"},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+    "provisionStateChanges": [
+        {
+            "hostname": "www.example.cn",
+            "targetState": "PROVISIONED",
+            "status": "COMPLETED",
+            "createdBy": "jdoe",
+            "createdAt": "2023-05-01T10:00:00Z"
+        }
+    ]
+}`,
+			expectedResponse: &ListProvisionStateChangesResponse{
+				ProvisionStateChanges: []ProvisionStateChange{
+					{
+						Hostname:    "www.example.cn",
+						TargetState: ProvisionStateProvisioned,
+						Status:      "COMPLETED",
+						CreatedBy:   "jdoe",
+						CreatedAt:   "2023-05-01T10:00:00Z",
+					},
+				},
+			},
+		},
+		"validation error": {
+			params:    ListProvisionStateChangesRequest{},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/chinacdn/v1/property-hostnames/www.example.cn/provision-state-changes", r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.ListProvisionStateChanges(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestGetCurrentProvisionStateChange(t *testing.T) {
+	tests := map[string]struct {
+		params           GetCurrentProvisionStateChangeRequest
+		responseStatus   int
+		responseBody     string
+		expectedResponse *ProvisionStateChange
+		withError        error
+	}{
+		"200 OK": {
+			params:           GetCurrentProvisionStateChangeRequest{Hostname: "www.example.cn"},
+			responseStatus:   http.StatusOK,
+			responseBody:     `{"hostname": "www.example.cn", "targetState": "DEPROVISIONED", "status": "IN_PROGRESS"}`,
+			expectedResponse: &ProvisionStateChange{Hostname: "www.example.cn", TargetState: ProvisionStateDeprovisioned, Status: "IN_PROGRESS"},
+		},
+		"404 no change in progress": {
+			params:         GetCurrentProvisionStateChangeRequest{Hostname: "www.example.cn"},
+			responseStatus: http.StatusNotFound,
+			responseBody:   `{"type": "not_found", "title": "Not Found", "detail": "No provision state change in progress", "status": 404}`,
+			withError: &Error{
+				Type:   "not_found",
+				Title:  "Not Found",
+				Detail: "No provision state change in progress",
+				Status: http.StatusNotFound,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/chinacdn/v1/property-hostnames/www.example.cn/current-provision-state-change", r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.GetCurrentProvisionStateChange(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestCreateProvisionStateChange(t *testing.T) {
+	tests := map[string]struct {
+		params           CreateProvisionStateChangeRequest
+		responseStatus   int
+		responseBody     string
+		expectedRequest  string
+		expectedResponse *ProvisionStateChange
+		withError        func(*testing.T, error)
+	}{
+		"201 Created": {
+			params: CreateProvisionStateChangeRequest{
+				Hostname:    "www.example.cn",
+				GroupID:     12345,
+				TargetState: ProvisionStateProvisioned,
+				SendEmail:   true,
+			},
+			responseStatus:   http.StatusCreated,
+			responseBody:     `{"hostname": "www.example.cn", "targetState": "PROVISIONED", "status": "PENDING", "sendEmail": true}`,
+			expectedRequest:  `{"hostname":"www.example.cn","targetState":"PROVISIONED","sendEmail":true}`,
+			expectedResponse: &ProvisionStateChange{Hostname: "www.example.cn", TargetState: ProvisionStateProvisioned, Status: "PENDING", SendEmail: true},
+		},
+		"validation error - invalid target state": {
+			params: CreateProvisionStateChangeRequest{
+				Hostname:    "www.example.cn",
+				GroupID:     12345,
+				TargetState: ProvisionStatePending,
+			},
+			withError: func(t *testing.T, err error) {
+				assert.True(t, errors.Is(err, ErrStructValidation), "want: %s; got: %s", ErrStructValidation, err)
+				assert.Contains(t, err.Error(), "value 'PENDING' is invalid")
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/chinacdn/v1/property-hostnames/www.example.cn/provision-state-changes?groupId=12345", r.URL.String())
+				assert.Equal(t, http.MethodPost, r.Method)
+				body, err := ioutil.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.JSONEq(t, test.expectedRequest, string(body))
+				w.WriteHeader(test.responseStatus)
+				_, err = w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.CreateProvisionStateChange(context.Background(), test.params)
+			if test.withError != nil {
+				test.withError(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/session"
 )
 
 type (
@@ -58,6 +60,10 @@ func (e *Error) Error() string {
 
 // Is handles error comparisons
 func (e *Error) Is(target error) bool {
+	if session.MatchStatus(e.StatusCode, target) {
+		return true
+	}
+
 	var t *Error
 	if !errors.As(target, &t) {
 		return false
@@ -73,3 +79,8 @@ func (e *Error) Is(target error) bool {
 
 	return e.Error() == t.Error()
 }
+
+// As converts the error into a *session.Problem, shared by the errors of all packages.
+func (e *Error) As(target interface{}) bool {
+	return session.AsProblem(e, e.StatusCode, target)
+}
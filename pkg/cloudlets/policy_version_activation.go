@@ -9,6 +9,7 @@ import (
 	"net/url"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/edgegriderr"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/tools"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 )
@@ -57,6 +58,8 @@ var (
 	ErrListPolicyActivations = errors.New("list policy activations")
 	// ErrActivatePolicyVersion is returned when ActivatePolicyVersion fails
 	ErrActivatePolicyVersion = errors.New("activate policy version")
+	// ErrPolicyActivationFailed is returned when awaited policy activation fails
+	ErrPolicyActivationFailed = errors.New("policy activation failed")
 )
 
 const (
@@ -180,3 +183,51 @@ func (c *cloudlets) ActivatePolicyVersion(ctx context.Context, params ActivatePo
 
 	return result, nil
 }
+
+// WaitForPolicyActivation polls ListPolicyActivations until all activations of the given policy version
+// on the requested network are active and returns them.
+func WaitForPolicyActivation(ctx context.Context, client PolicyVersionActivations, params ListPolicyActivationsRequest, version int64, opts tools.WaitOptions) ([]PolicyActivation, error) {
+	var result []PolicyActivation
+	if err := tools.WaitFor(ctx, policyActivationCheck(client, params, version, &result), opts); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// WatchPolicyActivation polls ListPolicyActivations like WaitForPolicyActivation, delivering the activation status
+// after every check on the returned channel, see tools.Watch.
+func WatchPolicyActivation(ctx context.Context, client PolicyVersionActivations, params ListPolicyActivationsRequest, version int64, opts tools.WaitOptions) <-chan tools.Event {
+	var result []PolicyActivation
+	return tools.Watch(ctx, policyActivationCheck(client, params, version, &result), opts)
+}
+
+// policyActivationCheck returns a check of the activations of the policy version, storing them in result
+func policyActivationCheck(client PolicyVersionActivations, params ListPolicyActivationsRequest, version int64, result *[]PolicyActivation) tools.CheckFunc {
+	return func(ctx context.Context) (string, bool, error) {
+		activations, err := client.ListPolicyActivations(ctx, params)
+		if err != nil {
+			return "", false, err
+		}
+		*result = (*result)[:0]
+		for _, act := range activations {
+			if act.PolicyInfo.Version == version {
+				*result = append(*result, act)
+			}
+		}
+		if len(*result) == 0 {
+			return string(PolicyActivationStatusPending), false, nil
+		}
+		for _, act := range *result {
+			switch act.PolicyInfo.Status {
+			case PolicyActivationStatusActive:
+				continue
+			case PolicyActivationStatusFailed:
+				return string(act.PolicyInfo.Status), false, fmt.Errorf("%w: policy %d version %d on property %s: %s",
+					ErrPolicyActivationFailed, act.PolicyInfo.PolicyID, version, act.PropertyInfo.Name, act.PolicyInfo.StatusDetail)
+			default:
+				return string(act.PolicyInfo.Status), false, nil
+			}
+		}
+		return string(PolicyActivationStatusActive), true, nil
+	}
+}
@@ -6,8 +6,11 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/tools"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -295,3 +298,69 @@ func TestActivatePolicyVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestWaitForPolicyActivation(t *testing.T) {
+	opts := tools.WaitOptions{Interval: time.Millisecond, Jitter: -1}
+	params := ListPolicyActivationsRequest{PolicyID: 1234, Network: PolicyActivationNetworkStaging}
+	activation := func(version int64, property string, status PolicyActivationStatus) PolicyActivation {
+		return PolicyActivation{
+			Network:      PolicyActivationNetworkStaging,
+			PolicyInfo:   PolicyInfo{PolicyID: 1234, Version: version, Status: status},
+			PropertyInfo: PropertyInfo{Name: property},
+		}
+	}
+
+	t.Run("active", func(t *testing.T) {
+		client := &Mock{}
+		client.On("ListPolicyActivations", mock.Anything, params).Return([]PolicyActivation{
+			activation(1, "www.example.com", PolicyActivationStatusActive),
+		}, nil).Once()
+		client.On("ListPolicyActivations", mock.Anything, params).Return([]PolicyActivation{
+			activation(2, "www.example.com", PolicyActivationStatusPending),
+			activation(1, "www.example.com", PolicyActivationStatusActive),
+		}, nil).Once()
+		client.On("ListPolicyActivations", mock.Anything, params).Return([]PolicyActivation{
+			activation(2, "www.example.com", PolicyActivationStatusActive),
+			activation(1, "www.example.com", PolicyActivationStatusActive),
+		}, nil).Once()
+
+		result, err := WaitForPolicyActivation(context.Background(), client, params, 2, opts)
+		require.NoError(t, err)
+		assert.Equal(t, []PolicyActivation{activation(2, "www.example.com", PolicyActivationStatusActive)}, result)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		client := &Mock{}
+		client.On("ListPolicyActivations", mock.Anything, params).Return([]PolicyActivation{
+			activation(2, "www.example.com", PolicyActivationStatusFailed),
+		}, nil).Once()
+
+		_, err := WaitForPolicyActivation(context.Background(), client, params, 2, opts)
+		assert.True(t, errors.Is(err, ErrPolicyActivationFailed), "want: %s; got: %s", ErrPolicyActivationFailed, err)
+	})
+}
+
+func TestWatchPolicyActivation(t *testing.T) {
+	opts := tools.WaitOptions{Interval: time.Millisecond, Jitter: -1}
+	params := ListPolicyActivationsRequest{PolicyID: 1234, Network: PolicyActivationNetworkStaging}
+	activation := PolicyActivation{
+		Network:      PolicyActivationNetworkStaging,
+		PolicyInfo:   PolicyInfo{PolicyID: 1234, Version: 2, Status: PolicyActivationStatusFailed, StatusDetail: "invalid rule"},
+		PropertyInfo: PropertyInfo{Name: "www.example.com"},
+	}
+
+	client := &Mock{}
+	client.On("ListPolicyActivations", mock.Anything, params).Return([]PolicyActivation{}, nil).Once()
+	client.On("ListPolicyActivations", mock.Anything, params).Return([]PolicyActivation{activation}, nil).Once()
+
+	var events []tools.Event
+	for event := range WatchPolicyActivation(context.Background(), client, params, 2, opts) {
+		events = append(events, event)
+	}
+	require.Len(t, events, 2)
+	assert.Equal(t, string(PolicyActivationStatusPending), events[0].Status)
+	assert.Equal(t, string(PolicyActivationStatusFailed), events[1].Status)
+	assert.True(t, errors.Is(events[1].Err, ErrPolicyActivationFailed), "want: %s; got: %s", ErrPolicyActivationFailed, events[1].Err)
+	client.AssertExpectations(t)
+}
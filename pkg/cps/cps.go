@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/session"
+	"github.com/apex/log"
 )
 
 var (
@@ -29,6 +30,7 @@ type (
 
 	cps struct {
 		session.Session
+		options session.ClientOptions
 	}
 
 	// Option defines a CPS option
@@ -47,5 +49,56 @@ func Client(sess session.Session, opts ...Option) CPS {
 	for _, opt := range opts {
 		opt(c)
 	}
+	c.Session = c.options.Apply(c.Session)
 	return c
 }
+
+// WithLogger sets the logger used by the CPS client instead of the session logger
+func WithLogger(l log.Interface) Option {
+	return func(c *cps) {
+		c.options.Logger = l
+	}
+}
+
+// WithRetries sets how many times idempotent CPS requests are retried after transient failures
+func WithRetries(retries int) Option {
+	return func(c *cps) {
+		c.options.Retries = retries
+	}
+}
+
+// WithRetryClassifier sets the callback deciding whether failed CPS requests are retried, see session.RetryClassifier
+func WithRetryClassifier(classifier session.RetryClassifier) Option {
+	return func(c *cps) {
+		c.options.RetryClassifier = classifier
+	}
+}
+
+// WithBackoff sets the strategy computing delays between retries of failed CPS requests, see session.Backoff
+func WithBackoff(backoff session.Backoff) Option {
+	return func(c *cps) {
+		c.options.Backoff = backoff
+	}
+}
+
+// WithIdempotencyKeys adds an idempotency key to CPS POST and PATCH requests, kept when they are
+// retried; it does not make them retried by itself, see session.WithIdempotencyKeys
+func WithIdempotencyKeys(enabled bool) Option {
+	return func(c *cps) {
+		c.options.IdempotencyKeys = enabled
+	}
+}
+
+// WithBaseURL overrides the scheme and host CPS requests are sent to
+func WithBaseURL(baseURL string) Option {
+	return func(c *cps) {
+		c.options.BaseURL = baseURL
+	}
+}
+
+// WithAccountSwitchKey makes CPS requests act on the account with given switch key
+func WithAccountSwitchKey(key string) Option {
+	return func(c *cps) {
+		c.options.AccountSwitchKey = key
+	}
+}
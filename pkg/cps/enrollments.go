@@ -8,6 +8,7 @@ import (
 	"net/url"
 	"strconv"
 
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/tools"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 )
 
@@ -157,7 +158,7 @@ type (
 
 	// ListEnrollmentsRequest contains Contract ID of enrollments that are to be fetched with ListEnrollments
 	ListEnrollmentsRequest struct {
-		ContractID string
+		ContractID string `query:"contractId"`
 	}
 
 	// GetEnrollmentRequest contains ID of an enrollment that is to be fetched with GetEnrollment
@@ -168,10 +169,10 @@ type (
 	// CreateEnrollmentRequest contains request body and path parameters used to create an enrollment
 	CreateEnrollmentRequest struct {
 		Enrollment
-		ContractID       string
-		DeployNotAfter   string
-		DeployNotBefore  string
-		AllowDuplicateCN bool
+		ContractID       string `query:"contractId"`
+		DeployNotAfter   string `query:"deploy-not-after,omitempty"`
+		DeployNotBefore  string `query:"deploy-not-before,omitempty"`
+		AllowDuplicateCN bool   `query:"allow-duplicate-cn,omitempty"`
 	}
 
 	// CreateEnrollmentResponse contains response body returned after successful enrollment creation
@@ -321,7 +322,10 @@ func (c *cps) ListEnrollments(ctx context.Context, params ListEnrollmentsRequest
 	logger := c.Log(ctx)
 	logger.Debug("ListEnrollments")
 
-	uri := fmt.Sprintf("/cps/v2/enrollments?contractId=%s", params.ContractID)
+	uri, err := tools.AppendQuery("/cps/v2/enrollments", params)
+	if err != nil {
+		return nil, fmt.Errorf("%w: parsing URL: %s", ErrListEnrollments, err)
+	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
 	if err != nil {
@@ -381,22 +385,11 @@ func (c *cps) CreateEnrollment(ctx context.Context, params CreateEnrollmentReque
 	logger := c.Log(ctx)
 	logger.Debug("CreateEnrollment")
 
-	uri, err := url.Parse(fmt.Sprintf("/cps/v2/enrollments?contractId=%s", params.ContractID))
+	uri, err := tools.AppendQuery("/cps/v2/enrollments", params)
 	if err != nil {
 		return nil, fmt.Errorf("%w: parsing URL: %s", ErrCreateEnrollment, err)
 	}
-	query := uri.Query()
-	if params.DeployNotAfter != "" {
-		query.Add("deploy-not-after", params.DeployNotAfter)
-	}
-	if params.DeployNotBefore != "" {
-		query.Add("deploy-not-before", params.DeployNotBefore)
-	}
-	if params.AllowDuplicateCN {
-		query.Add("allow-duplicate-cn", "true")
-	}
-	uri.RawQuery = query.Encode()
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri, nil)
 	if err != nil {
 		return nil, fmt.Errorf("%w: failed to create request: %s", ErrCreateEnrollment, err)
 	}
@@ -7,6 +7,7 @@ import (
 	"errors"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/session"
+	"github.com/apex/log"
 )
 
 var (
@@ -24,6 +25,7 @@ type (
 
 	ds struct {
 		session.Session
+		options session.ClientOptions
 	}
 
 	// Option defines a DS option
@@ -42,9 +44,60 @@ func Client(sess session.Session, opts ...Option) DS {
 	for _, opt := range opts {
 		opt(c)
 	}
+	c.Session = c.options.Apply(c.Session)
 	return c
 }
 
+// WithLogger sets the logger used by the DataStream client instead of the session logger
+func WithLogger(l log.Interface) Option {
+	return func(c *ds) {
+		c.options.Logger = l
+	}
+}
+
+// WithRetries sets how many times idempotent DataStream requests are retried after transient failures
+func WithRetries(retries int) Option {
+	return func(c *ds) {
+		c.options.Retries = retries
+	}
+}
+
+// WithRetryClassifier sets the callback deciding whether failed DataStream requests are retried, see session.RetryClassifier
+func WithRetryClassifier(classifier session.RetryClassifier) Option {
+	return func(c *ds) {
+		c.options.RetryClassifier = classifier
+	}
+}
+
+// WithBackoff sets the strategy computing delays between retries of failed DataStream requests, see session.Backoff
+func WithBackoff(backoff session.Backoff) Option {
+	return func(c *ds) {
+		c.options.Backoff = backoff
+	}
+}
+
+// WithIdempotencyKeys adds an idempotency key to DataStream POST and PATCH requests, kept when they are
+// retried; it does not make them retried by itself, see session.WithIdempotencyKeys
+func WithIdempotencyKeys(enabled bool) Option {
+	return func(c *ds) {
+		c.options.IdempotencyKeys = enabled
+	}
+}
+
+// WithBaseURL overrides the scheme and host DataStream requests are sent to
+func WithBaseURL(baseURL string) Option {
+	return func(c *ds) {
+		c.options.BaseURL = baseURL
+	}
+}
+
+// WithAccountSwitchKey makes DataStream requests act on the account with given switch key
+func WithAccountSwitchKey(key string) Option {
+	return func(c *ds) {
+		c.options.AccountSwitchKey = key
+	}
+}
+
 // DelimiterTypePtr returns the address of the DelimiterType
 func DelimiterTypePtr(d DelimiterType) *DelimiterType {
 	return &d
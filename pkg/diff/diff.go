@@ -0,0 +1,226 @@
+// Package diff computes structural differences between JSON documents or SDK structs, such as rule trees,
+// security configuration exports or GTM domains, reporting every change with its JSON path
+package diff
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+type (
+	// Change is a single difference between two documents
+	Change struct {
+		// Path is the JSON path of the changed value, e.g. $.rules.children[0].name
+		Path string
+		// Type tells whether the value was added, removed or modified
+		Type ChangeType
+		// From is the old value, nil for added values
+		From interface{}
+		// To is the new value, nil for removed values
+		To interface{}
+	}
+
+	// ChangeType is the kind of change
+	ChangeType string
+
+	// Option configures the comparison
+	Option func(*options)
+
+	options struct {
+		ignore map[string]bool
+	}
+)
+
+const (
+	// Added means the value is present only in the new document
+	Added ChangeType = "added"
+	// Removed means the value is present only in the old document
+	Removed ChangeType = "removed"
+	// Modified means the value is present in both documents but differs
+	Modified ChangeType = "modified"
+)
+
+var (
+	// ErrInvalidDocument is returned when a document cannot be decoded or encoded as JSON
+	ErrInvalidDocument = errors.New("invalid document")
+
+	identifierRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// WithIgnoredPaths excludes values at given JSON paths, and everything below them, from the comparison
+func WithIgnoredPaths(paths ...string) Option {
+	return func(o *options) {
+		for _, p := range paths {
+			o.ignore[p] = true
+		}
+	}
+}
+
+// JSON compares two JSON documents
+func JSON(from, to []byte, opts ...Option) ([]Change, error) {
+	a, err := decode(from)
+	if err != nil {
+		return nil, fmt.Errorf("%w: from: %s", ErrInvalidDocument, err)
+	}
+	b, err := decode(to)
+	if err != nil {
+		return nil, fmt.Errorf("%w: to: %s", ErrInvalidDocument, err)
+	}
+	return compare(a, b, opts), nil
+}
+
+// Values compares two values, e.g. SDK structs, using their JSON representation
+func Values(from, to interface{}, opts ...Option) ([]Change, error) {
+	a, err := normalize(from)
+	if err != nil {
+		return nil, fmt.Errorf("%w: from: %s", ErrInvalidDocument, err)
+	}
+	b, err := normalize(to)
+	if err != nil {
+		return nil, fmt.Errorf("%w: to: %s", ErrInvalidDocument, err)
+	}
+	return compare(a, b, opts), nil
+}
+
+// String returns a single line description of the change
+func (c Change) String() string {
+	switch c.Type {
+	case Added:
+		return fmt.Sprintf("+ %s: %s", c.Path, encode(c.To))
+	case Removed:
+		return fmt.Sprintf("- %s: %s", c.Path, encode(c.From))
+	}
+	return fmt.Sprintf("~ %s: %s -> %s", c.Path, encode(c.From), encode(c.To))
+}
+
+func normalize(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return decode(data)
+}
+
+func decode(data []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func encode(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+func compare(a, b interface{}, opts []Option) []Change {
+	o := options{ignore: make(map[string]bool)}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	var changes []Change
+	o.walk("$", a, b, &changes)
+	return changes
+}
+
+func (o options) walk(path string, a, b interface{}, changes *[]Change) {
+	if o.ignore[path] {
+		return
+	}
+
+	switch av := a.(type) {
+	case map[string]interface{}:
+		bv, ok := b.(map[string]interface{})
+		if !ok {
+			break
+		}
+		for _, key := range unionKeys(av, bv) {
+			p := childPath(path, key)
+			if o.ignore[p] {
+				continue
+			}
+			aval, inA := av[key]
+			bval, inB := bv[key]
+			switch {
+			case !inB:
+				*changes = append(*changes, Change{Path: p, Type: Removed, From: aval})
+			case !inA:
+				*changes = append(*changes, Change{Path: p, Type: Added, To: bval})
+			default:
+				o.walk(p, aval, bval, changes)
+			}
+		}
+		return
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok {
+			break
+		}
+		for i := 0; i < len(av) || i < len(bv); i++ {
+			p := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= len(bv):
+				if !o.ignore[p] {
+					*changes = append(*changes, Change{Path: p, Type: Removed, From: av[i]})
+				}
+			case i >= len(av):
+				if !o.ignore[p] {
+					*changes = append(*changes, Change{Path: p, Type: Added, To: bv[i]})
+				}
+			default:
+				o.walk(p, av[i], bv[i], changes)
+			}
+		}
+		return
+	}
+
+	if !equal(a, b) {
+		*changes = append(*changes, Change{Path: path, Type: Modified, From: a, To: b})
+	}
+}
+
+func equal(a, b interface{}) bool {
+	an, aok := a.(json.Number)
+	bn, bok := b.(json.Number)
+	if aok && bok {
+		// compare numerically so that e.g. 1.0 equals 1
+		af, aerr := an.Float64()
+		bf, berr := bn.Float64()
+		if aerr == nil && berr == nil {
+			return af == bf
+		}
+		return an == bn
+	}
+	return encode(a) == encode(b)
+}
+
+func unionKeys(a, b map[string]interface{}) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func childPath(path, key string) string {
+	if identifierRegexp.MatchString(key) {
+		return path + "." + key
+	}
+	return fmt.Sprintf("%s[%q]", path, key)
+}
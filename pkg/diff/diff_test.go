@@ -0,0 +1,95 @@
+package diff
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSON(t *testing.T) {
+	tests := map[string]struct {
+		from      string
+		to        string
+		opts      []Option
+		expected  []string
+		withError error
+	}{
+		"equal documents": {
+			from: `{"a": 1, "b": [1, 2], "c": {"d": null}}`,
+			to:   `{"c": {"d": null}, "b": [1, 2], "a": 1.0}`,
+		},
+		"nested changes": {
+			from: `{"rules": {"name": "default", "children": [{"name": "a"}, {"name": "b"}], "options": {"is_secure": false}}}`,
+			to:   `{"rules": {"name": "default", "children": [{"name": "a", "comments": "x"}], "options": {"is_secure": true}}}`,
+			expected: []string{
+				`+ $.rules.children[0].comments: "x"`,
+				`- $.rules.children[1]: {"name":"b"}`,
+				`~ $.rules.options.is_secure: false -> true`,
+			},
+		},
+		"type change": {
+			from:     `{"value": [1]}`,
+			to:       `{"value": {"0": 1}}`,
+			expected: []string{`~ $.value: [1] -> {"0":1}`},
+		},
+		"keys requiring brackets": {
+			from:     `{"a b": 1}`,
+			to:       `{"a b": 2}`,
+			expected: []string{`~ $["a b"]: 1 -> 2`},
+		},
+		"ignored paths": {
+			from:     `{"etag": "1", "version": 1, "rules": {"name": "a"}}`,
+			to:       `{"etag": "2", "version": 2, "rules": {"name": "b"}}`,
+			opts:     []Option{WithIgnoredPaths("$.etag", "$.version")},
+			expected: []string{`~ $.rules.name: "a" -> "b"`},
+		},
+		"invalid document": {
+			from:      `{`,
+			to:        `{}`,
+			withError: ErrInvalidDocument,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			changes, err := JSON([]byte(test.from), []byte(test.to), test.opts...)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			var result []string
+			for _, c := range changes {
+				result = append(result, c.String())
+			}
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestValues(t *testing.T) {
+	type item struct {
+		Name  string `json:"name"`
+		Count int    `json:"count,omitempty"`
+	}
+	type doc struct {
+		ID    int64  `json:"id"`
+		Items []item `json:"items"`
+	}
+
+	changes, err := Values(
+		doc{ID: 1, Items: []item{{Name: "a"}}},
+		doc{ID: 1, Items: []item{{Name: "a", Count: 3}, {Name: "b"}}},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []Change{
+		{Path: "$.items[0].count", Type: Added, To: json.Number("3")},
+		{Path: "$.items[1]", Type: Added, To: map[string]interface{}{"name": "b"}},
+	}, changes)
+
+	_, err = Values(make(chan int), doc{})
+	assert.True(t, errors.Is(err, ErrInvalidDocument))
+}
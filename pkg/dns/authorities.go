@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
 )
 
 type (
@@ -51,7 +52,7 @@ func (p *dns) GetAuthorities(ctx context.Context, contractID string) (*Authority
 		return nil, fmt.Errorf("%w: GetAuthorities reqs valid contractId", ErrBadRequest)
 	}
 
-	getURL := fmt.Sprintf("/config-dns/v2/data/authorities?contractIds=%s", contractID)
+	getURL := "/config-dns/v2/data/authorities?" + url.Values{"contractIds": {contractID}}.Encode()
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, getURL, nil)
 	if err != nil {
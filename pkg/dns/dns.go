@@ -8,6 +8,7 @@ import (
 	"net/http"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/session"
+	"github.com/apex/log"
 )
 
 var (
@@ -27,6 +28,7 @@ type (
 
 	dns struct {
 		session.Session
+		options session.ClientOptions
 	}
 
 	// Option defines a DNS option
@@ -45,9 +47,60 @@ func Client(sess session.Session, opts ...Option) DNS {
 	for _, opt := range opts {
 		opt(p)
 	}
+	p.Session = p.options.Apply(p.Session)
 	return p
 }
 
+// WithLogger sets the logger used by the Edge DNS client instead of the session logger
+func WithLogger(l log.Interface) Option {
+	return func(p *dns) {
+		p.options.Logger = l
+	}
+}
+
+// WithRetries sets how many times idempotent Edge DNS requests are retried after transient failures
+func WithRetries(retries int) Option {
+	return func(p *dns) {
+		p.options.Retries = retries
+	}
+}
+
+// WithRetryClassifier sets the callback deciding whether failed Edge DNS requests are retried, see session.RetryClassifier
+func WithRetryClassifier(classifier session.RetryClassifier) Option {
+	return func(p *dns) {
+		p.options.RetryClassifier = classifier
+	}
+}
+
+// WithBackoff sets the strategy computing delays between retries of failed Edge DNS requests, see session.Backoff
+func WithBackoff(backoff session.Backoff) Option {
+	return func(p *dns) {
+		p.options.Backoff = backoff
+	}
+}
+
+// WithIdempotencyKeys adds an idempotency key to Edge DNS POST and PATCH requests, kept when they are
+// retried; it does not make them retried by itself, see session.WithIdempotencyKeys
+func WithIdempotencyKeys(enabled bool) Option {
+	return func(p *dns) {
+		p.options.IdempotencyKeys = enabled
+	}
+}
+
+// WithBaseURL overrides the scheme and host Edge DNS requests are sent to
+func WithBaseURL(baseURL string) Option {
+	return func(p *dns) {
+		p.options.BaseURL = baseURL
+	}
+}
+
+// WithAccountSwitchKey makes Edge DNS requests act on the account with given switch key
+func WithAccountSwitchKey(key string) Option {
+	return func(p *dns) {
+		p.options.AccountSwitchKey = key
+	}
+}
+
 // Exec overrides the session.Exec to add dns options
 func (p *dns) Exec(r *http.Request, out interface{}, in ...interface{}) (*http.Response, error) {
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
 
 	"encoding/hex"
 	"net"
@@ -84,7 +85,8 @@ func (p *dns) GetRecordList(ctx context.Context, zone string, _ string, recordTy
 	logger.Debug("GetRecordList")
 
 	var records RecordSetResponse
-	getURL := fmt.Sprintf("/config-dns/v2/zones/%s/recordsets?types=%s&showAll=true", zone, recordType)
+	query := url.Values{"types": {recordType}, "showAll": {"true"}}
+	getURL := fmt.Sprintf("/config-dns/v2/zones/%s/recordsets?%s", zone, query.Encode())
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, getURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create GetRecordList request: %w", err)
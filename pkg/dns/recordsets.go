@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/session"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 
 	"strconv"
@@ -229,3 +230,17 @@ func (p *dns) UpdateRecordsets(ctx context.Context, recordsets *Recordsets, zone
 
 	return nil
 }
+
+// NewRecordsetsPager returns a pager listing zone recordsets page by page with GetRecordsets, starting from the first page.
+// The Page field of queryArgs is ignored.
+func NewRecordsetsPager(client RecordSets, zone string, queryArgs RecordsetQueryArgs) session.Pager[Recordset] {
+	return session.NewPager(func(ctx context.Context, page int) ([]Recordset, bool, error) {
+		args := queryArgs
+		args.Page = page + 1
+		resp, err := client.GetRecordsets(ctx, zone, args)
+		if err != nil {
+			return nil, false, err
+		}
+		return resp.Recordsets, resp.Metadata.Page < resp.Metadata.LastPage, nil
+	})
+}
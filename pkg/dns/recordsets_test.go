@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -274,3 +275,19 @@ func TestDns_UpdateRecordsets(t *testing.T) {
 		})
 	}
 }
+
+func TestDns_NewRecordsetsPager(t *testing.T) {
+	client := &Mock{}
+	client.On("GetRecordsets", mock.Anything, "example.com", []RecordsetQueryArgs{{Page: 1, PageSize: 1}}).Return(&RecordSetResponse{
+		Metadata:   MetadataH{Page: 1, PageSize: 1, LastPage: 2},
+		Recordsets: []Recordset{{Name: "www.example.com", Type: "A"}},
+	}, nil).Once()
+	client.On("GetRecordsets", mock.Anything, "example.com", []RecordsetQueryArgs{{Page: 2, PageSize: 1}}).Return(nil, errors.New("oops")).Once()
+
+	pager := NewRecordsetsPager(client, "example.com", RecordsetQueryArgs{PageSize: 1})
+	require.True(t, pager.Next(context.Background()))
+	assert.Equal(t, []Recordset{{Name: "www.example.com", Type: "A"}}, pager.Page())
+	assert.False(t, pager.Next(context.Background()))
+	assert.EqualError(t, pager.Err(), "oops")
+	client.AssertExpectations(t)
+}
@@ -6,6 +6,7 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 
 	"bytes"
 	"encoding/json"
@@ -13,6 +14,8 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/session"
 )
 
 var (
@@ -501,7 +504,7 @@ func (p *dns) SaveChangelist(ctx context.Context, zone *ZoneCreate) error {
 		return fmt.Errorf("failed to generate request body: %w", err)
 	}
 
-	postURL := fmt.Sprintf("/config-dns/v2/changelists/?zone=%s", zone.Zone)
+	postURL := "/config-dns/v2/changelists/?" + url.Values{"zone": {zone.Zone}}.Encode()
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, postURL, reqbody)
 	if err != nil {
 		return fmt.Errorf("failed to create SaveChangeList request: %w", err)
@@ -762,3 +765,20 @@ func (p *dns) GetZoneNameTypes(ctx context.Context, zname string, zone string) (
 
 	return &zntypes, nil
 }
+
+// NewZonesPager returns a pager listing zones page by page with ListZones, starting from the first page.
+// The Page field of queryArgs is ignored.
+func NewZonesPager(client Zones, queryArgs ZoneListQueryArgs) session.Pager[*ZoneResponse] {
+	return session.NewPager(func(ctx context.Context, page int) ([]*ZoneResponse, bool, error) {
+		args := queryArgs
+		args.Page = page + 1
+		resp, err := client.ListZones(ctx, args)
+		if err != nil {
+			return nil, false, err
+		}
+		if resp.Metadata == nil || resp.Metadata.ShowAll || resp.Metadata.PageSize == 0 {
+			return resp.Zones, false, nil
+		}
+		return resp.Zones, resp.Metadata.Page*resp.Metadata.PageSize < resp.Metadata.TotalElements, nil
+	})
+}
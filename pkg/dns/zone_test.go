@@ -9,6 +9,7 @@ import (
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/session"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -1099,3 +1100,20 @@ func Test_ValidateZoneErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestDns_NewZonesPager(t *testing.T) {
+	client := &Mock{}
+	client.On("ListZones", mock.Anything, ZoneListQueryArgs{Page: 1, PageSize: 2}).Return(&ZoneListResponse{
+		Metadata: &ListMetadata{Page: 1, PageSize: 2, TotalElements: 3},
+		Zones:    []*ZoneResponse{{Zone: "a.com"}, {Zone: "b.com"}},
+	}, nil).Once()
+	client.On("ListZones", mock.Anything, ZoneListQueryArgs{Page: 2, PageSize: 2}).Return(&ZoneListResponse{
+		Metadata: &ListMetadata{Page: 2, PageSize: 2, TotalElements: 3},
+		Zones:    []*ZoneResponse{{Zone: "c.com"}},
+	}, nil).Once()
+
+	zones, err := session.CollectPages(context.Background(), NewZonesPager(client, ZoneListQueryArgs{Page: 5, PageSize: 2}))
+	require.NoError(t, err)
+	assert.Equal(t, []*ZoneResponse{{Zone: "a.com"}, {Zone: "b.com"}, {Zone: "c.com"}}, zones)
+	client.AssertExpectations(t)
+}
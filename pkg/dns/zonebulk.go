@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/tools"
 )
 
 // BulkZonesCreate contains a list of one or more new Zones to create
@@ -204,3 +206,30 @@ func (p *dns) DeleteBulkZones(ctx context.Context, zoneslist *ZoneNameListRespon
 
 	return &status, nil
 }
+
+// WatchBulkZoneCreate polls GetBulkZoneCreateStatus until the bulk-create request is complete, delivering
+// the number of processed zones after every check on the returned channel, see tools.Watch.
+func WatchBulkZoneCreate(ctx context.Context, client Zones, requestID string, opts tools.WaitOptions) <-chan tools.Event {
+	return tools.Watch(ctx, bulkStatusCheck(func(ctx context.Context) (*BulkStatusResponse, error) {
+		return client.GetBulkZoneCreateStatus(ctx, requestID)
+	}), opts)
+}
+
+// WatchBulkZoneDelete polls GetBulkZoneDeleteStatus until the bulk-delete request is complete, delivering
+// the number of processed zones after every check on the returned channel, see tools.Watch.
+func WatchBulkZoneDelete(ctx context.Context, client Zones, requestID string, opts tools.WaitOptions) <-chan tools.Event {
+	return tools.Watch(ctx, bulkStatusCheck(func(ctx context.Context) (*BulkStatusResponse, error) {
+		return client.GetBulkZoneDeleteStatus(ctx, requestID)
+	}), opts)
+}
+
+func bulkStatusCheck(getStatus func(context.Context) (*BulkStatusResponse, error)) tools.CheckFunc {
+	return func(ctx context.Context) (string, bool, error) {
+		status, err := getStatus(ctx)
+		if err != nil {
+			return "", false, err
+		}
+		processed := status.SuccessCount + status.FailureCount
+		return fmt.Sprintf("%d of %d zones processed, %d failed", processed, status.ZonesSubmitted, status.FailureCount), status.IsComplete, nil
+	}
+}
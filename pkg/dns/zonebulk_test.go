@@ -6,8 +6,11 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/tools"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -477,3 +480,23 @@ func TestDns_DeleteBulkZones(t *testing.T) {
 		})
 	}
 }
+
+func TestWatchBulkZoneCreate(t *testing.T) {
+	opts := tools.WaitOptions{Interval: time.Millisecond, Jitter: -1}
+
+	client := &Mock{}
+	client.On("GetBulkZoneCreateStatus", mock.Anything, "15bc138f-8d82-451b-80b7-a56b88ffc474").
+		Return(&BulkStatusResponse{ZonesSubmitted: 3, SuccessCount: 1}, nil).Once()
+	client.On("GetBulkZoneCreateStatus", mock.Anything, "15bc138f-8d82-451b-80b7-a56b88ffc474").
+		Return(&BulkStatusResponse{ZonesSubmitted: 3, SuccessCount: 2, FailureCount: 1, IsComplete: true}, nil).Once()
+
+	var events []tools.Event
+	for event := range WatchBulkZoneCreate(context.Background(), client, "15bc138f-8d82-451b-80b7-a56b88ffc474", opts) {
+		events = append(events, event)
+	}
+	require.Len(t, events, 2)
+	assert.Equal(t, "1 of 3 zones processed, 0 failed", events[0].Status)
+	assert.Equal(t, "3 of 3 zones processed, 1 failed", events[1].Status)
+	assert.True(t, events[1].Done)
+	client.AssertExpectations(t)
+}
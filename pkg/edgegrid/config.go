@@ -4,6 +4,8 @@ package edgegrid
 import (
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
 	"strconv"
 	"strings"
@@ -22,6 +24,9 @@ const (
 
 	// MaxBodySize is the max payload size for client requests
 	MaxBodySize = 131072
+
+	// DefaultEnvPrefix is the default prefix of the environment variables read by FromEnv
+	DefaultEnvPrefix = "AKAMAI"
 )
 
 var (
@@ -39,7 +44,9 @@ var (
 
 type (
 	// Config struct provides all the necessary fields to
-	// create authorization header, debug is optional
+	// create authorization header, debug is optional.
+	// AccountKey, if set, is added as accountSwitchKey query parameter to every signed request
+	// which does not set this parameter itself.
 	Config struct {
 		Host         string   `ini:"host"`
 		ClientToken  string   `ini:"client_token"`
@@ -52,8 +59,16 @@ type (
 		Debug        bool     `ini:"debug"`
 
 		file    string
+		reader  io.Reader
 		section string
 		env     bool
+		values  *Config
+		// headersToSign are added to HeaderToSign by New, see WithHeadersToSign
+		headersToSign []string
+		requireFIPS   bool
+		debugHook     func(SignatureDebug)
+		decrypter     Decrypter
+		envPrefix     string
 	}
 
 	// Option defines a configuration option
@@ -71,19 +86,33 @@ func New(opts ...Option) (*Config, error) {
 		opt(c)
 	}
 
+	if c.requireFIPS && !FIPSEnabled() {
+		return nil, ErrFIPSNotEnabled
+	}
+
 	if c.env {
 		if err := c.FromEnv(c.section); err == nil {
+			c.HeaderToSign = appendHeaders(c.HeaderToSign, c.headersToSign...)
 			return c, nil
 		} else if !errors.Is(err, ErrRequiredOptionEnv) {
 			return nil, err
 		}
 	}
 
+	if c.reader != nil {
+		if err := c.FromReader(c.reader, c.section); err != nil {
+			return c, fmt.Errorf("unable to load config from environment or reader: %w", err)
+		}
+		c.HeaderToSign = appendHeaders(c.HeaderToSign, c.headersToSign...)
+		return c, nil
+	}
+
 	if c.file != "" {
 		if err := c.FromFile(c.file, c.section); err != nil {
 			return c, fmt.Errorf("unable to load config from environment or .edgerc file: %w", err)
 		}
 	}
+	c.HeaderToSign = appendHeaders(c.HeaderToSign, c.headersToSign...)
 
 	return c, nil
 }
@@ -96,6 +125,15 @@ func Must(config *Config, err error) *Config {
 	return config
 }
 
+// WithEnvPrefix sets the prefix of the environment variables read by FromEnv instead of DefaultEnvPrefix,
+// e.g. MYAPP_AKAMAI to read MYAPP_AKAMAI_HOST, so that several products embedding the SDK in one process
+// do not collide. A trailing underscore is ignored.
+func WithEnvPrefix(prefix string) Option {
+	return func(c *Config) {
+		c.envPrefix = strings.TrimSuffix(prefix, "_")
+	}
+}
+
 // WithFile sets the config file path
 func WithFile(file string) Option {
 	return func(c *Config) {
@@ -103,6 +141,31 @@ func WithFile(file string) Option {
 	}
 }
 
+// WithReader sets the reader the configuration is loaded from in standard INI format, instead of the config file
+func WithReader(r io.Reader) Option {
+	return func(c *Config) {
+		c.reader = r
+	}
+}
+
+// WithHeadersToSign adds headers to sign to the ones loaded from the headers_to_sign option of the .edgerc section
+// or the AKAMAI_HEADERS_TO_SIGN environment variable, so that custom headers required by specific endpoints
+// are covered by the signature. Header names are matched case-insensitively.
+func WithHeadersToSign(headers ...string) Option {
+	return func(c *Config) {
+		c.headersToSign = append(c.headersToSign, headers...)
+	}
+}
+
+// WithSignatureDebug calls hook with the data every request is signed with, e.g. to log the canonical request
+// when debugging "signature does not match" errors. The data does not contain the client secret,
+// but the logged values should still be handled with care.
+func WithSignatureDebug(hook func(SignatureDebug)) Option {
+	return func(c *Config) {
+		c.debugHook = hook
+	}
+}
+
 // WithSection sets the section in the config
 func WithSection(section string) Option {
 	return func(c *Config) {
@@ -118,22 +181,46 @@ func WithEnv(env bool) Option {
 	}
 }
 
-// FromFile creates a config the configuration in standard INI format
+// FromFile creates a config the configuration in standard INI format.
+// Files with .json, .yaml or .yml extension are loaded with FromJSON or FromYAML instead.
+// Files are decrypted first if a decrypter was set with WithDecrypter.
 func (c *Config) FromFile(file string, section string) error {
-	var (
-		requiredOptions = []string{"host", "client_token", "client_secret", "access_token"}
-	)
-
 	path, err := homedir.Expand(file)
 	if err != nil {
 		return fmt.Errorf("invalid path: %w", err)
 	}
 
+	if c.decrypter != nil {
+		return c.fromEncryptedFile(path, section)
+	}
+	if ok, err := c.fromFormat(path, section); ok {
+		return err
+	}
+
 	edgerc, err := ini.Load(path)
 	if err != nil {
 		return fmt.Errorf("%w: %s", ErrLoadingFile, err)
 	}
 
+	return c.fromINI(edgerc, section)
+}
+
+// FromReader creates a config from the configuration in standard INI format read from r,
+// e.g. embedded in the binary or held in memory, without accessing the file system
+func (c *Config) FromReader(r io.Reader, section string) error {
+	edgerc, err := ini.Load(ioutil.NopCloser(r))
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrLoadingFile, err)
+	}
+
+	return c.fromINI(edgerc, section)
+}
+
+func (c *Config) fromINI(edgerc *ini.File, section string) error {
+	var (
+		requiredOptions = []string{"host", "client_token", "client_secret", "access_token"}
+	)
+
 	sec, err := edgerc.GetSection(section)
 	if err != nil {
 		return fmt.Errorf("%w: %s", ErrSectionDoesNotExist, err)
@@ -160,23 +247,22 @@ func (c *Config) FromFile(file string, section string) error {
 // FromEnv creates a new config using the Environment (ENV)
 //
 // By default, it uses AKAMAI_HOST, AKAMAI_CLIENT_TOKEN, AKAMAI_CLIENT_SECRET,
-// AKAMAI_ACCESS_TOKEN and AKAMAI_MAX_BODY variables.
+// AKAMAI_ACCESS_TOKEN, AKAMAI_MAX_BODY, AKAMAI_ACCOUNT_KEY and AKAMAI_HEADERS_TO_SIGN variables,
+// the latter holding a comma-separated list of header names.
 //
 // You can define multiple configurations by prefixing with the section name specified, e.g.
 // passing "ccu" will cause it to look for AKAMAI_CCU_HOST, etc.
 //
 // If AKAMAI_{SECTION} does not exist, it will fall back to just AKAMAI_.
+//
+// The AKAMAI prefix can be replaced with WithEnvPrefix.
 func (c *Config) FromEnv(section string) error {
 	var (
 		requiredOptions = []string{"HOST", "CLIENT_TOKEN", "CLIENT_SECRET", "ACCESS_TOKEN"}
 		prefix          string
 	)
 
-	prefix = "AKAMAI"
-
-	if section != DefaultSection {
-		prefix = "AKAMAI_" + strings.ToUpper(section)
-	}
+	prefix = envPrefix(c.envPrefix, section)
 
 	for _, opt := range requiredOptions {
 		optKey := fmt.Sprintf("%s_%s", prefix, opt)
@@ -211,9 +297,45 @@ func (c *Config) FromEnv(section string) error {
 		c.AccountKey = val
 	}
 
+	if val := os.Getenv(fmt.Sprintf("%s_%s", prefix, "HEADERS_TO_SIGN")); val != "" {
+		c.HeaderToSign = appendHeaders(nil, strings.Split(val, ",")...)
+	}
+
 	return nil
 }
 
+// appendHeaders appends the trimmed, non-empty header names to headers, skipping ones already present
+func appendHeaders(headers []string, names ...string) []string {
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		present := false
+		for _, h := range headers {
+			if strings.EqualFold(h, name) {
+				present = true
+				break
+			}
+		}
+		if !present {
+			headers = append(headers, name)
+		}
+	}
+	return headers
+}
+
+// envPrefix returns the prefix of the environment variables of the section, using DefaultEnvPrefix if base is empty
+func envPrefix(base, section string) string {
+	if base == "" {
+		base = DefaultEnvPrefix
+	}
+	if section != DefaultSection {
+		return base + "_" + strings.ToUpper(section)
+	}
+	return base
+}
+
 // Timestamp returns an edgegrid timestamp from the time
 func Timestamp(t time.Time) string {
 	local := time.FixedZone("GMT", 0)
@@ -221,6 +343,11 @@ func Timestamp(t time.Time) string {
 	return t.Format("20060102T15:04:05-0700")
 }
 
+// Section returns the name of the section the config was created for with New
+func (c Config) Section() string {
+	return c.section
+}
+
 // Validate verifies that the host is not ending with the slash character
 func (c *Config) Validate() error {
 	if strings.HasSuffix(c.Host, "/") {
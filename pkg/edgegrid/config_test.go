@@ -1,9 +1,11 @@
 package edgegrid
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -28,6 +30,74 @@ func TestConfig_FromFile(t *testing.T) {
 				MaxBody:      131072,
 			},
 		},
+		"valid file and section with account key": {
+			fileName: "edgerc",
+			section:  "account-key",
+			expected: Config{
+				Host:         "xxxx-xxxxxxxxxxxxxxxx-xxxxxxxxxxxxxxxx.luna.akamaiapis.net",
+				ClientToken:  "xxxx-xxxxxxxxxxxxxxxx-xxxxxxxxxxxxxxxx",
+				ClientSecret: "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx=",
+				AccessToken:  "xxxx-xxxxxxxxxxxxxxxx-xxxxxxxxxxxxxxxx",
+				AccountKey:   "1-ABCDE:1-2345",
+				MaxBody:      131072,
+			},
+		},
+		"valid yaml file and section": {
+			fileName: "edgerc.yaml",
+			section:  "test",
+			expected: Config{
+				Host:         "xxxx-xxxxxxxxxxxxxxxx-xxxxxxxxxxxxxxxx.luna.akamaiapis.net",
+				ClientToken:  "xxxx-xxxxxxxxxxxxxxxx-xxxxxxxxxxxxxxxx",
+				ClientSecret: "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx=",
+				AccessToken:  "xxxx-xxxxxxxxxxxxxxxx-xxxxxxxxxxxxxxxx",
+				AccountKey:   "1-ABCDE:1-2345",
+				MaxBody:      1024,
+			},
+		},
+		"valid json file and section": {
+			fileName: "edgerc.json",
+			section:  "test",
+			expected: Config{
+				Host:         "xxxx-xxxxxxxxxxxxxxxx-xxxxxxxxxxxxxxxx.luna.akamaiapis.net",
+				ClientToken:  "xxxx-xxxxxxxxxxxxxxxx-xxxxxxxxxxxxxxxx",
+				ClientSecret: "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx=",
+				AccessToken:  "xxxx-xxxxxxxxxxxxxxxx-xxxxxxxxxxxxxxxx",
+				HeaderToSign: []string{"X-Test"},
+				MaxBody:      131072,
+			},
+		},
+		"yaml section does not exist": {
+			fileName:  "edgerc.yaml",
+			section:   "abc",
+			withError: ErrSectionDoesNotExist,
+		},
+		"yaml missing host": {
+			fileName:  "edgerc.yaml",
+			section:   "missing-host",
+			withError: ErrRequiredOptionEdgerc,
+		},
+		"json missing access token": {
+			fileName:  "edgerc.json",
+			section:   "missing-access-token",
+			withError: ErrRequiredOptionEdgerc,
+		},
+		"json file does not exist": {
+			fileName:  "test.json",
+			section:   "test",
+			withError: ErrLoadingFile,
+		},
+		"valid file and section with headers to sign": {
+			fileName: "edgerc",
+			section:  "headers-to-sign",
+			expected: Config{
+				Host:         "xxxx-xxxxxxxxxxxxxxxx-xxxxxxxxxxxxxxxx.luna.akamaiapis.net",
+				ClientToken:  "xxxx-xxxxxxxxxxxxxxxx-xxxxxxxxxxxxxxxx",
+				ClientSecret: "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx=",
+				AccessToken:  "xxxx-xxxxxxxxxxxxxxxx-xxxxxxxxxxxxxxxx",
+				HeaderToSign: []string{"X-First", "X-Second"},
+				MaxBody:      131072,
+			},
+		},
 		"file does not exist": {
 			fileName:  "test",
 			section:   "test",
@@ -73,9 +143,104 @@ func TestConfig_FromFile(t *testing.T) {
 	}
 }
 
+func TestConfig_FromReader(t *testing.T) {
+	edgerc := `
+[default]
+host = xxxx-xxxxxxxxxxxxxxxx-xxxxxxxxxxxxxxxx.luna.akamaiapis.net
+client_token = xxxx-xxxxxxxxxxxxxxxx-xxxxxxxxxxxxxxxx
+client_secret = xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx=
+access_token = xxxx-xxxxxxxxxxxxxxxx-xxxxxxxxxxxxxxxx
+max_body = 1024
+
+[missing-host]
+client_token = xxxx-xxxxxxxxxxxxxxxx-xxxxxxxxxxxxxxxx
+client_secret = xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx=
+access_token = xxxx-xxxxxxxxxxxxxxxx-xxxxxxxxxxxxxxxx
+`
+	tests := map[string]struct {
+		data      string
+		section   string
+		expected  Config
+		withError error
+	}{
+		"valid config and section": {
+			data:    edgerc,
+			section: "default",
+			expected: Config{
+				Host:         "xxxx-xxxxxxxxxxxxxxxx-xxxxxxxxxxxxxxxx.luna.akamaiapis.net",
+				ClientToken:  "xxxx-xxxxxxxxxxxxxxxx-xxxxxxxxxxxxxxxx",
+				ClientSecret: "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx=",
+				AccessToken:  "xxxx-xxxxxxxxxxxxxxxx-xxxxxxxxxxxxxxxx",
+				MaxBody:      1024,
+			},
+		},
+		"section does not exist": {
+			data:      edgerc,
+			section:   "abc",
+			withError: ErrSectionDoesNotExist,
+		},
+		"missing host": {
+			data:      edgerc,
+			section:   "missing-host",
+			withError: ErrRequiredOptionEdgerc,
+		},
+		"invalid config": {
+			data:      "[default\nhost = test",
+			section:   "default",
+			withError: ErrLoadingFile,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := Config{}
+			err := cfg.FromReader(strings.NewReader(test.data), test.section)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %v; got: %v", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, cfg)
+		})
+	}
+
+	t.Run("new with reader", func(t *testing.T) {
+		cfg, err := New(WithReader(strings.NewReader(edgerc)), WithFile("test/does-not-exist"))
+		require.NoError(t, err)
+		assert.Equal(t, "xxxx-xxxxxxxxxxxxxxxx-xxxxxxxxxxxxxxxx.luna.akamaiapis.net", cfg.Host)
+		assert.Equal(t, DefaultSection, cfg.Section())
+	})
+}
+
+func TestConfig_FromJSONAndYAML(t *testing.T) {
+	t.Run("invalid json", func(t *testing.T) {
+		err := (&Config{}).FromJSON(strings.NewReader(`{"default":`), "default")
+		assert.True(t, errors.Is(err, ErrLoadingFile), "want: %v; got: %v", ErrLoadingFile, err)
+	})
+	t.Run("invalid yaml", func(t *testing.T) {
+		err := (&Config{}).FromYAML(strings.NewReader("default: [host"), "default")
+		assert.True(t, errors.Is(err, ErrLoadingFile), "want: %v; got: %v", ErrLoadingFile, err)
+	})
+	t.Run("empty yaml", func(t *testing.T) {
+		err := (&Config{}).FromYAML(strings.NewReader(""), "default")
+		assert.True(t, errors.Is(err, ErrLoadingFile), "want: %v; got: %v", ErrLoadingFile, err)
+	})
+	t.Run("new with yaml file", func(t *testing.T) {
+		cfg, err := New(WithFile("test/edgerc.yaml"), WithSection("test"))
+		require.NoError(t, err)
+		assert.Equal(t, "1-ABCDE:1-2345", cfg.AccountKey)
+	})
+}
+
+func TestNew_WithHeadersToSign(t *testing.T) {
+	cfg, err := New(WithFile("test/edgerc"), WithSection("headers-to-sign"), WithHeadersToSign("x-second", "X-Third"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"X-First", "X-Second", "X-Third"}, cfg.HeaderToSign)
+}
+
 func TestConfig_FromEnv(t *testing.T) {
 	tests := map[string]struct {
 		section   string
+		envPrefix string
 		envs      map[string]string
 		expected  Config
 		withError error
@@ -132,6 +297,24 @@ func TestConfig_FromEnv(t *testing.T) {
 				AccountKey:   "account-key-123",
 			},
 		},
+		"default section, valid envs, headers to sign": {
+			section: "default",
+			envs: map[string]string{
+				"AKAMAI_HOST":            "test-host",
+				"AKAMAI_CLIENT_TOKEN":    "test-client-token",
+				"AKAMAI_CLIENT_SECRET":   "test-client-secret",
+				"AKAMAI_ACCESS_TOKEN":    "test-access-token",
+				"AKAMAI_HEADERS_TO_SIGN": "X-First, X-Second,",
+			},
+			expected: Config{
+				Host:         "test-host",
+				ClientToken:  "test-client-token",
+				ClientSecret: "test-client-secret",
+				AccessToken:  "test-access-token",
+				MaxBody:      131072,
+				HeaderToSign: []string{"X-First", "X-Second"},
+			},
+		},
 		"custom section, valid envs": {
 			section: "test",
 			envs: map[string]string{
@@ -148,6 +331,54 @@ func TestConfig_FromEnv(t *testing.T) {
 				MaxBody:      131072,
 			},
 		},
+		"custom prefix, default section": {
+			section:   "default",
+			envPrefix: "MYAPP_AKAMAI",
+			envs: map[string]string{
+				"MYAPP_AKAMAI_HOST":          "test-host",
+				"MYAPP_AKAMAI_CLIENT_TOKEN":  "test-client-token",
+				"MYAPP_AKAMAI_CLIENT_SECRET": "test-client-secret",
+				"MYAPP_AKAMAI_ACCESS_TOKEN":  "test-access-token",
+				"AKAMAI_HOST":                "other-host",
+			},
+			expected: Config{
+				Host:         "test-host",
+				ClientToken:  "test-client-token",
+				ClientSecret: "test-client-secret",
+				AccessToken:  "test-access-token",
+				MaxBody:      131072,
+				envPrefix:    "MYAPP_AKAMAI",
+			},
+		},
+		"custom prefix, custom section": {
+			section:   "test",
+			envPrefix: "MYAPP_AKAMAI",
+			envs: map[string]string{
+				"MYAPP_AKAMAI_TEST_HOST":          "test-host",
+				"MYAPP_AKAMAI_TEST_CLIENT_TOKEN":  "test-client-token",
+				"MYAPP_AKAMAI_TEST_CLIENT_SECRET": "test-client-secret",
+				"MYAPP_AKAMAI_TEST_ACCESS_TOKEN":  "test-access-token",
+			},
+			expected: Config{
+				Host:         "test-host",
+				ClientToken:  "test-client-token",
+				ClientSecret: "test-client-secret",
+				AccessToken:  "test-access-token",
+				MaxBody:      131072,
+				envPrefix:    "MYAPP_AKAMAI",
+			},
+		},
+		"custom prefix, default variables ignored": {
+			section:   "default",
+			envPrefix: "MYAPP_AKAMAI",
+			envs: map[string]string{
+				"AKAMAI_HOST":          "test-host",
+				"AKAMAI_CLIENT_TOKEN":  "test-client-token",
+				"AKAMAI_CLIENT_SECRET": "test-client-secret",
+				"AKAMAI_ACCESS_TOKEN":  "test-access-token",
+			},
+			withError: ErrRequiredOptionEnv,
+		},
 		"custom section, missing host": {
 			section: "test",
 			envs: map[string]string{
@@ -195,7 +426,7 @@ func TestConfig_FromEnv(t *testing.T) {
 					require.NoError(t, os.Unsetenv(k))
 				}
 			}()
-			cfg := Config{}
+			cfg := Config{envPrefix: test.envPrefix}
 			err := cfg.FromEnv(test.section)
 			if test.withError != nil {
 				assert.True(t, errors.Is(err, test.withError), "want: %v; got: %v", test.withError, err)
@@ -207,6 +438,26 @@ func TestConfig_FromEnv(t *testing.T) {
 	}
 }
 
+func TestNew_WithEnvPrefix(t *testing.T) {
+	envs := map[string]string{
+		"MYAPP_AKAMAI_HOST":          "test-host",
+		"MYAPP_AKAMAI_CLIENT_TOKEN":  "test-client-token",
+		"MYAPP_AKAMAI_CLIENT_SECRET": "test-client-secret",
+		"MYAPP_AKAMAI_ACCESS_TOKEN":  "test-access-token",
+	}
+	for k, v := range envs {
+		t.Setenv(k, v)
+	}
+
+	cfg, err := New(WithEnv(true), WithEnvPrefix("MYAPP_AKAMAI_"), WithFile("test/does-not-exist"))
+	require.NoError(t, err)
+	assert.Equal(t, "test-host", cfg.Host)
+
+	provided, err := NewEnvProvider(DefaultSection, WithProviderEnvPrefix("MYAPP_AKAMAI")).Provide(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "test-access-token", provided.AccessToken)
+}
+
 func TestConfig_Validate(t *testing.T) {
 	tests := map[string]struct {
 		fileName        string
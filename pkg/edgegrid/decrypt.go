@@ -0,0 +1,85 @@
+package edgegrid
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"gopkg.in/ini.v1"
+)
+
+type (
+	// Decrypter decrypts the content of an encrypted config file, e.g. with age, GPG or a KMS, see WithDecrypter
+	Decrypter interface {
+		Decrypt(encrypted []byte) ([]byte, error)
+	}
+
+	// DecrypterFunc is a function implementing Decrypter
+	DecrypterFunc func(encrypted []byte) ([]byte, error)
+)
+
+// ErrDecryptingFile is returned when the decrypter fails to decrypt the config file
+var ErrDecryptingFile = errors.New("decrypting config file")
+
+// Decrypt calls f(encrypted)
+func (f DecrypterFunc) Decrypt(encrypted []byte) ([]byte, error) {
+	return f(encrypted)
+}
+
+// WithDecrypter makes New, FromFile and ResolveConfig decrypt the config file with d before parsing it, so that
+// encrypted credential files can be committed. The format of the decrypted content is detected from the file extension
+// preceding the one of the encrypted file, e.g. credentials.yaml.age is parsed as YAML, and .edgerc.gpg as INI.
+func WithDecrypter(d Decrypter) Option {
+	return func(c *Config) {
+		c.decrypter = d
+	}
+}
+
+// fromEncryptedFile loads the config from the file at path, decrypted with the decrypter of the config
+func (c *Config) fromEncryptedFile(path, section string) error {
+	data, formatPath, err := c.readConfigFile(path)
+	if err != nil {
+		return err
+	}
+
+	if decode := sectionsDecoder(formatPath); decode != nil {
+		sections, err := decode(bytes.NewReader(data))
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrLoadingFile, err)
+		}
+		return c.fromSections(sections, section)
+	}
+
+	edgerc, err := ini.Load(data)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrLoadingFile, err)
+	}
+	return c.fromINI(edgerc, section)
+}
+
+// readConfigFile returns the content of the file at path, decrypted with the decrypter of the config if one is set,
+// and the path its format is to be detected from
+func (c *Config) readConfigFile(path string) ([]byte, string, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %s", ErrLoadingFile, err)
+	}
+	if c.decrypter == nil {
+		return data, path, nil
+	}
+	data, err = c.decrypter.Decrypt(data)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %s", ErrDecryptingFile, err)
+	}
+	return data, decryptedPath(path), nil
+}
+
+// decryptedPath returns path without the extension of the encrypted file, unless it already denotes a config format
+func decryptedPath(path string) string {
+	if sectionsDecoder(path) != nil {
+		return path
+	}
+	return path[:len(path)-len(filepath.Ext(path))]
+}
@@ -0,0 +1,81 @@
+package edgegrid
+
+import (
+	"encoding/base64"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestWithDecrypter(t *testing.T) {
+	base64Decrypter := DecrypterFunc(func(encrypted []byte) ([]byte, error) {
+		return base64.StdEncoding.DecodeString(string(encrypted))
+	})
+	failingDecrypter := DecrypterFunc(func([]byte) ([]byte, error) {
+		return nil, errors.New("wrong key")
+	})
+
+	tests := map[string]struct {
+		source        string
+		fileName      string
+		decrypter     Decrypter
+		expectedHost  string
+		expectedError error
+	}{
+		"encrypted edgerc": {
+			source:       "test/edgerc",
+			fileName:     "edgerc.enc",
+			decrypter:    base64Decrypter,
+			expectedHost: "xxxx-xxxxxxxxxxxxxxxx-xxxxxxxxxxxxxxxx.luna.akamaiapis.net",
+		},
+		"encrypted yaml file": {
+			source:       "test/edgerc.yaml",
+			fileName:     "edgerc.yaml.age",
+			decrypter:    base64Decrypter,
+			expectedHost: "xxxx-xxxxxxxxxxxxxxxx-xxxxxxxxxxxxxxxx.luna.akamaiapis.net",
+		},
+		"encrypted json file": {
+			source:       "test/edgerc.json",
+			fileName:     "edgerc.json.gpg",
+			decrypter:    base64Decrypter,
+			expectedHost: "xxxx-xxxxxxxxxxxxxxxx-xxxxxxxxxxxxxxxx.luna.akamaiapis.net",
+		},
+		"decryption error": {
+			source:        "test/edgerc",
+			fileName:      "edgerc.enc",
+			decrypter:     failingDecrypter,
+			expectedError: ErrDecryptingFile,
+		},
+		"invalid decrypted content": {
+			source:        "test/edgerc.json",
+			fileName:      "edgerc.json",
+			decrypter:     DecrypterFunc(func([]byte) ([]byte, error) { return []byte("{"), nil }),
+			expectedError: ErrLoadingFile,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			content, err := ioutil.ReadFile(test.source)
+			require.NoError(t, err)
+			path := filepath.Join(t.TempDir(), test.fileName)
+			require.NoError(t, ioutil.WriteFile(path, []byte(base64.StdEncoding.EncodeToString(content)), 0600))
+
+			for loader, load := range map[string]func(...Option) (*Config, error){"New": New, "ResolveConfig": ResolveConfig} {
+				t.Run(loader, func(t *testing.T) {
+					cfg, err := load(WithFile(path), WithSection("test"), WithDecrypter(test.decrypter))
+					if test.expectedError != nil {
+						assert.True(t, errors.Is(err, test.expectedError), "want: %v; got: %v", test.expectedError, err)
+						return
+					}
+					require.NoError(t, err)
+					assert.Equal(t, test.expectedHost, cfg.Host)
+				})
+			}
+		})
+	}
+}
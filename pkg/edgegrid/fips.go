@@ -0,0 +1,22 @@
+package edgegrid
+
+import "errors"
+
+// ErrFIPSNotEnabled is returned by New and ResolveConfig when FIPS mode is required with WithFIPS, but the binary
+// does not run with a FIPS-validated cryptographic module
+var ErrFIPSNotEnabled = errors.New("FIPS mode is not enabled")
+
+// FIPSEnabled reports whether the hashing and HMAC used to sign requests run in a FIPS-validated cryptographic module:
+// the Go Cryptographic Module in FIPS 140-3 mode (Go 1.24 and later, e.g. with GODEBUG=fips140=on)
+// or BoringCrypto (binaries built with GOEXPERIMENT=boringcrypto)
+func FIPSEnabled() bool {
+	return fipsEnabled()
+}
+
+// WithFIPS makes New and ResolveConfig fail with ErrFIPSNotEnabled unless FIPSEnabled reports true,
+// so that binaries required to sign requests with a FIPS-validated module cannot silently run without one
+func WithFIPS(required bool) Option {
+	return func(c *Config) {
+		c.requireFIPS = required
+	}
+}
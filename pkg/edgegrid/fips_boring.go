@@ -0,0 +1,9 @@
+//go:build boringcrypto
+
+package edgegrid
+
+import "crypto/boring"
+
+func fipsEnabled() bool {
+	return boring.Enabled()
+}
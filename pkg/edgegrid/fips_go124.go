@@ -0,0 +1,9 @@
+//go:build go1.24 && !boringcrypto
+
+package edgegrid
+
+import "crypto/fips140"
+
+func fipsEnabled() bool {
+	return fips140.Enabled()
+}
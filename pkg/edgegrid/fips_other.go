@@ -0,0 +1,7 @@
+//go:build !go1.24 && !boringcrypto
+
+package edgegrid
+
+func fipsEnabled() bool {
+	return false
+}
@@ -0,0 +1,53 @@
+package edgegrid
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+// TestSignatureVectors checks the hashing and HMAC used for signing against known answers,
+// so that builds using a FIPS-validated module, e.g. run with GODEBUG=fips140=on, produce the same signatures
+func TestSignatureVectors(t *testing.T) {
+	t.Run("HMAC-SHA256, RFC 4231 test case 2", func(t *testing.T) {
+		assert.Equal(t, "W9zBRr9gdU5qBCQmCJV1x1oAPwidJzmDnexYuWTsOEM=",
+			createSignature([]byte("what do ya want for nothing?"), "Jefe"))
+	})
+	t.Run("signing key", func(t *testing.T) {
+		assert.Equal(t, "BHJ6rAywLDjug9CIwFaWP2pL5tkYGw49K0z7QwaCWPc=",
+			createSignature([]byte("20230405T06:07:08+0000"), "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx="))
+	})
+	t.Run("content hash", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, "https://akamai.com/test", strings.NewReader("abc"))
+		require.NoError(t, err)
+		assert.Equal(t, "ungWv48Bz+pBQUDeXa4iI7ADYaOWF3qctBD/YfIAFa0=", createContentHash(req, MaxBodySize))
+	})
+}
+
+func TestNew_WithFIPS(t *testing.T) {
+	_, err := New(WithFile("test/edgerc"), WithSection("test"), WithFIPS(true))
+	if FIPSEnabled() {
+		require.NoError(t, err)
+		return
+	}
+	assert.True(t, errors.Is(err, ErrFIPSNotEnabled), "want: %v; got: %v", ErrFIPSNotEnabled, err)
+
+	_, err = New(WithFile("test/edgerc"), WithSection("test"), WithFIPS(false))
+	require.NoError(t, err)
+}
+
+func TestResolveConfig_WithFIPS(t *testing.T) {
+	_, err := ResolveConfig(WithFile("test/edgerc"), WithSection("test"), WithFIPS(true))
+	if FIPSEnabled() {
+		require.NoError(t, err)
+		return
+	}
+	assert.True(t, errors.Is(err, ErrFIPSNotEnabled), "want: %v; got: %v", ErrFIPSNotEnabled, err)
+
+	_, err = ResolveConfig(WithFile("test/edgerc"), WithSection("test"), WithFIPS(false))
+	require.NoError(t, err)
+}
@@ -0,0 +1,142 @@
+package edgegrid
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type (
+	// fileSection is a section of a YAML or JSON configuration file, using the same keys as .edgerc
+	fileSection struct {
+		Host         string   `json:"host" yaml:"host"`
+		ClientToken  string   `json:"client_token" yaml:"client_token"`
+		ClientSecret string   `json:"client_secret" yaml:"client_secret"`
+		AccessToken  string   `json:"access_token" yaml:"access_token"`
+		AccountKey   string   `json:"account_key" yaml:"account_key"`
+		HeaderToSign []string `json:"headers_to_sign" yaml:"headers_to_sign"`
+		MaxBody      int      `json:"max_body" yaml:"max_body"`
+		RequestLimit int      `json:"request_limit" yaml:"request_limit"`
+		Debug        bool     `json:"debug" yaml:"debug"`
+	}
+)
+
+// FromJSON creates a config from the configuration in JSON format read from r.
+// The document maps section names to objects with the same keys as the .edgerc file, e.g.
+//
+//	{"default": {"host": "...", "client_token": "...", "client_secret": "...", "access_token": "..."}}
+func (c *Config) FromJSON(r io.Reader, section string) error {
+	sections, err := decodeJSON(r)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrLoadingFile, err)
+	}
+
+	return c.fromSections(sections, section)
+}
+
+// FromYAML creates a config from the configuration in YAML format read from r.
+// The document maps section names to mappings with the same keys as the .edgerc file, e.g.
+//
+//	default:
+//	  host: ...
+//	  client_token: ...
+//	  client_secret: ...
+//	  access_token: ...
+func (c *Config) FromYAML(r io.Reader, section string) error {
+	sections, err := decodeYAML(r)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrLoadingFile, err)
+	}
+
+	return c.fromSections(sections, section)
+}
+
+func decodeJSON(r io.Reader) (map[string]fileSection, error) {
+	var sections map[string]fileSection
+	err := json.NewDecoder(r).Decode(&sections)
+	return sections, err
+}
+
+func decodeYAML(r io.Reader) (map[string]fileSection, error) {
+	var sections map[string]fileSection
+	err := yaml.NewDecoder(r).Decode(&sections)
+	return sections, err
+}
+
+// sectionsDecoder returns the decoder of the file at path based on its extension,
+// or nil if the extension does not denote the JSON or YAML format
+func sectionsDecoder(path string) func(io.Reader) (map[string]fileSection, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return decodeJSON
+	case ".yaml", ".yml":
+		return decodeYAML
+	}
+	return nil
+}
+
+// decodeFile reads the sections of the JSON or YAML file at path with decode
+func decodeFile(path string, decode func(io.Reader) (map[string]fileSection, error)) (map[string]fileSection, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return decode(f)
+}
+
+// fromFormat loads the file at path as JSON or YAML, based on its extension.
+// It reports false if the extension does not denote any of these formats.
+func (c *Config) fromFormat(path, section string) (bool, error) {
+	decode := sectionsDecoder(path)
+	if decode == nil {
+		return false, nil
+	}
+
+	sections, err := decodeFile(path, decode)
+	if err != nil {
+		return true, fmt.Errorf("%w: %s", ErrLoadingFile, err)
+	}
+
+	return true, c.fromSections(sections, section)
+}
+
+func (c *Config) fromSections(sections map[string]fileSection, section string) error {
+	sec, ok := sections[section]
+	if !ok {
+		return fmt.Errorf("%w: section %q does not exist", ErrSectionDoesNotExist, section)
+	}
+
+	var values Config
+	sec.apply(&values)
+	if missing := values.missingOptions(); len(missing) > 0 {
+		return fmt.Errorf("%w: %q", ErrRequiredOptionEdgerc, missing[0])
+	}
+
+	sec.apply(c)
+
+	if c.MaxBody == 0 {
+		c.MaxBody = MaxBodySize
+	}
+
+	return nil
+}
+
+// apply sets the values of the section to the config
+func (s fileSection) apply(c *Config) {
+	c.Host = s.Host
+	c.ClientToken = s.ClientToken
+	c.ClientSecret = s.ClientSecret
+	c.AccessToken = s.AccessToken
+	c.AccountKey = s.AccountKey
+	c.HeaderToSign = s.HeaderToSign
+	c.MaxBody = s.MaxBody
+	c.RequestLimit = s.RequestLimit
+	c.Debug = s.Debug
+}
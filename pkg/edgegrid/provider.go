@@ -0,0 +1,164 @@
+package edgegrid
+
+import (
+	"context"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mitchellh/go-homedir"
+)
+
+type (
+	// CredentialProvider provides the credentials requests are signed with, e.g. from a secret manager
+	// or rotated at runtime. Provide is called for every signed request, so implementations should be cheap,
+	// caching the credentials if needed, and safe for concurrent use.
+	CredentialProvider interface {
+		Provide(ctx context.Context) (Config, error)
+	}
+
+	// CredentialProviderFunc is a function implementing CredentialProvider
+	CredentialProviderFunc func(ctx context.Context) (Config, error)
+
+	// FileProvider provides credentials loaded from a section of an .edgerc file
+	FileProvider struct {
+		file           string
+		section        string
+		reloadInterval time.Duration
+		now            func() time.Time
+
+		mu        sync.Mutex
+		config    *Config
+		modTime   time.Time
+		checkedAt time.Time
+	}
+
+	// FileProviderOption configures a FileProvider
+	FileProviderOption func(*FileProvider)
+
+	// EnvProvider provides credentials loaded from environment variables, see Config.FromEnv
+	EnvProvider struct {
+		section   string
+		envPrefix string
+	}
+
+	// EnvProviderOption configures an EnvProvider
+	EnvProviderOption func(*EnvProvider)
+)
+
+// Provide calls f(ctx)
+func (f CredentialProviderFunc) Provide(ctx context.Context) (Config, error) {
+	return f(ctx)
+}
+
+// Provide returns the config itself, so that static credentials can be used as a CredentialProvider
+func (c Config) Provide(context.Context) (Config, error) {
+	return c, nil
+}
+
+// NewFileProvider returns a provider loading credentials from given section of the .edgerc file.
+// The file is read on first use; failed reads are repeated on following calls.
+func NewFileProvider(file, section string, opts ...FileProviderOption) *FileProvider {
+	p := &FileProvider{file: file, section: section, now: time.Now}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// WithReloadInterval makes the provider check the file for changes at most once per interval and reload
+// the credentials when the file was modified, so that rotated secrets are picked up by long-running processes.
+// If the modified file cannot be loaded, the previous credentials are kept until the next check.
+func WithReloadInterval(interval time.Duration) FileProviderOption {
+	return func(p *FileProvider) {
+		p.reloadInterval = interval
+	}
+}
+
+// Provide returns the credentials loaded from the file
+func (p *FileProvider) Provide(context.Context) (Config, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.config != nil && !p.reloadDue() {
+		return *p.config, nil
+	}
+
+	path, err := homedir.Expand(p.file)
+	if err != nil {
+		return p.keepConfig(err)
+	}
+	var modTime time.Time
+	if info, err := os.Stat(path); err == nil {
+		modTime = info.ModTime()
+	}
+	if p.config != nil && modTime.Equal(p.modTime) {
+		return *p.config, nil
+	}
+
+	config := Config{section: p.section}
+	if err := config.FromFile(p.file, p.section); err != nil {
+		return p.keepConfig(err)
+	}
+	p.config = &config
+	p.modTime = modTime
+	p.checkedAt = p.now()
+	return config, nil
+}
+
+// reloadDue reports whether the file should be checked for changes, marking it as checked
+func (p *FileProvider) reloadDue() bool {
+	if p.reloadInterval <= 0 {
+		return false
+	}
+	now := p.now()
+	if now.Sub(p.checkedAt) < p.reloadInterval {
+		return false
+	}
+	p.checkedAt = now
+	return true
+}
+
+// keepConfig returns the previously loaded credentials, if any, or err
+func (p *FileProvider) keepConfig(err error) (Config, error) {
+	if p.config != nil {
+		return *p.config, nil
+	}
+	return Config{}, err
+}
+
+// Section returns the section of the file the credentials are loaded from
+func (p *FileProvider) Section() string {
+	return p.section
+}
+
+// NewEnvProvider returns a provider loading credentials from environment variables for given section,
+// read again on every call
+func NewEnvProvider(section string, opts ...EnvProviderOption) *EnvProvider {
+	p := &EnvProvider{section: section}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// WithProviderEnvPrefix sets the prefix of the environment variables the provider reads, see WithEnvPrefix
+func WithProviderEnvPrefix(prefix string) EnvProviderOption {
+	return func(p *EnvProvider) {
+		p.envPrefix = strings.TrimSuffix(prefix, "_")
+	}
+}
+
+// Provide returns the credentials loaded from the environment
+func (p *EnvProvider) Provide(context.Context) (Config, error) {
+	config := Config{section: p.section, env: true, envPrefix: p.envPrefix}
+	if err := config.FromEnv(p.section); err != nil {
+		return Config{}, err
+	}
+	return config, nil
+}
+
+// Section returns the section the environment variables are looked up for
+func (p *EnvProvider) Section() string {
+	return p.section
+}
@@ -0,0 +1,113 @@
+package edgegrid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestFileProvider(t *testing.T) {
+	t.Run("valid file and section", func(t *testing.T) {
+		provider := NewFileProvider("test/edgerc", "test")
+		config, err := provider.Provide(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "xxxx-xxxxxxxxxxxxxxxx-xxxxxxxxxxxxxxxx.luna.akamaiapis.net", config.Host)
+		assert.Equal(t, "test", config.Section())
+		assert.Equal(t, "test", provider.Section())
+
+		provider.file = "test/does-not-exist"
+		cached, err := provider.Provide(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, config, cached)
+	})
+
+	t.Run("missing section", func(t *testing.T) {
+		_, err := NewFileProvider("test/edgerc", "abc").Provide(context.Background())
+		assert.True(t, errors.Is(err, ErrSectionDoesNotExist), "want: %v; got: %v", ErrSectionDoesNotExist, err)
+	})
+}
+
+func TestFileProvider_Reload(t *testing.T) {
+	section := func(secret string) string {
+		return fmt.Sprintf("[default]\nhost = test-host\nclient_token = test-token\nclient_secret = %s\naccess_token = test-access\n", secret)
+	}
+	file := filepath.Join(t.TempDir(), "edgerc")
+	modTime := time.Now().Add(-time.Hour)
+	write := func(content string) {
+		require.NoError(t, ioutil.WriteFile(file, []byte(content), 0600))
+		modTime = modTime.Add(time.Minute)
+		require.NoError(t, os.Chtimes(file, modTime, modTime))
+	}
+	write(section("secret-1"))
+
+	now := time.Now()
+	provider := NewFileProvider(file, "default", WithReloadInterval(time.Minute))
+	provider.now = func() time.Time { return now }
+	provide := func() string {
+		config, err := provider.Provide(context.Background())
+		require.NoError(t, err)
+		return config.ClientSecret
+	}
+	assert.Equal(t, "secret-1", provide())
+
+	write(section("secret-2"))
+	assert.Equal(t, "secret-1", provide(), "reloaded before interval passed")
+
+	now = now.Add(time.Minute)
+	assert.Equal(t, "secret-2", provide())
+
+	write("[default]\nhost = test-host\n")
+	now = now.Add(time.Minute)
+	assert.Equal(t, "secret-2", provide(), "invalid file replaced previous credentials")
+
+	write(section("secret-3"))
+	now = now.Add(time.Minute)
+	assert.Equal(t, "secret-3", provide())
+}
+
+func TestEnvProvider(t *testing.T) {
+	envs := map[string]string{
+		"AKAMAI_PROVIDER_HOST":          "test-host",
+		"AKAMAI_PROVIDER_CLIENT_TOKEN":  "test-client-token",
+		"AKAMAI_PROVIDER_CLIENT_SECRET": "test-client-secret",
+		"AKAMAI_PROVIDER_ACCESS_TOKEN":  "test-access-token",
+	}
+	for k, v := range envs {
+		require.NoError(t, os.Setenv(k, v))
+	}
+	defer func() {
+		for k := range envs {
+			require.NoError(t, os.Unsetenv(k))
+		}
+	}()
+
+	config, err := NewEnvProvider("provider").Provide(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "test-host", config.Host)
+	assert.Equal(t, "provider", config.Section())
+
+	_, err = NewEnvProvider("missing").Provide(context.Background())
+	assert.True(t, errors.Is(err, ErrRequiredOptionEnv), "want: %v; got: %v", ErrRequiredOptionEnv, err)
+}
+
+func TestCredentialProviderFunc(t *testing.T) {
+	var provider CredentialProvider = CredentialProviderFunc(func(context.Context) (Config, error) {
+		return Config{Host: "func-host"}, nil
+	})
+	config, err := provider.Provide(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "func-host", config.Host)
+
+	provider = Config{Host: "static-host"}
+	config, err = provider.Provide(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "static-host", config.Host)
+}
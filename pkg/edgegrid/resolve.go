@@ -0,0 +1,183 @@
+package edgegrid
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mitchellh/go-homedir"
+	"gopkg.in/ini.v1"
+)
+
+// ErrRequiredOption is returned by ResolveConfig when none of the sources provides a required value
+var ErrRequiredOption = errors.New("required option is missing")
+
+// WithValues sets values taking precedence over the environment and the config file in ResolveConfig;
+// zero fields are resolved from the other sources
+func WithValues(values Config) Option {
+	return func(c *Config) {
+		c.values = &values
+	}
+}
+
+// ResolveConfig returns a config merged field by field from the following sources, in order of precedence:
+//
+//  1. values set with WithValues
+//  2. environment variables of the section, see FromEnv and WithEnvPrefix
+//  3. the section of the config file set with WithFile, ~/.edgerc by default, or of the reader set with WithReader
+//
+// Sources which do not exist, such as a missing file or section, are skipped. An error is returned
+// if the file cannot be parsed, or if a required value is missing from all sources.
+// The section defaults to DefaultSection and can be set with WithSection; WithEnv has no effect.
+func ResolveConfig(opts ...Option) (*Config, error) {
+	c := &Config{
+		file:    DefaultConfigFile,
+		section: DefaultSection,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.requireFIPS && !FIPSEnabled() {
+		return nil, ErrFIPSNotEnabled
+	}
+
+	resolved := &Config{file: c.file, reader: c.reader, section: c.section, debugHook: c.debugHook}
+	if c.values != nil {
+		resolved.merge(*c.values)
+	}
+	resolved.merge(envValues(c.envPrefix, c.section))
+
+	fileValues, err := c.fileValues()
+	if err != nil {
+		return nil, err
+	}
+	resolved.merge(fileValues)
+	resolved.HeaderToSign = appendHeaders(resolved.HeaderToSign, c.headersToSign...)
+
+	if missing := resolved.missingOptions(); len(missing) > 0 {
+		return nil, fmt.Errorf("%w: %q", ErrRequiredOption, missing[0])
+	}
+	if resolved.MaxBody <= 0 {
+		resolved.MaxBody = MaxBodySize
+	}
+	if err := resolved.Validate(); err != nil {
+		return nil, err
+	}
+
+	return resolved, nil
+}
+
+// merge sets the zero fields of the config to the values of other
+func (c *Config) merge(other Config) {
+	if c.Host == "" {
+		c.Host = other.Host
+	}
+	if c.ClientToken == "" {
+		c.ClientToken = other.ClientToken
+	}
+	if c.ClientSecret == "" {
+		c.ClientSecret = other.ClientSecret
+	}
+	if c.AccessToken == "" {
+		c.AccessToken = other.AccessToken
+	}
+	if c.AccountKey == "" {
+		c.AccountKey = other.AccountKey
+	}
+	if len(c.HeaderToSign) == 0 {
+		c.HeaderToSign = other.HeaderToSign
+	}
+	if c.MaxBody == 0 {
+		c.MaxBody = other.MaxBody
+	}
+	if c.RequestLimit == 0 {
+		c.RequestLimit = other.RequestLimit
+	}
+	if !c.Debug {
+		c.Debug = other.Debug
+	}
+}
+
+// envValues returns the values set in the environment variables of the section, without requiring any of them
+func envValues(base, section string) Config {
+	prefix := envPrefix(base, section)
+	var c Config
+	for _, v := range []struct {
+		name  string
+		value *string
+	}{
+		{"HOST", &c.Host},
+		{"CLIENT_TOKEN", &c.ClientToken},
+		{"CLIENT_SECRET", &c.ClientSecret},
+		{"ACCESS_TOKEN", &c.AccessToken},
+		{"ACCOUNT_KEY", &c.AccountKey},
+	} {
+		*v.value = os.Getenv(fmt.Sprintf("%s_%s", prefix, v.name))
+	}
+	if i, err := strconv.Atoi(os.Getenv(fmt.Sprintf("%s_%s", prefix, "MAX_BODY"))); err == nil {
+		c.MaxBody = i
+	}
+	if val := os.Getenv(fmt.Sprintf("%s_%s", prefix, "HEADERS_TO_SIGN")); val != "" {
+		c.HeaderToSign = appendHeaders(nil, strings.Split(val, ",")...)
+	}
+	return c
+}
+
+// fileValues returns the values of the section of the reader or the config file, without requiring any of them;
+// a missing file or section results in no values
+func (c *Config) fileValues() (Config, error) {
+	var values Config
+	if c.reader != nil {
+		edgerc, err := ini.Load(ioutil.NopCloser(c.reader))
+		if err != nil {
+			return values, fmt.Errorf("%w: %s", ErrLoadingFile, err)
+		}
+		return values, iniValues(edgerc, c.section, &values)
+	}
+
+	path, err := homedir.Expand(c.file)
+	if err != nil {
+		return values, fmt.Errorf("invalid path: %w", err)
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return values, nil
+	}
+
+	data, formatPath, err := c.readConfigFile(path)
+	if err != nil {
+		return values, err
+	}
+	if decode := sectionsDecoder(formatPath); decode != nil {
+		sections, err := decode(bytes.NewReader(data))
+		if err != nil {
+			return values, fmt.Errorf("%w: %s", ErrLoadingFile, err)
+		}
+		if sec, ok := sections[c.section]; ok {
+			sec.apply(&values)
+		}
+		return values, nil
+	}
+
+	edgerc, err := ini.Load(data)
+	if err != nil {
+		return values, fmt.Errorf("%w: %s", ErrLoadingFile, err)
+	}
+	return values, iniValues(edgerc, c.section, &values)
+}
+
+// iniValues maps the section of the INI file, if it exists, to values
+func iniValues(edgerc *ini.File, section string, values *Config) error {
+	sec, err := edgerc.GetSection(section)
+	if err != nil {
+		return nil
+	}
+	if err := sec.MapTo(values); err != nil {
+		return fmt.Errorf("%w: %s", ErrLoadingFile, err)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package edgegrid
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestResolveConfig(t *testing.T) {
+	const (
+		host         = "xxxx-xxxxxxxxxxxxxxxx-xxxxxxxxxxxxxxxx.luna.akamaiapis.net"
+		clientToken  = "xxxx-xxxxxxxxxxxxxxxx-xxxxxxxxxxxxxxxx"
+		clientSecret = "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx="
+		accessToken  = "xxxx-xxxxxxxxxxxxxxxx-xxxxxxxxxxxxxxxx"
+	)
+
+	tests := map[string]struct {
+		options   []Option
+		envs      map[string]string
+		expected  Config
+		withError error
+	}{
+		"file only": {
+			options: []Option{WithFile("test/edgerc"), WithSection("test")},
+			expected: Config{
+				Host:         host,
+				ClientToken:  clientToken,
+				ClientSecret: clientSecret,
+				AccessToken:  accessToken,
+				MaxBody:      MaxBodySize,
+				file:         "test/edgerc",
+				section:      "test",
+			},
+		},
+		"explicit value completes file section": {
+			options: []Option{WithFile("test/edgerc"), WithSection("missing-host"), WithValues(Config{Host: "explicit-host"})},
+			expected: Config{
+				Host:         "explicit-host",
+				ClientToken:  clientToken,
+				ClientSecret: clientSecret,
+				AccessToken:  accessToken,
+				MaxBody:      MaxBodySize,
+				file:         "test/edgerc",
+				section:      "missing-host",
+			},
+		},
+		"env takes precedence over file": {
+			options: []Option{WithFile("test/edgerc.yaml"), WithSection("test")},
+			envs: map[string]string{
+				"AKAMAI_TEST_CLIENT_TOKEN": "env-token",
+				"AKAMAI_TEST_MAX_BODY":     "2048",
+			},
+			expected: Config{
+				Host:         host,
+				ClientToken:  "env-token",
+				ClientSecret: clientSecret,
+				AccessToken:  accessToken,
+				AccountKey:   "1-ABCDE:1-2345",
+				MaxBody:      2048,
+				file:         "test/edgerc.yaml",
+				section:      "test",
+			},
+		},
+		"explicit value takes precedence over env": {
+			options: []Option{WithFile("test/does-not-exist"), WithValues(Config{ClientToken: "explicit-token"})},
+			envs: map[string]string{
+				"AKAMAI_HOST":          "env-host",
+				"AKAMAI_CLIENT_TOKEN":  "env-token",
+				"AKAMAI_CLIENT_SECRET": "env-secret",
+				"AKAMAI_ACCESS_TOKEN":  "env-access-token",
+			},
+			expected: Config{
+				Host:         "env-host",
+				ClientToken:  "explicit-token",
+				ClientSecret: "env-secret",
+				AccessToken:  "env-access-token",
+				MaxBody:      MaxBodySize,
+				file:         "test/does-not-exist",
+				section:      DefaultSection,
+			},
+		},
+		"missing section and value": {
+			options:   []Option{WithFile("test/edgerc"), WithSection("abc"), WithValues(Config{Host: "explicit-host"})},
+			withError: ErrRequiredOption,
+		},
+		"missing value in all sources": {
+			options:   []Option{WithFile("test/edgerc"), WithSection("missing-access-token")},
+			withError: ErrRequiredOption,
+		},
+		"invalid reader": {
+			options:   []Option{WithReader(strings.NewReader("[default\nhost = test"))},
+			withError: ErrLoadingFile,
+		},
+		"host with slash at the end": {
+			options:   []Option{WithFile("test/edgerc"), WithSection("test"), WithValues(Config{Host: "explicit-host/"})},
+			withError: ErrHostContainsSlashAtTheEnd,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			for k, v := range test.envs {
+				require.NoError(t, os.Setenv(k, v))
+			}
+			defer func() {
+				for k := range test.envs {
+					require.NoError(t, os.Unsetenv(k))
+				}
+			}()
+			cfg, err := ResolveConfig(test.options...)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %v; got: %v", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, *cfg)
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package edgegrid
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/mitchellh/go-homedir"
+	"gopkg.in/ini.v1"
+)
+
+// SectionInfo describes a section of a config file, see DescribeSections
+type SectionInfo struct {
+	// Name is the name of the section
+	Name string
+	// Complete tells whether the section has all options required to sign requests
+	Complete bool
+	// Missing lists the required options the section lacks
+	Missing []string
+	// AccountKey tells whether the section sets an account switch key
+	AccountKey bool
+}
+
+// ListSections returns the names of the sections of the config file at path, in the order of the file
+// for .edgerc files and sorted for YAML and JSON files, so that tools can offer a choice of profiles
+func ListSections(path string) ([]string, error) {
+	sections, err := DescribeSections(path)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(sections))
+	for _, sec := range sections {
+		names = append(names, sec.Name)
+	}
+	return names, nil
+}
+
+// DescribeSections returns the sections of the config file at path, like ListSections,
+// together with whether they are complete
+func DescribeSections(path string) ([]SectionInfo, error) {
+	path, err := homedir.Expand(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid path: %w", err)
+	}
+
+	if decode := sectionsDecoder(path); decode != nil {
+		sections, err := decodeFile(path, decode)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrLoadingFile, err)
+		}
+		names := make([]string, 0, len(sections))
+		for name := range sections {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		infos := make([]SectionInfo, 0, len(names))
+		for _, name := range names {
+			var c Config
+			sections[name].apply(&c)
+			infos = append(infos, c.sectionInfo(name))
+		}
+		return infos, nil
+	}
+
+	edgerc, err := ini.Load(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrLoadingFile, err)
+	}
+	infos := make([]SectionInfo, 0, len(edgerc.Sections()))
+	for _, sec := range edgerc.Sections() {
+		if sec.Name() == ini.DefaultSection && len(sec.Keys()) == 0 {
+			// keys outside any section, implicitly added by the parser
+			continue
+		}
+		var c Config
+		if err := sec.MapTo(&c); err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrLoadingFile, err)
+		}
+		infos = append(infos, c.sectionInfo(sec.Name()))
+	}
+	return infos, nil
+}
+
+func (c Config) sectionInfo(name string) SectionInfo {
+	missing := c.missingOptions()
+	return SectionInfo{
+		Name:       name,
+		Complete:   len(missing) == 0,
+		Missing:    missing,
+		AccountKey: c.AccountKey != "",
+	}
+}
+
+// missingOptions returns the names of the required options which are not set
+func (c Config) missingOptions() []string {
+	var missing []string
+	for _, opt := range []struct {
+		option string
+		value  string
+	}{
+		{"host", c.Host},
+		{"client_token", c.ClientToken},
+		{"client_secret", c.ClientSecret},
+		{"access_token", c.AccessToken},
+	} {
+		if opt.value == "" {
+			missing = append(missing, opt.option)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,62 @@
+package edgegrid
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestListSections(t *testing.T) {
+	tests := map[string]struct {
+		path      string
+		expected  []string
+		withError error
+	}{
+		"edgerc file": {
+			path: "test/edgerc",
+			expected: []string{"test", "missing-host", "missing-client-secret", "missing-client-token",
+				"missing-access-token", "slash-at-the-end-of-host-value", "account-key", "headers-to-sign"},
+		},
+		"yaml file": {
+			path:     "test/edgerc.yaml",
+			expected: []string{"missing-host", "test"},
+		},
+		"json file": {
+			path:     "test/edgerc.json",
+			expected: []string{"missing-access-token", "test"},
+		},
+		"file does not exist": {
+			path:      "test/does-not-exist",
+			withError: ErrLoadingFile,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			sections, err := ListSections(test.path)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %v; got: %v", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, sections)
+		})
+	}
+}
+
+func TestDescribeSections(t *testing.T) {
+	sections, err := DescribeSections("test/edgerc")
+	require.NoError(t, err)
+	require.Len(t, sections, 8)
+	assert.Equal(t, SectionInfo{Name: "test", Complete: true}, sections[0])
+	assert.Equal(t, SectionInfo{Name: "missing-host", Missing: []string{"host"}}, sections[1])
+	assert.Equal(t, SectionInfo{Name: "account-key", Complete: true, AccountKey: true}, sections[6])
+
+	sections, err = DescribeSections("test/edgerc.json")
+	require.NoError(t, err)
+	assert.Equal(t, []SectionInfo{
+		{Name: "missing-access-token", Missing: []string{"access_token"}},
+		{Name: "test", Complete: true},
+	}, sections)
+}
@@ -5,6 +5,7 @@ import (
 	"crypto/sha256"
 	"encoding/base64"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"sort"
@@ -22,6 +23,19 @@ type (
 		CheckRequestLimit(requestLimit int)
 	}
 
+	// SignatureDebug holds the data a request was signed with, see WithSignatureDebug
+	SignatureDebug struct {
+		// DataToSign is the tab-separated message the signature is computed from: method, scheme, host,
+		// path with query, canonical headers, content hash and the authorization header without the signature
+		DataToSign string
+		// CanonicalHeaders are the signed headers in canonical form, tab-separated
+		CanonicalHeaders string
+		// ContentHash is the hash of the POST body, empty for other requests
+		ContentHash string
+		// AuthHeader is the computed value of the Authorization header
+		AuthHeader string
+	}
+
 	authHeader struct {
 		authType    string
 		clientToken string
@@ -43,6 +57,12 @@ var (
 
 // SignRequest adds a signed authorization header to the http request
 func (c Config) SignRequest(r *http.Request) {
+	c.SignRequestAt(r, time.Now())
+}
+
+// SignRequestAt adds an authorization header to the http request signed with the timestamp of given time,
+// e.g. the current time corrected for the skew of the local clock
+func (c Config) SignRequestAt(r *http.Request, at time.Time) {
 	if r.URL.Host == "" {
 		r.URL.Host = c.Host
 	}
@@ -50,7 +70,25 @@ func (c Config) SignRequest(r *http.Request) {
 		r.URL.Scheme = "https"
 	}
 	r.URL.RawQuery = c.addAccountSwitchKey(r)
-	r.Header.Set("Authorization", c.createAuthHeader(r).String())
+	r.Header.Set("Authorization", c.createAuthHeaderAt(r, at).String())
+}
+
+// GenerateAuthHeader returns the value of the Authorization header of the request signed with the config,
+// without modifying the request, e.g. to generate curl commands or HAR files. Requests without a host are signed
+// for the host of the config, and with an AccountKey, for the query with the accountSwitchKey parameter,
+// so such requests have to be sent to that host and with that parameter. A body which can be read only once
+// is read into memory and replaced with an equivalent one.
+func GenerateAuthHeader(config Config, r *http.Request) string {
+	req := r.Clone(r.Context())
+	if r.Body != nil && r.Body != http.NoBody {
+		if _, seekable := r.Body.(io.ReadSeeker); !seekable || r.GetBody != nil {
+			if body, err := requestBody(r); err == nil {
+				req.Body, req.GetBody = body, r.GetBody
+			}
+		}
+	}
+	config.SignRequest(req)
+	return req.Header.Get("Authorization")
 }
 
 // CheckRequestLimit waits if necessary to ensure that OpenAPI's request limit is not exceeded
@@ -64,7 +102,11 @@ func (c Config) CheckRequestLimit(limit int) {
 }
 
 func (c Config) createAuthHeader(r *http.Request) authHeader {
-	timestamp := Timestamp(time.Now())
+	return c.createAuthHeaderAt(r, time.Now())
+}
+
+func (c Config) createAuthHeaderAt(r *http.Request, at time.Time) authHeader {
+	timestamp := Timestamp(at)
 
 	auth := authHeader{
 		authType:    authType,
@@ -76,23 +118,40 @@ func (c Config) createAuthHeader(r *http.Request) authHeader {
 
 	msgPath := r.URL.EscapedPath()
 	if r.URL.RawQuery != "" {
-		msgPath = fmt.Sprintf("%s?%s", msgPath, r.URL.RawQuery)
+		msgPath += "?" + r.URL.RawQuery
 	}
+	headers := canonicalizeHeaders(r.Header, c.HeaderToSign)
+	contentHash := createContentHash(r, c.MaxBody)
 
 	// create the message to be signed
-	msgData := []string{
+	msg := getBuffer()
+	defer putBuffer(msg)
+	for i, part := range []string{
 		r.Method,
 		r.URL.Scheme,
 		r.URL.Host,
 		msgPath,
-		canonicalizeHeaders(r.Header, c.HeaderToSign),
-		createContentHash(r, c.MaxBody),
+		headers,
+		contentHash,
 		auth.String(),
+	} {
+		if i > 0 {
+			msg.WriteByte('\t')
+		}
+		msg.WriteString(part)
 	}
-	msg := strings.Join(msgData, "\t")
 
-	key := createSignature(timestamp, c.ClientSecret)
-	auth.signature = createSignature(msg, key)
+	key := createSignature([]byte(timestamp), c.ClientSecret)
+	auth.signature = createSignature(msg.Bytes(), key)
+
+	if c.debugHook != nil {
+		c.debugHook(SignatureDebug{
+			DataToSign:       msg.String(),
+			CanonicalHeaders: headers,
+			ContentHash:      contentHash,
+			AuthHeader:       auth.String(),
+		})
+	}
 	return auth
 }
 
@@ -105,7 +164,7 @@ func canonicalizeHeaders(requestHeaders http.Header, headersToSign []string) str
 	sort.Strings(unsortedHeader)
 	for _, k := range unsortedHeader {
 		for _, sign := range headersToSign {
-			if sign == k {
+			if strings.EqualFold(strings.TrimSpace(sign), k) {
 				v := strings.TrimSpace(requestHeaders.Get(k))
 				sortedHeader = append(sortedHeader, fmt.Sprintf("%s:%s", strings.ToLower(k), strings.ToLower(stringMinifier(v))))
 			}
@@ -119,30 +178,102 @@ func canonicalizeHeaders(requestHeaders http.Header, headersToSign []string) str
 // The size of the POST body must be less than or equal to the value specified by the service.
 // Any request that does not meet this criteria SHOULD be rejected during the signing process,
 // as the request will be rejected by EdgeGrid.
+//
+// The body is hashed while it is streamed from a copy obtained with requestBody, so it is read only once.
+// Bodies implementing io.ReadSeeker without GetBody, such as files or bodies created with NewSeekableBody,
+// are not copied: only the first maxBody bytes are read and the body is rewound.
 func createContentHash(r *http.Request, maxBody int) string {
-	var (
-		contentHash  string
-		preparedBody string
-		bodyBytes    []byte
-	)
+	if r.Method != http.MethodPost || r.Body == nil || r.Body == http.NoBody {
+		return ""
+	}
 
-	if r.Body != nil {
-		bodyBytes, _ = ioutil.ReadAll(r.Body)
-		r.Body = ioutil.NopCloser(bytes.NewBuffer(bodyBytes))
-		preparedBody = string(bodyBytes)
+	if rs, ok := r.Body.(io.ReadSeeker); ok && r.GetBody == nil {
+		return seekableContentHash(rs, maxBody)
 	}
 
-	if r.Method == http.MethodPost && len(preparedBody) > 0 {
-		if len(preparedBody) > maxBody {
-			preparedBody = preparedBody[0:maxBody]
+	body, err := requestBody(r)
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+
+	return hashBody(body, maxBody)
+}
+
+// seekableContentHash hashes the body from its current offset, restoring the offset afterwards
+func seekableContentHash(rs io.ReadSeeker, maxBody int) string {
+	offset, err := rs.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return ""
+	}
+	hash := hashBody(rs, maxBody)
+	if _, err := rs.Seek(offset, io.SeekStart); err != nil {
+		return ""
+	}
+	return hash
+}
+
+// hashBody returns the hash of at most maxBody bytes of the body, or an empty string if there are none
+func hashBody(body io.Reader, maxBody int) string {
+	h := sha256.New()
+	buf := copyBufferPool.Get().(*[]byte)
+	n, err := io.CopyBuffer(h, io.LimitReader(body, int64(maxBody)), *buf)
+	copyBufferPool.Put(buf)
+	if err != nil {
+		return ""
+	}
+	if n == 0 {
+		// the body is either empty or entirely cut off by maxBody
+		var probe [1]byte
+		if read, _ := io.ReadFull(body, probe[:]); read == 0 {
+			return ""
 		}
+	}
+
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+// NewSeekableBody returns a request body reading from rs which is signed without buffering it in memory,
+// e.g. for large uploads; only the first MaxBody bytes are hashed. rs is closed with the body if it implements io.Closer.
+func NewSeekableBody(rs io.ReadSeeker) io.ReadCloser {
+	return seekableBody{rs}
+}
+
+type seekableBody struct {
+	io.ReadSeeker
+}
 
-		sum := sha256.Sum256([]byte(preparedBody))
+// Close closes the underlying reader if it implements io.Closer
+func (b seekableBody) Close() error {
+	if c, ok := b.ReadSeeker.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
 
-		contentHash = base64.StdEncoding.EncodeToString(sum[:])
+// requestBody returns a copy of the request body. Requests without GetBody have their body read into memory once,
+// and GetBody set, so that neither signing nor retries and redirects need to read the body again.
+func requestBody(r *http.Request) (io.ReadCloser, error) {
+	if r.GetBody != nil {
+		return r.GetBody()
 	}
 
-	return contentHash
+	buf := getBuffer()
+	defer putBuffer(buf)
+	_, err := buf.ReadFrom(r.Body)
+	if closeErr := r.Body.Close(); err == nil {
+		err = closeErr
+	}
+	data := make([]byte, buf.Len())
+	copy(data, buf.Bytes())
+	r.GetBody = func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(data)), nil
+	}
+	r.Body, _ = r.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	return r.GetBody()
 }
 
 func (a authHeader) String() string {
@@ -161,6 +292,10 @@ func (a authHeader) String() string {
 func (c Config) addAccountSwitchKey(r *http.Request) string {
 	if c.AccountKey != "" {
 		values := r.URL.Query()
+		if values.Has("accountSwitchKey") {
+			// account switch key set explicitly for the request takes precedence
+			return r.URL.RawQuery
+		}
 		values.Add("accountSwitchKey", c.AccountKey)
 		r.URL.RawQuery = values.Encode()
 	}
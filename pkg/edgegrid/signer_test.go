@@ -1,7 +1,10 @@
 package edgegrid
 
 import (
+	"bytes"
 	"encoding/base64"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"strings"
 	"testing"
@@ -55,6 +58,91 @@ func TestConfig_createAuthHeader(t *testing.T) {
 	}
 }
 
+func TestConfig_SignRequestAt(t *testing.T) {
+	config := Config{ClientToken: "12345", AccessToken: "54321", MaxBody: MaxBodySize}
+	req, err := http.NewRequest(http.MethodGet, "https://akamai.com/test/path", nil)
+	require.NoError(t, err)
+
+	config.SignRequestAt(req, time.Date(2023, 4, 5, 6, 7, 8, 0, time.UTC))
+	assert.Contains(t, req.Header.Get("Authorization"), "timestamp=20230405T06:07:08+0000;")
+}
+
+func TestConfig_SignatureDebug(t *testing.T) {
+	var debug []SignatureDebug
+	config, err := New(WithFile("test/edgerc"), WithSection("headers-to-sign"), WithSignatureDebug(func(d SignatureDebug) {
+		debug = append(debug, d)
+	}))
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodPost, "https://akamai.com/test/path?query=test", strings.NewReader("abc"))
+	require.NoError(t, err)
+	req.Header.Set("X-First", "First  Value")
+	config.SignRequest(req)
+
+	require.Len(t, debug, 1)
+	assert.Equal(t, req.Header.Get("Authorization"), debug[0].AuthHeader)
+	assert.Equal(t, "x-first:first value", debug[0].CanonicalHeaders)
+	assert.Equal(t, "ungWv48Bz+pBQUDeXa4iI7ADYaOWF3qctBD/YfIAFa0=", debug[0].ContentHash)
+	parts := strings.Split(debug[0].DataToSign, "\t")
+	require.Len(t, parts, 7)
+	assert.Equal(t, []string{"POST", "https", "akamai.com", "/test/path?query=test"}, parts[:4])
+	assert.True(t, strings.HasPrefix(debug[0].AuthHeader, parts[6]+"signature="))
+}
+
+func TestGenerateAuthHeader(t *testing.T) {
+	config := Config{
+		Host:         "akab-xxxx.luna.akamaiapis.net",
+		ClientToken:  "12345",
+		AccessToken:  "54321",
+		ClientSecret: "secret",
+		AccountKey:   "1-ABCDE",
+		MaxBody:      MaxBodySize,
+	}
+	tests := map[string]struct {
+		body        func() io.ReadCloser
+		contentHash bool
+	}{
+		"without body": {},
+		"body without GetBody": {
+			body:        func() io.ReadCloser { return ioutil.NopCloser(strings.NewReader("abc")) },
+			contentHash: true,
+		},
+		"seekable body": {
+			body:        func() io.ReadCloser { return NewSeekableBody(strings.NewReader("abc")) },
+			contentHash: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, "/test/path", nil)
+			require.NoError(t, err)
+			if test.body != nil {
+				req.Body = test.body()
+			}
+
+			var debug SignatureDebug
+			config.debugHook = func(d SignatureDebug) { debug = d }
+			auth := GenerateAuthHeader(config, req)
+
+			assert.True(t, strings.HasPrefix(auth, "EG1-HMAC-SHA256 client_token=12345;access_token=54321;"))
+			assert.Contains(t, auth, ";signature=")
+			assert.Equal(t, auth, debug.AuthHeader)
+			assert.Contains(t, debug.DataToSign, "\takab-xxxx.luna.akamaiapis.net\t/test/path?accountSwitchKey=1-ABCDE\t")
+			assert.Equal(t, test.contentHash, debug.ContentHash != "")
+
+			assert.Empty(t, req.Header.Get("Authorization"))
+			assert.Empty(t, req.URL.Host)
+			assert.Empty(t, req.URL.RawQuery)
+			if test.body != nil {
+				body, err := ioutil.ReadAll(req.Body)
+				require.NoError(t, err)
+				assert.Equal(t, "abc", string(body))
+			}
+		})
+	}
+}
+
 func TestCanonicalizeHeaders(t *testing.T) {
 	tests := map[string]struct {
 		requestHeaders http.Header
@@ -70,6 +158,13 @@ func TestCanonicalizeHeaders(t *testing.T) {
 			headersToSign: []string{"B", "C"},
 			expected:      "b:val 2\tc:v a l 3",
 		},
+		"header names matched case-insensitively": {
+			requestHeaders: map[string][]string{
+				"X-Custom-Header": {"Value"},
+			},
+			headersToSign: []string{" x-custom-header"},
+			expected:      "x-custom-header:value",
+		},
 		"no matching headers found": {
 			requestHeaders: map[string][]string{
 				"A": {"val1"},
@@ -93,6 +188,7 @@ func TestCreateContentHash(t *testing.T) {
 	tests := map[string]struct {
 		httpMethod  string
 		body        string
+		withoutCopy bool
 		resultEmpty bool
 	}{
 		"PUT request": {
@@ -110,13 +206,26 @@ func TestCreateContentHash(t *testing.T) {
 			body:        `{"key":"value"}`,
 			resultEmpty: false,
 		},
+		"POST request, body without GetBody": {
+			httpMethod:  http.MethodPost,
+			body:        `{"key":"value"}`,
+			withoutCopy: true,
+			resultEmpty: false,
+		},
 	}
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
 			req, err := http.NewRequest(test.httpMethod, "", strings.NewReader(test.body))
 			require.NoError(t, err)
+			if test.withoutCopy {
+				req.Body = ioutil.NopCloser(strings.NewReader(test.body))
+				req.GetBody = nil
+			}
 			res := createContentHash(req, MaxBodySize)
+			body, err := ioutil.ReadAll(req.Body)
+			require.NoError(t, err)
+			assert.Equal(t, test.body, string(body))
 			if test.resultEmpty {
 				assert.Empty(t, res)
 				return
@@ -128,6 +237,42 @@ func TestCreateContentHash(t *testing.T) {
 	}
 }
 
+func TestCreateContentHash_Seekable(t *testing.T) {
+	body := strings.Repeat("0123456789", 100)
+	tests := map[string]struct {
+		maxBody  int
+		expected string
+	}{
+		"body shorter than max body": {
+			maxBody:  MaxBodySize,
+			expected: body,
+		},
+		"body longer than max body": {
+			maxBody:  64,
+			expected: body[:64],
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			expectedReq, err := http.NewRequest(http.MethodPost, "", strings.NewReader(test.expected))
+			require.NoError(t, err)
+			expected := createContentHash(expectedReq, MaxBodySize)
+
+			rs := strings.NewReader(body)
+			req, err := http.NewRequest(http.MethodPost, "", NewSeekableBody(rs))
+			require.NoError(t, err)
+			require.Nil(t, req.GetBody)
+
+			assert.Equal(t, expected, createContentHash(req, test.maxBody))
+			assert.Nil(t, req.GetBody, "seekable body must not be copied")
+			read, err := ioutil.ReadAll(req.Body)
+			require.NoError(t, err)
+			assert.Equal(t, body, string(read))
+		})
+	}
+}
+
 func TestAuthHeader_String(t *testing.T) {
 	tests := map[string]struct {
 		given    authHeader
@@ -213,6 +358,20 @@ func TestAddAccountSwitchKey(t *testing.T) {
 			}(),
 			expected: "accountSwitchKey=test_switch",
 		},
+		"test account switch key already set in request": {
+			config: Config{
+				ClientToken: "12345",
+				AccessToken: "54321",
+				AccountKey:  "test_switch",
+				MaxBody:     MaxBodySize,
+			},
+			request: func() *http.Request {
+				req, err := http.NewRequest(http.MethodGet, "http://akamai.com/test/path?accountSwitchKey=other_switch", nil)
+				require.NoError(t, err)
+				return req
+			}(),
+			expected: "accountSwitchKey=other_switch",
+		},
 	}
 
 	for name, test := range tests {
@@ -226,3 +385,22 @@ func TestAddAccountSwitchKey(t *testing.T) {
 		})
 	}
 }
+
+func BenchmarkConfig_SignRequest(b *testing.B) {
+	config := Config{
+		Host:         "akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net",
+		ClientToken:  "akab-client-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientSecret: "SOMESECRET",
+		AccessToken:  "akab-access-token-xxx-xxxxxxxxxxxxxxxx",
+		MaxBody:      MaxBodySize,
+	}
+	body := []byte(strings.Repeat(`{"key":"value"},`, 4096))
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		req, err := http.NewRequest(http.MethodPost, "/papi/v1/properties?contractId=ctr_1&groupId=grp_2", nil)
+		require.NoError(b, err)
+		req.Body = ioutil.NopCloser(bytes.NewBuffer(body))
+		config.SignRequest(req)
+	}
+}
@@ -0,0 +1,52 @@
+package edgegrid
+
+import (
+	"net/http"
+)
+
+// Transport is an http.RoundTripper adding EdgeGrid authentication to the requests it sends,
+// so that any http.Client, including ones of other libraries, can call Akamai APIs, e.g.
+//
+//	client := &http.Client{Transport: &edgegrid.Transport{Config: *config}}
+type Transport struct {
+	// Config holds the credentials requests are signed with, unless Provider is set
+	Config Config
+	// Provider, if set, provides the credentials for every request instead of Config
+	Provider CredentialProvider
+	// Base sends the signed requests, http.DefaultTransport if nil
+	Base http.RoundTripper
+}
+
+// NewTransport returns a transport signing requests with the config and sending them with base,
+// http.DefaultTransport if nil
+func NewTransport(config Config, base http.RoundTripper) *Transport {
+	return &Transport{Config: config, Base: base}
+}
+
+// RoundTrip signs a copy of the request and sends it with the base transport.
+// Requests without a host are sent to the host of the credentials.
+func (t *Transport) RoundTrip(r *http.Request) (*http.Response, error) {
+	config := t.Config
+	if t.Provider != nil {
+		var err error
+		if config, err = t.Provider.Provide(r.Context()); err != nil {
+			if r.Body != nil {
+				r.Body.Close()
+			}
+			return nil, err
+		}
+	}
+
+	req := r.Clone(r.Context())
+	config.SignRequest(req)
+	config.CheckRequestLimit(config.RequestLimit)
+
+	return t.base().RoundTrip(req)
+}
+
+func (t *Transport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
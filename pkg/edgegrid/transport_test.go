@@ -0,0 +1,70 @@
+package edgegrid
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestTransport_RoundTrip(t *testing.T) {
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "EG1-HMAC-SHA256 client_token=12345;"))
+		body, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		_, err = w.Write(body)
+		assert.NoError(t, err)
+	}))
+	defer mockServer.Close()
+	serverURL, err := url.Parse(mockServer.URL)
+	require.NoError(t, err)
+
+	config := Config{Host: serverURL.Host, ClientToken: "12345", AccessToken: "54321", MaxBody: MaxBodySize}
+	tests := map[string]struct {
+		transport *Transport
+		withError error
+	}{
+		"static config": {
+			transport: NewTransport(config, mockServer.Client().Transport),
+		},
+		"credential provider": {
+			transport: &Transport{Provider: config, Base: mockServer.Client().Transport},
+		},
+		"credential provider error": {
+			transport: &Transport{
+				Provider: CredentialProviderFunc(func(context.Context) (Config, error) {
+					return Config{}, ErrSectionDoesNotExist
+				}),
+				Base: mockServer.Client().Transport,
+			},
+			withError: ErrSectionDoesNotExist,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &http.Client{Transport: test.transport}
+			req, err := http.NewRequest(http.MethodPost, "/test", strings.NewReader(`{"name":"test"}`))
+			require.NoError(t, err)
+
+			resp, err := client.Do(req)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %v; got: %v", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			body, err := ioutil.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, `{"name":"test"}`, string(body))
+			assert.Empty(t, req.Header.Get("Authorization"), "original request must not be modified")
+			assert.Empty(t, req.URL.Host)
+		})
+	}
+}
@@ -1,21 +1,55 @@
 package edgegrid
 
 import (
+	"bytes"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/base64"
 	"regexp"
+	"sync"
 )
 
-var whitespaceRegexp = regexp.MustCompile("\\s{2,}")
+// maxPooledBufferSize limits the size of buffers returned to bufferPool, so that a single large body is not retained
+const maxPooledBufferSize = 1 << 20
+
+var (
+	whitespaceRegexp = regexp.MustCompile("\\s{2,}")
+
+	// bufferPool holds buffers used to read request bodies and build signed messages
+	bufferPool = sync.Pool{
+		New: func() interface{} {
+			return new(bytes.Buffer)
+		},
+	}
+
+	// copyBufferPool holds buffers used to stream request bodies into the content hash
+	copyBufferPool = sync.Pool{
+		New: func() interface{} {
+			buf := make([]byte, 32*1024)
+			return &buf
+		},
+	}
+)
+
+func getBuffer() *bytes.Buffer {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	return buf
+}
+
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() <= maxPooledBufferSize {
+		bufferPool.Put(buf)
+	}
+}
 
 func stringMinifier(in string) string {
 	return whitespaceRegexp.ReplaceAllString(in, " ")
 }
 
-func createSignature(message string, secret string) string {
+func createSignature(message []byte, secret string) string {
 	key := []byte(secret)
 	h := hmac.New(sha256.New, key)
-	h.Write([]byte(message))
+	h.Write(message)
 	return base64.StdEncoding.EncodeToString(h.Sum(nil))
 }
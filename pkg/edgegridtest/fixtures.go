@@ -0,0 +1,94 @@
+package edgegridtest
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+//go:embed fixtures
+var fixtures embed.FS
+
+// AppSec returns routes serving Application Security fixtures:
+// security configurations 43253 and 43254, with versions of configuration 43253.
+// Version 7 of configuration 43253 has website match target 2971336 and API match target 2971337
+// for security policy AAAA_81230, whose attack groups are SQL, XSS and CMD.
+func AppSec() []Route {
+	return []Route{
+		fixture(http.MethodGet, "/appsec/v1/configs", http.StatusOK, "appsec/configs.json"),
+		fixture(http.MethodGet, "/appsec/v1/configs/43253", http.StatusOK, "appsec/config_43253.json"),
+		fixture(http.MethodGet, "/appsec/v1/configs/43253/versions", http.StatusOK, "appsec/config_43253_versions.json"),
+		fixture(http.MethodGet, "/appsec/v1/configs/43253/versions/7/match-targets", http.StatusOK, "appsec/match_targets_43253_v7.json"),
+		fixture(http.MethodGet, "/appsec/v1/configs/43253/versions/7/match-targets/2971336", http.StatusOK, "appsec/match_target_2971336.json"),
+		fixture(http.MethodGet, "/appsec/v1/configs/43253/versions/7/security-policies/AAAA_81230/attack-groups", http.StatusOK, "appsec/attack_groups_AAAA_81230.json"),
+		fixture(http.MethodGet, "/appsec/v1/configs/43253/versions/7/security-policies/AAAA_81230/attack-groups/SQL", http.StatusOK, "appsec/attack_group_SQL.json"),
+	}
+}
+
+// PAPI returns routes serving Property Manager fixtures:
+// contract ctr_1-1TJZFW, groups grp_15166 and grp_15225 and property prp_175780 in group grp_15166.
+// Creating a property in group grp_15166 returns prp_175781.
+func PAPI() []Route {
+	return []Route{
+		fixture(http.MethodGet, "/papi/v1/contracts", http.StatusOK, "papi/contracts.json"),
+		fixture(http.MethodGet, "/papi/v1/groups", http.StatusOK, "papi/groups.json"),
+		fixture(http.MethodGet, "/papi/v1/properties?contractId=ctr_1-1TJZFW&groupId=grp_15166", http.StatusOK, "papi/properties.json"),
+		fixture(http.MethodGet, "/papi/v1/properties/prp_175780", http.StatusOK, "papi/property_prp_175780.json"),
+		fixture(http.MethodPost, "/papi/v1/properties?contractId=ctr_1-1TJZFW&groupId=grp_15166", http.StatusCreated, "papi/property_created.json"),
+	}
+}
+
+// DNS returns routes serving Edge DNS fixtures:
+// primary zone example.com with its record sets and secondary zone example.net
+func DNS() []Route {
+	return []Route{
+		fixture(http.MethodGet, "/config-dns/v2/zones", http.StatusOK, "dns/zones.json"),
+		fixture(http.MethodGet, "/config-dns/v2/zones/example.com", http.StatusOK, "dns/zone_example.com.json"),
+		fixture(http.MethodGet, "/config-dns/v2/zones/example.com/recordsets", http.StatusOK, "dns/recordsets_example.com.json"),
+	}
+}
+
+// All returns routes serving all available fixtures
+func All() []Route {
+	var routes []Route
+	for _, r := range [][]Route{AppSec(), PAPI(), DNS()} {
+		routes = append(routes, r...)
+	}
+	return routes
+}
+
+// JSONRoute returns a route serving v marshaled as JSON, e.g. to register a response built from SDK types
+func JSONRoute(method, path string, status int, v interface{}) Route {
+	body, err := json.Marshal(v)
+	if err != nil {
+		panic(fmt.Sprintf("edgegridtest: marshaling response of %s %s: %s", method, path, err))
+	}
+	return Route{Method: method, Path: path, Status: status, Body: body}
+}
+
+// ProblemRoute returns a route serving an RFC 7807 problem details error, like the ones returned by Akamai APIs
+func ProblemRoute(method, path string, status int, title, detail string) Route {
+	body, _ := json.Marshal(map[string]interface{}{
+		"type":   "https://problems.luna.akamaiapis.net/edgegridtest/error",
+		"title":  title,
+		"status": status,
+		"detail": detail,
+	})
+	return Route{
+		Method: method,
+		Path:   path,
+		Status: status,
+		Header: http.Header{"Content-Type": []string{"application/problem+json"}},
+		Body:   body,
+	}
+}
+
+func fixture(method, path string, status int, file string) Route {
+	body, err := fixtures.ReadFile("fixtures/" + file)
+	if err != nil {
+		// fixtures are embedded, so this can only happen on a typo in the file name
+		panic(err)
+	}
+	return Route{Method: method, Path: path, Status: status, Body: body}
+}
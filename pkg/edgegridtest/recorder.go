@@ -0,0 +1,346 @@
+package edgegridtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/edgegrid"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/session"
+)
+
+type (
+	// Recorder is an http.RoundTripper recording requests sent to Akamai APIs and their responses into a cassette file,
+	// or replaying them from it, so that tests of SDK based code can run offline against real API responses
+	Recorder struct {
+		path      string
+		mode      Mode
+		transport http.RoundTripper
+		redact    redactions
+
+		mu           sync.Mutex
+		interactions []Interaction
+		used         []bool
+	}
+
+	// RecorderOptions configures a Recorder
+	RecorderOptions struct {
+		// Mode tells whether to record or replay interactions, defaults to ModeReplay
+		Mode Mode
+		// Transport sends requests in ModeRecord, defaults to http.DefaultTransport
+		Transport http.RoundTripper
+		// RedactHeaders lists request and response headers whose values are redacted,
+		// in addition to Authorization, Cookie and Set-Cookie
+		RedactHeaders []string
+		// RedactQuery lists query parameters whose values are redacted, in addition to accountSwitchKey
+		RedactQuery []string
+		// RedactFields lists the names of JSON object fields whose values are redacted in request
+		// and response bodies, at any depth
+		RedactFields []string
+	}
+
+	// Mode tells whether a Recorder records or replays interactions
+	Mode int
+
+	// Cassette is the content of a cassette file
+	Cassette struct {
+		Interactions []Interaction `json:"interactions"`
+	}
+
+	// Interaction is a recorded request and its response
+	Interaction struct {
+		Request  RecordedRequest  `json:"request"`
+		Response RecordedResponse `json:"response"`
+	}
+
+	// RecordedRequest is a request of an Interaction. The host is not recorded, as it is part of the credentials.
+	RecordedRequest struct {
+		Method string      `json:"method"`
+		Path   string      `json:"path"`
+		Query  url.Values  `json:"query,omitempty"`
+		Header http.Header `json:"header,omitempty"`
+		Body   string      `json:"body,omitempty"`
+	}
+
+	// RecordedResponse is a response of an Interaction
+	RecordedResponse struct {
+		Status int         `json:"status"`
+		Header http.Header `json:"header,omitempty"`
+		Body   string      `json:"body,omitempty"`
+	}
+
+	redactions struct {
+		headers []string
+		query   []string
+		fields  map[string]struct{}
+	}
+)
+
+const (
+	// ModeReplay serves responses from the cassette without sending requests
+	ModeReplay Mode = iota
+	// ModeRecord sends requests and records them with their responses, see Recorder.Save
+	ModeRecord
+)
+
+// Redacted replaces redacted values in cassettes
+const Redacted = "REDACTED"
+
+var (
+	// ErrNoInteraction is returned in ModeReplay for requests which were not recorded
+	ErrNoInteraction = errors.New("no recorded interaction")
+	// ErrCassette is returned when the cassette file cannot be read or written
+	ErrCassette = errors.New("cassette")
+)
+
+// NewRecorder returns a recorder of the cassette file at path. In ModeReplay, the cassette is loaded from the file,
+// while in ModeRecord, recorded interactions are written to it by Save, e.g.:
+//
+//	mode := edgegridtest.ModeReplay
+//	if os.Getenv("RECORD") != "" {
+//		mode = edgegridtest.ModeRecord
+//	}
+//	rec, err := edgegridtest.NewRecorder("testdata/match_targets.json", edgegridtest.RecorderOptions{Mode: mode})
+//	require.NoError(t, err)
+//	defer rec.Save()
+//	sess, err := rec.Session(session.WithSigner(edgerc))
+//
+// Requests are replayed in the order they were recorded: each request gets the response of the first unused
+// interaction with the same method, path, query and body, after redaction.
+func NewRecorder(path string, opts RecorderOptions) (*Recorder, error) {
+	r := &Recorder{
+		path:      path,
+		mode:      opts.Mode,
+		transport: opts.Transport,
+		redact: redactions{
+			headers: append([]string{"Authorization", "Cookie", "Set-Cookie"}, opts.RedactHeaders...),
+			query:   append([]string{"accountSwitchKey"}, opts.RedactQuery...),
+			fields:  make(map[string]struct{}, len(opts.RedactFields)),
+		},
+	}
+	if r.transport == nil {
+		r.transport = http.DefaultTransport
+	}
+	for _, f := range opts.RedactFields {
+		r.redact.fields[strings.ToLower(f)] = struct{}{}
+	}
+
+	if r.mode == ModeReplay {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrCassette, err)
+		}
+		var cassette Cassette
+		if err := json.Unmarshal(data, &cassette); err != nil {
+			return nil, fmt.Errorf("%w: %s: %s", ErrCassette, path, err)
+		}
+		r.interactions = cassette.Interactions
+		r.used = make([]bool, len(r.interactions))
+	}
+	return r, nil
+}
+
+// Client returns an HTTP client sending requests through the recorder
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// Session returns a session sending requests through the recorder, additional options are applied after
+// the ones configuring the client and the signer. Requests are signed with dummy credentials, which are enough
+// in ModeReplay, while ModeRecord requires options setting the real ones.
+func (r *Recorder) Session(opts ...session.Option) (session.Session, error) {
+	opts = append([]session.Option{
+		session.WithClient(r.Client()),
+		session.WithSigner(&edgegrid.Config{Host: "akab-replay.luna.akamaiapis.net"}),
+	}, opts...)
+
+	return session.New(opts...)
+}
+
+// Interactions returns the recorded or loaded interactions
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Interaction(nil), r.interactions...)
+}
+
+// Save writes the recorded interactions to the cassette file in ModeRecord, and does nothing in ModeReplay
+func (r *Recorder) Save() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+	data, err := json.MarshalIndent(Cassette{Interactions: r.Interactions()}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrCassette, err)
+	}
+	if err := ioutil.WriteFile(r.path, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("%w: %s", ErrCassette, err)
+	}
+	return nil
+}
+
+// RoundTrip records or replays the request
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	// the request of the caller must not be modified, its body is read from a clone
+	out := req.Clone(req.Context())
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+		out.Body = body
+	}
+
+	recorded, err := r.recordRequest(out)
+	if err != nil {
+		return nil, err
+	}
+	if r.mode == ModeReplay {
+		return r.replay(req, recorded)
+	}
+
+	resp, err := r.transport.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+	resp.Request = req
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	r.mu.Lock()
+	r.interactions = append(r.interactions, Interaction{
+		Request: recorded,
+		Response: RecordedResponse{
+			Status: resp.StatusCode,
+			Header: r.redact.header(resp.Header),
+			Body:   r.redact.body(body),
+		},
+	})
+	r.mu.Unlock()
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.interactions {
+		if r.used[i] || !interaction.Request.matches(recorded) {
+			continue
+		}
+		r.used[i] = true
+		resp := interaction.Response
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", resp.Status, http.StatusText(resp.Status)),
+			StatusCode:    resp.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        resp.Header.Clone(),
+			Body:          ioutil.NopCloser(strings.NewReader(resp.Body)),
+			ContentLength: int64(len(resp.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("%w: %s %s", ErrNoInteraction, req.Method, req.URL.RequestURI())
+}
+
+// recordRequest returns the redacted request, restoring the body of req
+func (r *Recorder) recordRequest(req *http.Request) (RecordedRequest, error) {
+	var body []byte
+	if req.Body != nil {
+		data, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return RecordedRequest{}, err
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(data))
+		body = data
+	}
+
+	query := req.URL.Query()
+	for _, name := range r.redact.query {
+		if _, ok := query[name]; ok {
+			query.Set(name, Redacted)
+		}
+	}
+	if len(query) == 0 {
+		query = nil
+	}
+	return RecordedRequest{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  query,
+		Header: r.redact.header(req.Header),
+		Body:   r.redact.body(body),
+	}, nil
+}
+
+func (rr RecordedRequest) matches(other RecordedRequest) bool {
+	if rr.Method != other.Method || rr.Path != other.Path || rr.Body != other.Body || len(rr.Query) != len(other.Query) {
+		return false
+	}
+	return queryMatches(rr.Query, other.Query)
+}
+
+func (rd redactions) header(h http.Header) http.Header {
+	if len(h) == 0 {
+		return nil
+	}
+	h = h.Clone()
+	for _, name := range rd.headers {
+		if _, ok := h[http.CanonicalHeaderKey(name)]; ok {
+			h.Set(name, Redacted)
+		}
+	}
+	return h
+}
+
+// body redacts the configured fields of a JSON body; other bodies are returned unchanged
+func (rd redactions) body(body []byte) string {
+	if len(rd.fields) == 0 || !json.Valid(body) {
+		return string(body)
+	}
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil || !rd.value(v) {
+		return string(body)
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return string(body)
+	}
+	return string(data)
+}
+
+// value redacts the fields of JSON objects in v and reports whether any was found
+func (rd redactions) value(v interface{}) bool {
+	var redacted bool
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, field := range v {
+			if _, ok := rd.fields[strings.ToLower(k)]; ok {
+				v[k] = Redacted
+				redacted = true
+				continue
+			}
+			redacted = rd.value(field) || redacted
+		}
+	case []interface{}:
+		for _, item := range v {
+			redacted = rd.value(item) || redacted
+		}
+	}
+	return redacted
+}
@@ -0,0 +1,112 @@
+package edgegridtest
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/appsec"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/edgegrid"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder(t *testing.T) {
+	srv := NewServer(AppSec(), []Route{
+		{
+			Method: http.MethodPost,
+			Path:   "/secrets",
+			Header: http.Header{"Set-Cookie": []string{"session=abc"}},
+			Body:   []byte(`{"id":1,"credentials":{"password":"p4ss"}}`),
+		},
+	})
+	defer srv.Close()
+	cassette := filepath.Join(t.TempDir(), "cassette.json")
+	ctx := context.Background()
+
+	rec, err := NewRecorder(cassette, RecorderOptions{
+		Mode:         ModeRecord,
+		Transport:    srv.Client().Transport,
+		RedactFields: []string{"password"},
+	})
+	require.NoError(t, err)
+	sess, err := rec.Session(
+		session.WithSigner(&edgegrid.Config{Host: srv.Listener.Addr().String(), AccountKey: "1-ABCD"}),
+	)
+	require.NoError(t, err)
+
+	configs, err := appsec.Client(sess).GetConfigurations(ctx, appsec.GetConfigurationsRequest{})
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodPost, "/secrets", nil)
+	require.NoError(t, err)
+	_, err = sess.Exec(req, nil, map[string]string{"password": "hunter2", "name": "a"})
+	require.NoError(t, err)
+	require.NoError(t, rec.Save())
+
+	data, err := ioutil.ReadFile(cassette)
+	require.NoError(t, err)
+	for _, secret := range []string{"1-ABCD", "hunter2", "p4ss", "session=abc", "EG1-HMAC-SHA256", srv.Listener.Addr().String()} {
+		assert.NotContains(t, string(data), secret)
+	}
+	interactions := rec.Interactions()
+	require.Len(t, interactions, 2)
+	assert.Equal(t, "/appsec/v1/configs", interactions[0].Request.Path)
+	assert.Equal(t, Redacted, interactions[0].Request.Query.Get("accountSwitchKey"))
+	assert.Equal(t, `{"name":"a","password":"REDACTED"}`, interactions[1].Request.Body)
+	assert.Equal(t, `{"credentials":{"password":"REDACTED"},"id":1}`, interactions[1].Response.Body)
+
+	t.Run("replay", func(t *testing.T) {
+		rec, err := NewRecorder(cassette, RecorderOptions{RedactFields: []string{"password"}})
+		require.NoError(t, err)
+		sess, err := rec.Session(session.WithAccountSwitchKey("1-EFGH"))
+		require.NoError(t, err)
+
+		replayed, err := appsec.Client(sess).GetConfigurations(ctx, appsec.GetConfigurationsRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, configs, replayed)
+
+		req, err := http.NewRequest(http.MethodPost, "/secrets", nil)
+		require.NoError(t, err)
+		var out struct {
+			ID int `json:"id"`
+		}
+		resp, err := sess.Exec(req, &out, map[string]string{"password": "other", "name": "a"})
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, 1, out.ID)
+
+		// each interaction is replayed once
+		_, err = appsec.Client(sess).GetConfigurations(ctx, appsec.GetConfigurationsRequest{})
+		assert.True(t, errors.Is(err, ErrNoInteraction), "want: %s; got: %s", ErrNoInteraction, err)
+		assert.Len(t, srv.Requests(), 2)
+	})
+
+	t.Run("request of the caller is not modified", func(t *testing.T) {
+		rec, err := NewRecorder(filepath.Join(t.TempDir(), "cassette.json"), RecorderOptions{
+			Mode:      ModeRecord,
+			Transport: srv.Client().Transport,
+		})
+		require.NoError(t, err)
+
+		body := strings.NewReader(`{"name":"a"}`)
+		req, err := http.NewRequest(http.MethodPost, srv.URL+"/secrets", body)
+		require.NoError(t, err)
+		callerBody := req.Body
+		resp, err := rec.RoundTrip(req)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Same(t, req, resp.Request)
+		assert.Equal(t, callerBody, req.Body)
+		assert.Equal(t, `{"name":"a"}`, rec.Interactions()[0].Request.Body)
+	})
+
+	t.Run("missing cassette", func(t *testing.T) {
+		_, err := NewRecorder(filepath.Join(t.TempDir(), "missing.json"), RecorderOptions{})
+		assert.True(t, errors.Is(err, ErrCassette), "want: %s; got: %s", ErrCassette, err)
+	})
+}
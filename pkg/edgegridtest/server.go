@@ -0,0 +1,181 @@
+// Package edgegridtest provides an in-process fake Akamai API server preloaded with canned fixtures,
+// allowing end-to-end tests of SDK based workflows to run offline
+package edgegridtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/edgegrid"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/session"
+)
+
+type (
+	// Server is a fake Akamai API server answering requests with registered routes
+	Server struct {
+		*httptest.Server
+
+		mu       sync.Mutex
+		routes   []Route
+		requests []Request
+	}
+
+	// Route is a canned response served for matching requests
+	Route struct {
+		// Method is the HTTP method of the request
+		Method string
+		// Path is the request path. Query parameters given in the path have to be present in the request
+		// with the same values, while other request query parameters are ignored.
+		Path string
+		// Status is the response status code, defaults to 200
+		Status int
+		// Header contains additional response headers
+		Header http.Header
+		// Body is the response body, served as application/json
+		Body []byte
+	}
+
+	// Request is a request received by the server
+	Request struct {
+		Method string
+		Path   string
+		Query  url.Values
+		Header http.Header
+		Body   []byte
+	}
+)
+
+// NewServer starts a TLS server serving given routes, e.g.:
+//
+//	srv := edgegridtest.NewServer(edgegridtest.AppSec(), edgegridtest.PAPI())
+//	defer srv.Close()
+//	sess, err := srv.Session()
+//
+// The server has to be closed by the caller.
+func NewServer(routes ...[]Route) *Server {
+	s := &Server{}
+	for _, r := range routes {
+		s.Handle(r...)
+	}
+	s.Server = httptest.NewTLSServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Handle registers additional routes. Routes registered later take precedence over earlier ones
+// matching the same request, which allows overriding fixtures in a single test.
+func (s *Server) Handle(routes ...Route) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.routes = append(s.routes, routes...)
+}
+
+// Requests returns the requests received by the server in the order they arrived
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Request(nil), s.requests...)
+}
+
+// Session returns a session sending requests to the server, additional options are applied after
+// the ones configuring the client and the signer
+func (s *Server) Session(opts ...session.Option) (session.Session, error) {
+	serverURL, err := url.Parse(s.URL)
+	if err != nil {
+		return nil, err
+	}
+	opts = append([]session.Option{
+		session.WithClient(s.Client()),
+		session.WithSigner(&edgegrid.Config{Host: serverURL.Host}),
+	}, opts...)
+
+	return session.New(opts...)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeProblem(w, http.StatusBadRequest, "Bad Request", err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query(),
+		Header: r.Header.Clone(),
+		Body:   body,
+	})
+	route, ok := s.match(r)
+	s.mu.Unlock()
+
+	if !ok {
+		writeProblem(w, http.StatusNotFound, "Not Found", fmt.Sprintf("no fixture for %s %s", r.Method, r.URL.RequestURI()))
+		return
+	}
+
+	for name, values := range route.Header {
+		for _, v := range values {
+			w.Header().Add(name, v)
+		}
+	}
+	if len(route.Body) > 0 && w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "application/json")
+	}
+	status := route.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	w.WriteHeader(status)
+	_, _ = w.Write(route.Body)
+}
+
+// match returns the most recently registered route matching the request
+func (s *Server) match(r *http.Request) (Route, bool) {
+	for i := len(s.routes) - 1; i >= 0; i-- {
+		route := s.routes[i]
+		if route.Method != r.Method {
+			continue
+		}
+		u, err := url.Parse(route.Path)
+		if err != nil || u.Path != r.URL.Path {
+			continue
+		}
+		if queryMatches(u.Query(), r.URL.Query()) {
+			return route, true
+		}
+	}
+	return Route{}, false
+}
+
+func queryMatches(expected, actual url.Values) bool {
+	for name, values := range expected {
+		if len(actual[name]) != len(values) {
+			return false
+		}
+		for i, v := range values {
+			if actual[name][i] != v {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func writeProblem(w http.ResponseWriter, status int, title, detail string) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"type":   "https://problems.luna.akamaiapis.net/edgegridtest/error",
+		"title":  title,
+		"status": status,
+		"detail": detail,
+	})
+}
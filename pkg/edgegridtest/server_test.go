@@ -0,0 +1,162 @@
+package edgegridtest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/appsec"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/dns"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/papi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFixtures(t *testing.T) {
+	srv := NewServer(All())
+	defer srv.Close()
+	sess, err := srv.Session(session.WithStrictResponses(session.StrictError))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("appsec", func(t *testing.T) {
+		client := appsec.Client(sess)
+		configs, err := client.GetConfigurations(ctx, appsec.GetConfigurationsRequest{})
+		require.NoError(t, err)
+		require.Len(t, configs.Configurations, 2)
+		assert.Equal(t, 43253, configs.Configurations[0].ID)
+
+		versions, err := client.GetConfigurationVersions(ctx, appsec.GetConfigurationVersionsRequest{ConfigID: 43253})
+		require.NoError(t, err)
+		assert.Len(t, versions.VersionList, 3)
+
+		targets, err := client.GetMatchTargets(ctx, appsec.GetMatchTargetsRequest{ConfigID: 43253, ConfigVersion: 7})
+		require.NoError(t, err)
+		require.Len(t, targets.MatchTargets.WebsiteTargets, 1)
+		require.Len(t, targets.MatchTargets.APITargets, 1)
+		assert.Equal(t, "AAAA_81230", targets.MatchTargets.APITargets[0].SecurityPolicy.PolicyID)
+
+		target, err := client.GetMatchTarget(ctx, appsec.GetMatchTargetRequest{ConfigID: 43253, ConfigVersion: 7, TargetID: 2971336})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"www.example.com", "api.example.com"}, target.Hostnames)
+
+		groups, err := client.GetAttackGroups(ctx, appsec.GetAttackGroupsRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230"})
+		require.NoError(t, err)
+		require.Len(t, groups.AttackGroups, 3)
+		assert.NotNil(t, groups.AttackGroups[2].ConditionException)
+
+		group, err := client.GetAttackGroup(ctx, appsec.GetAttackGroupRequest{ConfigID: 43253, Version: 7, PolicyID: "AAAA_81230", Group: "SQL"})
+		require.NoError(t, err)
+		assert.Equal(t, "deny", group.Action)
+	})
+
+	t.Run("papi", func(t *testing.T) {
+		client := papi.Client(sess)
+		contracts, err := client.GetContracts(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "ctr_1-1TJZFW", contracts.Contracts.Items[0].ContractID)
+
+		property, err := client.GetProperty(ctx, papi.GetPropertyRequest{
+			PropertyID: "prp_175780",
+			ContractID: "ctr_1-1TJZFW",
+			GroupID:    "grp_15166",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "www.example.com", property.Property.PropertyName)
+
+		created, err := client.CreateProperty(ctx, papi.CreatePropertyRequest{
+			ContractID: "ctr_1-1TJZFW",
+			GroupID:    "grp_15166",
+			Property: papi.PropertyCreate{
+				ProductID:    "prd_Fresca",
+				PropertyName: "new.example.com",
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "prp_175781", created.PropertyID)
+	})
+
+	t.Run("dns", func(t *testing.T) {
+		client := dns.Client(sess)
+		zones, err := client.ListZones(ctx)
+		require.NoError(t, err)
+		assert.Len(t, zones.Zones, 2)
+
+		recordsets, err := client.GetRecordsets(ctx, "example.com")
+		require.NoError(t, err)
+		assert.Len(t, recordsets.Recordsets, 4)
+	})
+}
+
+func TestServer(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("unknown route returns not found", func(t *testing.T) {
+		srv := NewServer(PAPI())
+		defer srv.Close()
+		sess, err := srv.Session()
+		require.NoError(t, err)
+
+		_, err = papi.Client(sess).GetProperty(ctx, papi.GetPropertyRequest{PropertyID: "prp_1", ContractID: "ctr_1", GroupID: "grp_1"})
+		assert.True(t, errors.Is(err, session.ErrNotFound), "want: %s; got: %s", session.ErrNotFound, err)
+	})
+
+	t.Run("query parameters must match", func(t *testing.T) {
+		srv := NewServer(PAPI())
+		defer srv.Close()
+		sess, err := srv.Session()
+		require.NoError(t, err)
+
+		_, err = papi.Client(sess).GetProperties(ctx, papi.GetPropertiesRequest{ContractID: "ctr_1-1TJZFW", GroupID: "grp_1"})
+		assert.True(t, errors.Is(err, session.ErrNotFound), "want: %s; got: %s", session.ErrNotFound, err)
+	})
+
+	t.Run("later routes override fixtures and requests are recorded", func(t *testing.T) {
+		srv := NewServer(PAPI())
+		defer srv.Close()
+		srv.Handle(Route{
+			Method: http.MethodGet,
+			Path:   "/papi/v1/contracts",
+			Status: http.StatusForbidden,
+			Body:   []byte(`{"type":"forbidden","title":"Forbidden","status":403}`),
+		})
+		sess, err := srv.Session()
+		require.NoError(t, err)
+
+		_, err = papi.Client(sess).GetContracts(ctx)
+		assert.True(t, errors.Is(err, session.ErrForbidden), "want: %s; got: %s", session.ErrForbidden, err)
+
+		requests := srv.Requests()
+		require.Len(t, requests, 1)
+		assert.Equal(t, http.MethodGet, requests[0].Method)
+		assert.Equal(t, "/papi/v1/contracts", requests[0].Path)
+		assert.Contains(t, requests[0].Header.Get("Authorization"), "EG1-HMAC-SHA256")
+	})
+}
+
+func TestRoutes(t *testing.T) {
+	ctx := context.Background()
+	srv := NewServer(
+		[]Route{
+			JSONRoute(http.MethodGet, "/appsec/v1/configs", http.StatusOK, map[string]interface{}{
+				"configurations": []map[string]interface{}{{"id": 1, "name": "custom"}},
+			}),
+			ProblemRoute(http.MethodGet, "/appsec/v1/configs/2", http.StatusNotFound, "Not Found", "configuration 2 does not exist"),
+		},
+	)
+	defer srv.Close()
+	sess, err := srv.Session()
+	require.NoError(t, err)
+	client := appsec.Client(sess)
+
+	configs, err := client.GetConfigurations(ctx, appsec.GetConfigurationsRequest{})
+	require.NoError(t, err)
+	require.Len(t, configs.Configurations, 1)
+	assert.Equal(t, "custom", configs.Configurations[0].Name)
+
+	_, err = client.GetConfiguration(ctx, appsec.GetConfigurationRequest{ConfigID: 2})
+	assert.True(t, errors.Is(err, session.ErrNotFound), "want: %s; got: %s", session.ErrNotFound, err)
+	assert.Contains(t, err.Error(), "configuration 2 does not exist")
+}
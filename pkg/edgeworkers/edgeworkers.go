@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/session"
+	"github.com/apex/log"
 )
 
 var (
@@ -35,6 +36,7 @@ type (
 
 	edgeworkers struct {
 		session.Session
+		options session.ClientOptions
 	}
 
 	// Option defines an Edgeworkers option
@@ -53,5 +55,56 @@ func Client(sess session.Session, opts ...Option) Edgeworkers {
 	for _, opt := range opts {
 		opt(e)
 	}
+	e.Session = e.options.Apply(e.Session)
 	return e
 }
+
+// WithLogger sets the logger used by the EdgeWorkers client instead of the session logger
+func WithLogger(l log.Interface) Option {
+	return func(e *edgeworkers) {
+		e.options.Logger = l
+	}
+}
+
+// WithRetries sets how many times idempotent EdgeWorkers requests are retried after transient failures
+func WithRetries(retries int) Option {
+	return func(e *edgeworkers) {
+		e.options.Retries = retries
+	}
+}
+
+// WithRetryClassifier sets the callback deciding whether failed EdgeWorkers requests are retried, see session.RetryClassifier
+func WithRetryClassifier(classifier session.RetryClassifier) Option {
+	return func(e *edgeworkers) {
+		e.options.RetryClassifier = classifier
+	}
+}
+
+// WithBackoff sets the strategy computing delays between retries of failed EdgeWorkers requests, see session.Backoff
+func WithBackoff(backoff session.Backoff) Option {
+	return func(e *edgeworkers) {
+		e.options.Backoff = backoff
+	}
+}
+
+// WithIdempotencyKeys adds an idempotency key to EdgeWorkers POST and PATCH requests, kept when they are
+// retried; it does not make them retried by itself, see session.WithIdempotencyKeys
+func WithIdempotencyKeys(enabled bool) Option {
+	return func(e *edgeworkers) {
+		e.options.IdempotencyKeys = enabled
+	}
+}
+
+// WithBaseURL overrides the scheme and host EdgeWorkers requests are sent to
+func WithBaseURL(baseURL string) Option {
+	return func(e *edgeworkers) {
+		e.options.BaseURL = baseURL
+	}
+}
+
+// WithAccountSwitchKey makes EdgeWorkers requests act on the account with given switch key
+func WithAccountSwitchKey(key string) Option {
+	return func(e *edgeworkers) {
+		e.options.AccountSwitchKey = key
+	}
+}
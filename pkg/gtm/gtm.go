@@ -8,6 +8,7 @@ import (
 	"net/http"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/session"
+	"github.com/apex/log"
 )
 
 var (
@@ -29,6 +30,7 @@ type (
 
 	gtm struct {
 		session.Session
+		options session.ClientOptions
 	}
 
 	// Option defines a GTM option
@@ -47,9 +49,60 @@ func Client(sess session.Session, opts ...Option) GTM {
 	for _, opt := range opts {
 		opt(p)
 	}
+	p.Session = p.options.Apply(p.Session)
 	return p
 }
 
+// WithLogger sets the logger used by the GTM client instead of the session logger
+func WithLogger(l log.Interface) Option {
+	return func(p *gtm) {
+		p.options.Logger = l
+	}
+}
+
+// WithRetries sets how many times idempotent GTM requests are retried after transient failures
+func WithRetries(retries int) Option {
+	return func(p *gtm) {
+		p.options.Retries = retries
+	}
+}
+
+// WithRetryClassifier sets the callback deciding whether failed GTM requests are retried, see session.RetryClassifier
+func WithRetryClassifier(classifier session.RetryClassifier) Option {
+	return func(p *gtm) {
+		p.options.RetryClassifier = classifier
+	}
+}
+
+// WithBackoff sets the strategy computing delays between retries of failed GTM requests, see session.Backoff
+func WithBackoff(backoff session.Backoff) Option {
+	return func(p *gtm) {
+		p.options.Backoff = backoff
+	}
+}
+
+// WithIdempotencyKeys adds an idempotency key to GTM POST and PATCH requests, kept when they are
+// retried; it does not make them retried by itself, see session.WithIdempotencyKeys
+func WithIdempotencyKeys(enabled bool) Option {
+	return func(p *gtm) {
+		p.options.IdempotencyKeys = enabled
+	}
+}
+
+// WithBaseURL overrides the scheme and host GTM requests are sent to
+func WithBaseURL(baseURL string) Option {
+	return func(p *gtm) {
+		p.options.BaseURL = baseURL
+	}
+}
+
+// WithAccountSwitchKey makes GTM requests act on the account with given switch key
+func WithAccountSwitchKey(key string) Option {
+	return func(p *gtm) {
+		p.options.AccountSwitchKey = key
+	}
+}
+
 // Exec overrides the session.Exec to add dns options
 func (p *gtm) Exec(r *http.Request, out interface{}, in ...interface{}) (*http.Response, error) {
 
@@ -2,6 +2,8 @@ package hapi
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"strings"
@@ -66,3 +68,31 @@ func TestNewError(t *testing.T) {
 		})
 	}
 }
+
+func TestErrorIsSessionSentinel(t *testing.T) {
+	tests := map[string]struct {
+		err      error
+		target   error
+		expected bool
+	}{
+		"404 is ErrNotFound": {
+			err:      &Error{Status: http.StatusNotFound},
+			target:   session.ErrNotFound,
+			expected: true,
+		},
+		"wrapped 429 is ErrRateLimited": {
+			err:      fmt.Errorf("%s: %w", ErrGetEdgeHostname, &Error{Status: http.StatusTooManyRequests}),
+			target:   session.ErrRateLimited,
+			expected: true,
+		},
+		"409 is not ErrNotFound": {
+			err:    &Error{Status: http.StatusConflict},
+			target: session.ErrNotFound,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, errors.Is(test.err, test.target))
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"errors"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/session"
+	"github.com/apex/log"
 )
 
 var (
@@ -22,6 +23,7 @@ type (
 
 	hapi struct {
 		session.Session
+		options session.ClientOptions
 	}
 
 	// Option defines a HAPI option
@@ -40,5 +42,56 @@ func Client(sess session.Session, opts ...Option) HAPI {
 	for _, opt := range opts {
 		opt(h)
 	}
+	h.Session = h.options.Apply(h.Session)
 	return h
 }
+
+// WithLogger sets the logger used by the Edge Hostnames client instead of the session logger
+func WithLogger(l log.Interface) Option {
+	return func(h *hapi) {
+		h.options.Logger = l
+	}
+}
+
+// WithRetries sets how many times idempotent Edge Hostnames requests are retried after transient failures
+func WithRetries(retries int) Option {
+	return func(h *hapi) {
+		h.options.Retries = retries
+	}
+}
+
+// WithRetryClassifier sets the callback deciding whether failed Edge Hostnames requests are retried, see session.RetryClassifier
+func WithRetryClassifier(classifier session.RetryClassifier) Option {
+	return func(h *hapi) {
+		h.options.RetryClassifier = classifier
+	}
+}
+
+// WithBackoff sets the strategy computing delays between retries of failed Edge Hostnames requests, see session.Backoff
+func WithBackoff(backoff session.Backoff) Option {
+	return func(h *hapi) {
+		h.options.Backoff = backoff
+	}
+}
+
+// WithIdempotencyKeys adds an idempotency key to Edge Hostnames POST and PATCH requests, kept when they are
+// retried; it does not make them retried by itself, see session.WithIdempotencyKeys
+func WithIdempotencyKeys(enabled bool) Option {
+	return func(h *hapi) {
+		h.options.IdempotencyKeys = enabled
+	}
+}
+
+// WithBaseURL overrides the scheme and host Edge Hostnames requests are sent to
+func WithBaseURL(baseURL string) Option {
+	return func(h *hapi) {
+		h.options.BaseURL = baseURL
+	}
+}
+
+// WithAccountSwitchKey makes Edge Hostnames requests act on the account with given switch key
+func WithAccountSwitchKey(key string) Option {
+	return func(h *hapi) {
+		h.options.AccountSwitchKey = key
+	}
+}
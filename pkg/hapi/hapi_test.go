@@ -1,6 +1,7 @@
 package hapi
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"net/http"
@@ -52,3 +53,22 @@ func TestClient(t *testing.T) {
 		})
 	}
 }
+
+func TestClientOptions(t *testing.T) {
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/hapi/v1/edge-hostnames/1", r.URL.Path)
+		assert.Equal(t, "1-ABCD", r.URL.Query().Get("accountSwitchKey"))
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"edgeHostnameId": 1}`))
+		assert.NoError(t, err)
+	}))
+	defer mockServer.Close()
+
+	sess, err := session.New(session.WithClient(mockServer.Client()), session.WithSigner(&edgegrid.Config{Host: "unused.example.com"}))
+	require.NoError(t, err)
+	client := Client(sess, WithBaseURL(mockServer.URL), WithAccountSwitchKey("1-ABCD"))
+
+	result, err := client.GetEdgeHostname(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.EdgeHostnameID)
+}
@@ -0,0 +1,167 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+type (
+	// AccountSwitchKeys is the IAM API client account switch keys interface
+	AccountSwitchKeys interface {
+		// ListAccountSwitchKeys lists account switch keys available to an API client
+		//
+		// See: https://techdocs.akamai.com/iam-api/reference/get-client-account-switch-keys
+		ListAccountSwitchKeys(context.Context, ListAccountSwitchKeysRequest) (ListAccountSwitchKeysResponse, error)
+	}
+
+	// ListAccountSwitchKeysRequest contains the request parameters for the list account switch keys endpoint
+	ListAccountSwitchKeysRequest struct {
+		// ClientID is the API client to list the keys for, defaults to the client of the credentials
+		ClientID string
+		// Search filters the keys by account name or account switch key
+		Search string
+	}
+
+	// ListAccountSwitchKeysResponse contains the response of the list account switch keys endpoint
+	ListAccountSwitchKeysResponse []AccountSwitchKey
+
+	// AccountSwitchKey describes an account the API client can act on
+	AccountSwitchKey struct {
+		AccountName      string `json:"accountName"`
+		AccountSwitchKey string `json:"accountSwitchKey"`
+	}
+
+	// AccountSwitchKeyResolver resolves account names to account switch keys with ListAccountSwitchKeys,
+	// caching the resolved keys
+	AccountSwitchKeyResolver struct {
+		client AccountSwitchKeys
+		ttl    time.Duration
+		now    func() time.Time
+
+		mu   sync.Mutex
+		keys map[string]resolvedKey
+	}
+
+	resolvedKey struct {
+		key     string
+		expires time.Time
+	}
+)
+
+const selfClientID = "self"
+
+var (
+	// ErrListAccountSwitchKeys is returned when ListAccountSwitchKeys fails
+	ErrListAccountSwitchKeys = errors.New("list account switch keys")
+
+	// ErrAccountNotFound is returned by AccountSwitchKeyResolver when no account has given name
+	ErrAccountNotFound = errors.New("account not found")
+
+	// ErrAmbiguousAccount is returned by AccountSwitchKeyResolver when more than one account has given name
+	ErrAmbiguousAccount = errors.New("ambiguous account name")
+)
+
+func (i *iam) ListAccountSwitchKeys(ctx context.Context, params ListAccountSwitchKeysRequest) (ListAccountSwitchKeysResponse, error) {
+	logger := i.Log(ctx)
+	logger.Debug("ListAccountSwitchKeys")
+
+	clientID := params.ClientID
+	if clientID == "" {
+		clientID = selfClientID
+	}
+	u, err := url.Parse(fmt.Sprintf("/identity-management/v3/api-clients/%s/account-switch-keys", url.PathEscape(clientID)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to parse url: %s", ErrListAccountSwitchKeys, err)
+	}
+	if params.Search != "" {
+		q := u.Query()
+		q.Add("search", params.Search)
+		u.RawQuery = q.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrListAccountSwitchKeys, err)
+	}
+
+	var result ListAccountSwitchKeysResponse
+	resp, err := i.Exec(req, &result)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrListAccountSwitchKeys, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrListAccountSwitchKeys, i.Error(resp))
+	}
+
+	return result, nil
+}
+
+// NewAccountSwitchKeyResolver returns a resolver caching resolved keys for ttl; with zero ttl keys are cached forever
+func NewAccountSwitchKeyResolver(client AccountSwitchKeys, ttl time.Duration) *AccountSwitchKeyResolver {
+	return &AccountSwitchKeyResolver{
+		client: client,
+		ttl:    ttl,
+		now:    time.Now,
+		keys:   make(map[string]resolvedKey),
+	}
+}
+
+// Resolve returns the account switch key of the account with given name, compared case-insensitively
+func (r *AccountSwitchKeyResolver) Resolve(ctx context.Context, accountName string) (string, error) {
+	name := strings.ToLower(accountName)
+	r.mu.Lock()
+	cached, ok := r.keys[name]
+	r.mu.Unlock()
+	if ok && (cached.expires.IsZero() || r.now().Before(cached.expires)) {
+		return cached.key, nil
+	}
+
+	accounts, err := r.client.ListAccountSwitchKeys(ctx, ListAccountSwitchKeysRequest{Search: accountName})
+	if err != nil {
+		return "", err
+	}
+
+	var key string
+	for _, account := range accounts {
+		if strings.ToLower(account.AccountName) != name {
+			continue
+		}
+		if key != "" && key != account.AccountSwitchKey {
+			return "", fmt.Errorf("%w: %q", ErrAmbiguousAccount, accountName)
+		}
+		key = account.AccountSwitchKey
+	}
+	if key == "" {
+		return "", fmt.Errorf("%w: %q", ErrAccountNotFound, accountName)
+	}
+
+	resolved := resolvedKey{key: key}
+	if r.ttl > 0 {
+		resolved.expires = r.now().Add(r.ttl)
+	}
+	r.mu.Lock()
+	r.keys[name] = resolved
+	r.mu.Unlock()
+	return key, nil
+}
+
+// ResolveAll returns the account switch keys of the accounts with given names, in the same order,
+// e.g. to run an operation for these accounts with session.ForEachAccount
+func (r *AccountSwitchKeyResolver) ResolveAll(ctx context.Context, accountNames ...string) ([]string, error) {
+	keys := make([]string, 0, len(accountNames))
+	for _, name := range accountNames {
+		key, err := r.Resolve(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
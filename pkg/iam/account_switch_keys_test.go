@@ -0,0 +1,140 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIAM_ListAccountSwitchKeys(t *testing.T) {
+	tests := map[string]struct {
+		params           ListAccountSwitchKeysRequest
+		responseStatus   int
+		responseBody     string
+		expectedPath     string
+		expectedResponse ListAccountSwitchKeysResponse
+		withError        error
+	}{
+		"200 OK": {
+			params:         ListAccountSwitchKeysRequest{Search: "Example Corp"},
+			responseStatus: http.StatusOK,
+			responseBody: `
+[
+    {
+        "accountName": "Example Corp",
+        "accountSwitchKey": "1-ABCDE:1-2345"
+    }
+]`,
+			expectedPath: "/identity-management/v3/api-clients/self/account-switch-keys?search=Example+Corp",
+			expectedResponse: ListAccountSwitchKeysResponse{
+				{AccountName: "Example Corp", AccountSwitchKey: "1-ABCDE:1-2345"},
+			},
+		},
+		"200 OK with client ID": {
+			params:           ListAccountSwitchKeysRequest{ClientID: "abcd1234"},
+			responseStatus:   http.StatusOK,
+			responseBody:     `[]`,
+			expectedPath:     "/identity-management/v3/api-clients/abcd1234/account-switch-keys",
+			expectedResponse: ListAccountSwitchKeysResponse{},
+		},
+		"500 internal server error": {
+			params:         ListAccountSwitchKeysRequest{},
+			responseStatus: http.StatusInternalServerError,
+			responseBody: `
+{
+    "type": "internal_error",
+    "title": "Internal Server Error",
+    "detail": "Error making request",
+    "status": 500
+}`,
+			expectedPath: "/identity-management/v3/api-clients/self/account-switch-keys",
+			withError: &Error{
+				Type:       "internal_error",
+				Title:      "Internal Server Error",
+				Detail:     "Error making request",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.ListAccountSwitchKeys(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestAccountSwitchKeyResolver(t *testing.T) {
+	accounts := ListAccountSwitchKeysResponse{
+		{AccountName: "Example Corp", AccountSwitchKey: "1-ABCDE:1-2345"},
+		{AccountName: "Example Corp Staging", AccountSwitchKey: "1-FGHIJ:1-2345"},
+	}
+
+	t.Run("resolves and caches keys", func(t *testing.T) {
+		client := &Mock{}
+		client.On("ListAccountSwitchKeys", mock.Anything, ListAccountSwitchKeysRequest{Search: "Example Corp"}).Return(accounts, nil).Once()
+
+		resolver := NewAccountSwitchKeyResolver(client, time.Hour)
+		key, err := resolver.Resolve(context.Background(), "Example Corp")
+		require.NoError(t, err)
+		assert.Equal(t, "1-ABCDE:1-2345", key)
+
+		keys, err := resolver.ResolveAll(context.Background(), "example corp")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"1-ABCDE:1-2345"}, keys)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("refreshes expired keys", func(t *testing.T) {
+		client := &Mock{}
+		client.On("ListAccountSwitchKeys", mock.Anything, ListAccountSwitchKeysRequest{Search: "Example Corp"}).Return(accounts, nil).Twice()
+
+		now := time.Now()
+		resolver := NewAccountSwitchKeyResolver(client, time.Minute)
+		resolver.now = func() time.Time { return now }
+		_, err := resolver.Resolve(context.Background(), "Example Corp")
+		require.NoError(t, err)
+		now = now.Add(2 * time.Minute)
+		_, err = resolver.Resolve(context.Background(), "Example Corp")
+		require.NoError(t, err)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("account not found", func(t *testing.T) {
+		client := &Mock{}
+		client.On("ListAccountSwitchKeys", mock.Anything, ListAccountSwitchKeysRequest{Search: "Example"}).Return(accounts, nil).Once()
+
+		_, err := NewAccountSwitchKeyResolver(client, 0).Resolve(context.Background(), "Example")
+		assert.True(t, errors.Is(err, ErrAccountNotFound), "want: %s; got: %s", ErrAccountNotFound, err)
+	})
+
+	t.Run("ambiguous account", func(t *testing.T) {
+		client := &Mock{}
+		client.On("ListAccountSwitchKeys", mock.Anything, ListAccountSwitchKeysRequest{Search: "Example Corp"}).Return(append(accounts,
+			AccountSwitchKey{AccountName: "EXAMPLE CORP", AccountSwitchKey: "1-KLMNO:1-2345"}), nil).Once()
+
+		_, err := NewAccountSwitchKeyResolver(client, 0).Resolve(context.Background(), "Example Corp")
+		assert.True(t, errors.Is(err, ErrAmbiguousAccount), "want: %s; got: %s", ErrAmbiguousAccount, err)
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/session"
+	"github.com/apex/log"
 )
 
 var (
@@ -15,6 +16,7 @@ var (
 type (
 	// IAM is the IAM api interface
 	IAM interface {
+		AccountSwitchKeys
 		BlockedProperties
 		Groups
 		Roles
@@ -26,6 +28,7 @@ type (
 
 	iam struct {
 		session.Session
+		options session.ClientOptions
 	}
 
 	// Option defines a IAM option
@@ -44,5 +47,56 @@ func Client(sess session.Session, opts ...Option) IAM {
 	for _, opt := range opts {
 		opt(p)
 	}
+	p.Session = p.options.Apply(p.Session)
 	return p
 }
+
+// WithLogger sets the logger used by the IAM client instead of the session logger
+func WithLogger(l log.Interface) Option {
+	return func(p *iam) {
+		p.options.Logger = l
+	}
+}
+
+// WithRetries sets how many times idempotent IAM requests are retried after transient failures
+func WithRetries(retries int) Option {
+	return func(p *iam) {
+		p.options.Retries = retries
+	}
+}
+
+// WithRetryClassifier sets the callback deciding whether failed IAM requests are retried, see session.RetryClassifier
+func WithRetryClassifier(classifier session.RetryClassifier) Option {
+	return func(p *iam) {
+		p.options.RetryClassifier = classifier
+	}
+}
+
+// WithBackoff sets the strategy computing delays between retries of failed IAM requests, see session.Backoff
+func WithBackoff(backoff session.Backoff) Option {
+	return func(p *iam) {
+		p.options.Backoff = backoff
+	}
+}
+
+// WithIdempotencyKeys adds an idempotency key to IAM POST and PATCH requests, kept when they are
+// retried; it does not make them retried by itself, see session.WithIdempotencyKeys
+func WithIdempotencyKeys(enabled bool) Option {
+	return func(p *iam) {
+		p.options.IdempotencyKeys = enabled
+	}
+}
+
+// WithBaseURL overrides the scheme and host IAM requests are sent to
+func WithBaseURL(baseURL string) Option {
+	return func(p *iam) {
+		p.options.BaseURL = baseURL
+	}
+}
+
+// WithAccountSwitchKey makes IAM requests act on the account with given switch key
+func WithAccountSwitchKey(key string) Option {
+	return func(p *iam) {
+		p.options.AccountSwitchKey = key
+	}
+}
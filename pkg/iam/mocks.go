@@ -316,3 +316,13 @@ func (m *Mock) SetUserPassword(ctx context.Context, request SetUserPasswordReque
 
 	return args.Error(0)
 }
+
+func (m *Mock) ListAccountSwitchKeys(ctx context.Context, request ListAccountSwitchKeysRequest) (ListAccountSwitchKeysResponse, error) {
+	args := m.Called(ctx, request)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(ListAccountSwitchKeysResponse), args.Error(1)
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/session"
+	"github.com/apex/log"
 )
 
 var (
@@ -21,6 +22,7 @@ type (
 
 	imaging struct {
 		session.Session
+		options session.ClientOptions
 	}
 
 	// Option defines an Image and Video Manager option
@@ -39,5 +41,56 @@ func Client(sess session.Session, opts ...Option) Imaging {
 	for _, opt := range opts {
 		opt(c)
 	}
+	c.Session = c.options.Apply(c.Session)
 	return c
 }
+
+// WithLogger sets the logger used by the Image and Video Manager client instead of the session logger
+func WithLogger(l log.Interface) Option {
+	return func(c *imaging) {
+		c.options.Logger = l
+	}
+}
+
+// WithRetries sets how many times idempotent Image and Video Manager requests are retried after transient failures
+func WithRetries(retries int) Option {
+	return func(c *imaging) {
+		c.options.Retries = retries
+	}
+}
+
+// WithRetryClassifier sets the callback deciding whether failed Image and Video Manager requests are retried, see session.RetryClassifier
+func WithRetryClassifier(classifier session.RetryClassifier) Option {
+	return func(c *imaging) {
+		c.options.RetryClassifier = classifier
+	}
+}
+
+// WithBackoff sets the strategy computing delays between retries of failed Image and Video Manager requests, see session.Backoff
+func WithBackoff(backoff session.Backoff) Option {
+	return func(c *imaging) {
+		c.options.Backoff = backoff
+	}
+}
+
+// WithIdempotencyKeys adds an idempotency key to Image and Video Manager POST and PATCH requests, kept when they are
+// retried; it does not make them retried by itself, see session.WithIdempotencyKeys
+func WithIdempotencyKeys(enabled bool) Option {
+	return func(c *imaging) {
+		c.options.IdempotencyKeys = enabled
+	}
+}
+
+// WithBaseURL overrides the scheme and host Image and Video Manager requests are sent to
+func WithBaseURL(baseURL string) Option {
+	return func(c *imaging) {
+		c.options.BaseURL = baseURL
+	}
+}
+
+// WithAccountSwitchKey makes Image and Video Manager requests act on the account with given switch key
+func WithAccountSwitchKey(key string) Option {
+	return func(c *imaging) {
+		c.options.AccountSwitchKey = key
+	}
+}
@@ -2,10 +2,12 @@ package networklists
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
 
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/tools"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 )
 
@@ -37,10 +39,10 @@ type (
 
 	// GetActivationsRequest contains request parameters for getting activation status
 	GetActivationsRequest struct {
-		UniqueID     string `json:"-"`
-		Action       string `json:"-"`
-		Network      string `json:"network"`
-		ActivationID int    `json:"activationId"`
+		UniqueID     string       `json:"-"`
+		Action       string       `json:"-"`
+		Network      NetworkValue `json:"network"`
+		ActivationID int          `json:"activationId"`
 	}
 
 	// GetActivationRequest contains request parameters for getting activation details
@@ -50,13 +52,13 @@ type (
 
 	// GetActivationsResponse contains response with activation status
 	GetActivationsResponse struct {
-		ActivationID       int    `json:"activationId"`
-		ActivationComments string `json:"activationComments"`
-		ActivationStatus   string `json:"activationStatus"`
-		SyncPoint          int    `json:"syncPoint"`
-		UniqueID           string `json:"uniqueId"`
-		Fast               bool   `json:"fast"`
-		DispatchCount      int    `json:"dispatchCount"`
+		ActivationID       int         `json:"activationId"`
+		ActivationComments string      `json:"activationComments"`
+		ActivationStatus   StatusValue `json:"activationStatus"`
+		SyncPoint          int         `json:"syncPoint"`
+		UniqueID           string      `json:"uniqueId"`
+		Fast               bool        `json:"fast"`
+		DispatchCount      int         `json:"dispatchCount"`
 		Links              struct {
 			AppendItems struct {
 				Href   string `json:"href"`
@@ -86,15 +88,15 @@ type (
 
 	// GetActivationResponse contains response with activation details
 	GetActivationResponse struct {
-		ActivationID     int       `json:"activationId"`
-		CreateDate       time.Time `json:"createDate"`
-		CreatedBy        string    `json:"createdBy"`
-		Environment      string    `json:"environment"`
-		Fast             bool      `json:"fast"`
-		ActivationStatus string    `json:"status"`
+		ActivationID     int         `json:"activationId"`
+		CreateDate       time.Time   `json:"createDate"`
+		CreatedBy        string      `json:"createdBy"`
+		Environment      string      `json:"environment"`
+		Fast             bool        `json:"fast"`
+		ActivationStatus StatusValue `json:"status"`
 		NetworkList      struct {
-			ActivationComments string `json:"activationComments"`
-			ActivationStatus   string `json:"activationStatus"`
+			ActivationComments string      `json:"activationComments"`
+			ActivationStatus   StatusValue `json:"activationStatus"`
 			Links              struct {
 				AppendItems struct {
 					Href   string `json:"href"`
@@ -124,22 +126,22 @@ type (
 
 	// CreateActivationsRequest contains request parameters for creating new activation
 	CreateActivationsRequest struct {
-		UniqueID               string   `json:"-"`
-		Action                 string   `json:"-"`
-		Network                string   `json:"network"`
-		Comments               string   `json:"comments"`
-		NotificationRecipients []string `json:"notificationRecipients"`
+		UniqueID               string       `json:"-"`
+		Action                 string       `json:"-"`
+		Network                NetworkValue `json:"network"`
+		Comments               string       `json:"comments"`
+		NotificationRecipients []string     `json:"notificationRecipients"`
 	}
 
 	// CreateActivationsResponse contains response after creating new activation
 	CreateActivationsResponse struct {
-		ActivationID       int    `json:"activationId"`
-		ActivationComments string `json:"activationComments"`
-		ActivationStatus   string `json:"activationStatus"`
-		SyncPoint          int    `json:"syncPoint"`
-		UniqueID           string `json:"uniqueId"`
-		Fast               bool   `json:"fast"`
-		DispatchCount      int    `json:"dispatchCount"`
+		ActivationID       int         `json:"activationId"`
+		ActivationComments string      `json:"activationComments"`
+		ActivationStatus   StatusValue `json:"activationStatus"`
+		SyncPoint          int         `json:"syncPoint"`
+		UniqueID           string      `json:"uniqueId"`
+		Fast               bool        `json:"fast"`
+		DispatchCount      int         `json:"dispatchCount"`
 		Links              struct {
 			AppendItems struct {
 				Href   string `json:"href"`
@@ -169,23 +171,23 @@ type (
 
 	// RemoveActivationsRequest contains request parameters of Activation to deactivate
 	RemoveActivationsRequest struct {
-		UniqueID               string   `json:"-"`
-		ActivationID           int      `json:"-"`
-		Action                 string   `json:"action"`
-		Network                string   `json:"network"`
-		Comments               string   `json:"comments"`
-		NotificationRecipients []string `json:"notificationRecipients"`
+		UniqueID               string       `json:"-"`
+		ActivationID           int          `json:"-"`
+		Action                 string       `json:"action"`
+		Network                NetworkValue `json:"network"`
+		Comments               string       `json:"comments"`
+		NotificationRecipients []string     `json:"notificationRecipients"`
 	}
 
 	// RemoveActivationsResponse contains response of Activation deactivation
 	RemoveActivationsResponse struct {
-		ActivationID       int    `json:"activationId"`
-		ActivationComments string `json:"activationComments"`
-		ActivationStatus   string `json:"activationStatus"`
-		SyncPoint          int    `json:"syncPoint"`
-		UniqueID           string `json:"uniqueId"`
-		Fast               bool   `json:"fast"`
-		DispatchCount      int    `json:"dispatchCount"`
+		ActivationID       int         `json:"activationId"`
+		ActivationComments string      `json:"activationComments"`
+		ActivationStatus   StatusValue `json:"activationStatus"`
+		SyncPoint          int         `json:"syncPoint"`
+		UniqueID           string      `json:"uniqueId"`
+		Fast               bool        `json:"fast"`
+		DispatchCount      int         `json:"dispatchCount"`
 		Links              struct {
 			AppendItems struct {
 				Href   string `json:"href"`
@@ -256,6 +258,7 @@ const (
 func (v GetActivationsRequest) Validate() error {
 	return validation.Errors{
 		"UniqueID": validation.Validate(v.UniqueID, validation.Required),
+		"Network":  validation.Validate(v.Network, validation.In(NetworkStaging, NetworkProduction)),
 	}.Filter()
 }
 
@@ -266,6 +269,22 @@ func (v GetActivationRequest) Validate() error {
 	}.Filter()
 }
 
+// Validate validates CreateActivationsRequest
+func (v CreateActivationsRequest) Validate() error {
+	return validation.Errors{
+		"UniqueID": validation.Validate(v.UniqueID, validation.Required),
+		"Network":  validation.Validate(v.Network, validation.Required, validation.In(NetworkStaging, NetworkProduction)),
+	}.Filter()
+}
+
+// Validate validates RemoveActivationsRequest
+func (v RemoveActivationsRequest) Validate() error {
+	return validation.Errors{
+		"UniqueID": validation.Validate(v.UniqueID, validation.Required),
+		"Network":  validation.Validate(v.Network, validation.Required, validation.In(NetworkStaging, NetworkProduction)),
+	}.Filter()
+}
+
 func (p *networklists) GetActivations(ctx context.Context, params GetActivationsRequest) (*GetActivationsResponse, error) {
 	if err := params.Validate(); err != nil {
 		return nil, fmt.Errorf("%w: %s", ErrStructValidation, err.Error())
@@ -330,6 +349,9 @@ func (p *networklists) GetActivation(ctx context.Context, params GetActivationRe
 }
 
 func (p *networklists) CreateActivations(ctx context.Context, params CreateActivationsRequest) (*CreateActivationsResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrStructValidation, err.Error())
+	}
 
 	logger := p.Log(ctx)
 	logger.Debug("CreateActivations")
@@ -377,6 +399,9 @@ func (p *networklists) CreateActivations(ctx context.Context, params CreateActiv
 }
 
 func (p *networklists) RemoveActivations(ctx context.Context, params RemoveActivationsRequest) (*RemoveActivationsResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrStructValidation, err.Error())
+	}
 
 	logger := p.Log(ctx)
 	logger.Debug("RemoveActivations")
@@ -399,3 +424,41 @@ func (p *networklists) RemoveActivations(ctx context.Context, params RemoveActiv
 
 	return &rval, nil
 }
+
+// ErrActivationFailed is returned by WaitForActivation when the activation ends with FAILED or ABORTED status.
+var ErrActivationFailed = errors.New("activation failed")
+
+// WaitForActivation polls GetActivation until the network list activation is activated or deactivated and returns its final state.
+func WaitForActivation(ctx context.Context, client Activations, params GetActivationRequest, opts tools.WaitOptions) (*GetActivationResponse, error) {
+	var result *GetActivationResponse
+	if err := tools.WaitFor(ctx, activationCheck(client, params, &result), opts); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// WatchActivation polls GetActivation like WaitForActivation, delivering an event on the returned channel
+// whenever the network list activation status changes, see tools.WatchChanges.
+func WatchActivation(ctx context.Context, client Activations, params GetActivationRequest, opts tools.WaitOptions) <-chan tools.Event {
+	return tools.WatchChanges(ctx, activationCheck(client, params, nil), opts)
+}
+
+// activationCheck returns a check of the activation state, storing the last response in result if it is not nil
+func activationCheck(client Activations, params GetActivationRequest, result **GetActivationResponse) tools.CheckFunc {
+	return func(ctx context.Context) (string, bool, error) {
+		resp, err := client.GetActivation(ctx, params)
+		if err != nil {
+			return "", false, err
+		}
+		if result != nil {
+			*result = resp
+		}
+		switch resp.ActivationStatus {
+		case StatusActive, StatusDeactivated:
+			return string(resp.ActivationStatus), true, nil
+		case StatusFailed, StatusAborted:
+			return string(resp.ActivationStatus), false, fmt.Errorf("%w: activation %d status %s", ErrActivationFailed, resp.ActivationID, resp.ActivationStatus)
+		}
+		return string(resp.ActivationStatus), false, nil
+	}
+}
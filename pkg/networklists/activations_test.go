@@ -7,9 +7,12 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/session"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/tools"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -121,6 +124,10 @@ func TestAppSec_GetActivations(t *testing.T) {
 				StatusCode: http.StatusInternalServerError,
 			},
 		},
+		"invalid network": {
+			params:    GetActivationsRequest{UniqueID: "38069_INTERNALWHITELIST", Network: "staging"},
+			withError: ErrStructValidation,
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -142,3 +149,49 @@ func TestAppSec_GetActivations(t *testing.T) {
 		})
 	}
 }
+
+func TestNetworkList_WaitForActivation(t *testing.T) {
+	opts := tools.WaitOptions{Interval: time.Millisecond, Jitter: -1}
+	params := GetActivationRequest{ActivationID: 1234}
+
+	t.Run("activated", func(t *testing.T) {
+		client := &Mock{}
+		client.On("GetActivation", mock.Anything, params).Return(&GetActivationResponse{ActivationID: 1234, ActivationStatus: StatusPending}, nil).Once()
+		client.On("GetActivation", mock.Anything, params).Return(&GetActivationResponse{ActivationID: 1234, ActivationStatus: StatusActive}, nil).Once()
+
+		result, err := WaitForActivation(context.Background(), client, params, opts)
+		require.NoError(t, err)
+		assert.Equal(t, StatusActive, result.ActivationStatus)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("aborted", func(t *testing.T) {
+		client := &Mock{}
+		client.On("GetActivation", mock.Anything, params).Return(&GetActivationResponse{ActivationID: 1234, ActivationStatus: StatusAborted}, nil).Once()
+
+		_, err := WaitForActivation(context.Background(), client, params, opts)
+		assert.True(t, errors.Is(err, ErrActivationFailed), "want: %s; got: %s", ErrActivationFailed, err)
+	})
+}
+
+func TestNetworkList_WatchActivation(t *testing.T) {
+	opts := tools.WaitOptions{Interval: time.Millisecond, Jitter: -1}
+	params := GetActivationRequest{ActivationID: 1234}
+	activation := func(status StatusValue) *GetActivationResponse {
+		return &GetActivationResponse{ActivationID: 1234, ActivationStatus: status}
+	}
+
+	client := &Mock{}
+	client.On("GetActivation", mock.Anything, params).Return(activation(StatusPending), nil).Twice()
+	client.On("GetActivation", mock.Anything, params).Return(activation(StatusFailed), nil).Once()
+
+	var statuses []string
+	var last tools.Event
+	for event := range WatchActivation(context.Background(), client, params, opts) {
+		statuses = append(statuses, event.Status)
+		last = event
+	}
+	assert.Equal(t, []string{"RECEIVED", "FAILED"}, statuses)
+	assert.True(t, errors.Is(last.Err, ErrActivationFailed), "want: %s; got: %s", ErrActivationFailed, last.Err)
+	client.AssertExpectations(t)
+}
@@ -7,6 +7,7 @@ import (
 	"errors"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/session"
+	"github.com/apex/log"
 )
 
 var (
@@ -25,6 +26,7 @@ type (
 
 	networklists struct {
 		session.Session
+		options     session.ClientOptions
 		usePrefixes bool
 	}
 
@@ -44,5 +46,56 @@ func Client(sess session.Session, opts ...Option) NTWRKLISTS {
 	for _, opt := range opts {
 		opt(p)
 	}
+	p.Session = p.options.Apply(p.Session)
 	return p
 }
+
+// WithLogger sets the logger used by the Network Lists client instead of the session logger
+func WithLogger(l log.Interface) Option {
+	return func(p *networklists) {
+		p.options.Logger = l
+	}
+}
+
+// WithRetries sets how many times idempotent Network Lists requests are retried after transient failures
+func WithRetries(retries int) Option {
+	return func(p *networklists) {
+		p.options.Retries = retries
+	}
+}
+
+// WithRetryClassifier sets the callback deciding whether failed Network Lists requests are retried, see session.RetryClassifier
+func WithRetryClassifier(classifier session.RetryClassifier) Option {
+	return func(p *networklists) {
+		p.options.RetryClassifier = classifier
+	}
+}
+
+// WithBackoff sets the strategy computing delays between retries of failed Network Lists requests, see session.Backoff
+func WithBackoff(backoff session.Backoff) Option {
+	return func(p *networklists) {
+		p.options.Backoff = backoff
+	}
+}
+
+// WithIdempotencyKeys adds an idempotency key to Network Lists POST and PATCH requests, kept when they are
+// retried; it does not make them retried by itself, see session.WithIdempotencyKeys
+func WithIdempotencyKeys(enabled bool) Option {
+	return func(p *networklists) {
+		p.options.IdempotencyKeys = enabled
+	}
+}
+
+// WithBaseURL overrides the scheme and host Network Lists requests are sent to
+func WithBaseURL(baseURL string) Option {
+	return func(p *networklists) {
+		p.options.BaseURL = baseURL
+	}
+}
+
+// WithAccountSwitchKey makes Network Lists requests act on the account with given switch key
+func WithAccountSwitchKey(key string) Option {
+	return func(p *networklists) {
+		p.options.AccountSwitchKey = key
+	}
+}
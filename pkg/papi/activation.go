@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/url"
 
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/tools"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/spf13/cast"
 )
@@ -112,8 +113,8 @@ type (
 	// GetActivationRequest is the get activation request
 	GetActivationRequest struct {
 		PropertyID   string
-		ContractID   string
-		GroupID      string
+		ContractID   string `query:"contractId"`
+		GroupID      string `query:"groupId"`
 		ActivationID string
 	}
 
@@ -127,8 +128,8 @@ type (
 	CancelActivationRequest struct {
 		PropertyID   string
 		ActivationID string
-		ContractID   string
-		GroupID      string
+		ContractID   string `query:"contractId"`
+		GroupID      string `query:"groupId"`
 	}
 
 	// CancelActivationResponse is a response from deleting a PENDING activation
@@ -250,6 +251,8 @@ var (
 	ErrGetActivation = errors.New("fetching activation")
 	// ErrCancelActivation represents error when canceling activation fails
 	ErrCancelActivation = errors.New("canceling activation")
+	// ErrActivationFailed represents error when awaited activation ends with FAILED or ABORTED status
+	ErrActivationFailed = errors.New("activation failed")
 )
 
 func (p *papi) CreateActivation(ctx context.Context, params CreateActivationRequest) (*CreateActivationResponse, error) {
@@ -357,12 +360,10 @@ func (p *papi) GetActivation(ctx context.Context, params GetActivationRequest) (
 	logger := p.Log(ctx)
 	logger.Debug("GetActivation")
 
-	uri := fmt.Sprintf(
-		"/papi/v1/properties/%s/activations/%s?contractId=%s&groupId=%s",
-		params.PropertyID,
-		params.ActivationID,
-		params.ContractID,
-		params.GroupID)
+	uri, err := tools.AppendQuery(fmt.Sprintf("/papi/v1/properties/%s/activations/%s", params.PropertyID, params.ActivationID), params)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetActivation, err)
+	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
 	if err != nil {
@@ -401,12 +402,10 @@ func (p *papi) CancelActivation(ctx context.Context, params CancelActivationRequ
 	logger := p.Log(ctx)
 	logger.Debug("CancelActivation")
 
-	uri := fmt.Sprintf(
-		"/papi/v1/properties/%s/activations/%s?contractId=%s&groupId=%s",
-		params.PropertyID,
-		params.ActivationID,
-		params.ContractID,
-		params.GroupID)
+	uri, err := tools.AppendQuery(fmt.Sprintf("/papi/v1/properties/%s/activations/%s", params.PropertyID, params.ActivationID), params)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrCancelActivation, err)
+	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, uri, nil)
 	if err != nil {
@@ -426,3 +425,39 @@ func (p *papi) CancelActivation(ctx context.Context, params CancelActivationRequ
 
 	return &rval, nil
 }
+
+// WaitForActivation polls GetActivation until the activation or deactivation is complete and returns its final state.
+func WaitForActivation(ctx context.Context, client Activations, params GetActivationRequest, opts tools.WaitOptions) (*GetActivationResponse, error) {
+	var result *GetActivationResponse
+	if err := tools.WaitFor(ctx, activationCheck(client, params, &result), opts); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// WatchActivation polls GetActivation like WaitForActivation, delivering the activation status after every check
+// on the returned channel, see tools.Watch.
+func WatchActivation(ctx context.Context, client Activations, params GetActivationRequest, opts tools.WaitOptions) <-chan tools.Event {
+	return tools.Watch(ctx, activationCheck(client, params, nil), opts)
+}
+
+// activationCheck returns a check of the activation state, storing the last response in result if it is not nil
+func activationCheck(client Activations, params GetActivationRequest, result **GetActivationResponse) tools.CheckFunc {
+	return func(ctx context.Context) (string, bool, error) {
+		resp, err := client.GetActivation(ctx, params)
+		if err != nil {
+			return "", false, err
+		}
+		if result != nil {
+			*result = resp
+		}
+		status := resp.Activation.Status
+		switch status {
+		case ActivationStatusActive, ActivationStatusInactive, ActivationStatusDeactivated:
+			return string(status), true, nil
+		case ActivationStatusFailed, ActivationStatusAborted:
+			return string(status), false, fmt.Errorf("%w: activation %s status %s", ErrActivationFailed, params.ActivationID, status)
+		}
+		return string(status), false, nil
+	}
+}
@@ -6,8 +6,11 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/tools"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -780,3 +783,55 @@ func TestPapi_CancelActivation(t *testing.T) {
 		})
 	}
 }
+
+func TestPapi_WaitForActivation(t *testing.T) {
+	opts := tools.WaitOptions{Interval: time.Millisecond, Jitter: -1}
+	params := GetActivationRequest{PropertyID: "prp_175780", ActivationID: "atv_1696985", ContractID: "ctr_1-1TJZFW", GroupID: "grp_15166"}
+	activation := func(status ActivationStatus) *GetActivationResponse {
+		return &GetActivationResponse{Activation: &Activation{ActivationID: "atv_1696985", Status: status}}
+	}
+
+	t.Run("active", func(t *testing.T) {
+		client := &Mock{}
+		client.On("GetActivation", mock.Anything, params).Return(activation(ActivationStatusPending), nil).Once()
+		client.On("GetActivation", mock.Anything, params).Return(activation(ActivationStatusZone1), nil).Once()
+		client.On("GetActivation", mock.Anything, params).Return(activation(ActivationStatusActive), nil).Once()
+
+		result, err := WaitForActivation(context.Background(), client, params, opts)
+		require.NoError(t, err)
+		assert.Equal(t, ActivationStatusActive, result.Activation.Status)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		client := &Mock{}
+		client.On("GetActivation", mock.Anything, params).Return(activation(ActivationStatusFailed), nil).Once()
+
+		_, err := WaitForActivation(context.Background(), client, params, opts)
+		assert.True(t, errors.Is(err, ErrActivationFailed), "want: %s; got: %s", ErrActivationFailed, err)
+	})
+}
+
+func TestPapi_WatchActivation(t *testing.T) {
+	opts := tools.WaitOptions{Interval: time.Millisecond, Jitter: -1}
+	params := GetActivationRequest{PropertyID: "prp_175780", ActivationID: "atv_1696985", ContractID: "ctr_1-1TJZFW", GroupID: "grp_15166"}
+	activation := func(status ActivationStatus) *GetActivationResponse {
+		return &GetActivationResponse{Activation: &Activation{ActivationID: "atv_1696985", Status: status}}
+	}
+
+	client := &Mock{}
+	client.On("GetActivation", mock.Anything, params).Return(activation(ActivationStatusPending), nil).Once()
+	client.On("GetActivation", mock.Anything, params).Return(activation(ActivationStatusZone1), nil).Once()
+	client.On("GetActivation", mock.Anything, params).Return(activation(ActivationStatusActive), nil).Once()
+
+	var statuses []string
+	var last tools.Event
+	for event := range WatchActivation(context.Background(), client, params, opts) {
+		statuses = append(statuses, event.Status)
+		last = event
+	}
+	assert.Equal(t, []string{"PENDING", "ZONE_1", "ACTIVE"}, statuses)
+	assert.True(t, last.Done)
+	assert.NoError(t, last.Err)
+	client.AssertExpectations(t)
+}
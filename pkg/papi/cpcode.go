@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/tools"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 )
 
@@ -93,8 +94,8 @@ type (
 
 	// CreateCPCodeRequest contains data required to create CP code (both request body and group/contract information
 	CreateCPCodeRequest struct {
-		ContractID string
-		GroupID    string
+		ContractID string `query:"contractId"`
+		GroupID    string `query:"groupId"`
 		CPCode     CreateCPCode
 	}
 
@@ -113,15 +114,15 @@ type (
 	// GetCPCodeRequest gets details about a CP code.
 	GetCPCodeRequest struct {
 		CPCodeID   string
-		ContractID string
-		GroupID    string
+		ContractID string `query:"contractId"`
+		GroupID    string `query:"groupId"`
 	}
 
 	// GetCPCodesRequest contains parameters required to list/create CP codes
 	// GroupID and ContractID are required as part of every CP code operation, ID is required only for operating on specific CP code
 	GetCPCodesRequest struct {
-		ContractID string
-		GroupID    string
+		ContractID string `query:"contractId"`
+		GroupID    string `query:"groupId"`
 	}
 
 	// UpdateCPCodeRequest contains parameters required to update CP code, using CPRG API call
@@ -223,11 +224,10 @@ func (p *papi) GetCPCodes(ctx context.Context, params GetCPCodesRequest) (*GetCP
 	logger := p.Log(ctx)
 	logger.Debug("GetCPCodes")
 
-	getURL := fmt.Sprintf(
-		"/papi/v1/cpcodes?contractId=%s&groupId=%s",
-		params.ContractID,
-		params.GroupID,
-	)
+	getURL, err := tools.AppendQuery("/papi/v1/cpcodes", params)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetCPCodes, err)
+	}
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, getURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetCPCodes, err)
@@ -255,7 +255,10 @@ func (p *papi) GetCPCode(ctx context.Context, params GetCPCodeRequest) (*GetCPCo
 	logger := p.Log(ctx)
 	logger.Debug("GetCPCode")
 
-	getURL := fmt.Sprintf("/papi/v1/cpcodes/%s?contractId=%s&groupId=%s", params.CPCodeID, params.ContractID, params.GroupID)
+	getURL, err := tools.AppendQuery(fmt.Sprintf("/papi/v1/cpcodes/%s", params.CPCodeID), params)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetCPCode, err)
+	}
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, getURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetCPCode, err)
@@ -311,7 +314,10 @@ func (p *papi) CreateCPCode(ctx context.Context, r CreateCPCodeRequest) (*Create
 	logger := p.Log(ctx)
 	logger.Debug("CreateCPCode")
 
-	createURL := fmt.Sprintf("/papi/v1/cpcodes?contractId=%s&groupId=%s", r.ContractID, r.GroupID)
+	createURL, err := tools.AppendQuery("/papi/v1/cpcodes", r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrCreateCPCode, err)
+	}
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, createURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("%w: failed to create request: %s", ErrCreateCPCode, err)
@@ -5,9 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-	"strings"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/edgegriderr"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/tools"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 )
 
@@ -32,17 +32,17 @@ type (
 
 	// GetEdgeHostnamesRequest contains query params used for listing edge hostnames
 	GetEdgeHostnamesRequest struct {
-		ContractID string
-		GroupID    string
-		Options    []string
+		ContractID string   `query:"contractId"`
+		GroupID    string   `query:"groupId"`
+		Options    []string `query:"options,omitempty,comma"`
 	}
 
 	// GetEdgeHostnameRequest contains path and query params used to fetch specific edge hostname
 	GetEdgeHostnameRequest struct {
 		EdgeHostnameID string
-		ContractID     string
-		GroupID        string
-		Options        []string
+		ContractID     string   `query:"contractId"`
+		GroupID        string   `query:"groupId"`
+		Options        []string `query:"options,omitempty,comma"`
 	}
 
 	// GetEdgeHostnamesResponse contains data received by calling GetEdgeHostnames or GetEdgeHostname
@@ -81,9 +81,9 @@ type (
 
 	// CreateEdgeHostnameRequest contains query params and body required for creation of new edge hostname
 	CreateEdgeHostnameRequest struct {
-		ContractID   string
-		GroupID      string
-		Options      []string
+		ContractID   string   `query:"contractId"`
+		GroupID      string   `query:"groupId"`
+		Options      []string `query:"options,omitempty,comma"`
 		EdgeHostname EdgeHostnameCreate
 	}
 
@@ -197,13 +197,9 @@ func (p *papi) GetEdgeHostnames(ctx context.Context, params GetEdgeHostnamesRequ
 	logger := p.Log(ctx)
 	logger.Debug("GetEdgeHostnames")
 
-	getURL := fmt.Sprintf(
-		"/papi/v1/edgehostnames?contractId=%s&groupId=%s",
-		params.ContractID,
-		params.GroupID,
-	)
-	if len(params.Options) > 0 {
-		getURL = fmt.Sprintf("%s&options=%s", getURL, strings.Join(params.Options, ","))
+	getURL, err := tools.AppendQuery("/papi/v1/edgehostnames", params)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetEdgeHostnames, err)
 	}
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, getURL, nil)
 	if err != nil {
@@ -232,14 +228,9 @@ func (p *papi) GetEdgeHostname(ctx context.Context, params GetEdgeHostnameReques
 	logger := p.Log(ctx)
 	logger.Debug("GetEdgeHostname")
 
-	getURL := fmt.Sprintf(
-		"/papi/v1/edgehostnames/%s?contractId=%s&groupId=%s",
-		params.EdgeHostnameID,
-		params.ContractID,
-		params.GroupID,
-	)
-	if len(params.Options) > 0 {
-		getURL = fmt.Sprintf("%s&options=%s", getURL, strings.Join(params.Options, ","))
+	getURL, err := tools.AppendQuery(fmt.Sprintf("/papi/v1/edgehostnames/%s", params.EdgeHostnameID), params)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetEdgeHostname, err)
 	}
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, getURL, nil)
 	if err != nil {
@@ -272,13 +263,9 @@ func (p *papi) CreateEdgeHostname(ctx context.Context, r CreateEdgeHostnameReque
 	logger := p.Log(ctx)
 	logger.Debug("CreateEdgeHostname")
 
-	createURL := fmt.Sprintf(
-		"/papi/v1/edgehostnames?contractId=%s&groupId=%s",
-		r.ContractID,
-		r.GroupID,
-	)
-	if len(r.Options) > 0 {
-		createURL = fmt.Sprintf("%s&options=%s", createURL, strings.Join(r.Options, ","))
+	createURL, err := tools.AppendQuery("/papi/v1/edgehostnames", r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrCreateEdgeHostname, err)
 	}
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, createURL, nil)
 	if err != nil {
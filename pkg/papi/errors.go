@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/session"
 )
 
 type (
@@ -88,6 +90,10 @@ func (e *ActivationError) Error() string {
 
 // Is handles error comparisons
 func (e *Error) Is(target error) bool {
+	if session.MatchStatus(e.StatusCode, target) {
+		return true
+	}
+
 	if errors.Is(target, ErrSBDNotEnabled) {
 		return e.isErrSBDNotEnabled()
 	}
@@ -111,8 +117,17 @@ func (e *Error) Is(target error) bool {
 	return e.Error() == t.Error()
 }
 
+// As converts the error into a *session.Problem, shared by the errors of all packages.
+func (e *Error) As(target interface{}) bool {
+	return session.AsProblem(e, e.StatusCode, target)
+}
+
 // Is handles error comparisons for ActivationError type
 func (e *ActivationError) Is(target error) bool {
+	if session.MatchStatus(e.Status, target) {
+		return true
+	}
+
 	if errors.Is(target, ErrMissingComplianceRecord) {
 		return e.MessageID == "missing_compliance_record"
 	}
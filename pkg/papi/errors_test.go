@@ -2,6 +2,7 @@ package papi
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -126,3 +127,19 @@ func TestErrorIs(t *testing.T) {
 		})
 	}
 }
+
+func TestError_AsProblem(t *testing.T) {
+	err := fmt.Errorf("create property request failed: %w", &Error{
+		Type:       "https://problems.luna.akamaiapis.net/papi/v0/validation/attribute_required",
+		Title:      "Missing required attribute",
+		Detail:     "The request is missing a required attribute",
+		StatusCode: http.StatusBadRequest,
+		Errors:     []byte(`[{"type":"required","title":"Missing productId","detail":"productId is required"}]`),
+	})
+
+	var problem *session.Problem
+	require.True(t, errors.As(err, &problem))
+	assert.Equal(t, http.StatusBadRequest, problem.Status)
+	assert.Equal(t, "Missing required attribute", problem.Title)
+	assert.Equal(t, []session.Problem{{Type: "required", Title: "Missing productId", Detail: "productId is required"}}, problem.Errors)
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/session"
+	"github.com/apex/log"
 	"github.com/spf13/cast"
 )
 
@@ -50,6 +51,7 @@ type (
 
 	papi struct {
 		session.Session
+		options     session.ClientOptions
 		usePrefixes bool
 	}
 
@@ -80,9 +82,60 @@ func Client(sess session.Session, opts ...Option) PAPI {
 	for _, opt := range opts {
 		opt(p)
 	}
+	p.Session = p.options.Apply(p.Session)
 	return p
 }
 
+// WithLogger sets the logger used by the PAPI client instead of the session logger
+func WithLogger(l log.Interface) Option {
+	return func(p *papi) {
+		p.options.Logger = l
+	}
+}
+
+// WithRetries sets how many times idempotent PAPI requests are retried after transient failures
+func WithRetries(retries int) Option {
+	return func(p *papi) {
+		p.options.Retries = retries
+	}
+}
+
+// WithRetryClassifier sets the callback deciding whether failed PAPI requests are retried, see session.RetryClassifier
+func WithRetryClassifier(classifier session.RetryClassifier) Option {
+	return func(p *papi) {
+		p.options.RetryClassifier = classifier
+	}
+}
+
+// WithBackoff sets the strategy computing delays between retries of failed PAPI requests, see session.Backoff
+func WithBackoff(backoff session.Backoff) Option {
+	return func(p *papi) {
+		p.options.Backoff = backoff
+	}
+}
+
+// WithIdempotencyKeys adds an idempotency key to PAPI POST and PATCH requests, kept when they are
+// retried; it does not make them retried by itself, see session.WithIdempotencyKeys
+func WithIdempotencyKeys(enabled bool) Option {
+	return func(p *papi) {
+		p.options.IdempotencyKeys = enabled
+	}
+}
+
+// WithBaseURL overrides the scheme and host PAPI requests are sent to
+func WithBaseURL(baseURL string) Option {
+	return func(p *papi) {
+		p.options.BaseURL = baseURL
+	}
+}
+
+// WithAccountSwitchKey makes PAPI requests act on the account with given switch key
+func WithAccountSwitchKey(key string) Option {
+	return func(p *papi) {
+		p.options.AccountSwitchKey = key
+	}
+}
+
 // WithUsePrefixes sets the `PAPI-Use-Prefixes` header on requests
 // See: https://techdocs.akamai.com/property-mgr/reference/id-prefixes
 func WithUsePrefixes(usePrefixes bool) Option {
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/tools"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 )
 
@@ -20,7 +21,7 @@ type (
 
 	// GetProductsRequest contains data required to list products associated to a contract
 	GetProductsRequest struct {
-		ContractID string
+		ContractID string `query:"contractId"`
 	}
 
 	// GetProductsResponse contains details about all products associated to a contract
@@ -63,7 +64,10 @@ func (p *papi) GetProducts(ctx context.Context, params GetProductsRequest) (*Get
 	logger := p.Log(ctx)
 	logger.Debug("GetProducts")
 
-	getURL := fmt.Sprintf("/papi/v1/products?contractId=%s", params.ContractID)
+	getURL, err := tools.AppendQuery("/papi/v1/products", params)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetProducts, err)
+	}
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, getURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetProducts, err)
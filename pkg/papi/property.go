@@ -8,6 +8,7 @@ import (
 	"net/url"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/edgegriderr"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/tools"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 )
 
@@ -66,8 +67,8 @@ type (
 
 	// GetPropertiesRequest is the argument for GetProperties
 	GetPropertiesRequest struct {
-		ContractID string
-		GroupID    string
+		ContractID string `query:"contractId"`
+		GroupID    string `query:"groupId"`
 	}
 
 	// GetPropertiesResponse is the response for GetProperties
@@ -77,8 +78,8 @@ type (
 
 	// CreatePropertyRequest is passed to CreateProperty
 	CreatePropertyRequest struct {
-		ContractID string
-		GroupID    string
+		ContractID string `query:"contractId"`
+		GroupID    string `query:"groupId"`
 		Property   PropertyCreate
 	}
 
@@ -194,10 +195,10 @@ func (p *papi) GetProperties(ctx context.Context, params GetPropertiesRequest) (
 	logger := p.Log(ctx)
 	logger.Debug("GetProperties")
 
-	uri := fmt.Sprintf(
-		"/papi/v1/properties?contractId=%s&groupId=%s",
-		params.ContractID,
-		params.GroupID)
+	uri, err := tools.AppendQuery("/papi/v1/properties", params)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetProperties, err)
+	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
 	if err != nil {
@@ -224,10 +225,10 @@ func (p *papi) CreateProperty(ctx context.Context, params CreatePropertyRequest)
 	logger := p.Log(ctx)
 	logger.Debug("CreateProperty")
 
-	uri := fmt.Sprintf(
-		"/papi/v1/properties?contractId=%s&groupId=%s",
-		params.ContractID,
-		params.GroupID)
+	uri, err := tools.AppendQuery("/papi/v1/properties", params)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrCreateProperty, err)
+	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri, nil)
 	if err != nil {
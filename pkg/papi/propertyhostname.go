@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/tools"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 )
 
@@ -27,10 +28,10 @@ type (
 	GetPropertyVersionHostnamesRequest struct {
 		PropertyID        string
 		PropertyVersion   int
-		ContractID        string
-		GroupID           string
-		ValidateHostnames bool
-		IncludeCertStatus bool
+		ContractID        string `query:"contractId"`
+		GroupID           string `query:"groupId"`
+		ValidateHostnames bool   `query:"validateHostnames"`
+		IncludeCertStatus bool   `query:"includeCertStatus"`
 	}
 
 	// GetPropertyVersionHostnamesResponse contains all property version hostnames associated to the given parameters
@@ -81,10 +82,10 @@ type (
 	UpdatePropertyVersionHostnamesRequest struct {
 		PropertyID        string
 		PropertyVersion   int
-		ContractID        string
-		GroupID           string
-		ValidateHostnames bool
-		IncludeCertStatus bool
+		ContractID        string `query:"contractId"`
+		GroupID           string `query:"groupId"`
+		ValidateHostnames bool   `query:"validateHostnames"`
+		IncludeCertStatus bool   `query:"includeCertStatus"`
 		Hostnames         []Hostname
 	}
 
@@ -139,14 +140,10 @@ func (p *papi) GetPropertyVersionHostnames(ctx context.Context, params GetProper
 	logger := p.Log(ctx)
 	logger.Debug("GetPropertyVersionHostnames")
 
-	getURL := fmt.Sprintf(
-		"/papi/v1/properties/%s/versions/%d/hostnames?contractId=%s&groupId=%s&validateHostnames=%t&includeCertStatus=%t",
-		params.PropertyID,
-		params.PropertyVersion,
-		params.ContractID,
-		params.GroupID,
-		params.ValidateHostnames,
-		params.IncludeCertStatus)
+	getURL, err := tools.AppendQuery(fmt.Sprintf("/papi/v1/properties/%s/versions/%d/hostnames", params.PropertyID, params.PropertyVersion), params)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetPropertyVersionHostnames, err)
+	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, getURL, nil)
 	if err != nil {
@@ -173,15 +170,10 @@ func (p *papi) UpdatePropertyVersionHostnames(ctx context.Context, params Update
 	logger := p.Log(ctx)
 	logger.Debug("UpdatePropertyVersionHostnames")
 
-	putURL := fmt.Sprintf(
-		"/papi/v1/properties/%s/versions/%v/hostnames?contractId=%s&groupId=%s&validateHostnames=%t&includeCertStatus=%t",
-		params.PropertyID,
-		params.PropertyVersion,
-		params.ContractID,
-		params.GroupID,
-		params.ValidateHostnames,
-		params.IncludeCertStatus,
-	)
+	putURL, err := tools.AppendQuery(fmt.Sprintf("/papi/v1/properties/%s/versions/%d/hostnames", params.PropertyID, params.PropertyVersion), params)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrUpdatePropertyVersionHostnames, err)
+	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPut, putURL, nil)
 	if err != nil {
@@ -10,6 +10,8 @@ import (
 	"strconv"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/edgegriderr"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/session"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/tools"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 )
 
@@ -60,10 +62,10 @@ type (
 	// GetPropertyVersionsRequest contains path and query params used for listing property versions
 	GetPropertyVersionsRequest struct {
 		PropertyID string
-		ContractID string
-		GroupID    string
-		Limit      int
-		Offset     int
+		ContractID string `query:"contractId"`
+		GroupID    string `query:"groupId"`
+		Limit      int    `query:"limit,omitempty"`
+		Offset     int    `query:"offset,omitempty"`
 	}
 
 	// GetPropertyVersionsResponse contains GET response returned while fetching property versions or specific version
@@ -100,15 +102,15 @@ type (
 	GetPropertyVersionRequest struct {
 		PropertyID      string
 		PropertyVersion int
-		ContractID      string
-		GroupID         string
+		ContractID      string `query:"contractId"`
+		GroupID         string `query:"groupId"`
 	}
 
 	// CreatePropertyVersionRequest contains path and query params, as well as request body required to execute POST /versions request
 	CreatePropertyVersionRequest struct {
 		PropertyID string
-		ContractID string
-		GroupID    string
+		ContractID string `query:"contractId"`
+		GroupID    string `query:"groupId"`
 		Version    PropertyVersionCreate
 	}
 
@@ -127,9 +129,9 @@ type (
 	// GetLatestVersionRequest contains path and query params required to fetch latest property version
 	GetLatestVersionRequest struct {
 		PropertyID  string
-		ActivatedOn string
-		ContractID  string
-		GroupID     string
+		ActivatedOn string `query:"activatedOn,omitempty"`
+		ContractID  string `query:"contractId"`
+		GroupID     string `query:"groupId"`
 	}
 
 	// GetAvailableItemsRequest contains path and query params required to fetch available behaviors or criteria for a property
@@ -306,17 +308,9 @@ func (p *papi) GetPropertyVersions(ctx context.Context, params GetPropertyVersio
 	logger := p.Log(ctx)
 	logger.Debug("GetPropertyVersions")
 
-	getURL := fmt.Sprintf(
-		"/papi/v1/properties/%s/versions?contractId=%s&groupId=%s",
-		params.PropertyID,
-		params.ContractID,
-		params.GroupID,
-	)
-	if params.Limit != 0 {
-		getURL += fmt.Sprintf("&limit=%d", params.Limit)
-	}
-	if params.Offset != 0 {
-		getURL += fmt.Sprintf("&offset=%d", params.Offset)
+	getURL, err := tools.AppendQuery(fmt.Sprintf("/papi/v1/properties/%s/versions", params.PropertyID), params)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetPropertyVersions, err)
 	}
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, getURL, nil)
 	if err != nil {
@@ -336,6 +330,22 @@ func (p *papi) GetPropertyVersions(ctx context.Context, params GetPropertyVersio
 	return &versions, nil
 }
 
+// NewPropertyVersionsPager returns a pager listing property versions page by page with GetPropertyVersions,
+// each page holding up to params.Limit versions. The Offset field of params is ignored.
+// When params.Limit is not set, all versions are returned in a single page.
+func NewPropertyVersionsPager(client PropertyVersions, params GetPropertyVersionsRequest) session.Pager[PropertyVersionGetItem] {
+	return session.NewPager(func(ctx context.Context, page int) ([]PropertyVersionGetItem, bool, error) {
+		req := params
+		req.Offset = page
+		resp, err := client.GetPropertyVersions(ctx, req)
+		if err != nil {
+			return nil, false, err
+		}
+		items := resp.Versions.Items
+		return items, params.Limit > 0 && len(items) == params.Limit, nil
+	})
+}
+
 func (p *papi) GetLatestVersion(ctx context.Context, params GetLatestVersionRequest) (*GetPropertyVersionsResponse, error) {
 	if err := params.Validate(); err != nil {
 		return nil, fmt.Errorf("%s: %w: %s", ErrGetLatestVersion, ErrStructValidation, err)
@@ -344,14 +354,9 @@ func (p *papi) GetLatestVersion(ctx context.Context, params GetLatestVersionRequ
 	logger := p.Log(ctx)
 	logger.Debug("GetLatestVersion")
 
-	getURL := fmt.Sprintf(
-		"/papi/v1/properties/%s/versions/latest?contractId=%s&groupId=%s",
-		params.PropertyID,
-		params.ContractID,
-		params.GroupID,
-	)
-	if params.ActivatedOn != "" {
-		getURL += fmt.Sprintf("&activatedOn=%s", params.ActivatedOn)
+	getURL, err := tools.AppendQuery(fmt.Sprintf("/papi/v1/properties/%s/versions/latest", params.PropertyID), params)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetLatestVersion, err)
 	}
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, getURL, nil)
 	if err != nil {
@@ -382,13 +387,10 @@ func (p *papi) GetPropertyVersion(ctx context.Context, params GetPropertyVersion
 	logger := p.Log(ctx)
 	logger.Debug("GetPropertyVersion")
 
-	getURL := fmt.Sprintf(
-		"/papi/v1/properties/%s/versions/%d?contractId=%s&groupId=%s",
-		params.PropertyID,
-		params.PropertyVersion,
-		params.ContractID,
-		params.GroupID,
-	)
+	getURL, err := tools.AppendQuery(fmt.Sprintf("/papi/v1/properties/%s/versions/%d", params.PropertyID, params.PropertyVersion), params)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetPropertyVersion, err)
+	}
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, getURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetPropertyVersion, err)
@@ -418,12 +420,10 @@ func (p *papi) CreatePropertyVersion(ctx context.Context, request CreateProperty
 	logger := p.Log(ctx)
 	logger.Debug("CreatePropertyVersion")
 
-	getURL := fmt.Sprintf(
-		"/papi/v1/properties/%s/versions?contractId=%s&groupId=%s",
-		request.PropertyID,
-		request.ContractID,
-		request.GroupID,
-	)
+	getURL, err := tools.AppendQuery(fmt.Sprintf("/papi/v1/properties/%s/versions", request.PropertyID), request)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrCreatePropertyVersion, err)
+	}
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, getURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("%w: failed to create request: %s", ErrCreatePropertyVersion, err)
@@ -7,9 +7,11 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/session"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/tools"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -1934,3 +1936,24 @@ func TestPapi_ListReferencedIncludes(t *testing.T) {
 		})
 	}
 }
+
+func TestPapi_NewPropertyVersionsPager(t *testing.T) {
+	params := GetPropertyVersionsRequest{PropertyID: "prp_175780", ContractID: "ctr_1-1TJZFW", GroupID: "grp_15166", Limit: 2}
+	page := func(offset int) GetPropertyVersionsRequest {
+		p := params
+		p.Offset = offset
+		return p
+	}
+	client := &Mock{}
+	client.On("GetPropertyVersions", mock.Anything, page(0)).Return(&GetPropertyVersionsResponse{
+		Versions: PropertyVersionItems{Items: []PropertyVersionGetItem{{PropertyVersion: 3}, {PropertyVersion: 2}}},
+	}, nil).Once()
+	client.On("GetPropertyVersions", mock.Anything, page(1)).Return(&GetPropertyVersionsResponse{
+		Versions: PropertyVersionItems{Items: []PropertyVersionGetItem{{PropertyVersion: 1}}},
+	}, nil).Once()
+
+	versions, err := session.CollectPages(context.Background(), NewPropertyVersionsPager(client, params))
+	require.NoError(t, err)
+	assert.Equal(t, []PropertyVersionGetItem{{PropertyVersion: 3}, {PropertyVersion: 2}, {PropertyVersion: 1}}, versions)
+	client.AssertExpectations(t)
+}
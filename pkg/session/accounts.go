@@ -0,0 +1,66 @@
+package session
+
+import (
+	"context"
+)
+
+type (
+	// Account is an account an operation is run for by ForEachAccount
+	Account struct {
+		// Name identifies the account in results; defaults to SwitchKey
+		Name string
+		// SwitchKey is the account switch key requests of the account are sent with
+		SwitchKey string
+		// Session, if set, is used instead of the session passed to ForEachAccount,
+		// e.g. a session signed with a separate set of credentials; SwitchKey is still applied to it
+		Session Session
+	}
+
+	// AccountResult is the outcome of an operation run for an account by ForEachAccount
+	AccountResult[R any] struct {
+		Account Account
+		Value   R
+		Err     error
+	}
+)
+
+// AccountsFromSwitchKeys returns accounts for given account switch keys
+func AccountsFromSwitchKeys(keys ...string) []Account {
+	accounts := make([]Account, 0, len(keys))
+	for _, key := range keys {
+		accounts = append(accounts, Account{Name: key, SwitchKey: key})
+	}
+	return accounts
+}
+
+// ForEachAccount runs fn for every account with a session acting on that account, running at most opts.Concurrency
+// operations at the same time, e.g.:
+//
+//	results, err := session.ForEachAccount(ctx, sess, session.AccountsFromSwitchKeys(keys...),
+//		func(ctx context.Context, sess session.Session, account session.Account) (*appsec.GetConfigurationsResponse, error) {
+//			return appsec.Client(sess).GetConfigurations(ctx, appsec.GetConfigurationsRequest{})
+//		}, session.BatchOptions{Concurrency: 8})
+//
+// Results are returned in the order of accounts. When the operation fails for any account,
+// the returned error wraps ErrBatchFailed, see RunBatch.
+func ForEachAccount[R any](ctx context.Context, sess Session, accounts []Account,
+	fn func(context.Context, Session, Account) (R, error), opts BatchOptions) ([]AccountResult[R], error) {
+	batch, err := RunBatch(ctx, accounts, func(ctx context.Context, account Account) (R, error) {
+		accountSess := account.Session
+		if accountSess == nil {
+			accountSess = sess
+		}
+		accountSess = ClientOptions{AccountSwitchKey: account.SwitchKey}.Apply(accountSess)
+		return fn(ctx, accountSess, account)
+	}, opts)
+
+	results := make([]AccountResult[R], len(batch))
+	for i, r := range batch {
+		account := accounts[r.Index]
+		if account.Name == "" {
+			account.Name = account.SwitchKey
+		}
+		results[i] = AccountResult[R]{Account: account, Value: r.Value, Err: r.Err}
+	}
+	return results, err
+}
@@ -0,0 +1,65 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForEachAccount(t *testing.T) {
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("accountSwitchKey") == "1-FAIL" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"account": "` + r.URL.Query().Get("accountSwitchKey") + `"}`))
+		assert.NoError(t, err)
+	}))
+	defer mockServer.Close()
+	serverURL, err := url.Parse(mockServer.URL)
+	require.NoError(t, err)
+
+	sess, err := New(WithSigner(&edgegrid.Config{Host: serverURL.Host}), WithClient(mockServer.Client()))
+	require.NoError(t, err)
+	other, err := New(WithSigner(&edgegrid.Config{Host: serverURL.Host, AccountKey: "1-OTHER"}), WithClient(mockServer.Client()))
+	require.NoError(t, err)
+
+	accounts := append(AccountsFromSwitchKeys("1-ABC", "1-FAIL"), Account{Name: "other", Session: other})
+	results, err := ForEachAccount(context.Background(), sess, accounts,
+		func(ctx context.Context, sess Session, account Account) (string, error) {
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, "/test", nil)
+			if err != nil {
+				return "", err
+			}
+			var out struct {
+				Account string `json:"account"`
+			}
+			resp, err := sess.Exec(req, &out)
+			if err != nil {
+				return "", err
+			}
+			if resp.StatusCode != http.StatusOK {
+				return "", StatusError(resp.StatusCode)
+			}
+			return out.Account, nil
+		}, BatchOptions{Concurrency: 2})
+
+	assert.True(t, errors.Is(err, ErrBatchFailed), "want: %s; got: %s", ErrBatchFailed, err)
+	require.Len(t, results, 3)
+	assert.Equal(t, "1-ABC", results[0].Account.Name)
+	assert.Equal(t, "1-ABC", results[0].Value)
+	assert.NoError(t, results[0].Err)
+	assert.Equal(t, "1-FAIL", results[1].Account.Name)
+	assert.True(t, errors.Is(results[1].Err, ErrForbidden))
+	assert.Equal(t, "other", results[2].Account.Name)
+	assert.Equal(t, "1-OTHER", results[2].Value)
+	assert.NoError(t, results[2].Err)
+}
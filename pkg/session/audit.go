@@ -0,0 +1,186 @@
+package session
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type (
+	// AuditSink receives an AuditEvent for every executed POST, PUT, PATCH and DELETE request, see WithAuditSink.
+	// Implementations have to be safe for concurrent use and should not block.
+	AuditSink interface {
+		Audit(ctx context.Context, event AuditEvent)
+	}
+
+	// AuditSinkFunc is a function implementing AuditSink
+	AuditSinkFunc func(ctx context.Context, event AuditEvent)
+
+	// AuditEvent describes a change request sent to the API
+	AuditEvent struct {
+		Time time.Time
+		// Section is the section of the credentials used to sign the request, empty if unknown
+		Section string
+		Method  string
+		Host    string
+		Path    string
+		// Summary is the beginning of the request body, up to 512 bytes
+		Summary string
+		Outcome AuditOutcome
+		// StatusCode is the response status code, zero when no response was received
+		StatusCode int
+		// Err is the transport error, if any
+		Err      error
+		Duration time.Duration
+		// TraceID identifies the request on Akamai side, taken from the response headers
+		TraceID string
+		// Resources holds the identifiers of the resources the request acts on, resolved from the resource path
+		// and query, keyed by the names used by the API, e.g. configId, version and policyId for
+		// /appsec/v1/configs/43253/versions/7/security-policies/AAAA_81230/match-targets
+		Resources map[string]string
+	}
+
+	// AuditOutcome is the outcome of an audited request
+	AuditOutcome string
+)
+
+const (
+	// AuditSuccess is the outcome of requests completed with 2xx response
+	AuditSuccess AuditOutcome = "success"
+	// AuditFailure is the outcome of requests rejected by the API
+	AuditFailure AuditOutcome = "failure"
+	// AuditError is the outcome of requests which did not receive a response
+	AuditError AuditOutcome = "error"
+
+	auditSummaryLimit = 512
+)
+
+var (
+	// auditTraceHeaders are the response headers identifying the request, in order of preference
+	auditTraceHeaders = []string{"X-Trace-Id", "X-Akamai-Request-Id", "Akamai-Request-Id", "X-Request-Id"}
+
+	// auditResourceSegments maps the path segments of resource collections to the names of the identifiers following them
+	auditResourceSegments = map[string]string{
+		"configs":             "configId",
+		"versions":            "version",
+		"security-policies":   "policyId",
+		"match-targets":       "targetId",
+		"custom-rules":        "ruleId",
+		"rules":               "ruleId",
+		"rate-policies":       "ratePolicyId",
+		"reputation-profiles": "reputationProfileId",
+		"attack-groups":       "attackGroupId",
+		"custom-deny":         "customDenyId",
+		"activations":         "activationId",
+		"properties":          "propertyId",
+		"includes":            "includeId",
+		"cpcodes":             "cpcodeId",
+		"edgehostnames":       "edgeHostnameId",
+		"enrollments":         "enrollmentId",
+		"changes":             "changeId",
+		"network-lists":       "networkListId",
+		"zones":               "zone",
+		"domains":             "domainName",
+		"ids":                 "edgeWorkerId",
+		"namespaces":          "namespaceId",
+		"policies":            "policyId",
+		"streams":             "streamId",
+		"api-clients":         "clientId",
+		"ui-identities":       "uiIdentityId",
+	}
+
+	// auditResourceParams are the query parameters identifying the resources of requests
+	auditResourceParams = []string{"contractId", "groupId", "propertyId"}
+)
+
+// WithAuditSink sends an AuditEvent to sink for every POST, PUT, PATCH and DELETE request executed by the session.
+// Every attempt of a retried request is reported separately, while requests captured by plan mode are not reported.
+func WithAuditSink(sink AuditSink) Option {
+	return func(s *session) {
+		s.auditSink = sink
+	}
+}
+
+// Audit calls f
+func (f AuditSinkFunc) Audit(ctx context.Context, event AuditEvent) {
+	f(ctx, event)
+}
+
+func (s *session) audit(r *http.Request, body []byte, start time.Time, resp *http.Response, err error) {
+	if s.auditSink == nil || !isMutating(r.Method) {
+		return
+	}
+
+	event := AuditEvent{
+		Time:      start,
+		Method:    r.Method,
+		Host:      r.URL.Host,
+		Path:      r.URL.Path,
+		Err:       err,
+		Duration:  time.Since(start),
+		Resources: auditResources(r.URL),
+	}
+	var source interface{} = s.signer
+	if s.provider != nil {
+		source = s.provider
+	}
+	if signer, ok := source.(interface{ Section() string }); ok {
+		event.Section = signer.Section()
+	}
+	if len(body) > auditSummaryLimit {
+		body = body[:auditSummaryLimit]
+	}
+	event.Summary = string(body)
+
+	switch {
+	case err != nil || resp == nil:
+		event.Outcome = AuditError
+	case resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices:
+		event.Outcome = AuditSuccess
+	default:
+		event.Outcome = AuditFailure
+	}
+	if resp != nil {
+		event.StatusCode = resp.StatusCode
+		for _, h := range auditTraceHeaders {
+			if id := resp.Header.Get(h); id != "" {
+				event.TraceID = id
+				break
+			}
+		}
+	}
+
+	s.auditSink.Audit(r.Context(), event)
+}
+
+// auditResources resolves the identifiers of the resources a request acts on from the segments following known
+// resource collections in its path, and from its query parameters
+func auditResources(u *url.URL) map[string]string {
+	resources := make(map[string]string)
+	segments := strings.Split(strings.Trim(u.EscapedPath(), "/"), "/")
+	for i := 0; i+1 < len(segments); i++ {
+		name, ok := auditResourceSegments[segments[i]]
+		if !ok || auditResourceSegments[segments[i+1]] != "" {
+			continue
+		}
+		if id, err := url.PathUnescape(segments[i+1]); err == nil && id != "" {
+			resources[name] = id
+			i++
+		}
+	}
+	query := u.Query()
+	for _, name := range auditResourceParams {
+		if _, ok := resources[name]; ok {
+			continue
+		}
+		if value := query.Get(name); value != "" {
+			resources[name] = value
+		}
+	}
+	if len(resources) == 0 {
+		return nil
+	}
+	return resources
+}
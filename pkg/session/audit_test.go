@@ -0,0 +1,159 @@
+package session
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSession_ExecAudit(t *testing.T) {
+	tests := map[string]struct {
+		method          string
+		status          int
+		in              interface{}
+		closeServer     bool
+		expectedEvent   bool
+		expectedOutcome AuditOutcome
+		expectedSummary string
+		expectedTraceID string
+	}{
+		"successful POST": {
+			method:          http.MethodPost,
+			status:          http.StatusCreated,
+			in:              map[string]string{"name": "test"},
+			expectedEvent:   true,
+			expectedOutcome: AuditSuccess,
+			expectedSummary: `{"name":"test"}`,
+			expectedTraceID: "trace-1",
+		},
+		"failed DELETE": {
+			method:          http.MethodDelete,
+			status:          http.StatusForbidden,
+			expectedEvent:   true,
+			expectedOutcome: AuditFailure,
+			expectedTraceID: "trace-1",
+		},
+		"transport error": {
+			method:          http.MethodPut,
+			closeServer:     true,
+			expectedEvent:   true,
+			expectedOutcome: AuditError,
+		},
+		"GET is not audited": {
+			method: http.MethodGet,
+			status: http.StatusOK,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-Trace-Id", "trace-1")
+				w.WriteHeader(test.status)
+			}))
+			defer mockServer.Close()
+			serverURL, err := url.Parse(mockServer.URL)
+			require.NoError(t, err)
+
+			var mu sync.Mutex
+			var events []AuditEvent
+			config, err := edgegrid.New(edgegrid.WithFile("../edgegrid/test/edgerc"), edgegrid.WithSection("test"))
+			require.NoError(t, err)
+			config.Host = serverURL.Host
+			s, err := New(
+				WithSigner(config),
+				WithClient(mockServer.Client()),
+				WithAuditSink(AuditSinkFunc(func(_ context.Context, event AuditEvent) {
+					mu.Lock()
+					defer mu.Unlock()
+					events = append(events, event)
+				})),
+			)
+			require.NoError(t, err)
+			if test.closeServer {
+				mockServer.Close()
+			}
+
+			req, err := http.NewRequest(test.method, "/appsec/v1/configs/1", nil)
+			require.NoError(t, err)
+			if test.in != nil {
+				_, err = s.Exec(req, nil, test.in)
+			} else {
+				_, err = s.Exec(req, nil)
+			}
+			assert.Equal(t, test.closeServer, err != nil)
+
+			if !test.expectedEvent {
+				assert.Empty(t, events)
+				return
+			}
+			require.Len(t, events, 1)
+			event := events[0]
+			assert.Equal(t, "test", event.Section)
+			assert.Equal(t, test.method, event.Method)
+			assert.Equal(t, serverURL.Host, event.Host)
+			assert.Equal(t, "/appsec/v1/configs/1", event.Path)
+			assert.Equal(t, map[string]string{"configId": "1"}, event.Resources)
+			assert.Equal(t, test.expectedOutcome, event.Outcome)
+			assert.Equal(t, test.status, event.StatusCode)
+			assert.Equal(t, test.expectedSummary, event.Summary)
+			assert.Equal(t, test.expectedTraceID, event.TraceID)
+			assert.Equal(t, test.closeServer, event.Err != nil)
+			assert.False(t, event.Time.IsZero())
+		})
+	}
+}
+
+func TestAuditResources(t *testing.T) {
+	tests := map[string]struct {
+		url      string
+		expected map[string]string
+	}{
+		"appsec match targets": {
+			url: "/appsec/v1/configs/43253/versions/7/security-policies/AAAA_81230/match-targets?contractId=C-1",
+			expected: map[string]string{
+				"configId":   "43253",
+				"version":    "7",
+				"policyId":   "AAAA_81230",
+				"contractId": "C-1",
+			},
+		},
+		"appsec custom rule": {
+			url: "/appsec/v1/configs/43253/custom-rules/60039625",
+			expected: map[string]string{
+				"configId": "43253",
+				"ruleId":   "60039625",
+			},
+		},
+		"property version with query": {
+			url: "/papi/v1/properties/prp_175780/versions?contractId=ctr_1&groupId=grp_15225&accountSwitchKey=1-ABC",
+			expected: map[string]string{
+				"propertyId": "prp_175780",
+				"contractId": "ctr_1",
+				"groupId":    "grp_15225",
+			},
+		},
+		"escaped identifier": {
+			url:      "/network-list/v2/network-lists/12345_LIST%2FA",
+			expected: map[string]string{"networkListId": "12345_LIST/A"},
+		},
+		"collection": {
+			url: "/appsec/v1/configs",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			u, err := url.Parse(test.url)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, auditResources(u))
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package session
+
+import (
+	"math/rand"
+	"time"
+)
+
+type (
+	// Backoff computes the delay before retrying a failed request, see ClientOptions.
+	// Custom implementations can be supplied to align retries with the policies of the calling platform.
+	Backoff interface {
+		// Delay returns the delay before retry number attempt, starting with 1;
+		// previous is the delay before the preceding retry, zero before the first one
+		Delay(attempt int, previous time.Duration) time.Duration
+	}
+
+	// BackoffFunc adapts a function to the Backoff interface
+	BackoffFunc func(attempt int, previous time.Duration) time.Duration
+
+	// ExponentialBackoff doubles the delay with every retry, starting with Initial and capped by Max,
+	// which default to 1 and 30 seconds. With Jitter, a random delay between zero and the computed one is used.
+	// This is the default backoff.
+	ExponentialBackoff struct {
+		Initial time.Duration
+		Max     time.Duration
+		Jitter  bool
+	}
+
+	// DecorrelatedJitterBackoff waits a random delay between Base and three times the previous delay, capped by Max,
+	// which default to 1 and 30 seconds
+	DecorrelatedJitterBackoff struct {
+		Base time.Duration
+		Max  time.Duration
+	}
+
+	// FixedBackoff waits the same delay before every retry
+	FixedBackoff time.Duration
+)
+
+// Delay calls f(attempt, previous)
+func (f BackoffFunc) Delay(attempt int, previous time.Duration) time.Duration {
+	return f(attempt, previous)
+}
+
+// Delay returns Initial doubled for every retry after the first one, capped by Max
+func (b ExponentialBackoff) Delay(attempt int, _ time.Duration) time.Duration {
+	initial, max := withDefaultDelays(b.Initial, b.Max)
+	if attempt < 1 {
+		attempt = 1
+	}
+	delay := initial << (attempt - 1)
+	if delay > max || delay <= 0 {
+		delay = max
+	}
+	if b.Jitter {
+		delay = time.Duration(rand.Int63n(int64(delay) + 1))
+	}
+	return delay
+}
+
+// Delay returns a random delay between Base and three times the previous delay, capped by Max
+func (b DecorrelatedJitterBackoff) Delay(_ int, previous time.Duration) time.Duration {
+	base, max := withDefaultDelays(b.Base, b.Max)
+	if previous < base {
+		previous = base
+	}
+	upper := previous * 3
+	if upper > max || upper <= 0 {
+		upper = max
+	}
+	if upper <= base {
+		return upper
+	}
+	return base + time.Duration(rand.Int63n(int64(upper-base)+1))
+}
+
+// Delay returns the fixed delay
+func (b FixedBackoff) Delay(int, time.Duration) time.Duration {
+	return time.Duration(b)
+}
+
+func withDefaultDelays(initial, max time.Duration) (time.Duration, time.Duration) {
+	if initial <= 0 {
+		initial = retryDelay
+	}
+	if max <= 0 {
+		max = maxRetryDelay
+	}
+	return initial, max
+}
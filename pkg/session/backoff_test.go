@@ -0,0 +1,87 @@
+package session
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExponentialBackoff(t *testing.T) {
+	b := ExponentialBackoff{Initial: time.Second, Max: 5 * time.Second}
+	assert.Equal(t, time.Second, b.Delay(1, 0))
+	assert.Equal(t, 2*time.Second, b.Delay(2, time.Second))
+	assert.Equal(t, 4*time.Second, b.Delay(3, 2*time.Second))
+	assert.Equal(t, 5*time.Second, b.Delay(4, 4*time.Second))
+	assert.Equal(t, 5*time.Second, b.Delay(100, 5*time.Second))
+
+	assert.Equal(t, retryDelay, ExponentialBackoff{}.Delay(1, 0))
+	assert.Equal(t, maxRetryDelay, ExponentialBackoff{}.Delay(10, 0))
+
+	jitter := ExponentialBackoff{Initial: time.Second, Max: 5 * time.Second, Jitter: true}
+	for i := 0; i < 100; i++ {
+		delay := jitter.Delay(3, 0)
+		assert.True(t, delay >= 0 && delay <= 4*time.Second, "unexpected delay: %s", delay)
+	}
+}
+
+func TestDecorrelatedJitterBackoff(t *testing.T) {
+	b := DecorrelatedJitterBackoff{Base: time.Second, Max: 10 * time.Second}
+	var previous time.Duration
+	for attempt := 1; attempt <= 100; attempt++ {
+		delay := b.Delay(attempt, previous)
+		upper := 3 * previous
+		if upper < 3*time.Second {
+			upper = 3 * time.Second
+		}
+		if upper > 10*time.Second {
+			upper = 10 * time.Second
+		}
+		assert.True(t, delay >= time.Second && delay <= upper, "unexpected delay: %s", delay)
+		previous = delay
+	}
+
+	assert.Equal(t, time.Second, DecorrelatedJitterBackoff{Base: time.Second, Max: time.Second}.Delay(1, 0))
+}
+
+func TestFixedBackoff(t *testing.T) {
+	assert.Equal(t, 3*time.Second, FixedBackoff(3*time.Second).Delay(7, time.Second))
+}
+
+func TestClientOptions_Backoff(t *testing.T) {
+	var calls int32
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 4 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer mockServer.Close()
+
+	var attempts []int
+	var previousDelays []time.Duration
+	backoff := BackoffFunc(func(attempt int, previous time.Duration) time.Duration {
+		attempts = append(attempts, attempt)
+		previousDelays = append(previousDelays, previous)
+		return time.Duration(attempt) * time.Millisecond
+	})
+
+	s, err := New(WithSigner(&edgegrid.Config{Host: "unused.example.com"}), WithClient(mockServer.Client()))
+	require.NoError(t, err)
+	s = ClientOptions{BaseURL: mockServer.URL, Retries: 3, Backoff: backoff}.Apply(s)
+
+	req, err := http.NewRequest(http.MethodGet, "/test", nil)
+	require.NoError(t, err)
+	resp, err := s.Exec(req, nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(4), calls)
+	assert.Equal(t, []int{1, 2, 3}, attempts)
+	assert.Equal(t, []time.Duration{0, time.Millisecond, 2 * time.Millisecond}, previousDelays)
+}
@@ -0,0 +1,155 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+type (
+	// BatchMode controls how RunBatch reacts to failed calls
+	BatchMode int
+
+	// BatchOptions configures RunBatch
+	BatchOptions struct {
+		// Concurrency is the maximum number of calls running at the same time, defaults to 4
+		Concurrency int
+		// Mode tells whether to stop on the first error or to run all calls, defaults to BatchCollectAll
+		Mode BatchMode
+	}
+
+	// BatchResult is the outcome of a single call made by RunBatch
+	BatchResult[R any] struct {
+		// Index is the index of the input item in the slice passed to RunBatch
+		Index int
+		Value R
+		Err   error
+	}
+
+	// Batch collects calls of different kinds, e.g. to several API endpoints, to run them like RunBatch.
+	// The zero value is an empty batch ready to use.
+	Batch struct {
+		calls []func(context.Context) error
+	}
+)
+
+const (
+	// BatchCollectAll runs all calls regardless of failures and reports the error of every call in its result
+	BatchCollectAll BatchMode = iota
+	// BatchFirstError cancels the remaining calls when a call fails
+	BatchFirstError
+)
+
+const defaultBatchConcurrency = 4
+
+var (
+	// ErrBatchFailed is returned by RunBatch when at least one call failed
+	ErrBatchFailed = errors.New("batch failed")
+)
+
+// RunBatch calls fn for every item with at most opts.Concurrency calls running at the same time, e.g.:
+//
+//	results, err := session.RunBatch(ctx, configIDs, func(ctx context.Context, id int) (*appsec.GetConfigurationResponse, error) {
+//		return client.GetConfiguration(ctx, appsec.GetConfigurationRequest{ConfigID: id})
+//	}, session.BatchOptions{Concurrency: 8})
+//
+// Results are returned in the order of items, each carrying the error of its call. When any call fails,
+// the returned error wraps ErrBatchFailed and mentions the error of the first failed call.
+// In BatchFirstError mode, the context passed to running calls is canceled on the first failure
+// and results of the calls which were not started contain the context error.
+func RunBatch[T, R any](ctx context.Context, items []T, fn func(context.Context, T) (R, error), opts BatchOptions) ([]BatchResult[R], error) {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultBatchConcurrency
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([]BatchResult[R], len(items))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency && w < len(items); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i].Index = i
+				if err := ctx.Err(); err != nil {
+					results[i].Err = err
+					continue
+				}
+				results[i].Value, results[i].Err = fn(ctx, items[i])
+				if results[i].Err != nil && opts.Mode == BatchFirstError {
+					cancel()
+				}
+			}
+		}()
+	}
+	for i := range items {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results, batchError(results)
+}
+
+// batchError returns the error of the first failed call, preferring failures over calls canceled because of them
+func batchError[R any](results []BatchResult[R]) error {
+	var failed int
+	var first error
+	for _, r := range results {
+		if r.Err == nil {
+			continue
+		}
+		failed++
+		if first == nil || (errors.Is(first, context.Canceled) && !errors.Is(r.Err, context.Canceled)) {
+			first = r.Err
+		}
+	}
+	if failed == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %d of %d calls failed, first error: %s", ErrBatchFailed, failed, len(results), first)
+}
+
+// Add adds a call to the batch and returns its index in the errors returned by Run; calls store their results
+// themselves, e.g. in variables they close over
+func (b *Batch) Add(call func(context.Context) error) int {
+	b.calls = append(b.calls, call)
+	return len(b.calls) - 1
+}
+
+// Len returns the number of calls in the batch
+func (b *Batch) Len() int {
+	return len(b.calls)
+}
+
+// Run runs the calls of the batch with at most opts.Concurrency calls running at the same time, e.g.:
+//
+//	var b session.Batch
+//	var policies *appsec.GetSecurityPoliciesResponse
+//	var targets *appsec.GetMatchTargetsResponse
+//	b.Add(func(ctx context.Context) (err error) {
+//		policies, err = client.GetSecurityPolicies(ctx, appsec.GetSecurityPoliciesRequest{ConfigID: id, Version: v})
+//		return err
+//	})
+//	b.Add(func(ctx context.Context) (err error) {
+//		targets, err = client.GetMatchTargets(ctx, appsec.GetMatchTargetsRequest{ConfigID: id, ConfigVersion: v})
+//		return err
+//	})
+//	errs, err := b.Run(ctx, session.BatchOptions{})
+//
+// The returned errors are indexed like the calls, and the returned error is the one of RunBatch.
+func (b *Batch) Run(ctx context.Context, opts BatchOptions) ([]error, error) {
+	results, err := RunBatch(ctx, b.calls, func(ctx context.Context, call func(context.Context) error) (struct{}, error) {
+		return struct{}{}, call(ctx)
+	}, opts)
+	errs := make([]error, len(results))
+	for i, r := range results {
+		errs[i] = r.Err
+	}
+	return errs, err
+}
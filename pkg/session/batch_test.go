@@ -0,0 +1,183 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunBatch(t *testing.T) {
+	errFetch := errors.New("fetch failed")
+	items := []int{1, 2, 3, 4, 5, 6, 7, 8}
+
+	t.Run("results are returned in order", func(t *testing.T) {
+		results, err := RunBatch(context.Background(), items, func(_ context.Context, i int) (string, error) {
+			time.Sleep(time.Duration(len(items)-i) * time.Millisecond)
+			return fmt.Sprintf("item %d", i), nil
+		}, BatchOptions{Concurrency: 3})
+		require.NoError(t, err)
+		require.Len(t, results, len(items))
+		for i, r := range results {
+			assert.Equal(t, i, r.Index)
+			assert.Equal(t, fmt.Sprintf("item %d", items[i]), r.Value)
+			assert.NoError(t, r.Err)
+		}
+	})
+
+	t.Run("concurrency is bounded", func(t *testing.T) {
+		var running, peak int32
+		_, err := RunBatch(context.Background(), items, func(_ context.Context, i int) (int, error) {
+			n := atomic.AddInt32(&running, 1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			return i, nil
+		}, BatchOptions{Concurrency: 2})
+		require.NoError(t, err)
+		assert.True(t, peak <= 2, "peak concurrency: %d", peak)
+	})
+
+	t.Run("collect all runs every call", func(t *testing.T) {
+		var calls int32
+		results, err := RunBatch(context.Background(), items, func(_ context.Context, i int) (int, error) {
+			atomic.AddInt32(&calls, 1)
+			if i%2 == 0 {
+				return 0, errFetch
+			}
+			return i, nil
+		}, BatchOptions{})
+		assert.True(t, errors.Is(err, ErrBatchFailed), "want: %s; got: %s", ErrBatchFailed, err)
+		assert.Contains(t, err.Error(), "4 of 8 calls failed")
+		assert.Equal(t, int32(len(items)), calls)
+		for _, r := range results {
+			if items[r.Index]%2 == 0 {
+				assert.True(t, errors.Is(r.Err, errFetch))
+			} else {
+				assert.Equal(t, items[r.Index], r.Value)
+			}
+		}
+	})
+
+	t.Run("first error cancels remaining calls", func(t *testing.T) {
+		var calls int32
+		results, err := RunBatch(context.Background(), items, func(ctx context.Context, i int) (int, error) {
+			atomic.AddInt32(&calls, 1)
+			if i == 1 {
+				return 0, errFetch
+			}
+			select {
+			case <-ctx.Done():
+				return 0, ctx.Err()
+			case <-time.After(50 * time.Millisecond):
+				return i, nil
+			}
+		}, BatchOptions{Concurrency: 2, Mode: BatchFirstError})
+		assert.True(t, errors.Is(err, ErrBatchFailed), "want: %s; got: %s", ErrBatchFailed, err)
+		assert.Contains(t, err.Error(), errFetch.Error())
+		assert.True(t, calls < int32(len(items)), "calls: %d", calls)
+		assert.True(t, errors.Is(results[len(items)-1].Err, context.Canceled))
+	})
+
+	t.Run("no items", func(t *testing.T) {
+		results, err := RunBatch(context.Background(), nil, func(_ context.Context, i int) (int, error) {
+			return i, nil
+		}, BatchOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, results)
+	})
+}
+
+func TestBatch(t *testing.T) {
+	errFetch := errors.New("fetch failed")
+
+	var b Batch
+	var first string
+	var second int
+	b.Add(func(_ context.Context) error {
+		first = "policies"
+		return nil
+	})
+	failed := b.Add(func(_ context.Context) error {
+		return errFetch
+	})
+	b.Add(func(_ context.Context) error {
+		second = 42
+		return nil
+	})
+	require.Equal(t, 3, b.Len())
+
+	errs, err := b.Run(context.Background(), BatchOptions{Concurrency: 2})
+	assert.True(t, errors.Is(err, ErrBatchFailed), "want: %s; got: %s", ErrBatchFailed, err)
+	assert.Contains(t, err.Error(), "1 of 3 calls failed")
+	require.Len(t, errs, 3)
+	assert.Equal(t, 1, failed)
+	assert.NoError(t, errs[0])
+	assert.True(t, errors.Is(errs[failed], errFetch))
+	assert.NoError(t, errs[2])
+	assert.Equal(t, "policies", first)
+	assert.Equal(t, 42, second)
+
+	var empty Batch
+	errs, err = empty.Run(context.Background(), BatchOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, errs)
+}
+
+// TestBatch_SessionExec runs concurrent calls on one session, to be run with -race
+func TestBatch_SessionExec(t *testing.T) {
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, err := fmt.Fprintf(w, `{"path":%q}`, r.URL.Path)
+		assert.NoError(t, err)
+	}))
+	defer mockServer.Close()
+	serverURL, err := url.Parse(mockServer.URL)
+	require.NoError(t, err)
+
+	sess, err := New(
+		WithSigner(&edgegrid.Config{Host: serverURL.Host}),
+		WithClient(mockServer.Client()),
+		WithCircuitBreaker(CircuitBreaker{}),
+	)
+	require.NoError(t, err)
+	sess = ClientOptions{Retries: 1, AccountSwitchKey: "1-ABCDE"}.Apply(sess)
+
+	type result struct {
+		Path string `json:"path"`
+	}
+	var b Batch
+	results := make([]result, 8)
+	for i := range results {
+		i := i
+		b.Add(func(ctx context.Context) error {
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("/papi/v1/properties/prp_%d", i), nil)
+			if err != nil {
+				return err
+			}
+			_, err = sess.Exec(req, &results[i])
+			return err
+		})
+	}
+
+	errs, err := b.Run(context.Background(), BatchOptions{Concurrency: 4})
+	require.NoError(t, err)
+	assert.Len(t, errs, 8)
+	for i, r := range results {
+		assert.Equal(t, fmt.Sprintf("/papi/v1/properties/prp_%d", i), r.Path)
+	}
+}
@@ -0,0 +1,148 @@
+package session
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+type (
+	// CircuitBreaker configures the circuit breaker of a session, see WithCircuitBreaker
+	CircuitBreaker struct {
+		// Threshold is the number of consecutive failures of an API family opening its circuit, 5 by default
+		Threshold int
+		// Cooldown is the time an open circuit fails requests before letting a single probe request through,
+		// 30 seconds by default
+		Cooldown time.Duration
+		// IsFailure, if set, decides whether a response or error counts as a failure;
+		// by default transport errors and 5xx responses do
+		IsFailure func(resp *http.Response, err error) bool
+	}
+
+	// CircuitOpenError is returned without sending the request while the circuit of the API family is open
+	CircuitOpenError struct {
+		// Family is the API family of the request, e.g. appsec
+		Family string
+		// RetryAt is the time the circuit lets a probe request through
+		RetryAt time.Time
+	}
+
+	// breaker tracks the circuits of the API families
+	breaker struct {
+		config CircuitBreaker
+		now    func() time.Time
+
+		mu       sync.Mutex
+		circuits map[string]*circuit
+	}
+
+	// circuit is the state of the circuit of an API family
+	circuit struct {
+		failures int
+		openedAt time.Time
+		open     bool
+		probing  bool
+	}
+)
+
+// ErrCircuitOpen is matched by CircuitOpenError
+var ErrCircuitOpen = errors.New("circuit open")
+
+// WithCircuitBreaker makes the session fail requests to an API family, e.g. /appsec or /papi, with a CircuitOpenError
+// after Threshold consecutive failures, instead of sending them to a degraded endpoint. After Cooldown, one request
+// is let through: the circuit closes if it succeeds and opens again otherwise.
+func WithCircuitBreaker(config CircuitBreaker) Option {
+	return func(s *session) {
+		if config.Threshold <= 0 {
+			config.Threshold = 5
+		}
+		if config.Cooldown <= 0 {
+			config.Cooldown = 30 * time.Second
+		}
+		if config.IsFailure == nil {
+			config.IsFailure = isServerFailure
+		}
+		s.breaker = &breaker{config: config, now: time.Now, circuits: make(map[string]*circuit)}
+	}
+}
+
+// Error returns the error message
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("%s: %s API failing, retry after %s", ErrCircuitOpen, e.Family, e.RetryAt.Format(time.RFC3339))
+}
+
+// Is reports whether target is ErrCircuitOpen
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+// allow returns a CircuitOpenError if the request must not be sent, and whether the request is the probe
+// of an open circuit
+func (b *breaker) allow(r *http.Request) (bool, error) {
+	if b == nil {
+		return false, nil
+	}
+	family := apiFamily(r)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	c, ok := b.circuits[family]
+	if !ok || !c.open {
+		return false, nil
+	}
+	retryAt := c.openedAt.Add(b.config.Cooldown)
+	if c.probing || b.now().Before(retryAt) {
+		return false, &CircuitOpenError{Family: family, RetryAt: retryAt}
+	}
+	c.probing = true
+	return true, nil
+}
+
+// record updates the circuit of the API family of the request with the outcome of sending it;
+// while the circuit is open, only the outcome of the probe request changes its state
+func (b *breaker) record(r *http.Request, probe bool, resp *http.Response, err error) {
+	if b == nil {
+		return
+	}
+	family := apiFamily(r)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	c, ok := b.circuits[family]
+	if !ok {
+		c = &circuit{}
+		b.circuits[family] = c
+	}
+	if c.open && !probe {
+		// requests sent before the circuit opened say nothing about the probe
+		return
+	}
+	if probe {
+		c.probing = false
+	}
+
+	// requests cancelled by the caller say nothing about the API
+	if err != nil && r.Context().Err() != nil {
+		return
+	}
+	if !b.config.IsFailure(resp, err) {
+		c.failures = 0
+		c.open = false
+		return
+	}
+	c.failures++
+	if probe || c.failures >= b.config.Threshold {
+		c.open = true
+		c.openedAt = b.now()
+	}
+}
+
+// isServerFailure reports whether the request failed with a transport error or a 5xx response
+func isServerFailure(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp != nil && resp.StatusCode >= http.StatusInternalServerError
+}
@@ -0,0 +1,181 @@
+package session
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSession_CircuitBreaker(t *testing.T) {
+	type call struct {
+		path          string
+		status        int
+		after         time.Duration
+		expectedSent  bool
+		expectedError error
+	}
+	tests := map[string]struct {
+		config CircuitBreaker
+		calls  []call
+	}{
+		"opens after consecutive failures": {
+			config: CircuitBreaker{Threshold: 2, Cooldown: time.Minute},
+			calls: []call{
+				{path: "/appsec/v1/configs", status: http.StatusServiceUnavailable, expectedSent: true},
+				{path: "/appsec/v1/configs", status: http.StatusInternalServerError, expectedSent: true},
+				{path: "/appsec/v1/configs", expectedError: ErrCircuitOpen},
+			},
+		},
+		"success resets failures": {
+			config: CircuitBreaker{Threshold: 2, Cooldown: time.Minute},
+			calls: []call{
+				{path: "/appsec/v1/configs", status: http.StatusServiceUnavailable, expectedSent: true},
+				{path: "/appsec/v1/configs", status: http.StatusOK, expectedSent: true},
+				{path: "/appsec/v1/configs", status: http.StatusServiceUnavailable, expectedSent: true},
+				{path: "/appsec/v1/configs", status: http.StatusOK, expectedSent: true},
+			},
+		},
+		"client errors are not failures": {
+			config: CircuitBreaker{Threshold: 1, Cooldown: time.Minute},
+			calls: []call{
+				{path: "/appsec/v1/configs", status: http.StatusNotFound, expectedSent: true},
+				{path: "/appsec/v1/configs", status: http.StatusOK, expectedSent: true},
+			},
+		},
+		"circuits are kept per API family": {
+			config: CircuitBreaker{Threshold: 1, Cooldown: time.Minute},
+			calls: []call{
+				{path: "/appsec/v1/configs", status: http.StatusBadGateway, expectedSent: true},
+				{path: "/papi/v1/properties", status: http.StatusOK, expectedSent: true},
+				{path: "/appsec/v1/configs", expectedError: ErrCircuitOpen},
+			},
+		},
+		"successful probe closes circuit": {
+			config: CircuitBreaker{Threshold: 1, Cooldown: time.Minute},
+			calls: []call{
+				{path: "/appsec/v1/configs", status: http.StatusBadGateway, expectedSent: true},
+				{path: "/appsec/v1/configs", after: time.Minute, status: http.StatusOK, expectedSent: true},
+				{path: "/appsec/v1/configs", status: http.StatusOK, expectedSent: true},
+			},
+		},
+		"failed probe opens circuit again": {
+			config: CircuitBreaker{Threshold: 3, Cooldown: time.Minute},
+			calls: []call{
+				{path: "/appsec/v1/configs", status: http.StatusBadGateway, expectedSent: true},
+				{path: "/appsec/v1/configs", status: http.StatusBadGateway, expectedSent: true},
+				{path: "/appsec/v1/configs", status: http.StatusBadGateway, expectedSent: true},
+				{path: "/appsec/v1/configs", after: time.Minute, status: http.StatusBadGateway, expectedSent: true},
+				{path: "/appsec/v1/configs", after: time.Second, expectedError: ErrCircuitOpen},
+			},
+		},
+		"custom failures": {
+			config: CircuitBreaker{
+				Threshold: 1,
+				IsFailure: func(resp *http.Response, err error) bool {
+					return err != nil || resp.StatusCode == http.StatusTooManyRequests
+				},
+			},
+			calls: []call{
+				{path: "/appsec/v1/configs", status: http.StatusTooManyRequests, expectedSent: true},
+				{path: "/appsec/v1/configs", expectedError: ErrCircuitOpen},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var status, sent int32
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&sent, 1)
+				w.WriteHeader(int(atomic.LoadInt32(&status)))
+			}))
+			defer mockServer.Close()
+			serverURL, err := url.Parse(mockServer.URL)
+			require.NoError(t, err)
+
+			sess, err := New(
+				WithSigner(&edgegrid.Config{Host: serverURL.Host}),
+				WithClient(mockServer.Client()),
+				WithCircuitBreaker(test.config),
+			)
+			require.NoError(t, err)
+			now := time.Now()
+			b := sess.(*session).breaker
+			b.now = func() time.Time { return now }
+
+			for i, c := range test.calls {
+				now = now.Add(c.after)
+				atomic.StoreInt32(&status, int32(c.status))
+				atomic.StoreInt32(&sent, 0)
+
+				req, err := http.NewRequest(http.MethodGet, c.path, nil)
+				require.NoError(t, err)
+				resp, err := sess.Exec(req, nil)
+				assert.Equal(t, c.expectedSent, atomic.LoadInt32(&sent) == 1, "call %d", i)
+				if c.expectedError != nil {
+					assert.True(t, errors.Is(err, c.expectedError), "call %d: want: %s; got: %s", i, c.expectedError, err)
+					var openErr *CircuitOpenError
+					require.True(t, errors.As(err, &openErr))
+					assert.Equal(t, "appsec", openErr.Family)
+					continue
+				}
+				require.NoError(t, err, "call %d", i)
+				assert.Equal(t, c.status, resp.StatusCode, "call %d", i)
+			}
+		})
+	}
+}
+
+func TestBreaker_Probe(t *testing.T) {
+	now := time.Now()
+	b := &breaker{
+		config:   CircuitBreaker{Threshold: 1, Cooldown: time.Minute, IsFailure: isServerFailure},
+		now:      func() time.Time { return now },
+		circuits: make(map[string]*circuit),
+	}
+	req, err := http.NewRequest(http.MethodGet, "/appsec/v1/configs", nil)
+	require.NoError(t, err)
+	ok := &http.Response{StatusCode: http.StatusOK}
+	failed := &http.Response{StatusCode: http.StatusBadGateway}
+
+	// a request still in flight when the circuit opens
+	probe, err := b.allow(req)
+	require.NoError(t, err)
+	assert.False(t, probe)
+	b.record(req, false, failed, nil)
+	_, err = b.allow(req)
+	assert.True(t, errors.Is(err, ErrCircuitOpen))
+
+	now = now.Add(time.Minute)
+	probe, err = b.allow(req)
+	require.NoError(t, err)
+	assert.True(t, probe)
+
+	// the stale request finishing during the probe changes nothing
+	b.record(req, false, ok, nil)
+	_, err = b.allow(req)
+	assert.True(t, errors.Is(err, ErrCircuitOpen), "only the probe may be in flight")
+
+	// the failed probe opens the circuit again
+	b.record(req, true, failed, nil)
+	_, err = b.allow(req)
+	assert.True(t, errors.Is(err, ErrCircuitOpen))
+
+	now = now.Add(time.Minute)
+	probe, err = b.allow(req)
+	require.NoError(t, err)
+	assert.True(t, probe)
+	b.record(req, false, failed, nil)
+	b.record(req, true, ok, nil)
+	probe, err = b.allow(req)
+	require.NoError(t, err)
+	assert.False(t, probe, "the successful probe closes the circuit")
+}
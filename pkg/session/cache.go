@@ -0,0 +1,140 @@
+package session
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+type (
+	// Cache stores response bodies of cacheable requests, see WithCache.
+	// Implementations have to be safe for concurrent use.
+	Cache interface {
+		// Get returns the value stored under key, if present and not expired
+		Get(key string) ([]byte, bool)
+		// Set stores the value under key for the ttl duration
+		Set(key string, value []byte, ttl time.Duration)
+	}
+
+	// CacheRule makes successful GET responses of paths starting with PathPrefix cacheable for TTL
+	CacheRule struct {
+		PathPrefix string
+		TTL        time.Duration
+	}
+
+	// MemoryCache is an in-memory Cache
+	MemoryCache struct {
+		mu      sync.Mutex
+		entries map[string]cacheEntry
+		now     func() time.Time
+	}
+
+	cacheEntry struct {
+		value   []byte
+		expires time.Time
+	}
+
+	responseCache struct {
+		cache Cache
+		rules []CacheRule
+	}
+)
+
+// DefaultCacheRules caches slow-changing reference data for an hour: contracts, groups, products and rule formats
+// of Property Manager, SIEM definitions of Application Security and IAM support data, such as countries and time zones
+var DefaultCacheRules = []CacheRule{
+	{PathPrefix: "/papi/v1/contracts", TTL: time.Hour},
+	{PathPrefix: "/papi/v1/groups", TTL: time.Hour},
+	{PathPrefix: "/papi/v1/products", TTL: time.Hour},
+	{PathPrefix: "/papi/v1/rule-formats", TTL: time.Hour},
+	{PathPrefix: "/appsec/v1/siem-definitions", TTL: time.Hour},
+	{PathPrefix: "/identity-management/v2/user-admin/common/", TTL: time.Hour},
+}
+
+// WithCache makes the session serve GET requests matching one of the rules from the cache, e.g.:
+//
+//	sess, err := session.New(session.WithCache(session.NewMemoryCache(), session.DefaultCacheRules...))
+//
+// Successful responses are cached under the request URL, including the host and the account switch key,
+// so a cache may be shared between sessions using different credentials.
+// Without rules, DefaultCacheRules are used.
+func WithCache(cache Cache, rules ...CacheRule) Option {
+	return func(s *session) {
+		if len(rules) == 0 {
+			rules = DefaultCacheRules
+		}
+		s.cache = &responseCache{cache: cache, rules: rules}
+	}
+}
+
+// NewMemoryCache returns an empty in-memory cache
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{entries: make(map[string]cacheEntry), now: time.Now}
+}
+
+// Get returns the value stored under key, if present and not expired
+func (c *MemoryCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !c.now().Before(entry.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.value, true
+}
+
+// Set stores the value under key for the ttl duration
+func (c *MemoryCache) Set(key string, value []byte, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = cacheEntry{value: value, expires: c.now().Add(ttl)}
+}
+
+// Clear removes all values
+func (c *MemoryCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[string]cacheEntry)
+}
+
+// ttl returns for how long the response to the request can be cached, zero if it is not cacheable
+func (c *responseCache) ttl(r *http.Request) time.Duration {
+	if c == nil || r.Method != http.MethodGet {
+		return 0
+	}
+	for _, rule := range c.rules {
+		if strings.HasPrefix(r.URL.Path, rule.PathPrefix) {
+			return rule.TTL
+		}
+	}
+	return 0
+}
+
+func cacheKey(r *http.Request) string {
+	return r.Method + " " + r.URL.String()
+}
+
+// cachedResponse returns a response to the request with given body
+func cachedResponse(r *http.Request, body []byte) *http.Response {
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       r,
+	}
+}
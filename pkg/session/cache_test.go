@@ -0,0 +1,126 @@
+package session
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryCache(t *testing.T) {
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	c := NewMemoryCache()
+	c.now = func() time.Time { return now }
+
+	c.Set("a", []byte("value"), time.Minute)
+	value, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("value"), value)
+
+	_, ok = c.Get("b")
+	assert.False(t, ok)
+
+	now = now.Add(time.Minute)
+	_, ok = c.Get("a")
+	assert.False(t, ok)
+
+	c.Set("a", []byte("value"), time.Minute)
+	c.Clear()
+	_, ok = c.Get("a")
+	assert.False(t, ok)
+}
+
+func TestSession_ExecCache(t *testing.T) {
+	tests := map[string]struct {
+		method        string
+		path          string
+		accountKeys   []string
+		status        int
+		rules         []CacheRule
+		expectedCalls int
+	}{
+		"default rules, cached": {
+			method:        http.MethodGet,
+			path:          "/papi/v1/contracts",
+			status:        http.StatusOK,
+			expectedCalls: 1,
+		},
+		"default rules, not cached path": {
+			method:        http.MethodGet,
+			path:          "/papi/v1/properties",
+			status:        http.StatusOK,
+			expectedCalls: 3,
+		},
+		"custom rules": {
+			method:        http.MethodGet,
+			path:          "/papi/v1/properties",
+			status:        http.StatusOK,
+			rules:         []CacheRule{{PathPrefix: "/papi/v1/properties", TTL: time.Minute}},
+			expectedCalls: 1,
+		},
+		"errors are not cached": {
+			method:        http.MethodGet,
+			path:          "/papi/v1/contracts",
+			status:        http.StatusInternalServerError,
+			expectedCalls: 3,
+		},
+		"only GET is cached": {
+			method:        http.MethodPost,
+			path:          "/papi/v1/contracts",
+			status:        http.StatusOK,
+			expectedCalls: 3,
+		},
+		"accounts are cached separately": {
+			method:        http.MethodGet,
+			path:          "/papi/v1/contracts",
+			accountKeys:   []string{"1-A", "1-B", "1-A"},
+			status:        http.StatusOK,
+			expectedCalls: 2,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var calls int
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				w.WriteHeader(test.status)
+				_, err := w.Write([]byte(`{"a":"text","b":1}`))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			serverURL, err := url.Parse(mockServer.URL)
+			require.NoError(t, err)
+
+			cache := NewMemoryCache()
+			for i := 0; i < 3; i++ {
+				config := &edgegrid.Config{Host: serverURL.Host}
+				if test.accountKeys != nil {
+					config.AccountKey = test.accountKeys[i]
+				}
+				s, err := New(
+					WithSigner(config),
+					WithClient(mockServer.Client()),
+					WithCache(cache, test.rules...),
+				)
+				require.NoError(t, err)
+
+				req, err := http.NewRequest(test.method, test.path, nil)
+				require.NoError(t, err)
+				var out testStruct
+				resp, err := s.Exec(req, &out)
+				require.NoError(t, err)
+				assert.Equal(t, test.status, resp.StatusCode)
+				if test.status == http.StatusOK {
+					assert.Equal(t, testStruct{A: "text", B: 1}, out)
+				}
+			}
+			assert.Equal(t, test.expectedCalls, calls)
+		})
+	}
+}
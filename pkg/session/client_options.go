@@ -0,0 +1,247 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/apex/log"
+)
+
+type (
+	// ClientOptions holds the settings shared by the clients of all API packages.
+	// Packages expose them through the WithLogger, WithRetries, WithRetryClassifier, WithBackoff, WithIdempotencyKeys,
+	// WithBaseURL and WithAccountSwitchKey options of their Client constructors.
+	ClientOptions struct {
+		// Logger replaces the session logger; a logger set with WithContextLog still takes precedence
+		Logger log.Interface
+		// Retries is the number of times an idempotent request is repeated after a transport error
+		// or a 429, 502, 503 or 504 response; POST and PATCH requests are only retried if RetryClassifier decides so.
+		// It cannot be set for a session with a retry policy, see WithRetryPolicy.
+		Retries int
+		// RetryClassifier, if set, decides whether a failed request is retried and after what delay,
+		// overriding the default policy, e.g. to retry specific 403 errors; retries are still limited by Retries
+		RetryClassifier RetryClassifier
+		// Backoff computes the delays between retries not set by RetryClassifier or by the Retry-After header
+		// of the response, ExponentialBackoff by default
+		Backoff Backoff
+		// MaxElapsed, if set, limits the time spent on a request and its retries:
+		// no retry is attempted if it would start after MaxElapsed since the first attempt
+		MaxElapsed time.Duration
+		// Budget, if set, limits the retries of all requests of the client within a time window, see RetryBudget
+		Budget *RetryBudget
+		// IdempotencyKeys makes the session add a unique idempotency key to every POST and PATCH request which does
+		// not have one yet, like WithIdempotencyKeys does for all requests of the session. It does not make the requests
+		// retried: set RetryClassifier to RetryWithIdempotencyKey for APIs known to honor the key.
+		IdempotencyKeys bool
+		// BaseURL replaces the scheme and host requests are sent to, e.g. https://akab-xxx.luna.akamaiapis.net;
+		// requests are still signed for the host of the signer
+		BaseURL string
+		// AccountSwitchKey makes requests act on the account with given switch key, overriding the account key
+		// of the signer
+		AccountSwitchKey string
+	}
+
+	optionsSession struct {
+		Session
+		opts    ClientOptions
+		baseURL *url.URL
+		budget  *retryBudget
+		err     error
+	}
+)
+
+var (
+	// retryDelay is the delay before the first retry, doubled for every following one
+	retryDelay = time.Second
+	// maxRetryDelay caps the delay between retries
+	maxRetryDelay = 30 * time.Second
+)
+
+// Apply returns sess configured with the options, or sess itself when no option is set
+func (o ClientOptions) Apply(sess Session) Session {
+	if o.Logger == nil && o.Retries == 0 && !o.IdempotencyKeys && o.BaseURL == "" && o.AccountSwitchKey == "" {
+		return sess
+	}
+
+	s := &optionsSession{Session: sess, opts: o, budget: newRetryBudget(o.Budget)}
+	if base := baseSession(sess); o.Retries > 0 && base != nil && base.retryPolicy.Retries > 0 {
+		s.err = fmt.Errorf("%w: retries are already set by the retry policy of the session", ErrInvalidArgument)
+	}
+	if o.BaseURL != "" {
+		u, err := url.Parse(o.BaseURL)
+		if err == nil && u.Host == "" {
+			err = errors.New("missing host")
+		}
+		if err != nil {
+			s.err = fmt.Errorf("%w: invalid base URL %q: %s", ErrInvalidArgument, o.BaseURL, err)
+		}
+		s.baseURL = u
+	}
+	return s
+}
+
+// Log returns the context logger, or the logger of the options, or the session log
+func (s *optionsSession) Log(ctx context.Context) log.Interface {
+	if s.opts.Logger != nil && !hasContextLog(ctx) {
+		return s.opts.Logger
+	}
+	return s.Session.Log(ctx)
+}
+
+// Exec applies the options to the request and executes it with the underlying session
+func (s *optionsSession) Exec(r *http.Request, out interface{}, in ...interface{}) (*http.Response, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	base := baseSession(s.Session)
+	if base == nil {
+		return s.exec(r, out, in...)
+	}
+	// the deadline covers all attempts
+	r, cancel := base.withDeadline(r)
+	resp, err := s.exec(r, out, in...)
+	releaseDeadline(resp, cancel)
+	return resp, err
+}
+
+func (s *optionsSession) exec(r *http.Request, out interface{}, in ...interface{}) (*http.Response, error) {
+	if s.opts.Logger != nil && !hasContextLog(r.Context()) || s.baseURL != nil || s.opts.IdempotencyKeys {
+		o := &contextOptions{}
+		if current, ok := r.Context().Value(contextOptionKey).(*contextOptions); ok {
+			*o = *current
+		}
+		if o.log == nil {
+			o.log = s.opts.Logger
+		}
+		// the base URL is applied by the session after signing, so that requests are signed for the signer host;
+		// options without a base URL keep the one set by an outer wrapper
+		if s.baseURL != nil {
+			o.baseURL = s.baseURL
+		}
+		// the key is added by the session like with WithIdempotencyKeys, and kept by all attempts of the request
+		o.idempotencyKeys = o.idempotencyKeys || s.opts.IdempotencyKeys
+		r = r.WithContext(context.WithValue(r.Context(), contextOptionKey, o))
+	}
+	if s.opts.AccountSwitchKey != "" {
+		q := r.URL.Query()
+		q.Set("accountSwitchKey", s.opts.AccountSwitchKey)
+		r.URL.RawQuery = q.Encode()
+	}
+
+	backoff := s.opts.Backoff
+	if backoff == nil {
+		backoff = ExponentialBackoff{}
+	}
+	base := baseSession(s.Session)
+	start := time.Now()
+	var previous time.Duration
+	for attempt := 0; ; attempt++ {
+		resp, err := s.Session.Exec(r, out, in...)
+		if attempt >= s.opts.Retries || !canResend(r, in) {
+			return resp, err
+		}
+		// requests rejected with 429 were already repeated by the session, see WithRateLimitRetries
+		if resp != nil && resp.StatusCode == http.StatusTooManyRequests && base != nil && base.rateLimitRetries > 0 {
+			return resp, err
+		}
+		retry, delay := s.classifyRetry(r, resp, err, attempt)
+		if !retry {
+			return resp, err
+		}
+		if delay <= 0 {
+			delay = retryAfter(resp, time.Now())
+		}
+		if delay <= 0 {
+			delay = backoff.Delay(attempt+1, previous)
+		}
+		if s.opts.MaxElapsed > 0 && time.Since(start)+delay > s.opts.MaxElapsed {
+			return resp, err
+		}
+		if s.budget != nil && !s.budget.take(time.Now()) {
+			s.Log(r.Context()).Warnf("Retry budget exhausted, not retrying %s %s", r.Method, r.URL.Path)
+			return resp, err
+		}
+		previous = delay
+		if metrics := sessionMetrics(s.Session); metrics != nil {
+			metrics.ObserveRetry(RetryMetric{Endpoint: endpoint(r), Attempt: attempt + 1, Delay: delay})
+		}
+
+		if resp != nil {
+			resp.Body.Close()
+		}
+		if r.GetBody != nil && len(in) == 0 {
+			body, err := r.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			r.Body = body
+		}
+
+		s.Log(r.Context()).Debugf("Retrying %s %s in %s", r.Method, r.URL.Path, delay)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-r.Context().Done():
+			timer.Stop()
+			return nil, r.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func shouldRetry(r *http.Request, resp *http.Response, err error) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return isTransientFailure(r, resp, err)
+	}
+	return false
+}
+
+// isTransientFailure reports whether the request failed with a transport error or a 429, 502, 503 or 504 response
+func isTransientFailure(r *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		// errors which are not transport errors, such as failed unmarshaling, are not transient
+		return r.Context().Err() == nil && resp == nil && isTransportError(err)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// canResend reports whether the request body can be sent again
+func canResend(r *http.Request, in []interface{}) bool {
+	if form, ok := firstMultipartForm(in); ok {
+		return form.seekable()
+	}
+	return len(in) > 0 || r.Body == nil || r.Body == http.NoBody || r.GetBody != nil
+}
+
+func isTransportError(err error) bool {
+	_, ok := err.(*url.Error)
+	return ok
+}
+
+// baseSession returns the session wrapped by client options, if any
+func baseSession(sess Session) *session {
+	for {
+		switch s := sess.(type) {
+		case *session:
+			return s
+		case *optionsSession:
+			sess = s.Session
+		default:
+			return nil
+		}
+	}
+}
+
+func hasContextLog(ctx context.Context) bool {
+	o, ok := ctx.Value(contextOptionKey).(*contextOptions)
+	return ok && o.log != nil
+}
@@ -0,0 +1,495 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/edgegrid"
+	"github.com/apex/log"
+	"github.com/apex/log/handlers/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientOptions_Apply(t *testing.T) {
+	sess, err := New()
+	require.NoError(t, err)
+
+	assert.Equal(t, sess, ClientOptions{}.Apply(sess))
+	assert.NotEqual(t, sess, ClientOptions{Retries: 1}.Apply(sess))
+}
+
+func TestClientOptions_Exec(t *testing.T) {
+	retryDelay = time.Millisecond
+	defer func() { retryDelay = time.Second }()
+
+	tests := map[string]struct {
+		options        ClientOptions
+		accountKey     string
+		method         string
+		body           string
+		statuses       []int
+		expectedStatus int
+		expectedCalls  int32
+		expectedQuery  string
+		withError      error
+	}{
+		"base URL": {
+			options:        ClientOptions{BaseURL: "{{server}}"},
+			method:         http.MethodGet,
+			statuses:       []int{http.StatusOK},
+			expectedStatus: http.StatusOK,
+			expectedCalls:  1,
+		},
+		"invalid base URL": {
+			options:   ClientOptions{BaseURL: "not a URL"},
+			method:    http.MethodGet,
+			withError: ErrInvalidArgument,
+		},
+		"account switch key": {
+			options:        ClientOptions{BaseURL: "{{server}}", AccountSwitchKey: "1-ABCD:1-2345"},
+			method:         http.MethodGet,
+			statuses:       []int{http.StatusOK},
+			expectedStatus: http.StatusOK,
+			expectedCalls:  1,
+			expectedQuery:  "accountSwitchKey=1-ABCD%3A1-2345",
+		},
+		"account switch key overrides signer account key": {
+			options:        ClientOptions{BaseURL: "{{server}}", AccountSwitchKey: "1-ABCD:1-2345"},
+			accountKey:     "1-XYZ",
+			method:         http.MethodGet,
+			statuses:       []int{http.StatusOK},
+			expectedStatus: http.StatusOK,
+			expectedCalls:  1,
+			expectedQuery:  "accountSwitchKey=1-ABCD%3A1-2345",
+		},
+		"retries transient failures": {
+			options:        ClientOptions{BaseURL: "{{server}}", Retries: 3},
+			method:         http.MethodGet,
+			statuses:       []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK},
+			expectedStatus: http.StatusOK,
+			expectedCalls:  3,
+		},
+		"retries PUT with body": {
+			options:        ClientOptions{BaseURL: "{{server}}", Retries: 1},
+			method:         http.MethodPut,
+			body:           `{"a":"b"}`,
+			statuses:       []int{http.StatusBadGateway, http.StatusOK},
+			expectedStatus: http.StatusOK,
+			expectedCalls:  2,
+		},
+		"gives up after retries": {
+			options:        ClientOptions{BaseURL: "{{server}}", Retries: 1},
+			method:         http.MethodGet,
+			statuses:       []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusOK},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedCalls:  2,
+		},
+		"does not retry POST": {
+			options:        ClientOptions{BaseURL: "{{server}}", Retries: 3},
+			method:         http.MethodPost,
+			body:           `{"a":"b"}`,
+			statuses:       []int{http.StatusServiceUnavailable, http.StatusOK},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedCalls:  1,
+		},
+		"does not retry client errors": {
+			options:        ClientOptions{BaseURL: "{{server}}", Retries: 3},
+			method:         http.MethodGet,
+			statuses:       []int{http.StatusNotFound, http.StatusOK},
+			expectedStatus: http.StatusNotFound,
+			expectedCalls:  1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var calls int32
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				call := atomic.AddInt32(&calls, 1)
+				assert.Equal(t, test.expectedQuery, r.URL.RawQuery)
+				if test.body != "" {
+					body, err := ioutil.ReadAll(r.Body)
+					assert.NoError(t, err)
+					assert.Equal(t, test.body, string(body))
+				}
+				w.WriteHeader(test.statuses[call-1])
+			}))
+			defer mockServer.Close()
+
+			s, err := New(
+				WithSigner(&edgegrid.Config{Host: "unused.example.com", AccountKey: test.accountKey}),
+				WithClient(mockServer.Client()),
+			)
+			require.NoError(t, err)
+			opts := test.options
+			opts.BaseURL = strings.ReplaceAll(opts.BaseURL, "{{server}}", mockServer.URL)
+			s = opts.Apply(s)
+
+			req, err := http.NewRequest(test.method, "/test", strings.NewReader(test.body))
+			require.NoError(t, err)
+			resp, err := s.Exec(req, nil)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedStatus, resp.StatusCode)
+			assert.Equal(t, test.expectedCalls, calls)
+		})
+	}
+}
+
+// recordingSigner records the host of every request it signs
+type recordingSigner struct {
+	edgegrid.Config
+	hosts []string
+}
+
+func (s *recordingSigner) SignRequest(r *http.Request) {
+	s.Config.SignRequest(r)
+	s.hosts = append(s.hosts, r.URL.Host)
+}
+
+func TestClientOptions_BaseURLSigning(t *testing.T) {
+	var host string
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host = r.Host
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer mockServer.Close()
+	serverURL, err := url.Parse(mockServer.URL)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		wrap func(Session) Session
+	}{
+		"base URL": {
+			wrap: func(s Session) Session {
+				return ClientOptions{BaseURL: mockServer.URL}.Apply(s)
+			},
+		},
+		"base URL wrapping options without base URL": {
+			wrap: func(s Session) Session {
+				return ClientOptions{BaseURL: mockServer.URL}.Apply(ClientOptions{Logger: log.Log}.Apply(s))
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			host = ""
+			signer := &recordingSigner{Config: edgegrid.Config{Host: "akab-xxx.luna.akamaiapis.net"}}
+			s, err := New(WithSigner(signer), WithClient(mockServer.Client()))
+			require.NoError(t, err)
+			s = test.wrap(s)
+
+			req, err := http.NewRequest(http.MethodGet, "/test", nil)
+			require.NoError(t, err)
+			resp, err := s.Exec(req, nil)
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, []string{"akab-xxx.luna.akamaiapis.net"}, signer.hosts)
+			assert.Equal(t, serverURL.Host, host)
+		})
+	}
+}
+
+func TestClientOptions_Logger(t *testing.T) {
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer mockServer.Close()
+	serverURL, err := url.Parse(mockServer.URL)
+	require.NoError(t, err)
+
+	sessionLog := memory.New()
+	s, err := New(
+		WithSigner(&edgegrid.Config{Host: serverURL.Host}),
+		WithClient(mockServer.Client()),
+		WithLog(&log.Logger{Handler: sessionLog, Level: log.DebugLevel}),
+		WithHTTPTracing(true),
+	)
+	require.NoError(t, err)
+
+	clientLog := memory.New()
+	logger := &log.Logger{Handler: clientLog, Level: log.DebugLevel}
+	s = ClientOptions{Logger: logger}.Apply(s)
+	assert.Equal(t, logger, s.Log(context.Background()))
+
+	contextLogger := &log.Logger{Handler: memory.New()}
+	assert.Equal(t, contextLogger, s.Log(ContextWithOptions(context.Background(), WithContextLog(contextLogger))))
+
+	req, err := http.NewRequest(http.MethodGet, "/test", nil)
+	require.NoError(t, err)
+	_, err = s.Exec(req, nil)
+	require.NoError(t, err)
+	assert.NotEmpty(t, clientLog.Entries)
+	assert.Empty(t, sessionLog.Entries)
+}
+
+func TestClientOptions_RetryClassifier(t *testing.T) {
+	retryDelay = time.Millisecond
+	defer func() { retryDelay = time.Second }()
+
+	problem := `{"type": "/appsec/error-types/rate-limited-config", "title": "Forbidden", "detail": "Config locked", "status": 403}`
+	tests := map[string]struct {
+		method          string
+		responses       []string
+		classifier      RetryClassifier
+		expectedStatus  int
+		expectedCalls   int32
+		expectedProblem *Problem
+	}{
+		"retries classified error": {
+			method:    http.MethodPost,
+			responses: []string{problem, ""},
+			classifier: func(a RetryAttempt) *RetryDecision {
+				if a.Problem != nil && a.Problem.Type == "/appsec/error-types/rate-limited-config" {
+					return &RetryDecision{Retry: true, Delay: time.Millisecond}
+				}
+				return nil
+			},
+			expectedStatus: http.StatusOK,
+			expectedCalls:  2,
+		},
+		"stops default retry": {
+			method:    http.MethodGet,
+			responses: []string{"503", ""},
+			classifier: func(a RetryAttempt) *RetryDecision {
+				return &RetryDecision{Retry: false}
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedCalls:  1,
+		},
+		"falls back to default policy": {
+			method:    http.MethodGet,
+			responses: []string{"503", ""},
+			classifier: func(a RetryAttempt) *RetryDecision {
+				return nil
+			},
+			expectedStatus: http.StatusOK,
+			expectedCalls:  2,
+		},
+		"unclassified error keeps body": {
+			method:    http.MethodPost,
+			responses: []string{problem, ""},
+			classifier: func(a RetryAttempt) *RetryDecision {
+				return nil
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedCalls:  1,
+			expectedProblem: &Problem{
+				Type:   "/appsec/error-types/rate-limited-config",
+				Title:  "Forbidden",
+				Detail: "Config locked",
+				Status: http.StatusForbidden,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var calls int32
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch response := test.responses[atomic.AddInt32(&calls, 1)-1]; response {
+				case "":
+					w.WriteHeader(http.StatusOK)
+				case "503":
+					w.WriteHeader(http.StatusServiceUnavailable)
+				default:
+					w.Header().Set("Content-Type", "application/problem+json")
+					w.WriteHeader(http.StatusForbidden)
+					_, err := w.Write([]byte(response))
+					assert.NoError(t, err)
+				}
+			}))
+			defer mockServer.Close()
+
+			s, err := New(WithSigner(&edgegrid.Config{Host: "unused.example.com"}), WithClient(mockServer.Client()))
+			require.NoError(t, err)
+			s = ClientOptions{BaseURL: mockServer.URL, Retries: 3, RetryClassifier: test.classifier}.Apply(s)
+
+			req, err := http.NewRequest(test.method, "/test", nil)
+			require.NoError(t, err)
+			resp, err := s.Exec(req, nil)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedStatus, resp.StatusCode)
+			assert.Equal(t, test.expectedCalls, calls)
+			if test.expectedProblem != nil {
+				assert.Equal(t, test.expectedProblem, readProblem(resp))
+			}
+		})
+	}
+}
+
+func TestSession_RetryPolicy(t *testing.T) {
+	tests := map[string]struct {
+		policy         RetryPolicy
+		clientRetries  int
+		retryAfter     string
+		statuses       []int
+		expectedStatus int
+		expectedCalls  int32
+		withError      error
+	}{
+		"retries transient failures": {
+			policy:         RetryPolicy{Retries: 2, Backoff: FixedBackoff(time.Millisecond)},
+			statuses:       []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusOK},
+			expectedStatus: http.StatusOK,
+			expectedCalls:  3,
+		},
+		"retry after within budget": {
+			policy:         RetryPolicy{Retries: 1, MaxElapsed: time.Minute},
+			retryAfter:     "0",
+			statuses:       []int{http.StatusTooManyRequests, http.StatusOK},
+			expectedStatus: http.StatusOK,
+			expectedCalls:  2,
+		},
+		"retry after exceeding budget": {
+			policy:         RetryPolicy{Retries: 3, Backoff: FixedBackoff(time.Millisecond), MaxElapsed: 500 * time.Millisecond},
+			retryAfter:     "1",
+			statuses:       []int{http.StatusServiceUnavailable, http.StatusOK},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedCalls:  1,
+		},
+		"no policy": {
+			statuses:       []int{http.StatusServiceUnavailable, http.StatusOK},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedCalls:  1,
+		},
+		"client retries without policy": {
+			clientRetries:  1,
+			statuses:       []int{http.StatusServiceUnavailable, http.StatusOK},
+			expectedStatus: http.StatusOK,
+			expectedCalls:  2,
+		},
+		"client retries with policy": {
+			policy:        RetryPolicy{Retries: 2},
+			clientRetries: 1,
+			statuses:      []int{http.StatusOK},
+			withError:     ErrInvalidArgument,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			retryDelay = time.Millisecond
+			defer func() { retryDelay = time.Second }()
+
+			var calls int32
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				call := atomic.AddInt32(&calls, 1)
+				if test.retryAfter != "" {
+					w.Header().Set("Retry-After", test.retryAfter)
+				}
+				w.WriteHeader(test.statuses[call-1])
+			}))
+			defer mockServer.Close()
+			serverURL, err := url.Parse(mockServer.URL)
+			require.NoError(t, err)
+
+			s, err := New(
+				WithSigner(&edgegrid.Config{Host: serverURL.Host}),
+				WithClient(mockServer.Client()),
+				WithRetryPolicy(test.policy),
+			)
+			require.NoError(t, err)
+			s = ClientOptions{Retries: test.clientRetries}.Apply(s)
+
+			req, err := http.NewRequest(http.MethodGet, "/test", nil)
+			require.NoError(t, err)
+			resp, err := s.Exec(req, nil)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				assert.Zero(t, atomic.LoadInt32(&calls))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedStatus, resp.StatusCode)
+			assert.Equal(t, test.expectedCalls, atomic.LoadInt32(&calls))
+		})
+	}
+}
+
+func TestSession_RetryBudget(t *testing.T) {
+	var calls int32
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer mockServer.Close()
+	serverURL, err := url.Parse(mockServer.URL)
+	require.NoError(t, err)
+
+	s, err := New(
+		WithSigner(&edgegrid.Config{Host: serverURL.Host}),
+		WithClient(mockServer.Client()),
+		WithRetryPolicy(RetryPolicy{
+			Retries: 5,
+			Backoff: FixedBackoff(time.Millisecond),
+			Budget:  &RetryBudget{MaxRetries: 2, Window: time.Hour},
+		}),
+	)
+	require.NoError(t, err)
+
+	for i, expectedCalls := range []int32{3, 1, 1} {
+		atomic.StoreInt32(&calls, 0)
+		req, err := http.NewRequest(http.MethodGet, "/test", nil)
+		require.NoError(t, err)
+		resp, err := s.Exec(req, nil)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		assert.Equal(t, expectedCalls, atomic.LoadInt32(&calls), "call %d", i+1)
+	}
+}
+
+func TestRetryBudget_Take(t *testing.T) {
+	assert.Nil(t, newRetryBudget(nil))
+	assert.Nil(t, newRetryBudget(&RetryBudget{Window: time.Minute}))
+
+	b := newRetryBudget(&RetryBudget{MaxRetries: 2})
+	require.NotNil(t, b)
+	assert.Equal(t, DefaultRetryBudgetWindow, b.window)
+
+	now := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+	assert.True(t, b.take(now))
+	assert.True(t, b.take(now.Add(30*time.Second)))
+	assert.False(t, b.take(now.Add(59*time.Second)))
+	assert.True(t, b.take(now.Add(61*time.Second)), "first retry left the window")
+	assert.False(t, b.take(now.Add(62*time.Second)))
+	assert.True(t, b.take(now.Add(3*time.Minute)))
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		header   string
+		expected time.Duration
+	}{
+		"seconds":          {header: "120", expected: 2 * time.Minute},
+		"http date":        {header: now.Add(30 * time.Second).Format(http.TimeFormat), expected: 30 * time.Second},
+		"date in the past": {header: now.Add(-time.Minute).Format(http.TimeFormat)},
+		"negative":         {header: "-1"},
+		"invalid":          {header: "soon"},
+		"missing":          {},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}}
+			if test.header != "" {
+				resp.Header.Set("Retry-After", test.header)
+			}
+			assert.Equal(t, test.expected, retryAfter(resp, now))
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package session
+
+import (
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// timestampSigner is implemented by signers which can sign requests with a given timestamp, such as edgegrid.Config
+type timestampSigner interface {
+	SignRequestAt(r *http.Request, at time.Time)
+}
+
+// now returns the current time corrected for the clock skew detected from the API responses
+func (s *session) now() time.Time {
+	return time.Now().Add(time.Duration(atomic.LoadInt64(&s.clockSkew)))
+}
+
+// correctClockSkew handles responses rejecting the signature timestamp of the request: it computes the skew
+// of the local clock from the Date header of the response, and re-signs the request with the corrected timestamp.
+// It reports whether the request is ready to be sent again.
+func (s *session) correctClockSkew(r *http.Request, resp *http.Response, received time.Time) bool {
+	if !isTimestampError(resp) {
+		return false
+	}
+	date, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		return false
+	}
+	if r.Body != nil && r.Body != http.NoBody {
+		if r.GetBody == nil {
+			return false
+		}
+		body, err := r.GetBody()
+		if err != nil {
+			return false
+		}
+		r.Body = body
+	}
+
+	skew := date.Sub(received)
+	atomic.StoreInt64(&s.clockSkew, int64(skew))
+	s.Log(r.Context()).Warnf("Request timestamp rejected, retrying %s %s with clock skew of %s", r.Method, r.URL.Path, skew)
+	if err := s.Sign(r); err != nil {
+		return false
+	}
+	resp.Body.Close()
+	return true
+}
+
+// isTimestampError reports whether the API rejected the signature because of an invalid timestamp
+func isTimestampError(resp *http.Response) bool {
+	if resp.StatusCode != http.StatusBadRequest && resp.StatusCode != http.StatusUnauthorized {
+		return false
+	}
+	problem := readProblem(resp)
+	if problem == nil {
+		return false
+	}
+	return strings.Contains(strings.ToLower(problem.Title+" "+problem.Detail), "timestamp")
+}
@@ -0,0 +1,115 @@
+package session
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSession_ClockSkew(t *testing.T) {
+	serverTime := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+
+	tests := map[string]struct {
+		method        string
+		body          interface{}
+		errorBody     string
+		expectedCalls int
+		expectedSkew  bool
+	}{
+		"timestamp rejected, request re-signed": {
+			method:        http.MethodGet,
+			errorBody:     `{"type":"https://problems.luna.akamaiapis.net/-/pep-authn/request-error","title":"Bad request","detail":"Invalid timestamp","status":400}`,
+			expectedCalls: 2,
+			expectedSkew:  true,
+		},
+		"timestamp rejected, body sent again": {
+			method:        http.MethodPost,
+			body:          map[string]string{"name": "test"},
+			errorBody:     `{"title":"Bad request","detail":"Invalid timestamp","status":400}`,
+			expectedCalls: 2,
+			expectedSkew:  true,
+		},
+		"other bad request not retried": {
+			method:        http.MethodGet,
+			errorBody:     `{"title":"Bad request","detail":"Invalid name","status":400}`,
+			expectedCalls: 1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var calls int
+			var timestamps, bodies []string
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				timestamps = append(timestamps, authTimestamp(r.Header.Get("Authorization")))
+				body, err := ioutil.ReadAll(r.Body)
+				assert.NoError(t, err)
+				bodies = append(bodies, string(body))
+
+				w.Header().Set("Date", serverTime.Format(http.TimeFormat))
+				if calls == 1 {
+					w.Header().Set("Content-Type", "application/problem+json")
+					w.WriteHeader(http.StatusBadRequest)
+					_, err := w.Write([]byte(test.errorBody))
+					assert.NoError(t, err)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer mockServer.Close()
+			serverURL, err := url.Parse(mockServer.URL)
+			require.NoError(t, err)
+
+			s, err := New(WithSigner(&edgegrid.Config{Host: serverURL.Host}), WithClient(mockServer.Client()))
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(test.method, "/test", nil)
+			require.NoError(t, err)
+			var in []interface{}
+			if test.body != nil {
+				in = append(in, test.body)
+			}
+			resp, err := s.Exec(req, nil, in...)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedCalls, calls)
+			if !test.expectedSkew {
+				assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+				return
+			}
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, bodies[0], bodies[1])
+
+			signed, err := time.Parse("20060102T15:04:05-0700", timestamps[1])
+			require.NoError(t, err)
+			assert.WithinDuration(t, serverTime, signed, 5*time.Second)
+
+			// following requests are signed with the corrected clock right away
+			req, err = http.NewRequest(http.MethodGet, "/test", nil)
+			require.NoError(t, err)
+			_, err = s.Exec(req, nil)
+			require.NoError(t, err)
+			assert.Equal(t, 3, calls)
+			signed, err = time.Parse("20060102T15:04:05-0700", timestamps[2])
+			require.NoError(t, err)
+			assert.WithinDuration(t, serverTime, signed, 5*time.Second)
+		})
+	}
+}
+
+func authTimestamp(auth string) string {
+	for _, field := range strings.Split(auth, ";") {
+		if strings.HasPrefix(field, "timestamp=") {
+			return strings.TrimPrefix(field, "timestamp=")
+		}
+	}
+	return ""
+}
@@ -0,0 +1,79 @@
+package session
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// DefaultCompressionThreshold is the minimum size of request bodies compressed by WithRequestCompression
+// when no threshold is given
+const DefaultCompressionThreshold = 8 << 10
+
+// WithRequestCompression makes the session gzip JSON request bodies of at least threshold bytes,
+// e.g. AppSec configuration imports, setting the Content-Encoding header. Bodies are compressed before
+// the request is signed, so the signature covers the bytes sent. A threshold <= 0 selects DefaultCompressionThreshold.
+// Only enable it for APIs accepting compressed requests.
+//
+// Gzip-compressed responses are decompressed regardless of this option.
+func WithRequestCompression(threshold int) Option {
+	return func(s *session) {
+		if threshold <= 0 {
+			threshold = DefaultCompressionThreshold
+		}
+		s.compressionThreshold = threshold
+	}
+}
+
+// compress returns the gzip-compressed data if the session compresses request bodies of its size
+func (s *session) compress(r *http.Request, data []byte) ([]byte, bool) {
+	if s.compressionThreshold == 0 || len(data) < s.compressionThreshold || r.Header.Get("Content-Encoding") != "" {
+		return data, false
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return data, false
+	}
+	if err := zw.Close(); err != nil {
+		return data, false
+	}
+	return buf.Bytes(), true
+}
+
+// decompress replaces a gzip-compressed response body with the decompressed one. The transport already does it
+// for requests without an Accept-Encoding header, but not when the header was set by the caller.
+func decompress(resp *http.Response) error {
+	if resp.Uncompressed || !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") || resp.Body == http.NoBody {
+		return nil
+	}
+	zr, err := gzip.NewReader(resp.Body)
+	if err == io.EOF {
+		// empty body, e.g. of a HEAD request
+		return nil
+	}
+	if err != nil {
+		resp.Body.Close()
+		return fmt.Errorf("decompressing response: %w", err)
+	}
+	resp.Body = &gzipBody{Reader: zr, body: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return nil
+}
+
+// gzipBody reads the decompressed response body and closes the underlying one
+type gzipBody struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+// Close closes the underlying body
+func (b *gzipBody) Close() error {
+	return b.body.Close()
+}
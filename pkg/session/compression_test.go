@@ -0,0 +1,116 @@
+package session
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSession_RequestCompression(t *testing.T) {
+	large := testStruct{A: strings.Repeat("a", 100), B: 1}
+	small := testStruct{A: "a", B: 1}
+
+	tests := map[string]struct {
+		threshold      int
+		in             testStruct
+		expectedGzip   bool
+		withoutOptions bool
+	}{
+		"body above threshold is compressed": {
+			threshold:    50,
+			in:           large,
+			expectedGzip: true,
+		},
+		"body below threshold is not compressed": {
+			threshold: 50,
+			in:        small,
+		},
+		"default threshold": {
+			in: large,
+		},
+		"compression disabled": {
+			in:             large,
+			withoutOptions: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := ioutil.ReadAll(r.Body)
+				require.NoError(t, err)
+				assert.Equal(t, int64(len(body)), r.ContentLength)
+				if test.expectedGzip {
+					assert.Equal(t, "gzip", r.Header.Get("Content-Encoding"))
+					zr, err := gzip.NewReader(bytes.NewReader(body))
+					require.NoError(t, err)
+					body, err = ioutil.ReadAll(zr)
+					require.NoError(t, err)
+				} else {
+					assert.Empty(t, r.Header.Get("Content-Encoding"))
+				}
+				var received testStruct
+				require.NoError(t, json.Unmarshal(body, &received))
+				assert.Equal(t, test.in, received)
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer mockServer.Close()
+			serverURL, err := url.Parse(mockServer.URL)
+			require.NoError(t, err)
+
+			opts := []Option{WithSigner(&edgegrid.Config{Host: serverURL.Host}), WithClient(mockServer.Client())}
+			if !test.withoutOptions {
+				opts = append(opts, WithRequestCompression(test.threshold))
+			}
+			s, err := New(opts...)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, "/appsec/v1/configs", nil)
+			require.NoError(t, err)
+			resp, err := s.Exec(req, nil, test.in)
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		})
+	}
+}
+
+func TestSession_ResponseDecompression(t *testing.T) {
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.WriteHeader(http.StatusOK)
+		zw := gzip.NewWriter(w)
+		_, err := zw.Write([]byte(`{"a":"text","b":1}`))
+		assert.NoError(t, err)
+		assert.NoError(t, zw.Close())
+	}))
+	defer mockServer.Close()
+	serverURL, err := url.Parse(mockServer.URL)
+	require.NoError(t, err)
+
+	s, err := New(WithSigner(&edgegrid.Config{Host: serverURL.Host}), WithClient(mockServer.Client()))
+	require.NoError(t, err)
+
+	for name, acceptEncoding := range map[string]string{"transport": "", "explicit Accept-Encoding": "gzip"} {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "/appsec/v1/export", nil)
+			require.NoError(t, err)
+			if acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", acceptEncoding)
+			}
+			var out testStruct
+			resp, err := s.Exec(req, &out)
+			require.NoError(t, err)
+			assert.Equal(t, testStruct{A: "text", B: 1}, out)
+			assert.Empty(t, resp.Header.Get("Content-Encoding"))
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package session
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// WithDefaultTimeout sets the deadline of calls made with a context without one, so that a forgotten deadline
+// does not block the caller forever. Unlike the Overall timeout of WithTimeouts, limiting every attempt, the deadline
+// covers the whole call, including retries and rate limit waits. It can be changed for single calls with WithRequestTimeout.
+func WithDefaultTimeout(timeout time.Duration) Option {
+	return func(s *session) {
+		s.defaultTimeout = timeout
+	}
+}
+
+// WithRequestTimeout sets the deadline of calls made with the context, replacing the default timeout of the session.
+// A deadline of the context itself still applies if earlier, while a negative timeout disables the default one.
+func WithRequestTimeout(timeout time.Duration) ContextOption {
+	return func(o *contextOptions) {
+		o.requestTimeout = timeout
+	}
+}
+
+// withDeadline returns the request with the deadline of the call and the function releasing it,
+// or nil if the call has no deadline
+func (s *session) withDeadline(r *http.Request) (*http.Request, context.CancelFunc) {
+	if r == nil {
+		return r, nil
+	}
+	timeout := s.defaultTimeout
+	if o, ok := r.Context().Value(contextOptionKey).(*contextOptions); ok && o.requestTimeout != 0 {
+		timeout = o.requestTimeout
+	} else if _, ok := r.Context().Deadline(); ok {
+		return r, nil
+	}
+	if timeout <= 0 {
+		return r, nil
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	return r.WithContext(ctx), cancel
+}
+
+// releaseDeadline releases the deadline of the call when the response body is closed, or right away without a response
+func releaseDeadline(resp *http.Response, cancel context.CancelFunc) {
+	if cancel == nil {
+		return
+	}
+	if resp == nil || resp.Body == nil {
+		cancel()
+		return
+	}
+	resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+}
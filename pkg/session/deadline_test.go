@@ -0,0 +1,158 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSession_ExecDeadlines(t *testing.T) {
+	tests := map[string]struct {
+		defaultTimeout time.Duration
+		requestTimeout time.Duration
+		ctxTimeout     time.Duration
+		delay          time.Duration
+		withError      error
+	}{
+		"no deadline": {
+			delay: 50 * time.Millisecond,
+		},
+		"default timeout exceeded": {
+			defaultTimeout: 20 * time.Millisecond,
+			delay:          200 * time.Millisecond,
+			withError:      context.DeadlineExceeded,
+		},
+		"context deadline replaces default timeout": {
+			defaultTimeout: 20 * time.Millisecond,
+			ctxTimeout:     time.Second,
+			delay:          50 * time.Millisecond,
+		},
+		"request timeout replaces default timeout": {
+			defaultTimeout: 20 * time.Millisecond,
+			requestTimeout: time.Second,
+			delay:          50 * time.Millisecond,
+		},
+		"request timeout exceeded": {
+			requestTimeout: 20 * time.Millisecond,
+			delay:          200 * time.Millisecond,
+			withError:      context.DeadlineExceeded,
+		},
+		"earlier context deadline applies": {
+			requestTimeout: time.Second,
+			ctxTimeout:     20 * time.Millisecond,
+			delay:          200 * time.Millisecond,
+			withError:      context.DeadlineExceeded,
+		},
+		"negative request timeout disables default timeout": {
+			defaultTimeout: 20 * time.Millisecond,
+			requestTimeout: -1,
+			delay:          50 * time.Millisecond,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				select {
+				case <-time.After(test.delay):
+				case <-r.Context().Done():
+				}
+				w.WriteHeader(http.StatusOK)
+				_, err := w.Write([]byte(`{"a":"text","b":1}`))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			serverURL, err := url.Parse(mockServer.URL)
+			require.NoError(t, err)
+
+			s, err := New(
+				WithSigner(&edgegrid.Config{Host: serverURL.Host}),
+				WithClient(mockServer.Client()),
+				WithDefaultTimeout(test.defaultTimeout),
+			)
+			require.NoError(t, err)
+
+			ctx := context.Background()
+			if test.ctxTimeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, test.ctxTimeout)
+				defer cancel()
+			}
+			if test.requestTimeout != 0 {
+				ctx = ContextWithOptions(ctx, WithRequestTimeout(test.requestTimeout))
+			}
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, "/test", nil)
+			require.NoError(t, err)
+
+			var out testStruct
+			_, err = s.Exec(req, &out)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testStruct{A: "text", B: 1}, out)
+		})
+	}
+}
+
+func TestSession_ExecDeadlineCoversRetries(t *testing.T) {
+	var attempts int32
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer mockServer.Close()
+	serverURL, err := url.Parse(mockServer.URL)
+	require.NoError(t, err)
+
+	s, err := New(
+		WithSigner(&edgegrid.Config{Host: serverURL.Host}),
+		WithClient(mockServer.Client()),
+		WithRetryPolicy(RetryPolicy{Retries: 100, Backoff: FixedBackoff(30 * time.Millisecond)}),
+		WithDefaultTimeout(100*time.Millisecond),
+	)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodGet, "/test", nil)
+	require.NoError(t, err)
+	start := time.Now()
+	_, err = s.Exec(req, nil)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), "want: %s; got: %s", context.DeadlineExceeded, err)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Less(t, atomic.LoadInt32(&attempts), int32(10))
+}
+
+func TestSession_ExecDeadlineReleasedOnClose(t *testing.T) {
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"a":"text"}`))
+		assert.NoError(t, err)
+	}))
+	defer mockServer.Close()
+	serverURL, err := url.Parse(mockServer.URL)
+	require.NoError(t, err)
+
+	s, err := New(
+		WithSigner(&edgegrid.Config{Host: serverURL.Host}),
+		WithClient(mockServer.Client()),
+		WithDefaultTimeout(time.Minute),
+	)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodGet, "/test", nil)
+	require.NoError(t, err)
+	resp, err := s.Exec(req, nil)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.True(t, errors.Is(resp.Request.Context().Err(), context.Canceled))
+}
@@ -0,0 +1,295 @@
+package session
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/apex/log"
+)
+
+const (
+	// DefaultDebugBodySize is the number of body bytes logged by a DebugTransport without MaxBodySize
+	DefaultDebugBodySize = 64 << 10
+
+	// Redacted replaces the values of secrets logged by a DebugTransport
+	Redacted = "REDACTED"
+)
+
+// DebugTransport is an http.RoundTripper logging requests and responses at debug level, with their headers and bodies,
+// as structured entries. Secrets are redacted, so that verbose logging can be enabled safely in production: the values
+// of credential headers, such as Authorization and Cookie, of query parameters and JSON or form fields whose names
+// mention a secret, token, password, API key or private key, and of the configured headers and fields.
+type DebugTransport struct {
+	// Transport sends the requests, http.DefaultTransport if nil
+	Transport http.RoundTripper
+	// Log receives the entries unless a logger is set with WithContextLog, the apex log.Log if nil
+	Log log.Interface
+	// RedactHeaders lists additional headers whose values are redacted
+	RedactHeaders []string
+	// RedactFields lists additional names of JSON fields, form fields and query parameters whose values are redacted
+	RedactFields []string
+	// MaxBodySize limits the number of body bytes logged, DefaultDebugBodySize if not set; a negative size disables
+	// logging of bodies
+	MaxBodySize int
+}
+
+var (
+	// debugRedactedHeaders are the headers always redacted by DebugTransport
+	debugRedactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "X-Api-Key"}
+
+	// debugSecretNames are the parts of field names whose values are redacted by DebugTransport,
+	// compared with lower case names without separators
+	debugSecretNames = []string{"secret", "password", "passwd", "token", "apikey", "privatekey", "credential"}
+)
+
+// WithDebugLogging makes the session log every request and response it sends at debug level, with their headers
+// and bodies and secrets redacted, by wrapping the client transport with a DebugTransport. Additional headers and fields
+// to redact can be given with the transport; its Transport is replaced with the one of the client, and its Log
+// defaults to the log of the session.
+func WithDebugLogging(transport DebugTransport) Option {
+	return func(s *session) {
+		s.debug = &transport
+	}
+}
+
+// configureDebugLogging wraps the transport of a copy of the session client with the debug transport
+func (s *session) configureDebugLogging() {
+	if s.debug == nil {
+		return
+	}
+	t := *s.debug
+	t.Transport = s.client.Transport
+	if t.Log == nil {
+		t.Log = s.log
+	}
+	client := *s.client
+	client.Transport = &t
+	s.client = &client
+}
+
+// RoundTrip logs the request, sends it and logs the response
+func (t *DebugTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	transport := t.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	logger := t.Log
+	if o, ok := r.Context().Value(contextOptionKey).(*contextOptions); ok && o.log != nil {
+		logger = o.log
+	}
+	if logger == nil {
+		logger = log.Log
+	}
+
+	fields := log.Fields{
+		"method":  r.Method,
+		"url":     t.redactURL(r.URL),
+		"headers": t.redactHeader(r.Header),
+	}
+	if body, ok := t.requestBody(r); ok {
+		fields["body"] = body
+	}
+	logger.WithFields(fields).Debug("HTTP request")
+
+	start := time.Now()
+	resp, err := transport.RoundTrip(r)
+	if err != nil {
+		logger.WithFields(log.Fields{
+			"method":   r.Method,
+			"url":      t.redactURL(r.URL),
+			"duration": time.Since(start).String(),
+		}).WithError(err).Debug("HTTP request failed")
+		return nil, err
+	}
+
+	fields = log.Fields{
+		"method":   r.Method,
+		"url":      t.redactURL(r.URL),
+		"status":   resp.StatusCode,
+		"headers":  t.redactHeader(resp.Header),
+		"duration": time.Since(start).String(),
+	}
+	if body, ok := t.responseBody(resp); ok {
+		fields["body"] = body
+	}
+	logger.WithFields(fields).Debug("HTTP response")
+	return resp, nil
+}
+
+// requestBody returns the redacted request body, leaving the body readable
+func (t *DebugTransport) requestBody(r *http.Request) (string, bool) {
+	if t.MaxBodySize < 0 || r.Body == nil || r.Body == http.NoBody {
+		return "", false
+	}
+	var (
+		data []byte
+		err  error
+	)
+	if r.GetBody != nil {
+		var body io.ReadCloser
+		if body, err = r.GetBody(); err == nil {
+			data, err = ioutil.ReadAll(body)
+			body.Close()
+		}
+	} else {
+		data, err = ioutil.ReadAll(r.Body)
+		r.Body.Close()
+		r.Body = ioutil.NopCloser(bytes.NewReader(data))
+	}
+	if err != nil {
+		return fmt.Sprintf("[unreadable body: %s]", err), true
+	}
+	return t.redactBody(data, len(data), r.Header), true
+}
+
+// responseBody returns the redacted beginning of the response body, leaving the whole body readable
+func (t *DebugTransport) responseBody(resp *http.Response) (string, bool) {
+	if t.MaxBodySize < 0 || resp.Body == nil || resp.Body == http.NoBody {
+		return "", false
+	}
+	prefix, err := ioutil.ReadAll(io.LimitReader(resp.Body, int64(t.maxBodySize())+1))
+	resp.Body = &debugBody{Reader: io.MultiReader(bytes.NewReader(prefix), resp.Body), body: resp.Body}
+	if err != nil {
+		return fmt.Sprintf("[unreadable body: %s]", err), true
+	}
+	size := len(prefix)
+	if resp.ContentLength > int64(size) {
+		size = int(resp.ContentLength)
+	}
+	return t.redactBody(prefix, size, resp.Header), true
+}
+
+func (t *DebugTransport) maxBodySize() int {
+	if t.MaxBodySize == 0 {
+		return DefaultDebugBodySize
+	}
+	return t.MaxBodySize
+}
+
+// redactBody redacts JSON and form bodies, and truncates bodies longer than the maximum size;
+// size is the size of the whole body, at least len(data)
+func (t *DebugTransport) redactBody(data []byte, size int, header http.Header) string {
+	if len(data) > t.maxBodySize() {
+		// truncated JSON cannot be redacted field by field
+		if isJSON(header) {
+			return fmt.Sprintf("[%d bytes of JSON body not logged]", size)
+		}
+		data = data[:t.maxBodySize()]
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(header.Get("Content-Type"))
+	switch {
+	case mediaType == "application/x-www-form-urlencoded":
+		if values, err := url.ParseQuery(string(data)); err == nil {
+			t.redactValues(values)
+			return values.Encode()
+		}
+	case isJSON(header) || json.Valid(data):
+		var v interface{}
+		if err := json.Unmarshal(data, &v); err == nil {
+			t.redactJSON(v)
+			if redacted, err := json.Marshal(v); err == nil {
+				return string(redacted)
+			}
+		}
+		if isJSON(header) {
+			return fmt.Sprintf("[%d bytes of invalid JSON body not logged]", size)
+		}
+	case mediaType != "" && !strings.HasPrefix(mediaType, "text/"):
+		return fmt.Sprintf("[%d bytes of %s body]", size, mediaType)
+	}
+
+	if size > len(data) {
+		return fmt.Sprintf("%s... [%d bytes]", data, size)
+	}
+	return string(data)
+}
+
+func isJSON(header http.Header) bool {
+	mediaType, _, _ := mime.ParseMediaType(header.Get("Content-Type"))
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// redactJSON redacts the secret fields of JSON objects in v, at any depth
+func (t *DebugTransport) redactJSON(v interface{}) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, field := range v {
+			if t.isSecret(k) {
+				v[k] = Redacted
+				continue
+			}
+			t.redactJSON(field)
+		}
+	case []interface{}:
+		for _, item := range v {
+			t.redactJSON(item)
+		}
+	}
+}
+
+func (t *DebugTransport) redactValues(values url.Values) {
+	for k := range values {
+		if t.isSecret(k) {
+			values[k] = []string{Redacted}
+		}
+	}
+}
+
+func (t *DebugTransport) redactURL(u *url.URL) string {
+	if u.RawQuery == "" {
+		return u.String()
+	}
+	redacted := *u
+	values := redacted.Query()
+	t.redactValues(values)
+	redacted.RawQuery = values.Encode()
+	return redacted.String()
+}
+
+func (t *DebugTransport) redactHeader(h http.Header) http.Header {
+	redacted := h.Clone()
+	for _, names := range [][]string{debugRedactedHeaders, t.RedactHeaders} {
+		for _, name := range names {
+			if _, ok := redacted[http.CanonicalHeaderKey(name)]; ok {
+				redacted.Set(name, Redacted)
+			}
+		}
+	}
+	return redacted
+}
+
+// isSecret tells whether the values of a field or parameter with given name are redacted
+func (t *DebugTransport) isSecret(name string) bool {
+	normalized := strings.NewReplacer("_", "", "-", "", ".", "").Replace(strings.ToLower(name))
+	for _, secret := range debugSecretNames {
+		if strings.Contains(normalized, secret) {
+			return true
+		}
+	}
+	for _, field := range t.RedactFields {
+		if strings.EqualFold(field, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// debugBody reads the logged beginning of a response body followed by its rest, and closes the original body
+type debugBody struct {
+	io.Reader
+	body io.Closer
+}
+
+// Close closes the original body
+func (b *debugBody) Close() error {
+	return b.body.Close()
+}
@@ -0,0 +1,150 @@
+package session
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/edgegrid"
+	"github.com/apex/log"
+	"github.com/apex/log/handlers/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSession_DebugLogging(t *testing.T) {
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Contains(t, string(body), "s3cr3t", "request body must be sent unredacted")
+		assert.NotEqual(t, Redacted, r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=abc")
+		w.WriteHeader(http.StatusCreated)
+		_, err = w.Write([]byte(`{"name":"ci","clients":[{"clientToken":"akab-token","clientSecret":"akab-secret"}],"internalId":"42"}`))
+		assert.NoError(t, err)
+	}))
+	defer mockServer.Close()
+	serverURL, err := url.Parse(mockServer.URL)
+	require.NoError(t, err)
+
+	handler := memory.New()
+	s, err := New(
+		WithSigner(&edgegrid.Config{Host: serverURL.Host}),
+		WithClient(mockServer.Client()),
+		WithLog(&log.Logger{Handler: handler, Level: log.DebugLevel}),
+		WithDebugLogging(DebugTransport{RedactFields: []string{"internalId"}, RedactHeaders: []string{"X-Custom-Key"}}),
+	)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodPost, "/identity-management/v3/api-clients?access_token=abc&limit=5", nil)
+	require.NoError(t, err)
+	req.Header.Set("X-Custom-Key", "custom")
+	var out map[string]interface{}
+	resp, err := s.Exec(req, &out, map[string]interface{}{"name": "ci", "password": "s3cr3t", "nested": map[string]string{"api_key": "k"}})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, "42", out["internalId"], "response must be decoded unredacted")
+
+	var entries []*log.Entry
+	for _, e := range handler.Entries {
+		if strings.HasPrefix(e.Message, "HTTP ") {
+			entries = append(entries, e)
+		}
+	}
+	require.Len(t, entries, 2)
+
+	request := entries[0]
+	assert.Equal(t, "HTTP request", request.Message)
+	assert.Equal(t, http.MethodPost, request.Fields["method"])
+	assert.Contains(t, request.Fields["url"], "access_token=REDACTED")
+	assert.Contains(t, request.Fields["url"], "limit=5")
+	headers := request.Fields["headers"].(http.Header)
+	assert.Equal(t, Redacted, headers.Get("Authorization"))
+	assert.Equal(t, Redacted, headers.Get("X-Custom-Key"))
+	assert.JSONEq(t, `{"name":"ci","password":"REDACTED","nested":{"api_key":"REDACTED"}}`, request.Fields["body"].(string))
+
+	response := entries[1]
+	assert.Equal(t, "HTTP response", response.Message)
+	assert.Equal(t, http.StatusCreated, response.Fields["status"])
+	assert.Equal(t, Redacted, response.Fields["headers"].(http.Header).Get("Set-Cookie"))
+	assert.JSONEq(t, `{"name":"ci","clients":[{"clientToken":"REDACTED","clientSecret":"REDACTED"}],"internalId":"REDACTED"}`, response.Fields["body"].(string))
+}
+
+func TestDebugTransport_Bodies(t *testing.T) {
+	tests := map[string]struct {
+		contentType string
+		body        string
+		maxBodySize int
+		expected    string
+		noBody      bool
+	}{
+		"form": {
+			contentType: "application/x-www-form-urlencoded",
+			body:        "grant_type=client_credentials&client_secret=abc",
+			expected:    "client_secret=REDACTED&grant_type=client_credentials",
+		},
+		"text truncated": {
+			contentType: "text/csv",
+			body:        "a,b,c\n1,2,3\n",
+			maxBodySize: 5,
+			expected:    "a,b,c... [12 bytes]",
+		},
+		"large JSON not logged": {
+			contentType: "application/json",
+			body:        `{"clientSecret":"abc"}`,
+			maxBodySize: 10,
+			expected:    "[22 bytes of JSON body not logged]",
+		},
+		"binary": {
+			contentType: "application/gzip",
+			body:        "\x1f\x8b\x08",
+			expected:    "[3 bytes of application/gzip body]",
+		},
+		"bodies disabled": {
+			contentType: "application/json",
+			body:        `{"a":1}`,
+			maxBodySize: -1,
+			noBody:      true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			handler := memory.New()
+			transport := &DebugTransport{
+				Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode:    http.StatusOK,
+						Header:        http.Header{"Content-Type": []string{test.contentType}},
+						Body:          ioutil.NopCloser(strings.NewReader(test.body)),
+						ContentLength: int64(len(test.body)),
+						Request:       r,
+					}, nil
+				}),
+				Log:         &log.Logger{Handler: handler, Level: log.DebugLevel},
+				MaxBodySize: test.maxBodySize,
+			}
+			req, err := http.NewRequest(http.MethodGet, "https://akab-host.luna.akamaiapis.net/test", nil)
+			require.NoError(t, err)
+			resp, err := transport.RoundTrip(req)
+			require.NoError(t, err)
+
+			body, err := ioutil.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, test.body, string(body), "response body must be readable in full")
+			require.NoError(t, resp.Body.Close())
+
+			require.Len(t, handler.Entries, 2)
+			logged, ok := handler.Entries[1].Fields["body"]
+			if test.noBody {
+				assert.False(t, ok)
+				return
+			}
+			assert.Equal(t, test.expected, logged)
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package session
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// DecoderOptions controls how Exec decodes successful responses, see WithDecoderOptions
+type DecoderOptions struct {
+	// UseNumber decodes numbers into interface{} values as json.Number instead of float64,
+	// so that large numeric IDs are not rounded
+	UseNumber bool
+	// DisallowUnknownFields makes decoding fail with ErrUnmarshaling when a response contains fields
+	// unknown to the type it is decoded into
+	DisallowUnknownFields bool
+	// TimeLayouts are additional layouts, tried in order, for time.Time values which are not in RFC 3339 format
+	TimeLayouts []string
+}
+
+// CommonTimeLayouts are layouts of timestamps not in RFC 3339 format returned by some APIs: without the colon
+// in the zone offset, without a zone, with a space instead of the T separator, and dates only.
+// Values without a zone are parsed as UTC.
+var CommonTimeLayouts = []string{
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+var (
+	timeType        = reflect.TypeOf(time.Time{})
+	unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+)
+
+// WithDecoderOptions sets the options used to decode successful responses in Exec
+func WithDecoderOptions(opts DecoderOptions) Option {
+	return func(s *session) {
+		s.decoder = &opts
+	}
+}
+
+// decode unmarshals data into out using the decoder options of the session
+func (s *session) decode(data []byte, out interface{}) error {
+	if s.decoder == nil {
+		return json.Unmarshal(data, out)
+	}
+	return s.decoder.Unmarshal(data, out)
+}
+
+// Unmarshal decodes data into out like Exec decodes responses with these options. It is meant for the raw JSON
+// kept in json.RawMessage fields of the SDK types, so that it is decoded consistently with the rest of the response:
+//
+//	opts := session.DecoderOptions{UseNumber: true, TimeLayouts: session.CommonTimeLayouts}
+//	sess, err := session.New(session.WithSigner(edgerc), session.WithDecoderOptions(opts))
+//	...
+//	var used map[string]interface{}
+//	if err := opts.Unmarshal(policy.Used, &used); err != nil {
+//		return err
+//	}
+func (o DecoderOptions) Unmarshal(data []byte, out interface{}) error {
+	if len(o.TimeLayouts) > 0 {
+		normalized, err := normalizeTimes(data, reflect.TypeOf(out), o.TimeLayouts)
+		if err != nil {
+			return err
+		}
+		data = normalized
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if o.UseNumber {
+		dec.UseNumber()
+	}
+	if o.DisallowUnknownFields {
+		dec.DisallowUnknownFields()
+	}
+	return dec.Decode(out)
+}
+
+// normalizeTimes rewrites string values decoded into time.Time fields of typ to RFC 3339 format,
+// if they can be parsed with one of layouts
+func normalizeTimes(data []byte, typ reflect.Type, layouts []string) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var value interface{}
+	if err := dec.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	value, changed := rewriteTimes(value, typ, layouts)
+	if !changed {
+		return data, nil
+	}
+	return json.Marshal(value)
+}
+
+func rewriteTimes(value interface{}, typ reflect.Type, layouts []string) (interface{}, bool) {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ == timeType {
+		s, ok := value.(string)
+		if !ok {
+			return value, false
+		}
+		if _, err := time.Parse(time.RFC3339, s); err == nil {
+			return value, false
+		}
+		for _, layout := range layouts {
+			if t, err := time.Parse(layout, s); err == nil {
+				return t.Format(time.RFC3339Nano), true
+			}
+		}
+		return value, false
+	}
+	if reflect.PtrTo(typ).Implements(unmarshalerType) {
+		return value, false
+	}
+
+	var changed bool
+	switch typ.Kind() {
+	case reflect.Struct:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return value, false
+		}
+		fields := jsonFields(typ)
+		for key, v := range object {
+			fieldType, ok := lookupField(fields, key)
+			if !ok {
+				continue
+			}
+			if v, ok := rewriteTimes(v, fieldType, layouts); ok {
+				object[key] = v
+				changed = true
+			}
+		}
+	case reflect.Map:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return value, false
+		}
+		for key, v := range object {
+			if v, ok := rewriteTimes(v, typ.Elem(), layouts); ok {
+				object[key] = v
+				changed = true
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		list, ok := value.([]interface{})
+		if !ok {
+			return value, false
+		}
+		for i, v := range list {
+			if v, ok := rewriteTimes(v, typ.Elem(), layouts); ok {
+				list[i] = v
+				changed = true
+			}
+		}
+	}
+	return value, changed
+}
+
+// jsonFields returns the types of fields of struct typ by their JSON names, including fields of embedded structs
+func jsonFields(typ reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			for embeddedName, t := range jsonFields(fieldType) {
+				if _, ok := fields[embeddedName]; !ok {
+					fields[embeddedName] = t
+				}
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = field.Type
+	}
+	return fields
+}
+
+// lookupField returns the type of the field of a JSON key, preferring an exact match over a case-insensitive one,
+// like encoding/json
+func lookupField(fields map[string]reflect.Type, key string) (reflect.Type, bool) {
+	if t, ok := fields[key]; ok {
+		return t, true
+	}
+	for name, t := range fields {
+		if strings.EqualFold(name, key) {
+			return t, true
+		}
+	}
+	return nil, false
+}
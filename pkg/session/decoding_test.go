@@ -0,0 +1,152 @@
+package session
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type timedStruct struct {
+	Created  time.Time            `json:"created"`
+	Modified *time.Time           `json:"modified,omitempty"`
+	History  []time.Time          `json:"history,omitempty"`
+	ByName   map[string]time.Time `json:"byName,omitempty"`
+	Name     string               `json:"name"`
+}
+
+type embeddingStruct struct {
+	timedStruct
+	ID int64 `json:"id"`
+}
+
+func TestSession_ExecDecoderOptions(t *testing.T) {
+	created := time.Date(2023, 4, 5, 6, 7, 8, 0, time.UTC)
+	modified := time.Date(2023, 4, 6, 0, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		options      *DecoderOptions
+		responseBody string
+		out          interface{}
+		expected     interface{}
+		withError    error
+	}{
+		"default decoding": {
+			responseBody: `{"a":"text","b":1,"c":true}`,
+			out:          &testStruct{},
+			expected:     &testStruct{A: "text", B: 1},
+		},
+		"use number": {
+			options:      &DecoderOptions{UseNumber: true},
+			responseBody: `{"id":12345678901234567890}`,
+			out:          &map[string]interface{}{},
+			expected:     &map[string]interface{}{"id": json.Number("12345678901234567890")},
+		},
+		"disallow unknown fields": {
+			options:      &DecoderOptions{DisallowUnknownFields: true},
+			responseBody: `{"a":"text","b":1,"c":true}`,
+			out:          &testStruct{},
+			withError:    ErrUnmarshaling,
+		},
+		"custom time layouts": {
+			options: &DecoderOptions{TimeLayouts: []string{"2006-01-02 15:04:05", "2006-01-02"}},
+			responseBody: `{"created":"2023-04-05 06:07:08","modified":"2023-04-06","history":["2023-04-05T06:07:08Z","2023-04-06"],
+				"byName":{"first":"2023-04-05 06:07:08"},"name":"2023-04-06"}`,
+			out: &timedStruct{},
+			expected: &timedStruct{
+				Created:  created,
+				Modified: &modified,
+				History:  []time.Time{created, modified},
+				ByName:   map[string]time.Time{"first": created},
+				Name:     "2023-04-06",
+			},
+		},
+		"custom time layouts in embedded struct": {
+			options:      &DecoderOptions{TimeLayouts: []string{"2006-01-02"}, UseNumber: true},
+			responseBody: `{"Created":"2023-04-06","id":12345678901234567}`,
+			out:          &embeddingStruct{},
+			expected:     &embeddingStruct{timedStruct: timedStruct{Created: modified}, ID: 12345678901234567},
+		},
+		"time not matching any layout": {
+			options:      &DecoderOptions{TimeLayouts: []string{"2006-01-02"}},
+			responseBody: `{"created":"05/04/2023"}`,
+			out:          &timedStruct{},
+			withError:    ErrUnmarshaling,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			serverURL, err := url.Parse(mockServer.URL)
+			require.NoError(t, err)
+
+			opts := []Option{
+				WithSigner(&edgegrid.Config{Host: serverURL.Host}),
+				WithClient(mockServer.Client()),
+			}
+			if test.options != nil {
+				opts = append(opts, WithDecoderOptions(*test.options))
+			}
+			s, err := New(opts...)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodGet, "/test", nil)
+			require.NoError(t, err)
+			_, err = s.Exec(req, test.out)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, test.out)
+		})
+	}
+}
+
+func TestDecoderOptions_Unmarshal(t *testing.T) {
+	type policy struct {
+		Used json.RawMessage `json:"used"`
+	}
+	var p policy
+	require.NoError(t, json.Unmarshal([]byte(`{"used":{"configId":9007199254740993,"since":"2021-06-01 10:30:00"}}`), &p))
+
+	opts := DecoderOptions{UseNumber: true, TimeLayouts: CommonTimeLayouts}
+
+	var used map[string]interface{}
+	require.NoError(t, opts.Unmarshal(p.Used, &used))
+	assert.Equal(t, json.Number("9007199254740993"), used["configId"])
+
+	var typed struct {
+		ConfigID int64     `json:"configId"`
+		Since    time.Time `json:"since"`
+	}
+	require.NoError(t, opts.Unmarshal(p.Used, &typed))
+	assert.Equal(t, int64(9007199254740993), typed.ConfigID)
+	assert.Equal(t, time.Date(2021, 6, 1, 10, 30, 0, 0, time.UTC), typed.Since)
+
+	for value, expected := range map[string]time.Time{
+		"2021-06-01T10:30:00.123+0200": time.Date(2021, 6, 1, 10, 30, 0, 123000000, time.FixedZone("", 2*60*60)),
+		"2021-06-01T10:30:00":          time.Date(2021, 6, 1, 10, 30, 0, 0, time.UTC),
+		"2021-06-01 10:30:00Z":         time.Date(2021, 6, 1, 10, 30, 0, 0, time.UTC),
+		"2021-06-01":                   time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC),
+	} {
+		var out timedStruct
+		require.NoError(t, opts.Unmarshal([]byte(`{"created":"`+value+`"}`), &out), value)
+		assert.True(t, expected.Equal(out.Created), "want: %s; got: %s", expected, out.Created)
+	}
+
+	assert.Error(t, DecoderOptions{DisallowUnknownFields: true}.Unmarshal(p.Used, &timedStruct{}))
+}
@@ -0,0 +1,155 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apex/log"
+)
+
+type (
+	// Deprecation describes a deprecated method of an API package
+	Deprecation struct {
+		// Method is the deprecated method qualified with its package, e.g. appsec.GetWAFModes
+		Method string
+		// Replacement is the method to use instead, if any
+		Replacement string
+	}
+
+	// ResponseDeprecation describes the Deprecation, Sunset and Warning headers of an API response,
+	// announcing that the called endpoint is deprecated or going to be retired
+	ResponseDeprecation struct {
+		Method string
+		Path   string
+		// Deprecated is true when the response has a Deprecation header
+		Deprecated bool
+		// DeprecatedAt is the date of the Deprecation header, zero if it carries no date
+		DeprecatedAt time.Time
+		// Sunset is the date of the Sunset header after which the endpoint is going to be retired, zero if not set
+		Sunset time.Time
+		// Link is the Link header of the response, pointing to the deprecation or sunset policy if any
+		Link string
+		// Warnings are the values of the Warning headers
+		Warnings []string
+	}
+
+	// DeprecationHandler is called for every response announcing a deprecation, see WithDeprecationHandler
+	DeprecationHandler func(ctx context.Context, d ResponseDeprecation)
+)
+
+// WithDeprecationHandler sets the handler called for every response with Deprecation, Sunset or Warning headers.
+// Such responses are also logged as warnings once per method and path of the session, whether a handler is set or not.
+func WithDeprecationHandler(h DeprecationHandler) Option {
+	return func(s *session) {
+		s.deprecationHandler = h
+	}
+}
+
+// String returns the deprecation warning
+func (d Deprecation) String() string {
+	if d.Replacement == "" {
+		return fmt.Sprintf("%s is deprecated and will be removed in a future release", d.Method)
+	}
+	return fmt.Sprintf("%s is deprecated and will be removed in a future release, use %s instead", d.Method, d.Replacement)
+}
+
+// WarnDeprecated logs a warning about a call of a deprecated method with the session logger.
+// The warning is logged once per method for every session created with New; sessions of other
+// implementations log it on every call.
+func WarnDeprecated(ctx context.Context, sess Session, d Deprecation) {
+	if warned := warnedDeprecations(sess); warned != nil {
+		if _, ok := warned.LoadOrStore(d.Method, true); ok {
+			return
+		}
+	}
+	sess.Log(ctx).WithFields(log.Fields{
+		"method":      d.Method,
+		"replacement": d.Replacement,
+	}).Warn(d.String())
+}
+
+// String returns the deprecation warning
+func (d ResponseDeprecation) String() string {
+	msg := fmt.Sprintf("%s %s", d.Method, d.Path)
+	switch {
+	case !d.DeprecatedAt.IsZero():
+		msg += fmt.Sprintf(" is deprecated since %s", d.DeprecatedAt.Format(time.RFC3339))
+	case d.Deprecated:
+		msg += " is deprecated"
+	default:
+		msg += " returned a warning"
+	}
+	if !d.Sunset.IsZero() {
+		msg += fmt.Sprintf(" and will be retired on %s", d.Sunset.Format(time.RFC3339))
+	}
+	if len(d.Warnings) > 0 {
+		msg += ": " + strings.Join(d.Warnings, "; ")
+	}
+	return msg
+}
+
+// responseDeprecation returns the deprecation announced by the response headers, if any
+func responseDeprecation(r *http.Request, resp *http.Response) (ResponseDeprecation, bool) {
+	deprecation := resp.Header.Get("Deprecation")
+	sunset := resp.Header.Get("Sunset")
+	warnings := resp.Header.Values("Warning")
+	if deprecation == "" && sunset == "" && len(warnings) == 0 {
+		return ResponseDeprecation{}, false
+	}
+
+	d := ResponseDeprecation{
+		Method:       r.Method,
+		Path:         r.URL.Path,
+		Deprecated:   deprecation != "" && deprecation != "false",
+		DeprecatedAt: parseDeprecationDate(deprecation),
+		Sunset:       parseDeprecationDate(sunset),
+		Link:         resp.Header.Get("Link"),
+		Warnings:     warnings,
+	}
+	return d, true
+}
+
+// parseDeprecationDate parses a structured field date (@1688169599) or an HTTP date, returning zero time otherwise
+func parseDeprecationDate(value string) time.Time {
+	if strings.HasPrefix(value, "@") {
+		if seconds, err := strconv.ParseInt(value[1:], 10, 64); err == nil {
+			return time.Unix(seconds, 0).UTC()
+		}
+		return time.Time{}
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return t.UTC()
+	}
+	return time.Time{}
+}
+
+// reportDeprecation logs and passes to the deprecation handler the deprecation announced by the response, if any
+func (s *session) reportDeprecation(r *http.Request, resp *http.Response) {
+	d, ok := responseDeprecation(r, resp)
+	if !ok {
+		return
+	}
+	if _, warned := s.deprecations.LoadOrStore(d.Method+" "+d.Path, true); !warned {
+		s.Log(r.Context()).WithFields(log.Fields{
+			"method": d.Method,
+			"path":   d.Path,
+			"sunset": d.Sunset,
+		}).Warn(d.String())
+	}
+	if s.deprecationHandler != nil {
+		s.deprecationHandler(r.Context(), d)
+	}
+}
+
+// warnedDeprecations returns the deprecations already reported by the session underlying sess
+func warnedDeprecations(sess Session) *sync.Map {
+	if s := baseSession(sess); s != nil {
+		return &s.deprecations
+	}
+	return nil
+}
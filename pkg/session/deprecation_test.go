@@ -0,0 +1,164 @@
+package session
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/edgegrid"
+	"github.com/apex/log"
+	"github.com/apex/log/handlers/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeprecation_String(t *testing.T) {
+	assert.Equal(t, "pkg.Old is deprecated and will be removed in a future release, use pkg.New instead",
+		Deprecation{Method: "pkg.Old", Replacement: "pkg.New"}.String())
+	assert.Equal(t, "pkg.Old is deprecated and will be removed in a future release",
+		Deprecation{Method: "pkg.Old"}.String())
+}
+
+func TestWarnDeprecated(t *testing.T) {
+	handler := memory.New()
+	s, err := New(
+		WithSigner(&edgegrid.Config{}),
+		WithLog(&log.Logger{Handler: handler, Level: log.DebugLevel}),
+	)
+	require.NoError(t, err)
+
+	d := Deprecation{Method: "test.TestWarnDeprecated", Replacement: "test.Other"}
+	WarnDeprecated(context.Background(), s, d)
+	WarnDeprecated(context.Background(), s, d)
+	WarnDeprecated(context.Background(), s, Deprecation{Method: "test.TestWarnDeprecatedOther"})
+
+	// wrapped sessions share the warnings of the underlying session
+	WarnDeprecated(context.Background(), ClientOptions{Retries: 1}.Apply(s), d)
+
+	require.Len(t, handler.Entries, 2)
+	assert.Equal(t, log.WarnLevel, handler.Entries[0].Level)
+	assert.Equal(t, d.String(), handler.Entries[0].Message)
+	assert.Equal(t, "test.TestWarnDeprecated", handler.Entries[0].Fields.Get("method"))
+	assert.Equal(t, "test.Other", handler.Entries[0].Fields.Get("replacement"))
+
+	// every session warns once
+	other, err := New(
+		WithSigner(&edgegrid.Config{}),
+		WithLog(&log.Logger{Handler: handler, Level: log.DebugLevel}),
+	)
+	require.NoError(t, err)
+	WarnDeprecated(context.Background(), other, d)
+	assert.Len(t, handler.Entries, 3)
+}
+
+func TestSession_ExecDeprecationHeaders(t *testing.T) {
+	tests := map[string]struct {
+		headers      http.Header
+		path         string
+		expected     []ResponseDeprecation
+		expectedLogs int
+	}{
+		"no deprecation": {
+			headers: http.Header{},
+			path:    "/test/none",
+		},
+		"deprecation date and sunset": {
+			headers: http.Header{
+				"Deprecation": {"@1688169599"},
+				"Sunset":      {"Sun, 30 Jun 2024 23:59:59 GMT"},
+				"Link":        {`<https://techdocs.akamai.com/deprecation>; rel="deprecation"`},
+			},
+			path: "/test/dated",
+			expected: []ResponseDeprecation{{
+				Method:       http.MethodGet,
+				Path:         "/test/dated",
+				Deprecated:   true,
+				DeprecatedAt: time.Date(2023, 6, 30, 23, 59, 59, 0, time.UTC),
+				Sunset:       time.Date(2024, 6, 30, 23, 59, 59, 0, time.UTC),
+				Link:         `<https://techdocs.akamai.com/deprecation>; rel="deprecation"`,
+			}, {
+				Method:       http.MethodGet,
+				Path:         "/test/dated",
+				Deprecated:   true,
+				DeprecatedAt: time.Date(2023, 6, 30, 23, 59, 59, 0, time.UTC),
+				Sunset:       time.Date(2024, 6, 30, 23, 59, 59, 0, time.UTC),
+				Link:         `<https://techdocs.akamai.com/deprecation>; rel="deprecation"`,
+			}},
+			expectedLogs: 1,
+		},
+		"deprecation flag and warnings": {
+			headers: http.Header{
+				"Deprecation": {"true"},
+				"Warning":     {`299 - "Endpoint deprecated"`, `299 - "Use v2"`},
+			},
+			path: "/test/flag",
+			expected: []ResponseDeprecation{{
+				Method:     http.MethodGet,
+				Path:       "/test/flag",
+				Deprecated: true,
+				Warnings:   []string{`299 - "Endpoint deprecated"`, `299 - "Use v2"`},
+			}, {
+				Method:     http.MethodGet,
+				Path:       "/test/flag",
+				Deprecated: true,
+				Warnings:   []string{`299 - "Endpoint deprecated"`, `299 - "Use v2"`},
+			}},
+			expectedLogs: 1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for k, v := range test.headers {
+					w.Header()[k] = v
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer mockServer.Close()
+			serverURL, err := url.Parse(mockServer.URL)
+			require.NoError(t, err)
+
+			var reported []ResponseDeprecation
+			handler := memory.New()
+			s, err := New(
+				WithSigner(&edgegrid.Config{Host: serverURL.Host}),
+				WithClient(mockServer.Client()),
+				WithLog(&log.Logger{Handler: handler, Level: log.DebugLevel}),
+				WithDeprecationHandler(func(_ context.Context, d ResponseDeprecation) {
+					reported = append(reported, d)
+				}),
+			)
+			require.NoError(t, err)
+
+			for i := 0; i < 2; i++ {
+				req, err := http.NewRequest(http.MethodGet, test.path, nil)
+				require.NoError(t, err)
+				_, err = s.Exec(req, nil)
+				require.NoError(t, err)
+			}
+			assert.Equal(t, test.expected, reported)
+			require.Len(t, handler.Entries, test.expectedLogs)
+			if test.expectedLogs > 0 {
+				assert.Equal(t, log.WarnLevel, handler.Entries[0].Level)
+				assert.Equal(t, test.expected[0].String(), handler.Entries[0].Message)
+			}
+		})
+	}
+}
+
+func TestResponseDeprecation_String(t *testing.T) {
+	assert.Equal(t, "GET /test is deprecated since 2023-06-30T23:59:59Z and will be retired on 2024-06-30T23:59:59Z",
+		ResponseDeprecation{
+			Method:       http.MethodGet,
+			Path:         "/test",
+			Deprecated:   true,
+			DeprecatedAt: time.Date(2023, 6, 30, 23, 59, 59, 0, time.UTC),
+			Sunset:       time.Date(2024, 6, 30, 23, 59, 59, 0, time.UTC),
+		}.String())
+	assert.Equal(t, `POST /test returned a warning: 299 - "Use v2"`,
+		ResponseDeprecation{Method: http.MethodPost, Path: "/test", Warnings: []string{`299 - "Use v2"`}}.String())
+}
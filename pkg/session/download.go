@@ -0,0 +1,58 @@
+package session
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Progress reports the number of bytes of the response body written by ExecTo so far,
+// and the size of the body, or -1 if unknown
+type Progress func(written, total int64)
+
+// ExecTo executes the request with the session and streams the response body to w, instead of decoding it,
+// e.g. for tarballs, CSV reports or large configuration exports. Progress, if set, is called after every write.
+// The body is closed once copied, and the response is returned with it.
+//
+// Responses with a status code other than 2xx are not written; the returned error is the *Problem of the response,
+// whose body is left readable, so that service packages can still parse their own error type from it.
+//
+//	f, err := os.Create("bundle.tgz")
+//	...
+//	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/edgeworkers/v1/ids/42/versions/1/content", nil)
+//	_, err = session.ExecTo(sess, req, f, func(written, total int64) {
+//		log.Printf("downloaded %d of %d bytes", written, total)
+//	})
+func ExecTo(sess Session, r *http.Request, w io.Writer, progress Progress) (*http.Response, error) {
+	resp, err := sess.Exec(r, nil)
+	if err != nil {
+		return resp, err
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return resp, ParseProblem(resp)
+	}
+	defer resp.Body.Close()
+
+	if progress != nil {
+		w = &progressWriter{w: w, total: resp.ContentLength, progress: progress}
+	}
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return resp, fmt.Errorf("copying response body: %w", err)
+	}
+	return resp, nil
+}
+
+// progressWriter reports the bytes written to w
+type progressWriter struct {
+	w        io.Writer
+	written  int64
+	total    int64
+	progress Progress
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.written += int64(n)
+	p.progress(p.written, p.total)
+	return n, err
+}
@@ -0,0 +1,107 @@
+package session
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestExecTo(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789abcdef"), 16<<10)
+
+	tests := map[string]struct {
+		status         int
+		body           []byte
+		failWrite      bool
+		expectedBody   []byte
+		expectedStatus int
+		withError      error
+	}{
+		"binary body written": {
+			status:       http.StatusOK,
+			body:         content,
+			expectedBody: content,
+		},
+		"error response not written": {
+			status:         http.StatusNotFound,
+			body:           []byte(`{"type":"not-found","title":"Not Found","detail":"version not found"}`),
+			expectedStatus: http.StatusNotFound,
+			withError:      ErrNotFound,
+		},
+		"writer failure": {
+			status:    http.StatusOK,
+			body:      content,
+			failWrite: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/gzip")
+				w.Header().Set("Content-Length", strconv.Itoa(len(test.body)))
+				w.WriteHeader(test.status)
+				_, err := w.Write(test.body)
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			serverURL, err := url.Parse(mockServer.URL)
+			require.NoError(t, err)
+			s, err := New(WithSigner(&edgegrid.Config{Host: serverURL.Host}), WithClient(mockServer.Client()))
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodGet, "/edgeworkers/v1/ids/42/versions/1/content", nil)
+			require.NoError(t, err)
+
+			var (
+				buf          bytes.Buffer
+				calls        int
+				lastWritten  int64
+				reportedSize int64
+			)
+			progress := func(written, total int64) {
+				calls++
+				assert.GreaterOrEqual(t, written, lastWritten)
+				lastWritten, reportedSize = written, total
+			}
+
+			if test.failWrite {
+				_, err = ExecTo(s, req, failingWriter{}, progress)
+				assert.EqualError(t, err, "copying response body: disk full")
+				return
+			}
+			resp, err := ExecTo(s, req, &buf, progress)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				assert.Equal(t, test.expectedStatus, resp.StatusCode)
+				assert.Zero(t, buf.Len())
+				assert.Zero(t, calls)
+				body, err := ioutil.ReadAll(resp.Body)
+				require.NoError(t, err)
+				assert.Equal(t, test.body, body)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, test.expectedBody, buf.Bytes())
+			assert.Greater(t, calls, 1)
+			assert.Equal(t, int64(len(content)), lastWritten)
+			assert.Equal(t, int64(len(content)), reportedSize)
+		})
+	}
+}
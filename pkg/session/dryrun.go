@@ -0,0 +1,65 @@
+package session
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// DryRunError is returned by Exec in dry-run mode instead of sending the request, see WithDryRun
+type DryRunError struct {
+	// Request is the signed request which would have been sent, with the body set to a copy of Body
+	Request *http.Request
+	// Body is the body of the request as it would have been sent, if any; see WithRequestCompression
+	Body []byte
+}
+
+// ErrDryRun is matched by DryRunError
+var ErrDryRun = errors.New("dry run, request not sent")
+
+// WithDryRun puts the session into dry-run mode. Requests are built and signed as usual, but instead of sending them,
+// Exec returns a DryRunError carrying the request and its body, e.g.:
+//
+//	_, err := appsec.Client(sess).UpdateMatchTarget(ctx, params)
+//	var dryRun *session.DryRunError
+//	if errors.As(err, &dryRun) {
+//		fmt.Printf("%s %s\n%s\n", dryRun.Request.Method, dryRun.Request.URL, dryRun.Body)
+//	}
+//
+// Unlike plan mode, read requests are not sent either.
+func WithDryRun() Option {
+	return func(s *session) {
+		s.dryRun = true
+	}
+}
+
+func (e *DryRunError) Error() string {
+	return fmt.Sprintf("%s: %s %s", ErrDryRun, e.Request.Method, e.Request.URL)
+}
+
+// Is reports whether target is ErrDryRun
+func (e *DryRunError) Is(target error) bool {
+	return target == ErrDryRun
+}
+
+// dryRunError returns the DryRunError for the signed request r, sent to the base URL of the session if set
+func (s *session) dryRunError(r *http.Request) error {
+	var body []byte
+	if r.Body != nil && r.Body != http.NoBody {
+		data, err := ioutil.ReadAll(r.Body)
+		r.Body.Close()
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrMarshaling, err)
+		}
+		body = data
+	}
+	r = s.target(r)
+	if body != nil {
+		data := body
+		r.Body = ioutil.NopCloser(bytes.NewReader(data))
+		r.ContentLength = int64(len(data))
+	}
+	return &DryRunError{Request: r, Body: body}
+}
@@ -0,0 +1,95 @@
+package session
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSession_ExecWithDryRun(t *testing.T) {
+	var executed int
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		executed++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer mockServer.Close()
+	serverURL, err := url.Parse(mockServer.URL)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		method       string
+		path         string
+		in           []interface{}
+		body         string
+		sessionOpts  []Option
+		expectedURL  string
+		expectedBody string
+	}{
+		"update with JSON body": {
+			method:       http.MethodPut,
+			path:         "/appsec/v1/configs/1/versions/2/match-targets/3",
+			in:           []interface{}{testStruct{A: "new", B: 2}},
+			expectedURL:  "https://" + serverURL.Host + "/appsec/v1/configs/1/versions/2/match-targets/3",
+			expectedBody: `{"a":"new","b":2}`,
+		},
+		"body set on request": {
+			method:       http.MethodPost,
+			path:         "/test/resource",
+			body:         "plain text",
+			expectedURL:  "https://" + serverURL.Host + "/test/resource",
+			expectedBody: "plain text",
+		},
+		"read request": {
+			method:      http.MethodGet,
+			path:        "/test/resource?a=1",
+			expectedURL: "https://" + serverURL.Host + "/test/resource?a=1",
+		},
+		"base URL": {
+			method:      http.MethodDelete,
+			path:        "/test/resource/1",
+			sessionOpts: []Option{WithBaseURL("http://localhost:8080")},
+			expectedURL: "http://localhost:8080/test/resource/1",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			opts := append([]Option{
+				WithSigner(&edgegrid.Config{Host: serverURL.Host}),
+				WithClient(mockServer.Client()),
+				WithDryRun(),
+			}, test.sessionOpts...)
+			s, err := New(opts...)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(test.method, test.path, strings.NewReader(test.body))
+			require.NoError(t, err)
+			if test.body == "" {
+				req.Body = nil
+			}
+			resp, err := s.Exec(req, nil, test.in...)
+			assert.Nil(t, resp)
+			assert.True(t, errors.Is(err, ErrDryRun), "want: %s; got: %s", ErrDryRun, err)
+
+			var dryRun *DryRunError
+			require.True(t, errors.As(err, &dryRun))
+			assert.Equal(t, test.method, dryRun.Request.Method)
+			assert.Equal(t, test.expectedURL, dryRun.Request.URL.String())
+			assert.Contains(t, dryRun.Request.Header.Get("Authorization"), "EG1-HMAC-SHA256")
+			assert.Equal(t, test.expectedBody, string(dryRun.Body))
+			if test.expectedBody != "" {
+				body, err := ioutil.ReadAll(dryRun.Request.Body)
+				require.NoError(t, err)
+				assert.Equal(t, test.expectedBody, string(body))
+			}
+		})
+	}
+	assert.Zero(t, executed)
+}
@@ -0,0 +1,89 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+)
+
+// Sentinel errors shared by all service packages. API errors returned by the packages match them with errors.Is
+// based on the HTTP status code of the response, so callers can handle common outcomes regardless of the service, e.g.:
+//
+//	if errors.Is(err, session.ErrNotFound) {
+//		// resource is already gone
+//	}
+var (
+	// ErrNotFound is matched by API errors with 404 Not Found or 410 Gone status
+	ErrNotFound = errors.New("not found")
+	// ErrConflict is matched by API errors with 409 Conflict or 412 Precondition Failed status
+	ErrConflict = errors.New("conflict")
+	// ErrRateLimited is matched by API errors with 429 Too Many Requests status
+	ErrRateLimited = errors.New("rate limited")
+	// ErrForbidden is matched by API errors with 401 Unauthorized or 403 Forbidden status
+	ErrForbidden = errors.New("forbidden")
+	// ErrValidation is matched by API errors with 400 Bad Request or 422 Unprocessable Entity status
+	ErrValidation = errors.New("validation failed")
+)
+
+// StatusError returns the sentinel error corresponding to given HTTP status code or nil if there is none
+func StatusError(statusCode int) error {
+	switch statusCode {
+	case http.StatusNotFound, http.StatusGone:
+		return ErrNotFound
+	case http.StatusConflict, http.StatusPreconditionFailed:
+		return ErrConflict
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return ErrForbidden
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return ErrValidation
+	}
+	return nil
+}
+
+// MatchStatus reports whether target is the sentinel error corresponding to given HTTP status code.
+// It is meant to be used in Is methods of the API error types.
+func MatchStatus(statusCode int, target error) bool {
+	sentinel := StatusError(statusCode)
+	return sentinel != nil && sentinel == target
+}
+
+// IsNotFound reports whether err is an API error with 404 Not Found or 410 Gone status,
+// e.g. to treat removing a resource which is already gone as success
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsConflict reports whether err is an API error with 409 Conflict or 412 Precondition Failed status
+func IsConflict(err error) bool {
+	return errors.Is(err, ErrConflict)
+}
+
+// IsRateLimited reports whether err is an API error with 429 Too Many Requests status
+func IsRateLimited(err error) bool {
+	return errors.Is(err, ErrRateLimited)
+}
+
+// IsRetryable reports whether the request failing with err may succeed when repeated later: on API errors
+// with 429, 502, 503 or 504 status, on network timeouts and when the circuit of the API is open.
+// Errors caused by the context of the request being canceled or done are not retryable.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if IsRateLimited(err) || errors.Is(err, ErrCircuitOpen) {
+		return true
+	}
+	var problem *Problem
+	if errors.As(err, &problem) {
+		switch problem.Status {
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
@@ -0,0 +1,97 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusError(t *testing.T) {
+	tests := map[string]struct {
+		statusCode int
+		expected   error
+	}{
+		"400":        {statusCode: http.StatusBadRequest, expected: ErrValidation},
+		"401":        {statusCode: http.StatusUnauthorized, expected: ErrForbidden},
+		"403":        {statusCode: http.StatusForbidden, expected: ErrForbidden},
+		"404":        {statusCode: http.StatusNotFound, expected: ErrNotFound},
+		"409":        {statusCode: http.StatusConflict, expected: ErrConflict},
+		"410":        {statusCode: http.StatusGone, expected: ErrNotFound},
+		"412":        {statusCode: http.StatusPreconditionFailed, expected: ErrConflict},
+		"422":        {statusCode: http.StatusUnprocessableEntity, expected: ErrValidation},
+		"429":        {statusCode: http.StatusTooManyRequests, expected: ErrRateLimited},
+		"500 no map": {statusCode: http.StatusInternalServerError},
+		"200 no map": {statusCode: http.StatusOK},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, StatusError(test.statusCode))
+		})
+	}
+}
+
+type statusErr struct {
+	status int
+}
+
+func (e *statusErr) Error() string {
+	return fmt.Sprintf("status %d", e.status)
+}
+
+func (e *statusErr) Is(target error) bool {
+	return MatchStatus(e.status, target)
+}
+
+func TestMatchStatus(t *testing.T) {
+	err := fmt.Errorf("get something: %w", &statusErr{status: http.StatusNotFound})
+
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.False(t, errors.Is(err, ErrConflict))
+	assert.False(t, errors.Is(fmt.Errorf("wrapped: %w", &statusErr{status: http.StatusBadGateway}), ErrNotFound))
+}
+
+func TestErrorPredicates(t *testing.T) {
+	problem := func(status int) error {
+		return fmt.Errorf("request failed: %w", &Problem{Title: "Error", Status: status})
+	}
+	timeout := &url.Error{Op: "Get", URL: "https://akab.luna.akamaiapis.net", Err: &net.DNSError{IsTimeout: true}}
+
+	tests := map[string]struct {
+		err         error
+		notFound    bool
+		conflict    bool
+		rateLimited bool
+		retryable   bool
+	}{
+		"nil":              {},
+		"404":              {err: problem(http.StatusNotFound), notFound: true},
+		"410":              {err: problem(http.StatusGone), notFound: true},
+		"409":              {err: problem(http.StatusConflict), conflict: true},
+		"429":              {err: problem(http.StatusTooManyRequests), rateLimited: true, retryable: true},
+		"500":              {err: problem(http.StatusInternalServerError)},
+		"502":              {err: problem(http.StatusBadGateway), retryable: true},
+		"503":              {err: problem(http.StatusServiceUnavailable), retryable: true},
+		"504":              {err: problem(http.StatusGatewayTimeout), retryable: true},
+		"network timeout":  {err: timeout, retryable: true},
+		"circuit open":     {err: &CircuitOpenError{Family: "appsec"}, retryable: true},
+		"context canceled": {err: fmt.Errorf("request failed: %w", context.Canceled)},
+		"context deadline": {err: &url.Error{Op: "Get", URL: "https://akab.luna.akamaiapis.net", Err: context.DeadlineExceeded}},
+		"other error":      {err: errors.New("oops")},
+		"package error":    {err: &statusErr{status: http.StatusNotFound}, notFound: true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.notFound, IsNotFound(test.err))
+			assert.Equal(t, test.conflict, IsConflict(test.err))
+			assert.Equal(t, test.rateLimited, IsRateLimited(test.err))
+			assert.Equal(t, test.retryable, IsRetryable(test.err))
+		})
+	}
+}
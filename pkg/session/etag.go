@@ -0,0 +1,114 @@
+package session
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// etagEntry is a response stored with its validators, see WithETagCache
+type etagEntry struct {
+	ETag         string      `json:"etag,omitempty"`
+	LastModified string      `json:"lastModified,omitempty"`
+	Header       http.Header `json:"header"`
+	Body         []byte      `json:"body"`
+}
+
+// defaultETagRules keep the validators of all GET responses for a day
+var defaultETagRules = []CacheRule{{PathPrefix: "/", TTL: 24 * time.Hour}}
+
+// WithETagCache makes the session store the ETag and Last-Modified validators of successful GET responses matching
+// one of the rules, together with their body, and send conditional requests for them. When the API responds with
+// 304 Not Modified, the stored body is returned as a 200 response, so repeated reads of large unchanged resources,
+// such as attack groups in reconcile loops, do not transfer them again. Rule TTLs set how long entries are kept;
+// without rules, all GET responses are kept for a day.
+//
+// Unlike WithCache, every request still reaches the API. The stores of both options may be shared.
+func WithETagCache(cache Cache, rules ...CacheRule) Option {
+	return func(s *session) {
+		if len(rules) == 0 {
+			rules = defaultETagRules
+		}
+		s.etags = &responseCache{cache: cache, rules: rules}
+	}
+}
+
+// conditional returns the entry stored for the request and adds its validators to the request headers,
+// unless the caller already set them
+func (c *responseCache) conditional(r *http.Request) *etagEntry {
+	if c.ttl(r) <= 0 || r.Header.Get("If-None-Match") != "" || r.Header.Get("If-Modified-Since") != "" {
+		return nil
+	}
+	data, ok := c.cache.Get(etagKey(r))
+	if !ok {
+		return nil
+	}
+	var entry etagEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil
+	}
+
+	if entry.ETag != "" {
+		r.Header.Set("If-None-Match", entry.ETag)
+	}
+	if entry.LastModified != "" {
+		r.Header.Set("If-Modified-Since", entry.LastModified)
+	}
+	return &entry
+}
+
+// revalidated returns the stored response if the API responded with 304 Not Modified, otherwise resp;
+// the validators are removed from the request headers, so that they are not reused by retries
+func (c *responseCache) revalidated(r *http.Request, resp *http.Response, entry *etagEntry) *http.Response {
+	r.Header.Del("If-None-Match")
+	r.Header.Del("If-Modified-Since")
+	if resp.StatusCode != http.StatusNotModified {
+		return resp
+	}
+	resp.Body.Close()
+
+	header := entry.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	for k, v := range resp.Header {
+		header[k] = v
+	}
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         resp.Proto,
+		ProtoMajor:    resp.ProtoMajor,
+		ProtoMinor:    resp.ProtoMinor,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader(entry.Body)),
+		ContentLength: int64(len(entry.Body)),
+		Request:       r,
+	}
+}
+
+// storable reports whether the response to the request is stored with its validators
+func (c *responseCache) storable(r *http.Request, resp *http.Response) bool {
+	return c != nil && c.ttl(r) > 0 && resp.StatusCode == http.StatusOK &&
+		(resp.Header.Get("ETag") != "" || resp.Header.Get("Last-Modified") != "")
+}
+
+// store stores the body of the response with its validators
+func (c *responseCache) store(r *http.Request, resp *http.Response, body []byte) {
+	data, err := json.Marshal(etagEntry{
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		Header:       resp.Header,
+		Body:         body,
+	})
+	if err != nil {
+		return
+	}
+	c.cache.Set(etagKey(r), data, c.ttl(r))
+}
+
+func etagKey(r *http.Request) string {
+	return "etag " + cacheKey(r)
+}
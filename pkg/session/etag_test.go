@@ -0,0 +1,94 @@
+package session
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSession_ETagCache(t *testing.T) {
+	type call struct {
+		path                string
+		version             string
+		expectedIfNoneMatch string
+		expectedStatus      int
+		expectedBody        map[string]string
+	}
+	tests := map[string]struct {
+		rules []CacheRule
+		calls []call
+	}{
+		"unchanged resource served from store": {
+			calls: []call{
+				{path: "/appsec/v1/configs/1/attack-groups", version: "1", expectedStatus: http.StatusOK, expectedBody: map[string]string{"version": "1"}},
+				{path: "/appsec/v1/configs/1/attack-groups", version: "1", expectedIfNoneMatch: `"1"`, expectedStatus: http.StatusOK, expectedBody: map[string]string{"version": "1"}},
+			},
+		},
+		"changed resource stored again": {
+			calls: []call{
+				{path: "/appsec/v1/configs/1/attack-groups", version: "1", expectedStatus: http.StatusOK, expectedBody: map[string]string{"version": "1"}},
+				{path: "/appsec/v1/configs/1/attack-groups", version: "2", expectedIfNoneMatch: `"1"`, expectedStatus: http.StatusOK, expectedBody: map[string]string{"version": "2"}},
+				{path: "/appsec/v1/configs/1/attack-groups", version: "2", expectedIfNoneMatch: `"2"`, expectedStatus: http.StatusOK, expectedBody: map[string]string{"version": "2"}},
+			},
+		},
+		"resources stored per URL": {
+			calls: []call{
+				{path: "/appsec/v1/configs/1/attack-groups", version: "1", expectedStatus: http.StatusOK, expectedBody: map[string]string{"version": "1"}},
+				{path: "/appsec/v1/configs/2/attack-groups", version: "1", expectedStatus: http.StatusOK, expectedBody: map[string]string{"version": "1"}},
+			},
+		},
+		"paths not matching rules": {
+			rules: []CacheRule{{PathPrefix: "/papi/", TTL: time.Hour}},
+			calls: []call{
+				{path: "/appsec/v1/configs/1/attack-groups", version: "1", expectedStatus: http.StatusOK, expectedBody: map[string]string{"version": "1"}},
+				{path: "/appsec/v1/configs/1/attack-groups", version: "1", expectedStatus: http.StatusOK, expectedBody: map[string]string{"version": "1"}},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var version, expectedIfNoneMatch string
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, expectedIfNoneMatch, r.Header.Get("If-None-Match"))
+				etag := `"` + version + `"`
+				w.Header().Set("ETag", etag)
+				if r.Header.Get("If-None-Match") == etag {
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, err := w.Write([]byte(`{"version":"` + version + `"}`))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			serverURL, err := url.Parse(mockServer.URL)
+			require.NoError(t, err)
+
+			s, err := New(
+				WithSigner(&edgegrid.Config{Host: serverURL.Host}),
+				WithClient(mockServer.Client()),
+				WithETagCache(NewMemoryCache(), test.rules...),
+			)
+			require.NoError(t, err)
+
+			for i, c := range test.calls {
+				version, expectedIfNoneMatch = c.version, c.expectedIfNoneMatch
+				req, err := http.NewRequest(http.MethodGet, c.path, nil)
+				require.NoError(t, err)
+				var out map[string]string
+				resp, err := s.Exec(req, &out)
+				require.NoError(t, err, "call %d", i)
+				assert.Equal(t, c.expectedStatus, resp.StatusCode, "call %d", i)
+				assert.Equal(t, c.expectedBody, out, "call %d", i)
+				assert.Empty(t, req.Header.Get("If-None-Match"), "call %d", i)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package session
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+type hedgeResult struct {
+	attempt int
+	resp    *http.Response
+	err     error
+}
+
+// WithHedging makes the session hedge reads, reducing tail latency: when no response to a GET or HEAD request
+// without body arrived after delay, an identical request is sent, and the first successful response is used,
+// while the other request is canceled. Transport errors, 429 and 5xx responses are not successful: when one
+// request fails that way, the outcome of the other one is awaited. Hedged requests add load to the APIs, so the delay should be well above
+// the usual latency, e.g. its 95th percentile. It can be changed for single calls with WithContextHedging.
+func WithHedging(delay time.Duration) Option {
+	return func(s *session) {
+		s.hedgeDelay = delay
+	}
+}
+
+// WithContextHedging sets the hedging delay of reads made with the context, see WithHedging, e.g. only for
+// latency-sensitive lookups; a negative delay disables hedging
+func WithContextHedging(delay time.Duration) ContextOption {
+	return func(o *contextOptions) {
+		o.hedgeDelay = delay
+	}
+}
+
+// hedgingDelay returns the delay after which the request is hedged, or zero if it is not
+func (s *session) hedgingDelay(r *http.Request) time.Duration {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return 0
+	}
+	if r.Body != nil && r.Body != http.NoBody {
+		return 0
+	}
+	delay := s.hedgeDelay
+	if o, ok := r.Context().Value(contextOptionKey).(*contextOptions); ok && o.hedgeDelay != 0 {
+		delay = o.hedgeDelay
+	}
+	if delay < 0 {
+		return 0
+	}
+	return delay
+}
+
+// sendHedged sends the request, and a newly signed copy of it if no response arrived after delay,
+// returning the first successful response, or the last failed one
+func (s *session) sendHedged(r *http.Request, delay time.Duration) (*http.Response, error) {
+	results := make(chan hedgeResult, 2)
+	var cancels []context.CancelFunc
+	start := func(req *http.Request) {
+		ctx, cancel := context.WithCancel(req.Context())
+		attempt := len(cancels)
+		cancels = append(cancels, cancel)
+		go func() {
+			resp, err := s.sendOnce(req.WithContext(ctx))
+			results <- hedgeResult{attempt: attempt, resp: resp, err: err}
+		}()
+	}
+
+	start(r)
+	timer := time.NewTimer(delay)
+	select {
+	case res := <-results:
+		timer.Stop()
+		return keepHedge(res, cancels[res.attempt])
+	case <-r.Context().Done():
+		timer.Stop()
+		res := <-results
+		return keepHedge(res, cancels[res.attempt])
+	case <-timer.C:
+	}
+
+	hedged := r.Clone(r.Context())
+	if err := s.Sign(hedged); err != nil {
+		res := <-results
+		return keepHedge(res, cancels[res.attempt])
+	}
+	s.Log(r.Context()).Debugf("Hedging %s %s after %s", r.Method, r.URL.Path, delay)
+	start(hedged)
+
+	first := <-results
+	if hedgeFailed(first) {
+		second := <-results
+		// a failed response is still preferred to a transport error
+		kept, discarded := second, first
+		if second.err != nil && first.err == nil {
+			kept, discarded = first, second
+		}
+		discardHedge(discarded, cancels[discarded.attempt])
+		return keepHedge(kept, cancels[kept.attempt])
+	}
+	other := 1 - first.attempt
+	cancels[other]()
+	go func() {
+		discardHedge(<-results, cancels[other])
+	}()
+	return keepHedge(first, cancels[first.attempt])
+}
+
+// hedgeFailed reports whether the attempt failed with a transport error, a 429 or a 5xx response
+func hedgeFailed(res hedgeResult) bool {
+	if res.err != nil {
+		return true
+	}
+	return res.resp.StatusCode == http.StatusTooManyRequests || res.resp.StatusCode >= http.StatusInternalServerError
+}
+
+// discardHedge closes the response of an attempt which is not used and releases its context
+func discardHedge(res hedgeResult, cancel context.CancelFunc) {
+	if res.err == nil {
+		res.resp.Body.Close()
+	}
+	cancel()
+}
+
+// keepHedge returns the result of an attempt, releasing its context once the response body is closed
+func keepHedge(res hedgeResult, cancel context.CancelFunc) (*http.Response, error) {
+	if res.err != nil {
+		cancel()
+		return nil, res.err
+	}
+	res.resp.Body = &cancelBody{ReadCloser: res.resp.Body, cancel: cancel}
+	return res.resp, nil
+}
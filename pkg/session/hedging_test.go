@@ -0,0 +1,132 @@
+package session
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSession_ExecHedging(t *testing.T) {
+	tests := map[string]struct {
+		method        string
+		hedging       time.Duration
+		contextDelay  time.Duration
+		firstDelay    time.Duration
+		firstStatus   int
+		secondDelay   time.Duration
+		expectedCalls int32
+		expectedBody  string
+	}{
+		"slow first request hedged": {
+			method:        http.MethodGet,
+			hedging:       20 * time.Millisecond,
+			firstDelay:    2 * time.Second,
+			expectedCalls: 2,
+			expectedBody:  "call 2",
+		},
+		"failed first request waits for hedged request": {
+			method:        http.MethodGet,
+			hedging:       20 * time.Millisecond,
+			firstDelay:    50 * time.Millisecond,
+			firstStatus:   http.StatusServiceUnavailable,
+			secondDelay:   200 * time.Millisecond,
+			expectedCalls: 2,
+			expectedBody:  "call 2",
+		},
+		"fast first request not hedged": {
+			method:        http.MethodGet,
+			hedging:       time.Second,
+			expectedCalls: 1,
+			expectedBody:  "call 1",
+		},
+		"hedging disabled": {
+			method:        http.MethodGet,
+			firstDelay:    100 * time.Millisecond,
+			expectedCalls: 1,
+			expectedBody:  "call 1",
+		},
+		"hedging enabled for the call": {
+			method:        http.MethodGet,
+			contextDelay:  20 * time.Millisecond,
+			firstDelay:    2 * time.Second,
+			expectedCalls: 2,
+			expectedBody:  "call 2",
+		},
+		"hedging disabled for the call": {
+			method:        http.MethodGet,
+			hedging:       20 * time.Millisecond,
+			contextDelay:  -1,
+			firstDelay:    100 * time.Millisecond,
+			expectedCalls: 1,
+			expectedBody:  "call 1",
+		},
+		"writes not hedged": {
+			method:        http.MethodDelete,
+			hedging:       20 * time.Millisecond,
+			firstDelay:    100 * time.Millisecond,
+			expectedCalls: 1,
+			expectedBody:  "call 1",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var calls int32
+			authorizations := make(chan string, 2)
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				call := atomic.AddInt32(&calls, 1)
+				authorizations <- r.Header.Get("Authorization")
+				delay, status := test.secondDelay, http.StatusOK
+				if call == 1 {
+					delay = test.firstDelay
+					if test.firstStatus != 0 {
+						status = test.firstStatus
+					}
+				}
+				select {
+				case <-time.After(delay):
+				case <-r.Context().Done():
+					return
+				}
+				w.WriteHeader(status)
+				_, err := w.Write([]byte(`{"a":"call ` + string('0'+call) + `"}`))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			serverURL, err := url.Parse(mockServer.URL)
+			require.NoError(t, err)
+
+			s, err := New(
+				WithSigner(&edgegrid.Config{Host: serverURL.Host}),
+				WithClient(mockServer.Client()),
+				WithHedging(test.hedging),
+			)
+			require.NoError(t, err)
+
+			ctx := context.Background()
+			if test.contextDelay != 0 {
+				ctx = ContextWithOptions(ctx, WithContextHedging(test.contextDelay))
+			}
+			req, err := http.NewRequestWithContext(ctx, test.method, "/test", nil)
+			require.NoError(t, err)
+			var out testStruct
+			start := time.Now()
+			_, err = s.Exec(req, &out)
+			require.NoError(t, err)
+			assert.Less(t, time.Since(start), time.Second)
+			assert.Equal(t, test.expectedBody, out.A)
+			assert.Equal(t, test.expectedCalls, atomic.LoadInt32(&calls))
+			if test.expectedCalls == 2 {
+				assert.NotEqual(t, <-authorizations, <-authorizations, "hedged request must be signed again")
+			}
+		})
+	}
+}
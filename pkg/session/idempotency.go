@@ -0,0 +1,55 @@
+package session
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// IdempotencyKeyHeader is the header carrying the idempotency key of a request.
+	// APIs accepting it process repeated requests with the same key only once.
+	IdempotencyKeyHeader = "Idempotency-Key"
+)
+
+// WithIdempotencyKeys makes the session add a unique idempotency key to every POST and PATCH request which does not
+// have one yet. The key is kept when the request is retried, so retrying it after a timeout or a transient failure
+// does not create duplicate resources with APIs accepting the key. The keys do not change which requests are retried,
+// see RetryWithIdempotencyKey.
+func WithIdempotencyKeys(enabled bool) Option {
+	return func(s *session) {
+		s.idempotencyKeys = enabled
+	}
+}
+
+// WithContextIdempotencyKey sets the idempotency key for requests made with the context, allowing callers to reuse
+// the key of a request which has to be repeated later, e.g. after the process was restarted
+func WithContextIdempotencyKey(key string) ContextOption {
+	return func(o *contextOptions) {
+		o.idempotencyKey = key
+	}
+}
+
+// NewIdempotencyKey returns a new random idempotency key
+func NewIdempotencyKey() string {
+	return uuid.New().String()
+}
+
+// RetryWithIdempotencyKey is a RetryClassifier which also retries POST and PATCH requests carrying an idempotency key
+// after transport errors and 429, 502, 503 and 504 responses, and applies the default policy to other requests.
+// Only use it for APIs known to process repeated requests with the same key only once; the Akamai APIs do not
+// deduplicate requests by this header, so retrying them may create duplicate resources.
+func RetryWithIdempotencyKey(attempt RetryAttempt) *RetryDecision {
+	r := attempt.Request
+	if r.Header.Get(IdempotencyKeyHeader) == "" || (r.Method != http.MethodPost && r.Method != http.MethodPatch) {
+		return nil
+	}
+	return &RetryDecision{Retry: isTransientFailure(r, attempt.Response, attempt.Err)}
+}
+
+func requiresIdempotencyKey(r *http.Request) bool {
+	if r.Header.Get(IdempotencyKeyHeader) != "" {
+		return false
+	}
+	return r.Method == http.MethodPost || r.Method == http.MethodPatch
+}
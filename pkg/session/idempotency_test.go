@@ -0,0 +1,259 @@
+package session
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/edgegrid"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSession_ExecIdempotencyKeys(t *testing.T) {
+	tests := map[string]struct {
+		method       string
+		enabled      bool
+		clientOption bool
+		contextKey   string
+		headerKey    string
+		expected     func(*testing.T, string)
+	}{
+		"POST gets generated key": {
+			method:  http.MethodPost,
+			enabled: true,
+			expected: func(t *testing.T, key string) {
+				_, err := uuid.Parse(key)
+				assert.NoError(t, err)
+			},
+		},
+		"PATCH gets generated key": {
+			method:  http.MethodPatch,
+			enabled: true,
+			expected: func(t *testing.T, key string) {
+				assert.NotEmpty(t, key)
+			},
+		},
+		"GET has no key": {
+			method:  http.MethodGet,
+			enabled: true,
+			expected: func(t *testing.T, key string) {
+				assert.Empty(t, key)
+			},
+		},
+		"disabled": {
+			method: http.MethodPost,
+			expected: func(t *testing.T, key string) {
+				assert.Empty(t, key)
+			},
+		},
+		"key from context": {
+			method:     http.MethodPost,
+			enabled:    true,
+			contextKey: "context-key",
+			expected: func(t *testing.T, key string) {
+				assert.Equal(t, "context-key", key)
+			},
+		},
+		"key from context without generated keys": {
+			method:     http.MethodPut,
+			contextKey: "context-key",
+			expected: func(t *testing.T, key string) {
+				assert.Equal(t, "context-key", key)
+			},
+		},
+		"POST gets generated key with client option": {
+			method:       http.MethodPost,
+			clientOption: true,
+			expected: func(t *testing.T, key string) {
+				_, err := uuid.Parse(key)
+				assert.NoError(t, err)
+			},
+		},
+		"GET has no key with client option": {
+			method:       http.MethodGet,
+			clientOption: true,
+			expected: func(t *testing.T, key string) {
+				assert.Empty(t, key)
+			},
+		},
+		"key from context with client option": {
+			method:       http.MethodPost,
+			clientOption: true,
+			contextKey:   "context-key",
+			expected: func(t *testing.T, key string) {
+				assert.Equal(t, "context-key", key)
+			},
+		},
+		"key set on request is kept": {
+			method:    http.MethodPost,
+			enabled:   true,
+			headerKey: "request-key",
+			expected: func(t *testing.T, key string) {
+				assert.Equal(t, "request-key", key)
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var received string
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received = r.Header.Get(IdempotencyKeyHeader)
+				w.WriteHeader(http.StatusCreated)
+			}))
+			defer mockServer.Close()
+
+			s, err := New(
+				WithSigner(&edgegrid.Config{}),
+				WithClient(mockServer.Client()),
+				WithIdempotencyKeys(test.enabled),
+			)
+			require.NoError(t, err)
+			s = ClientOptions{IdempotencyKeys: test.clientOption}.Apply(s)
+
+			ctx := context.Background()
+			if test.contextKey != "" {
+				ctx = ContextWithOptions(ctx, WithContextIdempotencyKey(test.contextKey))
+			}
+			req, err := http.NewRequestWithContext(ctx, test.method, mockServer.URL+"/test", nil)
+			require.NoError(t, err)
+			if test.headerKey != "" {
+				req.Header.Set(IdempotencyKeyHeader, test.headerKey)
+			}
+			_, err = s.Exec(req, nil)
+			require.NoError(t, err)
+			test.expected(t, received)
+		})
+	}
+}
+
+func TestSession_ExecIdempotencyKeysRetry(t *testing.T) {
+	retryDelay = time.Millisecond
+	defer func() { retryDelay = time.Second }()
+
+	var keys []string
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get(IdempotencyKeyHeader))
+		if len(keys) == 1 {
+			w.WriteHeader(http.StatusGatewayTimeout)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer mockServer.Close()
+
+	tests := map[string]struct {
+		classifier     RetryClassifier
+		expectedStatus int
+		expectedKeys   int
+	}{
+		"not retried by default": {
+			expectedStatus: http.StatusGatewayTimeout,
+			expectedKeys:   1,
+		},
+		"retried with RetryWithIdempotencyKey": {
+			classifier:     RetryWithIdempotencyKey,
+			expectedStatus: http.StatusCreated,
+			expectedKeys:   2,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			keys = nil
+			s, err := New(
+				WithSigner(&edgegrid.Config{}),
+				WithClient(mockServer.Client()),
+				WithIdempotencyKeys(true),
+			)
+			require.NoError(t, err)
+			s = ClientOptions{Retries: 2, RetryClassifier: test.classifier, BaseURL: mockServer.URL}.Apply(s)
+
+			req, err := http.NewRequest(http.MethodPost, "/test", nil)
+			require.NoError(t, err)
+			resp, err := s.Exec(req, nil, map[string]string{"name": "test"})
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedStatus, resp.StatusCode)
+			require.Len(t, keys, test.expectedKeys)
+			assert.NotEmpty(t, keys[0])
+			assert.Equal(t, keys[0], keys[len(keys)-1])
+		})
+	}
+}
+
+func TestClientOptions_IdempotencyKeysConnectionReset(t *testing.T) {
+	retryDelay = time.Millisecond
+	defer func() { retryDelay = time.Second }()
+
+	var keys []string
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get(IdempotencyKeyHeader))
+		if len(keys) == 1 {
+			// the connection is reset before the response is sent
+			conn, _, err := w.(http.Hijacker).Hijack()
+			require.NoError(t, err)
+			conn.Close()
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer mockServer.Close()
+
+	tests := map[string]struct {
+		idempotencyKeys bool
+		classifier      RetryClassifier
+		expectedKeys    int
+		withError       bool
+	}{
+		"POST with idempotency key is retried with RetryWithIdempotencyKey": {
+			idempotencyKeys: true,
+			classifier:      RetryWithIdempotencyKey,
+			expectedKeys:    2,
+		},
+		"POST with idempotency key is not retried by default": {
+			idempotencyKeys: true,
+			expectedKeys:    1,
+			withError:       true,
+		},
+		"POST without idempotency key is not retried": {
+			classifier:   RetryWithIdempotencyKey,
+			expectedKeys: 1,
+			withError:    true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			keys = nil
+			s, err := New(
+				WithSigner(&edgegrid.Config{}),
+				WithClient(mockServer.Client()),
+			)
+			require.NoError(t, err)
+			s = ClientOptions{
+				Retries:         2,
+				IdempotencyKeys: test.idempotencyKeys,
+				RetryClassifier: test.classifier,
+				BaseURL:         mockServer.URL,
+			}.Apply(s)
+
+			req, err := http.NewRequest(http.MethodPost, "/appsec/v1/configs/43253/versions/7/match-targets", nil)
+			require.NoError(t, err)
+			resp, err := s.Exec(req, nil, map[string]string{"type": "website"})
+			require.Len(t, keys, test.expectedKeys)
+			if test.withError {
+				assert.Error(t, err)
+				assert.Equal(t, test.idempotencyKeys, keys[0] != "")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusCreated, resp.StatusCode)
+			assert.NotEmpty(t, keys[0])
+			assert.Equal(t, keys[0], keys[1])
+		})
+	}
+}
@@ -0,0 +1,8 @@
+// Package logadapter adapts structured loggers to session.Logger, so that the logs of the session
+// end up in the logs of the application, e.g.
+//
+//	sess, err := session.New(session.WithLogger(logadapter.Slog(slog.Default())))
+//
+// Adapters for loggers outside the standard library live in separate modules, so that the SDK does not
+// depend on them: zapadapter for zap and zerologadapter for zerolog.
+package logadapter
@@ -0,0 +1,45 @@
+//go:build go1.21
+
+package logadapter
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/session"
+	"github.com/apex/log"
+)
+
+// LevelFatal is the slog level of fatal session logs, above slog.LevelError
+const LevelFatal = slog.LevelError + 4
+
+// Slog returns a session.Logger writing to logger
+func Slog(logger *slog.Logger) session.Logger {
+	return session.LoggerFunc(func(level log.Level, msg string, fields log.Fields) {
+		logger.Log(context.Background(), slogLevel(level), msg, keysAndValues(fields)...)
+	})
+}
+
+func slogLevel(level log.Level) slog.Level {
+	switch level {
+	case log.DebugLevel:
+		return slog.LevelDebug
+	case log.WarnLevel:
+		return slog.LevelWarn
+	case log.ErrorLevel:
+		return slog.LevelError
+	case log.FatalLevel:
+		return LevelFatal
+	}
+	return slog.LevelInfo
+}
+
+// keysAndValues returns the fields as alternating names and values, in the order of their names
+func keysAndValues(fields log.Fields) []interface{} {
+	names := fields.Names()
+	kv := make([]interface{}, 0, 2*len(names))
+	for _, name := range names {
+		kv = append(kv, name, fields[name])
+	}
+	return kv
+}
@@ -0,0 +1,36 @@
+//go:build go1.21
+
+package logadapter
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/apex/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlog(t *testing.T) {
+	var buf bytes.Buffer
+	handler := slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+	logger := Slog(slog.New(handler))
+
+	logger.Log(log.DebugLevel, "filtered", nil)
+	logger.Log(log.ErrorLevel, "request failed", log.Fields{"status": 500, "path": "/appsec/v1/configs"})
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, map[string]interface{}{
+		"level": "ERROR", "msg": "request failed", "status": float64(500), "path": "/appsec/v1/configs",
+	}, entry)
+}
@@ -0,0 +1,27 @@
+module github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/session/logadapter/zapadapter
+
+go 1.18
+
+require (
+	github.com/akamai/AkamaiOPEN-edgegrid-golang/v6 v6.0.0
+	github.com/apex/log v1.9.0
+	github.com/stretchr/testify v1.8.2
+	go.uber.org/zap v1.23.0
+)
+
+require (
+	github.com/andres-erbsen/clock v0.0.0-20160526145045-9e14626cd129 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/google/uuid v1.1.1 // indirect
+	github.com/mitchellh/go-homedir v1.1.0 // indirect
+	github.com/pkg/errors v0.9.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/stretchr/objx v0.5.0 // indirect
+	go.uber.org/atomic v1.7.0 // indirect
+	go.uber.org/multierr v1.6.0 // indirect
+	go.uber.org/ratelimit v0.2.0 // indirect
+	gopkg.in/ini.v1 v1.51.1 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/akamai/AkamaiOPEN-edgegrid-golang/v6 => ../../../..
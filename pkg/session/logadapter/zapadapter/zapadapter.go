@@ -0,0 +1,41 @@
+// Package zapadapter adapts zap loggers to session.Logger, so that the logs of the session
+// end up in the logs of the application, e.g.
+//
+//	sess, err := session.New(session.WithLogger(zapadapter.New(logger)))
+package zapadapter
+
+import (
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/session"
+	"github.com/apex/log"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// New returns a session.Logger writing to logger. Fatal logs are written at error level,
+// so that the logger does not exit the process.
+func New(logger *zap.Logger) session.Logger {
+	logger = logger.WithOptions(zap.AddCallerSkip(1))
+	return session.LoggerFunc(func(level log.Level, msg string, fields log.Fields) {
+		entry := logger.Check(zapLevel(level), msg)
+		if entry == nil {
+			return
+		}
+		zapFields := make([]zap.Field, 0, len(fields))
+		for _, name := range fields.Names() {
+			zapFields = append(zapFields, zap.Any(name, fields[name]))
+		}
+		entry.Write(zapFields...)
+	})
+}
+
+func zapLevel(level log.Level) zapcore.Level {
+	switch level {
+	case log.DebugLevel:
+		return zapcore.DebugLevel
+	case log.WarnLevel:
+		return zapcore.WarnLevel
+	case log.ErrorLevel, log.FatalLevel:
+		return zapcore.ErrorLevel
+	}
+	return zapcore.InfoLevel
+}
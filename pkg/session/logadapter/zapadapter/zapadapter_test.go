@@ -0,0 +1,28 @@
+package zapadapter
+
+import (
+	"testing"
+
+	"github.com/apex/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestNew(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	logger := New(zap.New(core))
+
+	logger.Log(log.DebugLevel, "filtered", nil)
+	logger.Log(log.InfoLevel, "fetching config", log.Fields{"configId": 123, "version": 2})
+	logger.Log(log.FatalLevel, "failed", log.Fields{"error": "boom"})
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 2)
+	assert.Equal(t, zapcore.InfoLevel, entries[0].Level)
+	assert.Equal(t, "fetching config", entries[0].Message)
+	assert.Equal(t, map[string]interface{}{"configId": int64(123), "version": int64(2)}, entries[0].ContextMap())
+	assert.Equal(t, zapcore.ErrorLevel, entries[1].Level)
+}
@@ -0,0 +1,29 @@
+module github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/session/logadapter/zerologadapter
+
+go 1.18
+
+require (
+	github.com/akamai/AkamaiOPEN-edgegrid-golang/v6 v6.0.0
+	github.com/apex/log v1.9.0
+	github.com/rs/zerolog v1.29.0
+	github.com/stretchr/testify v1.8.2
+)
+
+require (
+	github.com/andres-erbsen/clock v0.0.0-20160526145045-9e14626cd129 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/google/uuid v1.1.1 // indirect
+	github.com/jtolds/gls v4.20.0+incompatible // indirect
+	github.com/mattn/go-colorable v0.1.12 // indirect
+	github.com/mattn/go-isatty v0.0.14 // indirect
+	github.com/mitchellh/go-homedir v1.1.0 // indirect
+	github.com/pkg/errors v0.9.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/stretchr/objx v0.5.0 // indirect
+	go.uber.org/ratelimit v0.2.0 // indirect
+	golang.org/x/sys v0.5.0 // indirect
+	gopkg.in/ini.v1 v1.51.1 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/akamai/AkamaiOPEN-edgegrid-golang/v6 => ../../../..
@@ -0,0 +1,40 @@
+// Package zerologadapter adapts zerolog loggers to session.Logger, so that the logs of the session
+// end up in the logs of the application, e.g.
+//
+//	sess, err := session.New(session.WithLogger(zerologadapter.New(logger)))
+package zerologadapter
+
+import (
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/session"
+	"github.com/apex/log"
+	"github.com/rs/zerolog"
+)
+
+// New returns a session.Logger writing to logger. Fatal logs are written at fatal level
+// without exiting the process.
+func New(logger zerolog.Logger) session.Logger {
+	return session.LoggerFunc(func(level log.Level, msg string, fields log.Fields) {
+		event := logger.WithLevel(zerologLevel(level))
+		if event == nil {
+			return
+		}
+		for _, name := range fields.Names() {
+			event = event.Interface(name, fields[name])
+		}
+		event.Msg(msg)
+	})
+}
+
+func zerologLevel(level log.Level) zerolog.Level {
+	switch level {
+	case log.DebugLevel:
+		return zerolog.DebugLevel
+	case log.WarnLevel:
+		return zerolog.WarnLevel
+	case log.ErrorLevel:
+		return zerolog.ErrorLevel
+	case log.FatalLevel:
+		return zerolog.FatalLevel
+	}
+	return zerolog.InfoLevel
+}
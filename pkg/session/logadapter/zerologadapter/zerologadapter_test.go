@@ -0,0 +1,24 @@
+package zerologadapter
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/apex/log"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(zerolog.New(&buf).Level(zerolog.InfoLevel))
+
+	logger.Log(log.DebugLevel, "filtered", nil)
+	logger.Log(log.WarnLevel, "config locked", log.Fields{"configId": 123})
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, map[string]interface{}{"level": "warn", "message": "config locked", "configId": float64(123)}, entry)
+}
@@ -0,0 +1,44 @@
+package session
+
+import (
+	"github.com/apex/log"
+)
+
+type (
+	// Logger is the minimal structured logger the session can write its logs to, e.g. the logger of the application,
+	// see WithLogger. The logadapter package provides an implementation for log/slog, and the zapadapter and
+	// zerologadapter modules for zap and zerolog.
+	Logger interface {
+		// Log writes the message with given level and fields
+		Log(level log.Level, msg string, fields log.Fields)
+	}
+
+	// LoggerFunc is a function implementing Logger
+	LoggerFunc func(level log.Level, msg string, fields log.Fields)
+
+	// loggerHandler is a log handler writing the entries to a Logger
+	loggerHandler struct {
+		logger Logger
+	}
+)
+
+// Log calls f(level, msg, fields)
+func (f LoggerFunc) Log(level log.Level, msg string, fields log.Fields) {
+	f(level, msg, fields)
+}
+
+// WithLogger makes the session write its logs to logger, at all levels; the logger filters them
+func WithLogger(logger Logger) Option {
+	return WithLog(LogInterface(logger))
+}
+
+// LogInterface returns a log interface writing to logger, to be used with WithContextLog or ClientOptions
+func LogInterface(logger Logger) log.Interface {
+	return &log.Logger{Handler: loggerHandler{logger: logger}, Level: log.DebugLevel}
+}
+
+// HandleLog writes the entry to the logger
+func (h loggerHandler) HandleLog(e *log.Entry) error {
+	h.logger.Log(e.Level, e.Message, e.Fields)
+	return nil
+}
@@ -0,0 +1,33 @@
+package session
+
+import (
+	"context"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/edgegrid"
+	"github.com/apex/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithLogger(t *testing.T) {
+	type entry struct {
+		level  log.Level
+		msg    string
+		fields log.Fields
+	}
+	var entries []entry
+	logger := LoggerFunc(func(level log.Level, msg string, fields log.Fields) {
+		entries = append(entries, entry{level: level, msg: msg, fields: fields})
+	})
+
+	s, err := New(WithSigner(&edgegrid.Config{}), WithLogger(logger))
+	require.NoError(t, err)
+	s.Log(context.Background()).WithField("configId", 123).Debug("fetching config")
+	s.Log(context.Background()).Warnf("config %d is locked", 123)
+
+	assert.Equal(t, []entry{
+		{level: log.DebugLevel, msg: "fetching config", fields: log.Fields{"configId": 123}},
+		{level: log.WarnLevel, msg: "config 123 is locked", fields: log.Fields{}},
+	}, entries)
+}
@@ -0,0 +1,94 @@
+package session
+
+import (
+	"net/http"
+	"time"
+)
+
+type (
+	// Metrics records the activity of a session, e.g. to alert on the health of the Akamai APIs, see WithMetrics.
+	// Implementations must be safe for concurrent use; see the prommetrics module for a Prometheus implementation.
+	Metrics interface {
+		// ObserveRequest is called after every request sent to the API, including retries
+		ObserveRequest(RequestMetric)
+		// ObserveRetry is called before a failed request is retried
+		ObserveRetry(RetryMetric)
+		// ObserveRateLimit is called when the API rejects a request with 429 Too Many Requests,
+		// or when the session holds a request because of the rate limit, see WithRateLimitThrottling
+		ObserveRateLimit(RateLimitMetric)
+	}
+
+	// Endpoint identifies the endpoint a request is sent to
+	Endpoint struct {
+		// Family is the API family, e.g. appsec
+		Family string
+		// Method is the HTTP method
+		Method string
+		// Route is the path with identifiers replaced by {id}, e.g. /appsec/v1/configs/{id}
+		Route string
+	}
+
+	// RequestMetric describes a request sent to the API
+	RequestMetric struct {
+		Endpoint
+		// StatusCode is the status code of the response, zero if no response was received
+		StatusCode int
+		// Err is the error returned by the client, if any
+		Err error
+		// Duration is the time until the response headers were received
+		Duration time.Duration
+	}
+
+	// RetryMetric describes the retry of a failed request
+	RetryMetric struct {
+		Endpoint
+		// Attempt is the number of the retry, starting with 1
+		Attempt int
+		// Delay is the delay before the retry
+		Delay time.Duration
+	}
+
+	// RateLimitMetric describes a request limited by the rate limit of the API
+	RateLimitMetric struct {
+		Endpoint
+		// Rejected tells whether the API rejected the request with 429 Too Many Requests
+		Rejected bool
+		// Wait is the time the session held the request, zero if Rejected
+		Wait time.Duration
+	}
+)
+
+// WithMetrics makes the session record request counts, latencies, retries and rate limit events
+func WithMetrics(metrics Metrics) Option {
+	return func(s *session) {
+		s.metrics = metrics
+	}
+}
+
+// endpoint returns the endpoint of the request
+func endpoint(r *http.Request) Endpoint {
+	return Endpoint{Family: apiFamily(r), Method: r.Method, Route: pathTemplate(r.URL.Path)}
+}
+
+// observeRequest records the outcome of sending the request
+func (s *session) observeRequest(r *http.Request, resp *http.Response, err error, duration time.Duration) {
+	if s.metrics == nil {
+		return
+	}
+	m := RequestMetric{Endpoint: endpoint(r), Err: err, Duration: duration}
+	if resp != nil {
+		m.StatusCode = resp.StatusCode
+		if resp.StatusCode == http.StatusTooManyRequests {
+			s.metrics.ObserveRateLimit(RateLimitMetric{Endpoint: m.Endpoint, Rejected: true})
+		}
+	}
+	s.metrics.ObserveRequest(m)
+}
+
+// sessionMetrics returns the metrics of the session underlying sess, if any
+func sessionMetrics(sess Session) Metrics {
+	if s := baseSession(sess); s != nil {
+		return s.metrics
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package session
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordedMetrics struct {
+	mu         sync.Mutex
+	requests   []RequestMetric
+	retries    []RetryMetric
+	rateLimits []RateLimitMetric
+}
+
+func (m *recordedMetrics) ObserveRequest(r RequestMetric) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests = append(m.requests, r)
+}
+
+func (m *recordedMetrics) ObserveRetry(r RetryMetric) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.retries = append(m.retries, r)
+}
+
+func (m *recordedMetrics) ObserveRateLimit(r RateLimitMetric) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rateLimits = append(m.rateLimits, r)
+}
+
+func TestSession_Metrics(t *testing.T) {
+	var calls int32
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer mockServer.Close()
+	serverURL, err := url.Parse(mockServer.URL)
+	require.NoError(t, err)
+
+	metrics := &recordedMetrics{}
+	s, err := New(
+		WithSigner(&edgegrid.Config{Host: serverURL.Host}),
+		WithClient(mockServer.Client()),
+		WithMetrics(metrics),
+		WithRetryPolicy(RetryPolicy{Retries: 1, Backoff: FixedBackoff(time.Millisecond)}),
+	)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodGet, "/appsec/v1/configs/123", nil)
+	require.NoError(t, err)
+	resp, err := s.Exec(req, nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	expectedEndpoint := Endpoint{Family: "appsec", Method: http.MethodGet, Route: "/appsec/v1/configs/{id}"}
+	require.Len(t, metrics.requests, 2)
+	assert.Equal(t, expectedEndpoint, metrics.requests[0].Endpoint)
+	assert.Equal(t, http.StatusTooManyRequests, metrics.requests[0].StatusCode)
+	assert.Equal(t, http.StatusOK, metrics.requests[1].StatusCode)
+	assert.Equal(t, []RetryMetric{{Endpoint: expectedEndpoint, Attempt: 1, Delay: time.Millisecond}}, metrics.retries)
+	assert.Equal(t, []RateLimitMetric{{Endpoint: expectedEndpoint, Rejected: true}}, metrics.rateLimits)
+}
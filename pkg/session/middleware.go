@@ -0,0 +1,30 @@
+package session
+
+import "net/http"
+
+type (
+	// Handler sends a signed request and returns its response, see Use
+	Handler func(r *http.Request) (*http.Response, error)
+
+	// Middleware wraps the handler sending the requests of a session, e.g. to audit requests, add headers,
+	// serve responses from a cache or inject failures in tests. It can return a response without calling next.
+	Middleware func(next Handler) Handler
+)
+
+// Use adds middleware to the chain every request of the session is sent through, after it is signed.
+// The first middleware is the outermost one. Requests already carry their signature, so middleware must not
+// change the method, URL, body or signed headers of the request.
+func Use(middleware ...Middleware) Option {
+	return func(s *session) {
+		s.middleware = append(s.middleware, middleware...)
+	}
+}
+
+// handler returns the handler sending the requests of the session through its middleware
+func (s *session) handler() Handler {
+	h := Handler(s.do)
+	for i := len(s.middleware) - 1; i >= 0; i-- {
+		h = s.middleware[i](h)
+	}
+	return h
+}
@@ -0,0 +1,126 @@
+package session
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSession_Use(t *testing.T) {
+	injected := errors.New("injected failure")
+
+	tests := map[string]struct {
+		middleware     func(order *[]string) []Middleware
+		expectedOrder  []string
+		expectedCalls  int32
+		expectedHeader string
+		expectedBody   string
+		expectedError  error
+	}{
+		"middleware called in order": {
+			middleware: func(order *[]string) []Middleware {
+				return []Middleware{named("first", order), named("second", order)}
+			},
+			expectedOrder: []string{"first", "second"},
+			expectedCalls: 1,
+			expectedBody:  `{"a":"b"}`,
+		},
+		"header mutation": {
+			middleware: func(*[]string) []Middleware {
+				return []Middleware{func(next Handler) Handler {
+					return func(r *http.Request) (*http.Response, error) {
+						assert.Contains(t, r.Header.Get("Authorization"), "signature=")
+						r.Header.Set("X-Trace", "trace-1")
+						return next(r)
+					}
+				}}
+			},
+			expectedCalls:  1,
+			expectedHeader: "trace-1",
+			expectedBody:   `{"a":"b"}`,
+		},
+		"short circuit": {
+			middleware: func(*[]string) []Middleware {
+				return []Middleware{func(next Handler) Handler {
+					return func(r *http.Request) (*http.Response, error) {
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Header:     http.Header{},
+							Body:       ioutil.NopCloser(bytes.NewBufferString(`{"a":"cached"}`)),
+							Request:    r,
+						}, nil
+					}
+				}}
+			},
+			expectedBody: `{"a":"cached"}`,
+		},
+		"injected failure": {
+			middleware: func(*[]string) []Middleware {
+				return []Middleware{func(next Handler) Handler {
+					return func(r *http.Request) (*http.Response, error) {
+						return nil, injected
+					}
+				}}
+			},
+			expectedError: injected,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var calls int32
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&calls, 1)
+				assert.Equal(t, test.expectedHeader, r.Header.Get("X-Trace"))
+				w.WriteHeader(http.StatusOK)
+				_, err := w.Write([]byte(`{"a":"b"}`))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			serverURL, err := url.Parse(mockServer.URL)
+			require.NoError(t, err)
+
+			var order []string
+			s, err := New(
+				WithSigner(&edgegrid.Config{Host: serverURL.Host}),
+				WithClient(mockServer.Client()),
+				Use(test.middleware(&order)...),
+			)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodGet, "/test", nil)
+			require.NoError(t, err)
+			var out map[string]string
+			_, err = s.Exec(req, &out)
+			assert.Equal(t, test.expectedCalls, atomic.LoadInt32(&calls))
+			if test.expectedError != nil {
+				assert.True(t, errors.Is(err, test.expectedError), "want: %s; got: %s", test.expectedError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedOrder, order)
+			expected := map[string]string{}
+			require.NoError(t, json.Unmarshal([]byte(test.expectedBody), &expected))
+			assert.Equal(t, expected, out)
+		})
+	}
+}
+
+func named(name string, order *[]string) Middleware {
+	return func(next Handler) Handler {
+		return func(r *http.Request) (*http.Response, error) {
+			*order = append(*order, name)
+			return next(r)
+		}
+	}
+}
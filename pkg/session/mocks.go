@@ -0,0 +1,46 @@
+//revive:disable:exported
+
+package session
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/apex/log"
+	"github.com/stretchr/testify/mock"
+)
+
+type Mock struct {
+	mock.Mock
+}
+
+var _ Session = &Mock{}
+
+func (m *Mock) Exec(r *http.Request, out interface{}, in ...interface{}) (*http.Response, error) {
+	args := m.Called(r, out, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*http.Response), args.Error(1)
+}
+
+func (m *Mock) Sign(r *http.Request) error {
+	args := m.Called(r)
+	return args.Error(0)
+}
+
+func (m *Mock) Log(ctx context.Context) log.Interface {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(log.Interface)
+}
+
+func (m *Mock) Client() *http.Client {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(*http.Client)
+}
@@ -0,0 +1,184 @@
+package session
+
+import (
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type (
+	// MultipartForm is a multipart/form-data request body. Passed to Exec as the in argument,
+	// it is streamed to the API instead of being marshaled to JSON, with the matching Content-Type header.
+	//
+	// When the content of every file implements io.Seeker, e.g. *os.File, the body is never held in memory:
+	// the contents are rewound to their offsets from when the form was first sent to sign, retry or redirect the request.
+	// Other contents are read into memory once to sign the request, and such requests are not retried.
+	MultipartForm struct {
+		// Fields are the form values, written in the order of their names before the files
+		Fields map[string]string
+		// Files are the file parts of the form
+		Files []FormFile
+
+		// offsets are the offsets of seekable file contents when the form was first sent, nil otherwise
+		offsets []int64
+	}
+
+	// FormFile is a file part of a MultipartForm
+	FormFile struct {
+		// FieldName is the name of the form field
+		FieldName string
+		// FileName is the name of the file sent to the API
+		FileName string
+		// ContentType is the content type of the file, application/octet-stream by default
+		ContentType string
+		// Content is read to the end when the body is sent
+		Content io.Reader
+	}
+
+	// multipartBody writes the form to a pipe once it is first read, so that creating a body,
+	// e.g. with GetBody, does not consume the file contents
+	multipartBody struct {
+		form     *MultipartForm
+		boundary string
+		offsets  []int64
+
+		once sync.Once
+		pr   *io.PipeReader
+		done chan struct{}
+	}
+)
+
+// setMultipartBody sets the body and the content type of the request to the form
+func setMultipartBody(r *http.Request, form *MultipartForm) {
+	boundary := multipart.NewWriter(nil).Boundary()
+	if form.offsets == nil {
+		form.offsets, _ = form.currentOffsets()
+	}
+	offsets := form.offsets
+
+	r.Header.Set("Content-Type", "multipart/form-data; boundary="+boundary)
+	r.Body = &multipartBody{form: form, boundary: boundary, offsets: offsets}
+	r.GetBody = nil
+	r.ContentLength = -1
+	if offsets != nil {
+		r.GetBody = func() (io.ReadCloser, error) {
+			return &multipartBody{form: form, boundary: boundary, offsets: offsets}, nil
+		}
+	}
+}
+
+// seekable reports whether the file contents of the form can be rewound
+func (f *MultipartForm) seekable() bool {
+	for _, file := range f.Files {
+		if _, ok := file.Content.(io.Seeker); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// currentOffsets returns the current offsets of the file contents, or nil if any of them cannot be rewound
+func (f *MultipartForm) currentOffsets() ([]int64, bool) {
+	offsets := make([]int64, len(f.Files))
+	for i, file := range f.Files {
+		seeker, ok := file.Content.(io.Seeker)
+		if !ok {
+			return nil, false
+		}
+		offset, err := seeker.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return nil, false
+		}
+		offsets[i] = offset
+	}
+	return offsets, true
+}
+
+// Read reads the encoded form, starting to write it on the first call
+func (b *multipartBody) Read(p []byte) (int, error) {
+	b.once.Do(b.start)
+	return b.pr.Read(p)
+}
+
+// Close stops writing the form, waiting until the file contents are no longer read
+func (b *multipartBody) Close() error {
+	b.once.Do(func() {})
+	if b.pr == nil {
+		return nil
+	}
+	b.pr.Close()
+	<-b.done
+	return nil
+}
+
+func (b *multipartBody) start() {
+	pr, pw := io.Pipe()
+	b.pr = pr
+	b.done = make(chan struct{})
+	go func() {
+		defer close(b.done)
+		pw.CloseWithError(b.write(pw))
+	}()
+}
+
+func (b *multipartBody) write(w io.Writer) error {
+	mw := multipart.NewWriter(w)
+	if err := mw.SetBoundary(b.boundary); err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(b.form.Fields))
+	for name := range b.form.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := mw.WriteField(name, b.form.Fields[name]); err != nil {
+			return err
+		}
+	}
+
+	for i, file := range b.form.Files {
+		if b.offsets != nil {
+			if _, err := file.Content.(io.Seeker).Seek(b.offsets[i], io.SeekStart); err != nil {
+				return fmt.Errorf("rewinding %q: %w", file.FileName, err)
+			}
+		}
+		contentType := file.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		h := make(textproto.MIMEHeader)
+		h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`,
+			escapeQuotes(file.FieldName), escapeQuotes(file.FileName)))
+		h.Set("Content-Type", contentType)
+		part, err := mw.CreatePart(h)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(part, file.Content); err != nil {
+			return err
+		}
+	}
+	return mw.Close()
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+func escapeQuotes(s string) string {
+	return quoteEscaper.Replace(s)
+}
+
+// firstMultipartForm returns the form passed as the in argument of Exec, if any
+func firstMultipartForm(in []interface{}) (*MultipartForm, bool) {
+	if len(in) == 0 {
+		return nil, false
+	}
+	form, ok := in[0].(*MultipartForm)
+	return form, ok && form != nil
+}
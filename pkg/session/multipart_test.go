@@ -0,0 +1,100 @@
+package session
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSession_ExecMultipart(t *testing.T) {
+	tests := map[string]struct {
+		method        string
+		content       func() io.Reader
+		failures      int32
+		expectedCalls int32
+		expectedCode  int
+	}{
+		"seekable content": {
+			method:        http.MethodPost,
+			content:       func() io.Reader { return strings.NewReader("bundle content") },
+			expectedCalls: 1,
+			expectedCode:  http.StatusCreated,
+		},
+		"content which cannot be rewound": {
+			method:        http.MethodPost,
+			content:       func() io.Reader { return io.MultiReader(strings.NewReader("bundle content")) },
+			expectedCalls: 1,
+			expectedCode:  http.StatusCreated,
+		},
+		"seekable content sent again on retry": {
+			method:        http.MethodPut,
+			content:       func() io.Reader { return strings.NewReader("bundle content") },
+			failures:      1,
+			expectedCalls: 2,
+			expectedCode:  http.StatusCreated,
+		},
+		"content which cannot be rewound is not retried": {
+			method:        http.MethodPut,
+			content:       func() io.Reader { return io.MultiReader(strings.NewReader("bundle content")) },
+			failures:      1,
+			expectedCalls: 1,
+			expectedCode:  http.StatusServiceUnavailable,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var calls int32
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&calls, 1) <= test.failures {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				assert.Contains(t, r.Header.Get("Authorization"), "signature=")
+				require.NoError(t, r.ParseMultipartForm(1<<20))
+				assert.Equal(t, "bundle-1", r.FormValue("name"))
+				assert.Equal(t, "1.0", r.FormValue("version"))
+
+				file, header, err := r.FormFile("bundle")
+				require.NoError(t, err)
+				defer file.Close()
+				assert.Equal(t, "bundle.tgz", header.Filename)
+				assert.Equal(t, "application/gzip", header.Header.Get("Content-Type"))
+				content, err := ioutil.ReadAll(file)
+				require.NoError(t, err)
+				assert.Equal(t, "bundle content", string(content))
+				w.WriteHeader(http.StatusCreated)
+			}))
+			defer mockServer.Close()
+			serverURL, err := url.Parse(mockServer.URL)
+			require.NoError(t, err)
+
+			s, err := New(WithSigner(&edgegrid.Config{Host: serverURL.Host, MaxBody: edgegrid.MaxBodySize}), WithClient(mockServer.Client()))
+			require.NoError(t, err)
+			s = ClientOptions{Retries: 1, Backoff: FixedBackoff(0)}.Apply(s)
+
+			form := &MultipartForm{
+				Fields: map[string]string{"name": "bundle-1", "version": "1.0"},
+				Files: []FormFile{
+					{FieldName: "bundle", FileName: "bundle.tgz", ContentType: "application/gzip", Content: test.content()},
+				},
+			}
+			req, err := http.NewRequest(test.method, "/test", nil)
+			require.NoError(t, err)
+			resp, err := s.Exec(req, nil, form)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedCode, resp.StatusCode)
+			assert.Equal(t, test.expectedCalls, atomic.LoadInt32(&calls))
+			assert.True(t, strings.HasPrefix(req.Header.Get("Content-Type"), "multipart/form-data; boundary="))
+		})
+	}
+}
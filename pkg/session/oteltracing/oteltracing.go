@@ -0,0 +1,68 @@
+// Package oteltracing provides an OpenTelemetry implementation of session.Tracer
+package oteltracing
+
+import (
+	"net/http"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/session"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type (
+	// Tracer emits an OpenTelemetry client span for every Exec call of a session, to be passed to session.WithTracer
+	Tracer struct {
+		tracer trace.Tracer
+	}
+
+	span struct {
+		span trace.Span
+	}
+)
+
+const tracerName = "github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/session"
+
+// New returns a tracer creating spans with the provider. Spans carry the API family, method, path template,
+// status code and Akamai request ID as attributes. The trace context of the request context is propagated
+// to the API with the global propagator, see otel.SetTextMapPropagator.
+func New(provider trace.TracerProvider) *Tracer {
+	return &Tracer{tracer: provider.Tracer(tracerName, trace.WithInstrumentationVersion(session.Version))}
+}
+
+// StartSpan starts the span of the request and injects its trace context in the request headers
+func (t *Tracer) StartSpan(r *http.Request, start session.SpanStart) session.Span {
+	ctx, s := t.tracer.Start(r.Context(), start.Name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("akamai.api.family", start.Family),
+			attribute.String("http.method", start.Method),
+			attribute.String("http.route", start.Route),
+		),
+	)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(r.Header))
+	return span{span: s}
+}
+
+// End records the outcome of the request and ends the span
+func (s span) End(end session.SpanEnd) {
+	defer s.span.End()
+	if end.StatusCode != 0 {
+		s.span.SetAttributes(
+			attribute.Int("http.status_code", end.StatusCode),
+			attribute.String("akamai.client_request_id", end.ClientRequestID),
+		)
+		if end.StatusCode >= http.StatusBadRequest {
+			s.span.SetStatus(codes.Error, http.StatusText(end.StatusCode))
+		}
+	}
+	if end.RequestID != "" {
+		s.span.SetAttributes(attribute.String("akamai.request_id", end.RequestID))
+	}
+	if end.Err != nil {
+		s.span.RecordError(end.Err)
+		s.span.SetStatus(codes.Error, end.Err.Error())
+	}
+}
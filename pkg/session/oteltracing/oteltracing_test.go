@@ -0,0 +1,101 @@
+package oteltracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/edgegrid"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracer(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+
+	tests := map[string]struct {
+		path               string
+		status             int
+		expectedName       string
+		expectedAttributes map[attribute.Key]attribute.Value
+		expectedStatus     codes.Code
+	}{
+		"successful call": {
+			path:         "/appsec/v1/configs/12345/versions/3/security-policies/abc1_23456",
+			status:       http.StatusOK,
+			expectedName: "GET /appsec/v1/configs/{id}/versions/{id}/security-policies/{id}",
+			expectedAttributes: map[attribute.Key]attribute.Value{
+				"akamai.api.family": attribute.StringValue("appsec"),
+				"http.method":       attribute.StringValue(http.MethodGet),
+				"http.route":        attribute.StringValue("/appsec/v1/configs/{id}/versions/{id}/security-policies/{id}"),
+				"http.status_code":  attribute.IntValue(http.StatusOK),
+				"akamai.request_id": attribute.StringValue("trace-123"),
+			},
+			expectedStatus: codes.Unset,
+		},
+		"failed call": {
+			path:         "/papi/v1/properties/prp_1",
+			status:       http.StatusNotFound,
+			expectedName: "GET /papi/v1/properties/{id}",
+			expectedAttributes: map[attribute.Key]attribute.Value{
+				"akamai.api.family": attribute.StringValue("papi"),
+				"http.status_code":  attribute.IntValue(http.StatusNotFound),
+			},
+			expectedStatus: codes.Error,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			recorder := tracetest.NewSpanRecorder()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+			parentCtx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Contains(t, r.Header.Get("traceparent"), parent.SpanContext().TraceID().String())
+				w.Header().Set("X-Trace-Id", "trace-123")
+				w.WriteHeader(test.status)
+			}))
+			defer mockServer.Close()
+			serverURL, err := url.Parse(mockServer.URL)
+			require.NoError(t, err)
+
+			s, err := session.New(
+				session.WithSigner(&edgegrid.Config{Host: serverURL.Host}),
+				session.WithClient(mockServer.Client()),
+				session.WithTracer(New(provider)),
+			)
+			require.NoError(t, err)
+
+			req, err := http.NewRequestWithContext(parentCtx, http.MethodGet, test.path, nil)
+			require.NoError(t, err)
+			_, err = s.Exec(req, nil)
+			require.NoError(t, err)
+			parent.End()
+
+			spans := recorder.Ended()
+			require.Len(t, spans, 2)
+			span := spans[0]
+			assert.Equal(t, test.expectedName, span.Name())
+			assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+			attributes := make(map[attribute.Key]attribute.Value)
+			for _, attr := range span.Attributes() {
+				attributes[attr.Key] = attr.Value
+			}
+			for key, value := range test.expectedAttributes {
+				assert.Equal(t, value, attributes[key], key)
+			}
+			assert.Equal(t, test.expectedStatus, span.Status().Code)
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package session
+
+import (
+	"context"
+)
+
+type (
+	// Pager iterates over the pages of a paginated list endpoint.
+	// Service packages provide implementations for their paginated list operations, e.g.:
+	//
+	//	pager := dns.NewZonesPager(client, dns.ZoneListQueryArgs{PageSize: 100})
+	//	for pager.Next(ctx) {
+	//		for _, zone := range pager.Page() {
+	//			// do something with zone
+	//		}
+	//	}
+	//	if err := pager.Err(); err != nil {
+	//		// handle error
+	//	}
+	Pager[T any] interface {
+		// Next fetches the next page and reports whether it is available.
+		// It returns false when there are no more pages or an error occurred.
+		Next(ctx context.Context) bool
+
+		// Page returns the items of the page fetched by the last call to Next
+		Page() []T
+
+		// Err returns the error which stopped the iteration, if any
+		Err() error
+	}
+
+	// PageFunc fetches the page with given zero-based index and reports whether more pages are available
+	PageFunc[T any] func(ctx context.Context, page int) (items []T, more bool, err error)
+
+	// PageResult is a single page delivered by StreamPages
+	PageResult[T any] struct {
+		Items []T
+		Err   error
+	}
+
+	// ItemIterator iterates over the items of all pages of a Pager, fetching the pages as needed, e.g.:
+	//
+	//	versions := session.NewItemIterator(appsec.NewConfigurationVersionsPager(client, params))
+	//	for versions.Next(ctx) {
+	//		version := versions.Item()
+	//		// do something with version
+	//	}
+	//	if err := versions.Err(); err != nil {
+	//		// handle error
+	//	}
+	ItemIterator[T any] struct {
+		pager Pager[T]
+		items []T
+		item  T
+	}
+
+	pager[T any] struct {
+		fetch PageFunc[T]
+		page  int
+		items []T
+		done  bool
+		err   error
+	}
+)
+
+// NewPager returns a Pager fetching consecutive pages with fetch until it reports no more pages
+func NewPager[T any](fetch PageFunc[T]) Pager[T] {
+	return &pager[T]{fetch: fetch}
+}
+
+func (p *pager[T]) Next(ctx context.Context) bool {
+	if p.done {
+		p.items = nil
+		return false
+	}
+
+	items, more, err := p.fetch(ctx, p.page)
+	if err != nil {
+		p.err = err
+		p.items = nil
+		p.done = true
+		return false
+	}
+
+	p.page++
+	p.items = items
+	p.done = !more
+	return true
+}
+
+func (p *pager[T]) Page() []T {
+	return p.items
+}
+
+func (p *pager[T]) Err() error {
+	return p.err
+}
+
+// NewItemIterator returns an iterator over the items of all pages of p
+func NewItemIterator[T any](p Pager[T]) *ItemIterator[T] {
+	return &ItemIterator[T]{pager: p}
+}
+
+// Next advances to the next item, fetching the next non-empty page if needed, and reports whether it is available.
+// It returns false when there are no more items or an error occurred.
+func (it *ItemIterator[T]) Next(ctx context.Context) bool {
+	for len(it.items) == 0 {
+		if !it.pager.Next(ctx) {
+			var zero T
+			it.item = zero
+			return false
+		}
+		it.items = it.pager.Page()
+	}
+	it.item, it.items = it.items[0], it.items[1:]
+	return true
+}
+
+// Item returns the item reached by the last call to Next
+func (it *ItemIterator[T]) Item() T {
+	return it.item
+}
+
+// Err returns the error which stopped the iteration, if any
+func (it *ItemIterator[T]) Err() error {
+	return it.pager.Err()
+}
+
+// CollectPages drains the pager and returns the items of all pages
+func CollectPages[T any](ctx context.Context, p Pager[T]) ([]T, error) {
+	var result []T
+	for p.Next(ctx) {
+		result = append(result, p.Page()...)
+	}
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// StreamPages fetches pages in the background and delivers them on the returned channel.
+// The channel is closed once all pages are delivered, after delivering an error or when ctx is done.
+func StreamPages[T any](ctx context.Context, p Pager[T]) <-chan PageResult[T] {
+	ch := make(chan PageResult[T])
+	go func() {
+		defer close(ch)
+		for p.Next(ctx) {
+			select {
+			case ch <- PageResult[T]{Items: p.Page()}:
+			case <-ctx.Done():
+				return
+			}
+		}
+		if err := p.Err(); err != nil {
+			select {
+			case ch <- PageResult[T]{Err: err}:
+			case <-ctx.Done():
+			}
+		}
+	}()
+	return ch
+}
@@ -0,0 +1,139 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func pagesFetcher(pages [][]int, failOn int) PageFunc[int] {
+	return func(_ context.Context, page int) ([]int, bool, error) {
+		if page == failOn {
+			return nil, false, errors.New("oops")
+		}
+		return pages[page], page < len(pages)-1, nil
+	}
+}
+
+func TestPager(t *testing.T) {
+	tests := map[string]struct {
+		pages     [][]int
+		failOn    int
+		expected  [][]int
+		withError bool
+	}{
+		"single page": {
+			pages:    [][]int{{1, 2}},
+			failOn:   -1,
+			expected: [][]int{{1, 2}},
+		},
+		"multiple pages": {
+			pages:    [][]int{{1, 2}, {3, 4}, {5}},
+			failOn:   -1,
+			expected: [][]int{{1, 2}, {3, 4}, {5}},
+		},
+		"error on second page": {
+			pages:     [][]int{{1, 2}, {3, 4}},
+			failOn:    1,
+			expected:  [][]int{{1, 2}},
+			withError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := NewPager(pagesFetcher(test.pages, test.failOn))
+			var pages [][]int
+			for p.Next(context.Background()) {
+				pages = append(pages, p.Page())
+			}
+			assert.Equal(t, test.expected, pages)
+			assert.Nil(t, p.Page())
+			assert.False(t, p.Next(context.Background()))
+			if test.withError {
+				assert.Error(t, p.Err())
+				return
+			}
+			assert.NoError(t, p.Err())
+		})
+	}
+}
+
+func TestItemIterator(t *testing.T) {
+	tests := map[string]struct {
+		pages     [][]int
+		failOn    int
+		expected  []int
+		withError bool
+	}{
+		"multiple pages": {
+			pages:    [][]int{{1, 2}, {3, 4}, {5}},
+			failOn:   -1,
+			expected: []int{1, 2, 3, 4, 5},
+		},
+		"empty pages skipped": {
+			pages:    [][]int{{}, {1}, {}, {2, 3}, {}},
+			failOn:   -1,
+			expected: []int{1, 2, 3},
+		},
+		"error on second page": {
+			pages:     [][]int{{1, 2}, {3, 4}},
+			failOn:    1,
+			expected:  []int{1, 2},
+			withError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			it := NewItemIterator(NewPager(pagesFetcher(test.pages, test.failOn)))
+			var items []int
+			for it.Next(context.Background()) {
+				items = append(items, it.Item())
+			}
+			assert.Equal(t, test.expected, items)
+			assert.Zero(t, it.Item())
+			assert.False(t, it.Next(context.Background()))
+			if test.withError {
+				assert.Error(t, it.Err())
+				return
+			}
+			assert.NoError(t, it.Err())
+		})
+	}
+}
+
+func TestCollectPages(t *testing.T) {
+	items, err := CollectPages(context.Background(), NewPager(pagesFetcher([][]int{{1, 2}, {3}}, -1)))
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, items)
+
+	_, err = CollectPages(context.Background(), NewPager(pagesFetcher([][]int{{1, 2}, {3}}, 1)))
+	assert.Error(t, err)
+}
+
+func TestStreamPages(t *testing.T) {
+	var (
+		pages [][]int
+		err   error
+	)
+	for res := range StreamPages(context.Background(), NewPager(pagesFetcher([][]int{{1}, {2}, {3}}, 2))) {
+		if res.Err != nil {
+			err = res.Err
+			continue
+		}
+		pages = append(pages, res.Items)
+	}
+	assert.Equal(t, [][]int{{1}, {2}}, pages)
+	assert.Error(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := StreamPages(ctx, NewPager(pagesFetcher([][]int{{1}, {2}, {3}}, -1)))
+	<-ch
+	cancel()
+	for range ch {
+	}
+}
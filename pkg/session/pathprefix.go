@@ -0,0 +1,77 @@
+package session
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// pathPrefix replaces the from prefix of request paths with to
+type pathPrefix struct {
+	from string
+	to   string
+}
+
+// WithPathPrefix makes the session send requests whose path starts with the from prefix of an API family to the to prefix
+// instead, e.g. to route /appsec/v1 to /appsec/v1-beta, or to /akamai/appsec/v1 on an API gateway fronting Akamai.
+// Prefixes match whole path segments, and when several options match a path, the longest from prefix wins.
+// Paths are rewritten before requests are signed, so the signature covers the path sent.
+func WithPathPrefix(from, to string) Option {
+	return func(s *session) {
+		from = normalizePathPrefix(from)
+		if from == "" {
+			return
+		}
+		s.pathPrefixes = append(s.pathPrefixes, pathPrefix{from: from, to: normalizePathPrefix(to)})
+		sort.SliceStable(s.pathPrefixes, func(i, j int) bool {
+			return len(s.pathPrefixes[i].from) > len(s.pathPrefixes[j].from)
+		})
+	}
+}
+
+// normalizePathPrefix returns prefix with a leading slash and without trailing ones, or an empty string for the root
+func normalizePathPrefix(prefix string) string {
+	prefix = strings.TrimRight(strings.TrimSpace(prefix), "/")
+	if prefix != "" && !strings.HasPrefix(prefix, "/") {
+		prefix = "/" + prefix
+	}
+	return prefix
+}
+
+// rewritePath replaces the prefix of the request path matching the longest path prefix of the session
+func (s *session) rewritePath(r *http.Request) {
+	for _, p := range s.pathPrefixes {
+		path, ok := replacePathPrefix(r.URL.Path, p)
+		if !ok {
+			continue
+		}
+		r.URL.Path = path
+		if r.URL.RawPath != "" {
+			if rawPath, ok := replacePathPrefix(r.URL.RawPath, p); ok {
+				r.URL.RawPath = rawPath
+			} else {
+				r.URL.RawPath = ""
+			}
+		}
+		return
+	}
+}
+
+func replacePathPrefix(path string, p pathPrefix) (string, bool) {
+	if !hasPathPrefix(path, p.from) {
+		return path, false
+	}
+	if strings.HasPrefix(p.to, p.from+"/") && hasPathPrefix(path, p.to) {
+		// already rewritten, e.g. by a previous attempt of a retried request
+		return path, false
+	}
+	path = p.to + strings.TrimPrefix(path, p.from)
+	if path == "" {
+		path = "/"
+	}
+	return path, true
+}
+
+func hasPathPrefix(path, prefix string) bool {
+	return path == prefix || strings.HasPrefix(path, prefix+"/")
+}
@@ -0,0 +1,112 @@
+package session
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSession_PathPrefix(t *testing.T) {
+	tests := map[string]struct {
+		prefixes     [][2]string
+		path         string
+		expectedPath string
+	}{
+		"beta endpoint": {
+			prefixes:     [][2]string{{"/appsec/v1", "/appsec/v1-beta"}},
+			path:         "/appsec/v1/configs/43253/versions",
+			expectedPath: "/appsec/v1-beta/configs/43253/versions",
+		},
+		"gateway prefix": {
+			prefixes:     [][2]string{{"appsec/v1/", "/gateway/akamai/appsec/v1"}},
+			path:         "/appsec/v1/configs",
+			expectedPath: "/gateway/akamai/appsec/v1/configs",
+		},
+		"whole path": {
+			prefixes:     [][2]string{{"/appsec/v1", "/appsec/v1-beta"}},
+			path:         "/appsec/v1",
+			expectedPath: "/appsec/v1-beta",
+		},
+		"prefix removed": {
+			prefixes:     [][2]string{{"/gateway/papi/v1", "/papi/v1"}, {"/gateway", ""}},
+			path:         "/gateway/appsec/v1/configs",
+			expectedPath: "/appsec/v1/configs",
+		},
+		"longest prefix wins": {
+			prefixes:     [][2]string{{"/appsec", "/gateway/appsec"}, {"/appsec/v1", "/appsec/v1-beta"}},
+			path:         "/appsec/v1/configs",
+			expectedPath: "/appsec/v1-beta/configs",
+		},
+		"partial segment does not match": {
+			prefixes:     [][2]string{{"/appsec/v1", "/appsec/v1-beta"}},
+			path:         "/appsec/v10/configs",
+			expectedPath: "/appsec/v10/configs",
+		},
+		"other API family": {
+			prefixes:     [][2]string{{"/appsec/v1", "/appsec/v1-beta"}},
+			path:         "/papi/v1/properties",
+			expectedPath: "/papi/v1/properties",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var path string
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				path = r.URL.Path
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer mockServer.Close()
+			serverURL, err := url.Parse(mockServer.URL)
+			require.NoError(t, err)
+
+			opts := []Option{WithSigner(&edgegrid.Config{Host: serverURL.Host}), WithClient(mockServer.Client())}
+			for _, p := range test.prefixes {
+				opts = append(opts, WithPathPrefix(p[0], p[1]))
+			}
+			s, err := New(opts...)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodGet, test.path, nil)
+			require.NoError(t, err)
+			_, err = s.Exec(req, nil)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedPath, path)
+		})
+	}
+}
+
+func TestSession_PathPrefixRetries(t *testing.T) {
+	var paths []string
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if len(paths) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer mockServer.Close()
+	serverURL, err := url.Parse(mockServer.URL)
+	require.NoError(t, err)
+
+	s, err := New(
+		WithSigner(&edgegrid.Config{Host: serverURL.Host}),
+		WithClient(mockServer.Client()),
+		WithPathPrefix("/appsec/v1", "/appsec/v1/beta"),
+	)
+	require.NoError(t, err)
+	s = ClientOptions{Retries: 1, Backoff: FixedBackoff(time.Millisecond)}.Apply(s)
+
+	req, err := http.NewRequest(http.MethodGet, "/appsec/v1/configs", nil)
+	require.NoError(t, err)
+	_, err = s.Exec(req, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/appsec/v1/beta/configs", "/appsec/v1/beta/configs"}, paths)
+}
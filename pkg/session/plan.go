@@ -0,0 +1,132 @@
+package session
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+type (
+	// Plan collects mutating requests captured by a session in plan mode, see WithPlan.
+	// It is safe for concurrent use.
+	Plan struct {
+		mu      sync.Mutex
+		changes []PlannedChange
+	}
+
+	// PlannedChange is a single mutating request captured instead of being executed
+	PlannedChange struct {
+		// Method is the HTTP method of the request
+		Method string `json:"method"`
+		// Path is the request path including the query string
+		Path string `json:"path"`
+		// Resource is the path of the resource targeted by the request
+		Resource string `json:"resource"`
+		// Body is the JSON body which would have been sent, if any
+		Body json.RawMessage `json:"body,omitempty"`
+	}
+)
+
+var (
+	// ErrPlanned is returned by Exec for mutating requests captured into a Plan instead of being executed
+	ErrPlanned = errors.New("request captured in plan, not executed")
+)
+
+// NewPlan returns an empty plan
+func NewPlan() *Plan {
+	return &Plan{}
+}
+
+// WithPlan puts the session into plan mode. GET, HEAD and OPTIONS requests are executed as usual,
+// while POST, PUT, PATCH and DELETE requests are appended to the plan and Exec returns ErrPlanned for them.
+func WithPlan(plan *Plan) Option {
+	return func(s *session) {
+		s.plan = plan
+	}
+}
+
+// Changes returns the captured changes in the order they were requested
+func (p *Plan) Changes() []PlannedChange {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([]PlannedChange(nil), p.changes...)
+}
+
+// Len returns the number of captured changes
+func (p *Plan) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return len(p.changes)
+}
+
+// String returns a human-readable summary of the plan
+func (p *Plan) String() string {
+	changes := p.Changes()
+	if len(changes) == 0 {
+		return "No changes."
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d change(s) planned:\n", len(changes))
+	for i, c := range changes {
+		fmt.Fprintf(&b, "\n%d. %s %s\n", i+1, c.Method, c.Path)
+		if len(c.Body) == 0 {
+			continue
+		}
+		var body interface{}
+		if err := json.Unmarshal(c.Body, &body); err != nil {
+			fmt.Fprintf(&b, "%s\n", c.Body)
+			continue
+		}
+		indented, err := json.MarshalIndent(body, "   ", "  ")
+		if err != nil {
+			fmt.Fprintf(&b, "%s\n", c.Body)
+			continue
+		}
+		fmt.Fprintf(&b, "   %s\n", indented)
+	}
+	return b.String()
+}
+
+func (p *Plan) add(r *http.Request, body []byte) error {
+	change := PlannedChange{
+		Method:   r.Method,
+		Path:     r.URL.RequestURI(),
+		Resource: r.URL.Path,
+	}
+	if body == nil && r.Body != nil {
+		// body was set directly on the request instead of being passed to Exec
+		data, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrMarshaling, err)
+		}
+		body = data
+	}
+	if len(body) > 0 {
+		if !json.Valid(body) {
+			// keep non-JSON bodies as JSON strings so that the plan itself can be marshaled
+			body, _ = json.Marshal(string(body))
+		}
+		change.Body = append(json.RawMessage(nil), body...)
+	}
+
+	p.mu.Lock()
+	p.changes = append(p.changes, change)
+	p.mu.Unlock()
+
+	return fmt.Errorf("%w: %s %s", ErrPlanned, change.Method, change.Path)
+}
+
+func isMutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+package session
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSession_ExecWithPlan(t *testing.T) {
+	var executed []string
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		executed = append(executed, r.Method+" "+r.URL.String())
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"a":"text","b":1}`))
+		assert.NoError(t, err)
+	}))
+	defer mockServer.Close()
+	serverURL, err := url.Parse(mockServer.URL)
+	require.NoError(t, err)
+
+	plan := NewPlan()
+	s, err := New(
+		WithSigner(&edgegrid.Config{Host: serverURL.Host}),
+		WithClient(mockServer.Client()),
+		WithPlan(plan),
+	)
+	require.NoError(t, err)
+
+	// reads are executed
+	req, err := http.NewRequest(http.MethodGet, "/test/resource", nil)
+	require.NoError(t, err)
+	var out testStruct
+	_, err = s.Exec(req, &out)
+	require.NoError(t, err)
+	assert.Equal(t, testStruct{A: "text", B: 1}, out)
+
+	// writes are captured
+	req, err = http.NewRequest(http.MethodPut, "/test/resource/1?version=2", nil)
+	require.NoError(t, err)
+	_, err = s.Exec(req, &out, testStruct{A: "new", B: 2})
+	assert.True(t, errors.Is(err, ErrPlanned), "want: %s; got: %s", ErrPlanned, err)
+
+	req, err = http.NewRequest(http.MethodPost, "/test/resource", bytes.NewBufferString("plain text"))
+	require.NoError(t, err)
+	_, err = s.Exec(req, nil)
+	assert.True(t, errors.Is(err, ErrPlanned), "want: %s; got: %s", ErrPlanned, err)
+
+	req, err = http.NewRequest(http.MethodDelete, "/test/resource/1", nil)
+	require.NoError(t, err)
+	_, err = s.Exec(req, nil)
+	assert.True(t, errors.Is(err, ErrPlanned), "want: %s; got: %s", ErrPlanned, err)
+
+	assert.Equal(t, []string{"GET /test/resource"}, executed)
+	assert.Equal(t, 3, plan.Len())
+	assert.Equal(t, []PlannedChange{
+		{Method: http.MethodPut, Path: "/test/resource/1?version=2", Resource: "/test/resource/1", Body: json.RawMessage(`{"a":"new","b":2}`)},
+		{Method: http.MethodPost, Path: "/test/resource", Resource: "/test/resource", Body: json.RawMessage(`"plain text"`)},
+		{Method: http.MethodDelete, Path: "/test/resource/1", Resource: "/test/resource/1"},
+	}, plan.Changes())
+
+	_, err = json.Marshal(plan.Changes())
+	assert.NoError(t, err)
+}
+
+func TestPlan_String(t *testing.T) {
+	plan := NewPlan()
+	assert.Equal(t, "No changes.", plan.String())
+
+	plan.changes = []PlannedChange{
+		{Method: http.MethodPut, Path: "/test/resource/1", Body: json.RawMessage(`{"a":"new"}`)},
+		{Method: http.MethodDelete, Path: "/test/resource/2"},
+	}
+	assert.Equal(t, `2 change(s) planned:
+
+1. PUT /test/resource/1
+   {
+     "a": "new"
+   }
+
+2. DELETE /test/resource/2
+`, plan.String())
+}
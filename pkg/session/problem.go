@@ -0,0 +1,162 @@
+package session
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+)
+
+// Problem holds the problem details (RFC 7807) returned by the APIs on errors. The API errors of all service packages
+// can be converted into it with errors.As, so callers can inspect errors regardless of the service, e.g.:
+//
+//	var problem *session.Problem
+//	if errors.As(err, &problem) {
+//		for _, e := range problem.Errors {
+//			log.Printf("%s: %s", e.Title, e.Detail)
+//		}
+//	}
+type Problem struct {
+	// Type is a URI identifying the problem type
+	Type string `json:"type,omitempty"`
+	// Title is a short summary of the problem type
+	Title string `json:"title,omitempty"`
+	// Detail explains this occurrence of the problem
+	Detail string `json:"detail,omitempty"`
+	// Instance is a URI identifying this occurrence of the problem
+	Instance string `json:"instance,omitempty"`
+	// Status is the HTTP status code of the response
+	Status int `json:"status,omitempty"`
+	// Errors are the nested problems reported by some APIs, e.g. for every invalid field
+	Errors []Problem `json:"errors,omitempty"`
+	// Extensions are the other members of the problem, e.g. behaviorName or errorLocation
+	Extensions map[string]json.RawMessage `json:"-"`
+	// RequestID is the ID Akamai assigned to the failed request, if known
+	RequestID string `json:"-"`
+	// ClientRequestID is the ID the session sent with the failed request, if known
+	ClientRequestID string `json:"-"`
+	// Method is the HTTP method of the failed request, if known
+	Method string `json:"-"`
+	// URL is the URL of the failed request, if known
+	URL string `json:"-"`
+}
+
+// ParseProblem returns the problem details of an error response. The body is restored, so it can still be read
+// by the caller. Bodies which are not problem details result in a Problem with the status code and the body as detail.
+func ParseProblem(resp *http.Response) *Problem {
+	metadata := Metadata(resp)
+	p := &Problem{RequestID: metadata.RequestID, ClientRequestID: metadata.ClientRequestID}
+	if resp.Request != nil && resp.Request.URL != nil {
+		p.Method, p.URL = resp.Request.Method, resp.Request.URL.String()
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		p.Title = "Failed to read error body"
+		p.Detail = err.Error()
+	} else if err := json.Unmarshal(body, p); err != nil {
+		p.Title = http.StatusText(resp.StatusCode)
+		p.Detail = string(body)
+	}
+	p.Status = resp.StatusCode
+	return p
+}
+
+// AsProblem converts a problem details error e of a service package into the Problem target points to,
+// if target is a **Problem. The status code is used unless the JSON encoding of e contains one.
+// It is meant to be used in As methods of the API error types.
+func AsProblem(e interface{}, statusCode int, target interface{}) bool {
+	t, ok := target.(**Problem)
+	if !ok {
+		return false
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return false
+	}
+	var p Problem
+	if err := json.Unmarshal(data, &p); err != nil {
+		return false
+	}
+	if p.Status == 0 {
+		p.Status = statusCode
+	}
+	*t = &p
+	return true
+}
+
+// UnmarshalJSON decodes the standard members of the problem, keeping the other ones in Extensions.
+// The status code is also read from statusCode and httpStatus members, used by some APIs.
+func (p *Problem) UnmarshalJSON(data []byte) error {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return err
+	}
+
+	for name, target := range map[string]*string{"type": &p.Type, "title": &p.Title, "detail": &p.Detail, "instance": &p.Instance} {
+		if raw, ok := members[name]; ok {
+			if err := json.Unmarshal(raw, target); err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+			delete(members, name)
+		}
+	}
+	for _, name := range []string{"status", "statusCode", "httpStatus"} {
+		raw, ok := members[name]
+		if !ok {
+			continue
+		}
+		if status, err := strconv.Atoi(string(bytes.Trim(raw, `"`))); err == nil {
+			if p.Status == 0 {
+				p.Status = status
+			}
+			delete(members, name)
+		}
+	}
+	if raw, ok := members["errors"]; ok {
+		// APIs not following the problem details format for nested errors keep them as an extension
+		if err := json.Unmarshal(raw, &p.Errors); err == nil {
+			delete(members, "errors")
+		} else {
+			p.Errors = nil
+		}
+	}
+	if len(members) > 0 {
+		p.Extensions = members
+	}
+	return nil
+}
+
+// Error returns a string formatted using the title, type, and detail of the problem, followed by the failed request,
+// its status code and the request IDs if known
+func (p *Problem) Error() string {
+	msg := fmt.Sprintf("Title: %s; Type: %s; Detail: %s", p.Title, p.Type, p.Detail)
+	if p.Method != "" {
+		msg += fmt.Sprintf("; Request: %s %s; Status: %d", p.Method, p.URL, p.Status)
+	}
+	if p.RequestID != "" {
+		msg += fmt.Sprintf("; Request ID: %s", p.RequestID)
+	}
+	if p.ClientRequestID != "" {
+		msg += fmt.Sprintf("; Client Request ID: %s", p.ClientRequestID)
+	}
+	return msg
+}
+
+// Is matches the sentinel error of the status code, see StatusError, and problems with the same status code,
+// type, title and detail
+func (p *Problem) Is(target error) bool {
+	if MatchStatus(p.Status, target) {
+		return true
+	}
+	var t *Problem
+	if !errors.As(target, &t) {
+		return false
+	}
+	return p == t || (p.Status == t.Status && p.Type == t.Type && p.Title == t.Title && p.Detail == t.Detail)
+}
@@ -0,0 +1,121 @@
+package session
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseProblem(t *testing.T) {
+	tests := map[string]struct {
+		body     string
+		header   http.Header
+		request  *http.Request
+		expected *Problem
+	}{
+		"problem details": {
+			body: `{"type":"/appsec/error-types/invalid-input","title":"Invalid Input","detail":"Invalid match target","instance":"/appsec/errors/1","status":400,
+				"errors":[{"type":"/appsec/error-types/invalid-field","title":"Invalid Field","detail":"hostnames is required"}],"behaviorName":"origin"}`,
+			header: http.Header{"X-Trace-Id": []string{"trace-123"}},
+			expected: &Problem{
+				Type:     "/appsec/error-types/invalid-input",
+				Title:    "Invalid Input",
+				Detail:   "Invalid match target",
+				Instance: "/appsec/errors/1",
+				Status:   http.StatusBadRequest,
+				Errors: []Problem{
+					{Type: "/appsec/error-types/invalid-field", Title: "Invalid Field", Detail: "hostnames is required"},
+				},
+				Extensions: map[string]json.RawMessage{"behaviorName": json.RawMessage(`"origin"`)},
+				RequestID:  "trace-123",
+			},
+		},
+		"nested errors in another format": {
+			body: `{"title":"Invalid Input","statusCode":400,"errors":["first","second"]}`,
+			expected: &Problem{
+				Title:      "Invalid Input",
+				Status:     http.StatusBadRequest,
+				Extensions: map[string]json.RawMessage{"errors": json.RawMessage(`["first","second"]`)},
+			},
+		},
+		"not a problem": {
+			body: `upstream unavailable`,
+			expected: &Problem{
+				Title:  "Bad Request",
+				Detail: "upstream unavailable",
+				Status: http.StatusBadRequest,
+			},
+		},
+		"request context": {
+			body:    `{"title":"Not Found","status":404}`,
+			request: httptest.NewRequest(http.MethodDelete, "https://akab-host.luna.akamaiapis.net/appsec/v1/configs/43253", nil),
+			expected: &Problem{
+				Title:  "Not Found",
+				Status: http.StatusBadRequest,
+				Method: http.MethodDelete,
+				URL:    "https://akab-host.luna.akamaiapis.net/appsec/v1/configs/43253",
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := &http.Response{
+				StatusCode: http.StatusBadRequest,
+				Header:     test.header,
+				Body:       ioutil.NopCloser(strings.NewReader(test.body)),
+				Request:    test.request,
+			}
+			problem := ParseProblem(resp)
+			assert.Equal(t, test.expected, problem)
+
+			body, err := ioutil.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, test.body, string(body))
+		})
+	}
+}
+
+func TestProblem_Is(t *testing.T) {
+	problem := &Problem{Type: "a", Title: "b", Detail: "c", Status: http.StatusNotFound, RequestID: "trace-123"}
+
+	assert.True(t, errors.Is(problem, ErrNotFound))
+	assert.False(t, errors.Is(problem, ErrConflict))
+	assert.True(t, errors.Is(problem, &Problem{Type: "a", Title: "b", Detail: "c", Status: http.StatusNotFound}))
+	assert.False(t, errors.Is(problem, &Problem{Type: "a", Title: "b", Detail: "d", Status: http.StatusNotFound}))
+	assert.Equal(t, "Title: b; Type: a; Detail: c; Request ID: trace-123", problem.Error())
+
+	problem.Method, problem.URL = http.MethodGet, "https://akab-host.luna.akamaiapis.net/appsec/v1/configs"
+	assert.Equal(t, "Title: b; Type: a; Detail: c; Request: GET https://akab-host.luna.akamaiapis.net/appsec/v1/configs; Status: 404; Request ID: trace-123", problem.Error())
+	assert.True(t, errors.Is(problem, &Problem{Type: "a", Title: "b", Detail: "c", Status: http.StatusNotFound}))
+}
+
+func TestAsProblem(t *testing.T) {
+	type apiError struct {
+		Type          string `json:"type"`
+		Title         string `json:"title"`
+		Detail        string `json:"detail"`
+		ErrorLocation string `json:"errorLocation,omitempty"`
+		StatusCode    int    `json:"-"`
+	}
+	e := &apiError{Type: "a", Title: "b", Detail: "c", ErrorLocation: "/rules", StatusCode: http.StatusConflict}
+
+	var problem *Problem
+	require.True(t, AsProblem(e, e.StatusCode, &problem))
+	assert.Equal(t, &Problem{
+		Type:       "a",
+		Title:      "b",
+		Detail:     "c",
+		Status:     http.StatusConflict,
+		Extensions: map[string]json.RawMessage{"errorLocation": json.RawMessage(`"/rules"`)},
+	}, problem)
+
+	var other *apiError
+	assert.False(t, AsProblem(e, e.StatusCode, &other))
+}
@@ -0,0 +1,125 @@
+// Package prommetrics provides a Prometheus implementation of session.Metrics
+package prommetrics
+
+import (
+	"strconv"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/session"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type (
+	// Metrics records the metrics of sessions in Prometheus collectors. It implements prometheus.Collector,
+	// so it can be registered directly, and session.Metrics, to be passed to session.WithMetrics.
+	Metrics struct {
+		requests   *prometheus.CounterVec
+		latency    *prometheus.HistogramVec
+		retries    *prometheus.CounterVec
+		rejected   *prometheus.CounterVec
+		throttling *prometheus.CounterVec
+	}
+
+	// Options configures the collectors
+	Options struct {
+		// Namespace prefixes the metric names, akamai by default
+		Namespace string
+		// Buckets are the buckets of the latency histogram, prometheus.DefBuckets by default
+		Buckets []float64
+		// ConstLabels are added to all metrics, e.g. the name of the service
+		ConstLabels prometheus.Labels
+	}
+)
+
+var endpointLabels = []string{"family", "method", "route"}
+
+// New returns metrics with the following collectors:
+//
+//   - akamai_requests_total, counting requests by endpoint and status code, "error" for transport errors
+//   - akamai_request_duration_seconds, a histogram of request latencies by endpoint
+//   - akamai_retries_total, counting retries by endpoint
+//   - akamai_rate_limited_total, counting requests rejected with 429 Too Many Requests by endpoint
+//   - akamai_throttled_seconds_total, the time requests were held by the session rate limit throttling, by endpoint
+func New(opts Options) *Metrics {
+	if opts.Namespace == "" {
+		opts.Namespace = "akamai"
+	}
+	if opts.Buckets == nil {
+		opts.Buckets = prometheus.DefBuckets
+	}
+
+	return &Metrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   opts.Namespace,
+			Name:        "requests_total",
+			Help:        "Number of requests sent to the Akamai APIs.",
+			ConstLabels: opts.ConstLabels,
+		}, append(endpointLabels, "code")),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   opts.Namespace,
+			Name:        "request_duration_seconds",
+			Help:        "Latency of the requests sent to the Akamai APIs.",
+			Buckets:     opts.Buckets,
+			ConstLabels: opts.ConstLabels,
+		}, endpointLabels),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   opts.Namespace,
+			Name:        "retries_total",
+			Help:        "Number of retried requests to the Akamai APIs.",
+			ConstLabels: opts.ConstLabels,
+		}, endpointLabels),
+		rejected: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   opts.Namespace,
+			Name:        "rate_limited_total",
+			Help:        "Number of requests rejected by the rate limit of the Akamai APIs.",
+			ConstLabels: opts.ConstLabels,
+		}, endpointLabels),
+		throttling: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   opts.Namespace,
+			Name:        "throttled_seconds_total",
+			Help:        "Time requests were held to respect the rate limit of the Akamai APIs.",
+			ConstLabels: opts.ConstLabels,
+		}, endpointLabels),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
+	for _, c := range m.collectors() {
+		c.Describe(ch)
+	}
+}
+
+// Collect implements prometheus.Collector
+func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
+	for _, c := range m.collectors() {
+		c.Collect(ch)
+	}
+}
+
+// ObserveRequest implements session.Metrics
+func (m *Metrics) ObserveRequest(r session.RequestMetric) {
+	code := "error"
+	if r.Err == nil {
+		code = strconv.Itoa(r.StatusCode)
+	}
+	m.requests.WithLabelValues(r.Family, r.Method, r.Route, code).Inc()
+	m.latency.WithLabelValues(r.Family, r.Method, r.Route).Observe(r.Duration.Seconds())
+}
+
+// ObserveRetry implements session.Metrics
+func (m *Metrics) ObserveRetry(r session.RetryMetric) {
+	m.retries.WithLabelValues(r.Family, r.Method, r.Route).Inc()
+}
+
+// ObserveRateLimit implements session.Metrics
+func (m *Metrics) ObserveRateLimit(r session.RateLimitMetric) {
+	if r.Rejected {
+		m.rejected.WithLabelValues(r.Family, r.Method, r.Route).Inc()
+		return
+	}
+	m.throttling.WithLabelValues(r.Family, r.Method, r.Route).Add(r.Wait.Seconds())
+}
+
+func (m *Metrics) collectors() []prometheus.Collector {
+	return []prometheus.Collector{m.requests, m.latency, m.retries, m.rejected, m.throttling}
+}
@@ -0,0 +1,47 @@
+package prommetrics
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/session"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics(t *testing.T) {
+	m := New(Options{ConstLabels: prometheus.Labels{"service": "test"}})
+	registry := prometheus.NewPedanticRegistry()
+	require.NoError(t, registry.Register(m))
+
+	endpoint := session.Endpoint{Family: "appsec", Method: http.MethodGet, Route: "/appsec/v1/configs/{id}"}
+	m.ObserveRequest(session.RequestMetric{Endpoint: endpoint, StatusCode: http.StatusOK, Duration: 200 * time.Millisecond})
+	m.ObserveRequest(session.RequestMetric{Endpoint: endpoint, Err: errors.New("connection reset"), Duration: time.Second})
+	m.ObserveRetry(session.RetryMetric{Endpoint: endpoint, Attempt: 1, Delay: time.Second})
+	m.ObserveRateLimit(session.RateLimitMetric{Endpoint: endpoint, Rejected: true})
+	m.ObserveRateLimit(session.RateLimitMetric{Endpoint: endpoint, Wait: 1500 * time.Millisecond})
+
+	expected := `
+# HELP akamai_rate_limited_total Number of requests rejected by the rate limit of the Akamai APIs.
+# TYPE akamai_rate_limited_total counter
+akamai_rate_limited_total{family="appsec",method="GET",route="/appsec/v1/configs/{id}",service="test"} 1
+# HELP akamai_requests_total Number of requests sent to the Akamai APIs.
+# TYPE akamai_requests_total counter
+akamai_requests_total{code="200",family="appsec",method="GET",route="/appsec/v1/configs/{id}",service="test"} 1
+akamai_requests_total{code="error",family="appsec",method="GET",route="/appsec/v1/configs/{id}",service="test"} 1
+# HELP akamai_retries_total Number of retried requests to the Akamai APIs.
+# TYPE akamai_retries_total counter
+akamai_retries_total{family="appsec",method="GET",route="/appsec/v1/configs/{id}",service="test"} 1
+# HELP akamai_throttled_seconds_total Time requests were held to respect the rate limit of the Akamai APIs.
+# TYPE akamai_throttled_seconds_total counter
+akamai_throttled_seconds_total{family="appsec",method="GET",route="/appsec/v1/configs/{id}",service="test"} 1.5
+`
+	assert.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected),
+		"akamai_rate_limited_total", "akamai_requests_total", "akamai_retries_total", "akamai_throttled_seconds_total"))
+	assert.Equal(t, 1, testutil.CollectAndCount(m, "akamai_request_duration_seconds"))
+}
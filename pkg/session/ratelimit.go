@@ -0,0 +1,200 @@
+package session
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type (
+	// rateLimiter tracks the rate limits reported by the APIs for every API family, see WithRateLimitThrottling
+	rateLimiter struct {
+		reserve int
+
+		mu     sync.Mutex
+		limits map[string]rateLimit
+	}
+
+	// rateLimit is the state of the rate limit of an API family, as of its last response
+	rateLimit struct {
+		remaining int
+		reset     time.Time
+	}
+
+	// RateLimitEvent describes a 429 Too Many Requests response the session waits out before repeating the request,
+	// see WithRateLimitRetries
+	RateLimitEvent struct {
+		Method string
+		Path   string
+		// Attempt is the number of the repetition of the request about to be made, starting at 1
+		Attempt int
+		// Wait is the time the session sleeps before repeating the request, as advised by the Retry-After
+		// or rate limit headers of the response
+		Wait time.Duration
+	}
+
+	// RateLimitHandler is called before the session waits out a 429 Too Many Requests response, see WithRateLimitRetries
+	RateLimitHandler func(ctx context.Context, e RateLimitEvent)
+)
+
+// WithRateLimitThrottling makes the session track the X-RateLimit-Remaining and X-RateLimit-Reset (or X-RateLimit-Next)
+// headers returned by the APIs, and hold requests to an API family, e.g. /appsec, once no more than reserve requests
+// remain, until the limit resets. This keeps bulk reads, such as iterating attack groups across many policies,
+// from tripping the server limits.
+func WithRateLimitThrottling(reserve int) Option {
+	return func(s *session) {
+		if reserve < 0 {
+			reserve = 0
+		}
+		s.rateLimiter = &rateLimiter{reserve: reserve, limits: make(map[string]rateLimit)}
+	}
+}
+
+// WithRateLimitRetries makes the session repeat requests rejected with 429 Too Many Requests up to maxRetries times,
+// after sleeping the interval advised by the Retry-After header, or by the X-RateLimit-Reset or X-RateLimit-Next headers,
+// or an exponential backoff if the response has none. Since rejected requests were not processed, requests of all
+// methods are repeated, unlike with the retries of ClientOptions. The handler, if not nil, is called before every wait,
+// so that callers can log or surface the progress of long bulk operations. A response is returned as is
+// when the advised interval ends after the deadline of the request context. Requests still rejected after maxRetries
+// are not repeated again by the retries of ClientOptions or WithRetryPolicy.
+func WithRateLimitRetries(maxRetries int, handler RateLimitHandler) Option {
+	return func(s *session) {
+		s.rateLimitRetries = maxRetries
+		s.rateLimitHandler = handler
+	}
+}
+
+// wait blocks until a request can be sent to the API family of r, or until ctx is done,
+// and returns the time it waited
+func (l *rateLimiter) wait(ctx context.Context, r *http.Request) (time.Duration, error) {
+	if l == nil {
+		return 0, nil
+	}
+	var waited time.Duration
+	for {
+		l.mu.Lock()
+		limit, ok := l.limits[apiFamily(r)]
+		l.mu.Unlock()
+		if !ok || limit.remaining > l.reserve {
+			return waited, nil
+		}
+		delay := time.Until(limit.reset)
+		if delay <= 0 {
+			return waited, nil
+		}
+
+		start := time.Now()
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return waited + time.Since(start), ctx.Err()
+		case <-timer.C:
+			waited += time.Since(start)
+		}
+	}
+}
+
+// update records the rate limit reported by the response; responses without the headers leave the state unchanged
+func (l *rateLimiter) update(r *http.Request, resp *http.Response, now time.Time) {
+	if l == nil || resp == nil {
+		return
+	}
+	remaining, err := strconv.Atoi(strings.TrimSpace(resp.Header.Get("X-RateLimit-Remaining")))
+	if err != nil {
+		return
+	}
+	reset, ok := rateLimitReset(resp.Header, now)
+	if !ok {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.limits[apiFamily(r)] = rateLimit{remaining: remaining, reset: reset}
+}
+
+// rateLimitReset returns the time the rate limit resets at, from the X-RateLimit-Reset header in seconds, as a Unix time
+// or an HTTP date, or from the X-RateLimit-Next header as an RFC 3339 time
+func rateLimitReset(h http.Header, now time.Time) (time.Time, bool) {
+	if value := strings.TrimSpace(h.Get("X-RateLimit-Reset")); value != "" {
+		if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+			// small values are a number of seconds rather than a Unix time
+			if seconds < 1e9 {
+				return now.Add(time.Duration(seconds) * time.Second), true
+			}
+			return time.Unix(seconds, 0), true
+		}
+		if at, err := http.ParseTime(value); err == nil {
+			return at, true
+		}
+	}
+	if value := strings.TrimSpace(h.Get("X-RateLimit-Next")); value != "" {
+		if at, err := time.Parse(time.RFC3339Nano, value); err == nil {
+			return at, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// apiFamily returns the first segment of the request path, e.g. appsec, which APIs apply their rate limits to
+func apiFamily(r *http.Request) string {
+	path := strings.TrimPrefix(r.URL.Path, "/")
+	if i := strings.Index(path, "/"); i >= 0 {
+		path = path[:i]
+	}
+	return path
+}
+
+// waitRateLimit waits out the 429 response to r before its attempt-th repetition, and prepares the request
+// to be sent again; it returns false if the request is not to be repeated
+func (s *session) waitRateLimit(r *http.Request, resp *http.Response, attempt int) (bool, error) {
+	if resp.StatusCode != http.StatusTooManyRequests || attempt > s.rateLimitRetries {
+		return false, nil
+	}
+	if r.Body != nil && r.Body != http.NoBody && r.GetBody == nil {
+		return false, nil
+	}
+	now := time.Now()
+	wait := retryAfter(resp, now)
+	if wait <= 0 {
+		if reset, ok := rateLimitReset(resp.Header, now); ok {
+			wait = reset.Sub(now)
+		}
+	}
+	if wait <= 0 {
+		wait = ExponentialBackoff{}.Delay(attempt, 0)
+	}
+	if deadline, ok := r.Context().Deadline(); ok && now.Add(wait).After(deadline) {
+		return false, nil
+	}
+
+	if s.rateLimitHandler != nil {
+		s.rateLimitHandler(r.Context(), RateLimitEvent{Method: r.Method, Path: r.URL.Path, Attempt: attempt, Wait: wait})
+	}
+	s.Log(r.Context()).Infof("Rate limit exceeded, repeating %s %s in %s", r.Method, r.URL.Path, wait)
+	resp.Body.Close()
+
+	timer := time.NewTimer(wait)
+	select {
+	case <-r.Context().Done():
+		timer.Stop()
+		return false, r.Context().Err()
+	case <-timer.C:
+	}
+	if s.metrics != nil {
+		s.metrics.ObserveRateLimit(RateLimitMetric{Endpoint: endpoint(r), Wait: wait})
+	}
+
+	if r.GetBody != nil {
+		body, err := r.GetBody()
+		if err != nil {
+			return false, err
+		}
+		r.Body = body
+	}
+	return true, s.Sign(r)
+}
@@ -0,0 +1,254 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSession_RateLimitThrottling(t *testing.T) {
+	const resetAfter = 300 * time.Millisecond
+
+	tests := map[string]struct {
+		reserve       int
+		remaining     int
+		secondPath    string
+		cancelled     bool
+		expectedWait  bool
+		expectedError error
+	}{
+		"requests held when limit is exhausted": {
+			remaining:    0,
+			secondPath:   "/appsec/v1/configs/2",
+			expectedWait: true,
+		},
+		"requests held when reserve is reached": {
+			reserve:      2,
+			remaining:    2,
+			secondPath:   "/appsec/v1/configs/2",
+			expectedWait: true,
+		},
+		"requests sent while limit remains": {
+			remaining:  5,
+			secondPath: "/appsec/v1/configs/2",
+		},
+		"other API families not held": {
+			remaining:  0,
+			secondPath: "/papi/v1/properties",
+		},
+		"held request cancelled": {
+			remaining:     0,
+			secondPath:    "/appsec/v1/configs/2",
+			cancelled:     true,
+			expectedError: context.DeadlineExceeded,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var calls int32
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&calls, 1) == 1 {
+					w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(test.remaining))
+					w.Header().Set("X-RateLimit-Next", time.Now().Add(resetAfter).UTC().Format(time.RFC3339Nano))
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer mockServer.Close()
+			serverURL, err := url.Parse(mockServer.URL)
+			require.NoError(t, err)
+
+			s, err := New(
+				WithSigner(&edgegrid.Config{Host: serverURL.Host}),
+				WithClient(mockServer.Client()),
+				WithRateLimitThrottling(test.reserve),
+			)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodGet, "/appsec/v1/configs/1", nil)
+			require.NoError(t, err)
+			_, err = s.Exec(req, nil)
+			require.NoError(t, err)
+
+			ctx := context.Background()
+			if test.cancelled {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, 10*time.Millisecond)
+				defer cancel()
+			}
+			req, err = http.NewRequestWithContext(ctx, http.MethodGet, test.secondPath, nil)
+			require.NoError(t, err)
+			start := time.Now()
+			_, err = s.Exec(req, nil)
+			if test.expectedError != nil {
+				assert.True(t, errors.Is(err, test.expectedError), "want: %s; got: %s", test.expectedError, err)
+				assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedWait, time.Since(start) >= resetAfter/2)
+		})
+	}
+}
+
+func TestRateLimitReset(t *testing.T) {
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		header     string
+		value      string
+		expected   time.Time
+		expectedOK bool
+	}{
+		"reset in seconds": {
+			header: "X-RateLimit-Reset", value: "30", expected: now.Add(30 * time.Second), expectedOK: true,
+		},
+		"reset as unix time": {
+			header: "X-RateLimit-Reset", value: strconv.FormatInt(now.Add(time.Minute).Unix(), 10), expected: now.Add(time.Minute), expectedOK: true,
+		},
+		"reset as http date": {
+			header: "X-RateLimit-Reset", value: now.Add(time.Minute).Format(http.TimeFormat), expected: now.Add(time.Minute), expectedOK: true,
+		},
+		"next as RFC 3339": {
+			header: "X-RateLimit-Next", value: "2024-03-01T12:00:05.5Z", expected: now.Add(5500 * time.Millisecond), expectedOK: true,
+		},
+		"invalid": {
+			header: "X-RateLimit-Reset", value: "later",
+		},
+		"missing": {},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			h := http.Header{}
+			if test.header != "" {
+				h.Set(test.header, test.value)
+			}
+			reset, ok := rateLimitReset(h, now)
+			assert.Equal(t, test.expectedOK, ok)
+			assert.True(t, test.expected.Equal(reset), "want: %s; got: %s", test.expected, reset)
+		})
+	}
+}
+
+func TestSession_RateLimitRetries(t *testing.T) {
+	tests := map[string]struct {
+		method           string
+		maxRetries       int
+		clientRetries    int
+		rejections       int32
+		advisedWait      time.Duration
+		timeout          time.Duration
+		expectedStatus   int
+		expectedRequests int32
+		expectedEvents   []int
+	}{
+		"POST repeated after advised wait": {
+			maxRetries:       3,
+			rejections:       2,
+			advisedWait:      50 * time.Millisecond,
+			expectedStatus:   http.StatusCreated,
+			expectedRequests: 3,
+			expectedEvents:   []int{1, 2},
+		},
+		"retries exhausted": {
+			maxRetries:       1,
+			rejections:       5,
+			advisedWait:      50 * time.Millisecond,
+			expectedStatus:   http.StatusTooManyRequests,
+			expectedRequests: 2,
+			expectedEvents:   []int{1},
+		},
+		"not retried again by client options": {
+			method:           http.MethodPut,
+			maxRetries:       1,
+			clientRetries:    2,
+			rejections:       5,
+			advisedWait:      50 * time.Millisecond,
+			expectedStatus:   http.StatusTooManyRequests,
+			expectedRequests: 2,
+			expectedEvents:   []int{1},
+		},
+		// the deadline leaves time for the TLS handshake and ends long before the advised wait
+		"advised wait ends after deadline": {
+			maxRetries:       3,
+			rejections:       1,
+			advisedWait:      time.Hour,
+			timeout:          5 * time.Second,
+			expectedStatus:   http.StatusTooManyRequests,
+			expectedRequests: 1,
+		},
+		"not repeated without option": {
+			rejections:       1,
+			advisedWait:      50 * time.Millisecond,
+			expectedStatus:   http.StatusTooManyRequests,
+			expectedRequests: 1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var requests int32
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&requests, 1) <= test.rejections {
+					w.Header().Set("X-RateLimit-Next", time.Now().Add(test.advisedWait).Format(time.RFC3339Nano))
+					w.WriteHeader(http.StatusTooManyRequests)
+					return
+				}
+				w.WriteHeader(http.StatusCreated)
+			}))
+			defer mockServer.Close()
+			serverURL, err := url.Parse(mockServer.URL)
+			require.NoError(t, err)
+
+			var events []RateLimitEvent
+			s, err := New(
+				WithSigner(&edgegrid.Config{Host: serverURL.Host}),
+				WithClient(mockServer.Client()),
+				WithRateLimitRetries(test.maxRetries, func(_ context.Context, e RateLimitEvent) {
+					events = append(events, e)
+				}),
+			)
+			require.NoError(t, err)
+			s = ClientOptions{Retries: test.clientRetries}.Apply(s)
+
+			ctx := context.Background()
+			if test.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, test.timeout)
+				defer cancel()
+			}
+			method := http.MethodPost
+			if test.method != "" {
+				method = test.method
+			}
+			req, err := http.NewRequestWithContext(ctx, method, "/appsec/v1/configs/43253/versions/7/match-targets", nil)
+			require.NoError(t, err)
+			start := time.Now()
+			resp, err := s.Exec(req, nil, map[string]string{"type": "website"})
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedStatus, resp.StatusCode)
+			assert.Equal(t, test.expectedRequests, atomic.LoadInt32(&requests))
+
+			require.Len(t, events, len(test.expectedEvents))
+			for i, attempt := range test.expectedEvents {
+				assert.Equal(t, attempt, events[i].Attempt)
+				assert.Equal(t, method, events[i].Method)
+				assert.Equal(t, "/appsec/v1/configs/43253/versions/7/match-targets", events[i].Path)
+				assert.True(t, events[i].Wait > 0 && events[i].Wait <= test.advisedWait, "unexpected wait: %s", events[i].Wait)
+			}
+			if len(events) > 0 {
+				assert.True(t, time.Since(start) >= time.Duration(len(events))*test.advisedWait/2)
+			}
+		})
+	}
+}
@@ -2,35 +2,86 @@ package session
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/http/httputil"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
+// maxPooledBufferSize limits the size of buffers returned to bufferPool, so that a single large body is not retained
+const maxPooledBufferSize = 1 << 20
+
 var (
+	// bufferPool holds buffers used to read response bodies
+	bufferPool = sync.Pool{
+		New: func() interface{} {
+			return new(bytes.Buffer)
+		},
+	}
+
 	// ErrInvalidArgument is returned when invalid number of arguments were supplied to a function
 	ErrInvalidArgument = errors.New("invalid arguments provided")
 	// ErrMarshaling represents marshaling error
 	ErrMarshaling = errors.New("marshaling input")
 	// ErrUnmarshaling represents unmarshaling error
 	ErrUnmarshaling = errors.New("unmarshaling output")
+	// ErrCredentials is returned when the credential provider of the session fails to provide credentials
+	ErrCredentials = errors.New("providing credentials")
 )
 
 // Exec will sign and execute the request using the client edgegrid.Config
 func (s *session) Exec(r *http.Request, out interface{}, in ...interface{}) (*http.Response, error) {
+	r, cancel := s.withDeadline(r)
+	if s.tracer == nil {
+		resp, err := s.exec(r, out, in...)
+		releaseDeadline(resp, cancel)
+		return resp, err
+	}
+	span := s.startSpan(r)
+	resp, err := s.exec(r, out, in...)
+	endSpan(span, resp, err)
+	releaseDeadline(resp, cancel)
+	return resp, err
+}
+
+func (s *session) exec(r *http.Request, out interface{}, in ...interface{}) (*http.Response, error) {
 	if len(in) > 1 {
 		return nil, fmt.Errorf("%w: %s", ErrInvalidArgument, "'in' argument must have 0 or 1 value")
 	}
 	log := s.Log(r.Context())
 
+	idempotencyKeys := s.idempotencyKeys
 	// Apply any context header overrides
 	if o, ok := r.Context().Value(contextOptionKey).(*contextOptions); ok {
+		idempotencyKeys = idempotencyKeys || o.idempotencyKeys
 		for k, v := range o.header {
 			r.Header[k] = v
 		}
+		if o.idempotencyKey != "" {
+			r.Header.Set(IdempotencyKeyHeader, o.idempotencyKey)
+		}
+		if o.accountSwitchKey != "" {
+			q := r.URL.Query()
+			q.Set("accountSwitchKey", o.accountSwitchKey)
+			r.URL.RawQuery = q.Encode()
+		}
+	}
+	if s.accountSwitchKey != "" && !r.URL.Query().Has("accountSwitchKey") {
+		q := r.URL.Query()
+		q.Set("accountSwitchKey", s.accountSwitchKey)
+		r.URL.RawQuery = q.Encode()
+	}
+	s.rewritePath(r)
+	setClientRequestID(r)
+	if idempotencyKeys && requiresIdempotencyKey(r) {
+		r.Header.Set(IdempotencyKeyHeader, NewIdempotencyKey())
 	}
 
 	r.URL.RawQuery = r.URL.Query().Encode()
@@ -46,18 +97,44 @@ func (s *session) Exec(r *http.Request, out interface{}, in ...interface{}) (*ht
 		r.URL.Scheme = "https"
 	}
 
-	if len(in) > 0 {
+	var body []byte
+	if form, ok := firstMultipartForm(in); ok {
+		setMultipartBody(r, form)
+	} else if len(in) > 0 {
 		data, err := json.Marshal(in[0])
 		if err != nil {
 			return nil, fmt.Errorf("%w: %s", ErrMarshaling, err)
 		}
+		body = data
 
-		r.Body = ioutil.NopCloser(bytes.NewBuffer(data))
+		if compressed, ok := s.compress(r, data); ok {
+			r.Header.Set("Content-Encoding", "gzip")
+			data = compressed
+		}
+		r.Body = ioutil.NopCloser(bytes.NewReader(data))
+		r.GetBody = func() (io.ReadCloser, error) {
+			return ioutil.NopCloser(bytes.NewReader(data)), nil
+		}
 		r.ContentLength = int64(len(data))
 	}
 
-	s.client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
-		return s.Sign(req)
+	if s.plan != nil && isMutating(r.Method) {
+		return nil, s.plan.add(r, body)
+	}
+
+	if s.dryRun {
+		if err := s.Sign(r); err != nil {
+			return nil, err
+		}
+		return nil, s.dryRunError(r)
+	}
+
+	waited, err := s.rateLimiter.wait(r.Context(), r)
+	if waited > 0 && s.metrics != nil {
+		s.metrics.ObserveRateLimit(RateLimitMetric{Endpoint: endpoint(r), Wait: waited})
+	}
+	if err != nil {
+		return nil, err
 	}
 
 	if err := s.Sign(r); err != nil {
@@ -73,43 +150,230 @@ func (s *session) Exec(r *http.Request, out interface{}, in ...interface{}) (*ht
 		}
 	}
 
-	resp, err := s.client.Do(r)
-	if err != nil {
-		return nil, err
+	var (
+		resp     *http.Response
+		respData []byte
+	)
+	ttl := s.cache.ttl(r)
+	if ttl > 0 {
+		if data, ok := s.cache.cache.Get(cacheKey(r)); ok {
+			log.Debugf("Serving %s %s from cache", r.Method, r.URL.Path)
+			resp = cachedResponse(r, data)
+		}
 	}
 
-	if s.trace {
-		data, err := httputil.DumpResponse(resp, true)
+	if resp == nil {
+		var (
+			err   error
+			entry *etagEntry
+		)
+		if s.etags != nil {
+			entry = s.etags.conditional(r)
+		}
+		send := s.handler()
+		start := time.Now()
+		resp, err = send(r)
+		s.audit(r, body, start, resp, err)
+		if err == nil && s.correctClockSkew(r, resp, time.Now()) {
+			start = time.Now()
+			resp, err = send(r)
+			s.audit(r, body, start, resp, err)
+		}
+		for attempt := 1; err == nil; attempt++ {
+			repeat, waitErr := s.waitRateLimit(r, resp, attempt)
+			if waitErr != nil {
+				return nil, waitErr
+			}
+			if !repeat {
+				break
+			}
+			start = time.Now()
+			resp, err = send(r)
+			s.audit(r, body, start, resp, err)
+		}
 		if err != nil {
-			log.WithError(err).Error("Failed to dump response")
-		} else {
-			log.Debug(string(data))
+			return nil, err
+		}
+		if entry != nil {
+			resp = s.etags.revalidated(r, resp, entry)
+		}
+		s.reportDeprecation(r, resp)
+
+		if s.trace {
+			data, err := httputil.DumpResponse(resp, true)
+			if err != nil {
+				log.WithError(err).Error("Failed to dump response")
+			} else {
+				log.Debug(string(data))
+			}
+		}
+
+		cacheable := ttl > 0 && resp.StatusCode == http.StatusOK && !isStreaming(r)
+		storeETag := s.etags.storable(r, resp) && !isStreaming(r)
+		if cacheable || storeETag {
+			respData, err = readBody(resp)
+			if err != nil {
+				return nil, err
+			}
+			if cacheable {
+				s.cache.cache.Set(cacheKey(r), respData, ttl)
+			}
+			if storeETag {
+				s.etags.store(r, resp, respData)
+			}
 		}
 	}
 
 	if out != nil &&
 		resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices &&
 		resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusResetContent {
-		data, err := ioutil.ReadAll(resp.Body)
-		resp.Body = ioutil.NopCloser(bytes.NewBuffer(data))
-		if err != nil {
-			return nil, err
+		if respData == nil && isStreaming(r) {
+			if err := s.decodeStream(resp, out); err != nil {
+				return nil, fmt.Errorf("%w: %s", ErrUnmarshaling, err)
+			}
+			return resp, nil
+		}
+
+		data := respData
+		if data == nil {
+			var err error
+			if data, err = readBody(resp); err != nil {
+				return nil, err
+			}
 		}
 
-		if err := json.Unmarshal(data, out); err != nil {
+		if err := s.decode(data, out); err != nil {
 			return nil, fmt.Errorf("%w: %s", ErrUnmarshaling, err)
 		}
+
+		if s.strict != StrictOff {
+			if err := checkResponse(data, out); err != nil {
+				if s.strict == StrictError {
+					return nil, err
+				}
+				entry := log.WithError(err)
+				var drift *SchemaDriftError
+				if errors.As(err, &drift) && len(drift.UnknownFields) > 0 {
+					entry = entry.WithField("unknownFields", drift.UnknownFields)
+				}
+				entry.Warn("Response does not match SDK type")
+			}
+		}
 	}
 
 	return resp, nil
 }
 
+// target returns the signed request to send, redirected to the base URL set in the request context
+// or else to the base URL of the session
+func (s *session) target(r *http.Request) *http.Request {
+	baseURL := s.baseURL
+	if o, ok := r.Context().Value(contextOptionKey).(*contextOptions); ok && o.baseURL != nil {
+		baseURL = o.baseURL
+	}
+	if baseURL == nil {
+		return r
+	}
+	r = r.Clone(r.Context())
+	r.URL.Scheme = baseURL.Scheme
+	r.URL.Host = baseURL.Host
+	r.Host = ""
+	return r
+}
+
+// do sends the request unless the circuit of its API family is open, and records the rate limit reported
+// by the response and the outcome for the circuit breaker
+func (s *session) do(r *http.Request) (*http.Response, error) {
+	probe, err := s.breaker.allow(r)
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	resp, err := s.send(r)
+	s.observeRequest(r, resp, err, time.Since(start))
+	s.rateLimiter.update(r, resp, time.Now())
+	s.breaker.record(r, probe, resp, err)
+	return resp, err
+}
+
+// send sends the request, hedged if enabled for it
+func (s *session) send(r *http.Request) (*http.Response, error) {
+	if delay := s.hedgingDelay(r); delay > 0 {
+		return s.sendHedged(r, delay)
+	}
+	return s.sendOnce(r)
+}
+
+// sendOnce sends the request to its target, limited by the overall timeout
+func (s *session) sendOnce(r *http.Request) (*http.Response, error) {
+	r = s.target(r)
+	timeout := s.requestTimeout(r)
+	if timeout <= 0 {
+		resp, err := s.client.Do(r)
+		if err != nil {
+			return nil, err
+		}
+		if err := decompress(resp); err != nil {
+			return nil, err
+		}
+		return resp, nil
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	resp, err := s.client.Do(r.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+	if err := decompress(resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // Sign will only sign a request
 func (s *session) Sign(r *http.Request) error {
-	s.signer.SignRequest(r)
+	signer := s.signer
+	if o, ok := r.Context().Value(contextOptionKey).(*contextOptions); ok && o.signer != nil {
+		signer = o.signer
+	} else if s.provider != nil {
+		config, err := s.provider.Provide(r.Context())
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrCredentials, err)
+		}
+		signer = config
+	}
+
+	if ts, ok := signer.(timestampSigner); ok && atomic.LoadInt64(&s.clockSkew) != 0 {
+		ts.SignRequestAt(r, s.now())
+	} else {
+		signer.SignRequest(r)
+	}
 
 	if s.requestLimit != 0 {
-		s.signer.CheckRequestLimit(s.requestLimit)
+		signer.CheckRequestLimit(s.requestLimit)
 	}
 	return nil
 }
+
+// readBody reads the response body once into a pooled buffer, replacing it with an in-memory copy
+// which can still be read by callers
+func readBody(resp *http.Response) ([]byte, error) {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer func() {
+		if buf.Cap() <= maxPooledBufferSize {
+			bufferPool.Put(buf)
+		}
+	}()
+
+	if resp.ContentLength > 0 && resp.ContentLength <= maxPooledBufferSize {
+		buf.Grow(int(resp.ContentLength))
+	}
+	_, err := buf.ReadFrom(resp.Body)
+	resp.Body.Close()
+	data := make([]byte, buf.Len())
+	copy(data, buf.Bytes())
+	resp.Body = ioutil.NopCloser(bytes.NewReader(data))
+	return data, err
+}
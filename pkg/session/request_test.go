@@ -1,9 +1,13 @@
 package session
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/json"
 	"errors"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -199,3 +203,178 @@ func TestSession_Exec(t *testing.T) {
 		})
 	}
 }
+
+func BenchmarkSession_Exec(b *testing.B) {
+	items := make([]testStruct, 1000)
+	for i := range items {
+		items[i] = testStruct{A: "text", B: i}
+	}
+	responseBody, err := json.Marshal(items)
+	require.NoError(b, err)
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := io.Copy(ioutil.Discard, r.Body)
+		assert.NoError(b, err)
+		w.WriteHeader(http.StatusOK)
+		_, err = w.Write(responseBody)
+		assert.NoError(b, err)
+	}))
+	defer mockServer.Close()
+	serverURL, err := url.Parse(mockServer.URL)
+	require.NoError(b, err)
+	s, err := New(
+		WithSigner(&edgegrid.Config{Host: serverURL.Host, MaxBody: edgegrid.MaxBodySize}),
+		WithClient(mockServer.Client()),
+	)
+	require.NoError(b, err)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		req, err := http.NewRequest(http.MethodPost, "/test", nil)
+		require.NoError(b, err)
+		var out []testStruct
+		_, err = s.Exec(req, &out, items)
+		require.NoError(b, err)
+	}
+}
+
+func TestSession_CredentialProvider(t *testing.T) {
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Contains(t, r.Header.Get("Authorization"), "client_token=rotated-token")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer mockServer.Close()
+	serverURL, err := url.Parse(mockServer.URL)
+	require.NoError(t, err)
+
+	var calls int
+	var providerErr error
+	s, err := New(
+		WithClient(mockServer.Client()),
+		WithCredentialProvider(edgegrid.CredentialProviderFunc(func(ctx context.Context) (edgegrid.Config, error) {
+			calls++
+			return edgegrid.Config{Host: serverURL.Host, ClientToken: "rotated-token"}, providerErr
+		})),
+	)
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		req, err := http.NewRequest(http.MethodGet, "/test", nil)
+		require.NoError(t, err)
+		resp, err := s.Exec(req, nil)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+	assert.Equal(t, 2, calls)
+
+	providerErr = errors.New("secret not available")
+	req, err := http.NewRequest(http.MethodGet, "/test", nil)
+	require.NoError(t, err)
+	_, err = s.Exec(req, nil)
+	assert.True(t, errors.Is(err, ErrCredentials), "want: %s; got: %s", ErrCredentials, err)
+}
+
+func TestSession_ContextCredentials(t *testing.T) {
+	var auth, switchKey string
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		switchKey = r.URL.Query().Get("accountSwitchKey")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer mockServer.Close()
+	serverURL, err := url.Parse(mockServer.URL)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		options           []ContextOption
+		sessionOptions    []Option
+		clientOptions     ClientOptions
+		expectedToken     string
+		expectedSwitchKey string
+	}{
+		"session defaults": {
+			expectedToken:     "session-token",
+			expectedSwitchKey: "1-SESSION",
+		},
+		"context signer": {
+			options: []ContextOption{
+				WithContextSigner(edgegrid.Config{Host: serverURL.Host, ClientToken: "tenant-token", AccountKey: "1-TENANT"}),
+			},
+			expectedToken:     "tenant-token",
+			expectedSwitchKey: "1-TENANT",
+		},
+		"context account switch key": {
+			options:           []ContextOption{WithContextAccountSwitchKey("1-CONTEXT")},
+			expectedToken:     "session-token",
+			expectedSwitchKey: "1-CONTEXT",
+		},
+		"session account switch key": {
+			sessionOptions:    []Option{WithAccountSwitchKey("1-ACCOUNT")},
+			expectedToken:     "session-token",
+			expectedSwitchKey: "1-ACCOUNT",
+		},
+		"client option overrides session account switch key": {
+			sessionOptions:    []Option{WithAccountSwitchKey("1-ACCOUNT")},
+			clientOptions:     ClientOptions{AccountSwitchKey: "1-CLIENT"},
+			expectedToken:     "session-token",
+			expectedSwitchKey: "1-CLIENT",
+		},
+		"context account switch key overrides session option": {
+			options:           []ContextOption{WithContextAccountSwitchKey("1-CONTEXT")},
+			sessionOptions:    []Option{WithAccountSwitchKey("1-ACCOUNT")},
+			expectedToken:     "session-token",
+			expectedSwitchKey: "1-CONTEXT",
+		},
+		"context account switch key overrides client option": {
+			options:           []ContextOption{WithContextAccountSwitchKey("1-CONTEXT")},
+			clientOptions:     ClientOptions{AccountSwitchKey: "1-CLIENT"},
+			expectedToken:     "session-token",
+			expectedSwitchKey: "1-CONTEXT",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			s, err := New(append([]Option{
+				WithClient(mockServer.Client()),
+				WithSigner(edgegrid.Config{Host: serverURL.Host, ClientToken: "session-token", AccountKey: "1-SESSION"}),
+			}, test.sessionOptions...)...)
+			require.NoError(t, err)
+			s = test.clientOptions.Apply(s)
+
+			ctx := ContextWithOptions(context.Background(), test.options...)
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, "/test", nil)
+			require.NoError(t, err)
+			_, err = s.Exec(req, nil)
+			require.NoError(t, err)
+			assert.Contains(t, auth, "client_token="+test.expectedToken+";")
+			assert.Equal(t, test.expectedSwitchKey, switchKey)
+		})
+	}
+}
+
+func TestSession_BaseURL(t *testing.T) {
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/test", r.URL.Path)
+		assert.Contains(t, r.Header.Get("Authorization"), "signature=")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer mockServer.Close()
+
+	s, err := New(
+		WithSigner(&edgegrid.Config{Host: "akab-xxxx.luna.akamaiapis.net"}),
+		WithClient(mockServer.Client()),
+		WithBaseURL(mockServer.URL),
+	)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodGet, "/test", nil)
+	require.NoError(t, err)
+	resp, err := s.Exec(req, nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "akab-xxxx.luna.akamaiapis.net", req.URL.Host, "request must be signed for the host of the credentials")
+
+	_, err = New(WithSigner(&edgegrid.Config{}), WithBaseURL("localhost"))
+	assert.True(t, errors.Is(err, ErrInvalidArgument), "want: %s; got: %s", ErrInvalidArgument, err)
+}
@@ -0,0 +1,41 @@
+package session
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// ClientRequestIDHeader is the header carrying the ID the session generates for every request,
+// unless the request already has one, so that client logs can be correlated with support tickets
+const ClientRequestIDHeader = "X-Client-Request-Id"
+
+// ResponseMetadata identifies the request a response was returned for, e.g. to reference it in support tickets
+type ResponseMetadata struct {
+	// ClientRequestID is the ID generated by the session, sent in the ClientRequestIDHeader header
+	ClientRequestID string
+	// RequestID is the ID Akamai assigned to the request, from the X-Trace-Id or X-Request-Id response header
+	RequestID string
+	// StatusCode is the status code of the response
+	StatusCode int
+}
+
+// Metadata returns the metadata of a response returned by Exec
+func Metadata(resp *http.Response) ResponseMetadata {
+	if resp == nil {
+		return ResponseMetadata{}
+	}
+	m := ResponseMetadata{RequestID: akamaiRequestID(resp), StatusCode: resp.StatusCode}
+	if resp.Request != nil {
+		m.ClientRequestID = resp.Request.Header.Get(ClientRequestIDHeader)
+	}
+	return m
+}
+
+// setClientRequestID adds a new client request ID to the request if it has none;
+// retries of the request keep the same ID
+func setClientRequestID(r *http.Request) {
+	if r.Header.Get(ClientRequestIDHeader) == "" {
+		r.Header.Set(ClientRequestIDHeader, uuid.New().String())
+	}
+}
@@ -0,0 +1,59 @@
+package session
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSession_ClientRequestID(t *testing.T) {
+	var received []string
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r.Header.Get(ClientRequestIDHeader))
+		w.Header().Set("X-Trace-Id", "trace-123")
+		if len(received) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer mockServer.Close()
+	serverURL, err := url.Parse(mockServer.URL)
+	require.NoError(t, err)
+
+	s, err := New(
+		WithSigner(&edgegrid.Config{Host: serverURL.Host}),
+		WithClient(mockServer.Client()),
+		WithRetryPolicy(RetryPolicy{Retries: 1, Backoff: FixedBackoff(time.Millisecond)}),
+	)
+	require.NoError(t, err)
+
+	t.Run("generated and kept on retry", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, "/test", nil)
+		require.NoError(t, err)
+		resp, err := s.Exec(req, nil)
+		require.NoError(t, err)
+
+		require.Len(t, received, 2)
+		assert.NotEmpty(t, received[0])
+		assert.Equal(t, received[0], received[1])
+		assert.Equal(t, ResponseMetadata{ClientRequestID: received[0], RequestID: "trace-123", StatusCode: http.StatusOK}, Metadata(resp))
+	})
+
+	t.Run("set by caller", func(t *testing.T) {
+		received = nil
+		req, err := http.NewRequest(http.MethodGet, "/test", nil)
+		require.NoError(t, err)
+		req.Header.Set(ClientRequestIDHeader, "caller-id")
+		resp, err := s.Exec(req, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "caller-id", received[len(received)-1])
+		assert.Equal(t, "caller-id", Metadata(resp).ClientRequestID)
+	})
+}
@@ -0,0 +1,192 @@
+package session
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type (
+	// RetryClassifier decides whether a failed request is retried, see ClientOptions.
+	// It is called after every failed attempt while retries remain; returning nil applies the default policy.
+	RetryClassifier func(attempt RetryAttempt) *RetryDecision
+
+	// RetryAttempt describes a failed attempt of a request
+	RetryAttempt struct {
+		Request *http.Request
+		// Response is the received response, nil on transport errors; its body can be read again by the caller
+		Response *http.Response
+		// Problem is the problem details of the response body, nil if the body is not a problem JSON
+		Problem *Problem
+		// Err is the error returned for the attempt, if any
+		Err error
+		// Attempt is the number of the failed attempt, starting with 1
+		Attempt int
+	}
+
+	// RetryDecision is the decision of a RetryClassifier
+	RetryDecision struct {
+		// Retry tells whether to repeat the request
+		Retry bool
+		// Delay is the delay before the retry; when zero, the configured Backoff is used
+		Delay time.Duration
+	}
+
+	// RetryPolicy configures the automatic retries of all requests of a session, see WithRetryPolicy
+	RetryPolicy struct {
+		// Retries is the number of times an idempotent request is repeated after a transport error
+		// or a 429, 502, 503 or 504 response; POST and PATCH requests are only retried if Classifier decides so
+		Retries int
+		// Classifier, if set, overrides the default policy, see ClientOptions.RetryClassifier
+		Classifier RetryClassifier
+		// Backoff computes the delays between retries not set by the Retry-After header of the response,
+		// ExponentialBackoff with jitter by default
+		Backoff Backoff
+		// MaxElapsed, if set, limits the time spent on a request and its retries
+		MaxElapsed time.Duration
+		// Budget, if set, limits the retries of all requests of the session within a time window
+		Budget *RetryBudget
+	}
+
+	// RetryBudget limits the number of retries within a sliding time window, shared by all requests it applies to,
+	// so that under sustained API problems calls fail fast instead of multiplying the load. Once the budget is spent,
+	// failed requests are not retried and their failure is returned, until older retries leave the window.
+	RetryBudget struct {
+		// MaxRetries is the number of retries allowed within Window; budgets without any are ignored
+		MaxRetries int
+		// Window is the period over which retries are counted, DefaultRetryBudgetWindow if not set
+		Window time.Duration
+	}
+
+	// retryBudget tracks the retries spent from a RetryBudget
+	retryBudget struct {
+		mu      sync.Mutex
+		max     int
+		window  time.Duration
+		retries []time.Time
+	}
+)
+
+// DefaultRetryBudgetWindow is the window of a RetryBudget without one
+const DefaultRetryBudgetWindow = time.Minute
+
+// WithRetryPolicy makes the session retry failed requests according to the policy, honoring the Retry-After header
+// of 429 and 503 responses. Requests of API clients created with the WithRetries option on such a session
+// fail with ErrInvalidArgument, so that retries are configured in one place only.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(s *session) {
+		s.retryPolicy = policy
+	}
+}
+
+// withRetries returns the session, wrapped to retry requests if the session has a retry policy
+func (s *session) withRetries() Session {
+	if s.retryPolicy.Retries <= 0 {
+		return s
+	}
+	backoff := s.retryPolicy.Backoff
+	if backoff == nil {
+		backoff = ExponentialBackoff{Jitter: true}
+	}
+	opts := ClientOptions{
+		Retries:         s.retryPolicy.Retries,
+		RetryClassifier: s.retryPolicy.Classifier,
+		Backoff:         backoff,
+		MaxElapsed:      s.retryPolicy.MaxElapsed,
+		Budget:          s.retryPolicy.Budget,
+	}
+	return &optionsSession{Session: s, opts: opts, budget: newRetryBudget(opts.Budget)}
+}
+
+// retryAfter returns the delay requested by the Retry-After header of the response, in seconds or as an HTTP date,
+// or zero if there is none
+func retryAfter(resp *http.Response, now time.Time) time.Duration {
+	if resp == nil {
+		return 0
+	}
+	value := strings.TrimSpace(resp.Header.Get("Retry-After"))
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil && at.After(now) {
+		return at.Sub(now)
+	}
+	return 0
+}
+
+// classifyRetry returns whether the failed attempt of the request is retried and the delay before the retry,
+// zero for the default backoff
+func (s *optionsSession) classifyRetry(r *http.Request, resp *http.Response, err error, attempt int) (bool, time.Duration) {
+	failed := err != nil || (resp != nil && resp.StatusCode >= http.StatusBadRequest)
+	if s.opts.RetryClassifier == nil || !failed || r.Context().Err() != nil {
+		return shouldRetry(r, resp, err), 0
+	}
+
+	a := RetryAttempt{Request: r, Response: resp, Err: err, Attempt: attempt + 1}
+	if resp != nil && err == nil {
+		a.Problem = readProblem(resp)
+	}
+	if decision := s.opts.RetryClassifier(a); decision != nil {
+		return decision.Retry, decision.Delay
+	}
+	return shouldRetry(r, resp, err), 0
+}
+
+// readProblem parses the problem details of the response body, leaving the body readable
+func readProblem(resp *http.Response) *Problem {
+	if resp.Body == nil {
+		return nil
+	}
+	body, err := readBody(resp)
+	if err != nil {
+		return nil
+	}
+
+	var problem Problem
+	if err := json.Unmarshal(body, &problem); err != nil {
+		return nil
+	}
+	if problem.Type == "" && problem.Title == "" && problem.Detail == "" {
+		return nil
+	}
+	return &problem
+}
+
+// newRetryBudget returns the tracker of the budget, or nil if the budget does not limit retries
+func newRetryBudget(budget *RetryBudget) *retryBudget {
+	if budget == nil || budget.MaxRetries <= 0 {
+		return nil
+	}
+	window := budget.Window
+	if window <= 0 {
+		window = DefaultRetryBudgetWindow
+	}
+	return &retryBudget{max: budget.MaxRetries, window: window}
+}
+
+// take spends a retry from the budget at now, and reports whether one was left
+func (b *retryBudget) take(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	start := now.Add(-b.window)
+	expired := 0
+	for expired < len(b.retries) && !b.retries[expired].After(start) {
+		expired++
+	}
+	b.retries = b.retries[expired:]
+	if len(b.retries) >= b.max {
+		return false
+	}
+	b.retries = append(b.retries, now)
+	return true
+}
@@ -3,9 +3,15 @@ package session
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
 	"runtime"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/edgegrid"
 	"github.com/apex/log"
@@ -35,17 +41,62 @@ type (
 
 	// session is the base akamai http client
 	session struct {
-		client       *http.Client
-		signer       edgegrid.Signer
-		log          log.Interface
-		trace        bool
-		userAgent    string
-		requestLimit int
+		// clockSkew is the offset of the server clock from the local one in nanoseconds, accessed atomically;
+		// it is the first field to keep it 64-bit aligned
+		clockSkew            int64
+		client               *http.Client
+		signer               edgegrid.Signer
+		provider             edgegrid.CredentialProvider
+		log                  log.Interface
+		trace                bool
+		debug                *DebugTransport
+		userAgent            string
+		products             []string
+		requestLimit         int
+		plan                 *Plan
+		dryRun               bool
+		strict               StrictMode
+		decoder              *DecoderOptions
+		idempotencyKeys      bool
+		compressionThreshold int
+		cache                *responseCache
+		auditSink            AuditSink
+		deprecationHandler   DeprecationHandler
+		pathPrefixes         []pathPrefix
+		rawBaseURL           string
+		baseURL              *url.URL
+		proxy                string
+		tlsConfig            *tls.Config
+		pool                 ConnectionPool
+		http2                *bool
+		timeouts             Timeouts
+		defaultTimeout       time.Duration
+		hedgeDelay           time.Duration
+		accountSwitchKey     string
+		retryPolicy          RetryPolicy
+		rateLimiter          *rateLimiter
+		rateLimitRetries     int
+		rateLimitHandler     RateLimitHandler
+		breaker              *breaker
+		middleware           []Middleware
+		tracer               Tracer
+		metrics              Metrics
+		etags                *responseCache
+		deprecations         sync.Map
 	}
 
 	contextOptions struct {
-		log    log.Interface
-		header http.Header
+		log              log.Interface
+		header           http.Header
+		baseURL          *url.URL
+		idempotencyKey   string
+		idempotencyKeys  bool
+		signer           edgegrid.Signer
+		accountSwitchKey string
+		timeout          time.Duration
+		requestTimeout   time.Duration
+		hedgeDelay       time.Duration
+		streaming        bool
 	}
 
 	// Option defines a client option
@@ -83,7 +134,27 @@ func New(opts ...Option) (Session, error) {
 		opt(s)
 	}
 
-	if s.signer == nil {
+	if len(s.products) > 0 {
+		s.userAgent = strings.Join(append([]string{s.userAgent}, s.products...), " ")
+	}
+
+	if s.rawBaseURL != "" {
+		u, err := url.Parse(s.rawBaseURL)
+		if err == nil && u.Host == "" {
+			err = errors.New("missing host")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid base URL %q: %s", ErrInvalidArgument, s.rawBaseURL, err)
+		}
+		s.baseURL = u
+	}
+
+	if err := s.configureTransport(); err != nil {
+		return nil, err
+	}
+	s.configureDebugLogging()
+
+	if s.signer == nil && s.provider == nil {
 		config, err := edgegrid.New()
 		if err != nil {
 			return nil, err
@@ -91,7 +162,15 @@ func New(opts ...Option) (Session, error) {
 		s.signer = config
 	}
 
-	return s, nil
+	// redirected requests are signed again; the client is copied so that the one passed with WithClient,
+	// which may be http.DefaultClient, is left unchanged
+	client := *s.client
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		return s.Sign(req)
+	}
+	s.client = &client
+
+	return s.withRetries(), nil
 }
 
 // Must is a helper tthat will result in a panic if an error is returned
@@ -125,6 +204,38 @@ func WithUserAgent(u string) Option {
 	}
 }
 
+// WithProduct identifies the application embedding the SDK in the User-Agent header, by appending the name/version
+// product token to the user agent of the session, e.g. "Akamai-Open-Edgegrid-golang/6.0.0 golang/1.21.0 waf-auditor/2.3.1".
+// Products of several options are appended in order, also to a user agent set with WithUserAgent. The version may be empty.
+func WithProduct(name, version string) Option {
+	return func(s *session) {
+		product := strings.ReplaceAll(strings.TrimSpace(name), " ", "-")
+		if version = strings.ReplaceAll(strings.TrimSpace(version), " ", "-"); version != "" {
+			product += "/" + version
+		}
+		if product != "" {
+			s.products = append(s.products, product)
+		}
+	}
+}
+
+// WithBaseURL sends all requests to the scheme and host of baseURL, e.g. a local mock server or an Akamai sandbox,
+// while they are still signed for the host of the credentials
+func WithBaseURL(baseURL string) Option {
+	return func(s *session) {
+		s.rawBaseURL = baseURL
+	}
+}
+
+// WithAccountSwitchKey makes all requests of the session act on the account with given switch key, overriding
+// the account key of the signer, so that sessions for several accounts can share one client and its connection pool.
+// The AccountSwitchKey client option and WithContextAccountSwitchKey take precedence.
+func WithAccountSwitchKey(key string) Option {
+	return func(s *session) {
+		s.accountSwitchKey = key
+	}
+}
+
 // WithSigner sets the request signer for the session
 func WithSigner(signer edgegrid.Signer) Option {
 	return func(s *session) {
@@ -132,6 +243,14 @@ func WithSigner(signer edgegrid.Signer) Option {
 	}
 }
 
+// WithCredentialProvider makes the session sign every request with the credentials returned by the provider
+// for the request context, instead of a static signer set with WithSigner
+func WithCredentialProvider(provider edgegrid.CredentialProvider) Option {
+	return func(s *session) {
+		s.provider = provider
+	}
+}
+
 // WithRequestLimit sets the maximum number of API calls that the provider will make per second.
 func WithRequestLimit(requestLimit int) Option {
 	return func(s *session) {
@@ -191,3 +310,19 @@ func WithContextHeaders(h http.Header) ContextOption {
 		o.header = h
 	}
 }
+
+// WithContextSigner signs the request with given signer, e.g. an edgegrid.Config with the credentials of another tenant,
+// instead of the signer or credential provider of the session
+func WithContextSigner(signer edgegrid.Signer) ContextOption {
+	return func(o *contextOptions) {
+		o.signer = signer
+	}
+}
+
+// WithContextAccountSwitchKey makes the request act on the account with given switch key, overriding the account key
+// of the signer, the AccountSwitchKey client option and the WithAccountSwitchKey option of the session
+func WithContextAccountSwitchKey(key string) ContextOption {
+	return func(o *contextOptions) {
+		o.accountSwitchKey = key
+	}
+}
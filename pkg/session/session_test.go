@@ -68,11 +68,47 @@ func TestNew(t *testing.T) {
 			}
 			res, err := New(options...)
 			require.NoError(t, err)
+			require.IsType(t, &session{}, res)
+			s := res.(*session)
+			assert.NotNil(t, s.client.CheckRedirect)
+			assert.Nil(t, http.DefaultClient.CheckRedirect)
+			if test.client != nil {
+				assert.Nil(t, test.client.CheckRedirect)
+			}
+			s.client.CheckRedirect = nil
 			assert.Equal(t, test.expected, res)
 		})
 	}
 }
 
+func TestWithProduct(t *testing.T) {
+	defaultUserAgent := "Akamai-Open-Edgegrid-golang/6.0.0 golang/" + strings.TrimPrefix(runtime.Version(), "go")
+	tests := map[string]struct {
+		options  []Option
+		expected string
+	}{
+		"single product": {
+			options:  []Option{WithProduct("waf-auditor", "2.3.1")},
+			expected: defaultUserAgent + " waf-auditor/2.3.1",
+		},
+		"products in order": {
+			options:  []Option{WithProduct("terraform", "1.5.0"), WithProduct("terraform-provider-akamai", "5.0.0")},
+			expected: defaultUserAgent + " terraform/1.5.0 terraform-provider-akamai/5.0.0",
+		},
+		"custom user agent, no version and spaces": {
+			options:  []Option{WithProduct(" my tool ", ""), WithUserAgent("custom/1.0"), WithProduct("", "")},
+			expected: "custom/1.0 my-tool",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			res, err := New(append([]Option{WithSigner(&edgegrid.Config{})}, test.options...)...)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, res.(*session).userAgent)
+		})
+	}
+}
+
 func TestSession_Log(t *testing.T) {
 	tests := map[string]struct {
 		ctx           context.Context
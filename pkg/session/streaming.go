@@ -0,0 +1,112 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// WithContextStreaming makes Exec decode the responses of requests made with the context directly from the response
+// stream, instead of reading the whole body into memory first, e.g. for configuration exports of tens of megabytes.
+// UseNumber and DisallowUnknownFields decoder options still apply, while TimeLayouts and strict responses,
+// which need the whole body, are skipped, and such responses are not stored by the response caches.
+func WithContextStreaming() ContextOption {
+	return func(o *contextOptions) {
+		o.streaming = true
+	}
+}
+
+func isStreaming(r *http.Request) bool {
+	o, ok := r.Context().Value(contextOptionKey).(*contextOptions)
+	return ok && o.streaming
+}
+
+// decodeStream decodes the response body into out using the decoder options of the session, and closes it
+func (s *session) decodeStream(resp *http.Response, out interface{}) error {
+	defer resp.Body.Close()
+
+	dec := json.NewDecoder(resp.Body)
+	if s.decoder != nil && s.decoder.UseNumber {
+		dec.UseNumber()
+	}
+	if s.decoder != nil && s.decoder.DisallowUnknownFields {
+		dec.DisallowUnknownFields()
+	}
+	return dec.Decode(out)
+}
+
+// StreamItems decodes the items of a JSON array from r one by one and calls fn for each of them, so that
+// large listings can be processed without holding all items in memory. The array is the top-level value
+// if path is empty, and otherwise the value of the object field at the dot-separated path, e.g.:
+//
+//	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/appsec/v1/configs/43253/versions/7/match-targets", nil)
+//	resp, err := sess.Exec(req, nil)
+//	...
+//	defer resp.Body.Close()
+//	err = session.StreamItems(resp.Body, "matchTargets.websiteTargets", func(target WebsiteTarget) error {
+//		return index.Add(target)
+//	})
+//
+// A null value is treated as an empty array. Decoding stops at the first error returned by fn, which is returned as is;
+// malformed input and a missing field result in an error wrapping ErrUnmarshaling.
+func StreamItems[T any](r io.Reader, path string, fn func(T) error) error {
+	dec := json.NewDecoder(r)
+	if path != "" {
+		for _, field := range strings.Split(path, ".") {
+			if err := seekField(dec, field); err != nil {
+				return fmt.Errorf("%w: %s: %s", ErrUnmarshaling, path, err)
+			}
+		}
+	}
+
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrUnmarshaling, err)
+	}
+	if tok == nil {
+		return nil
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return fmt.Errorf("%w: expected array, got %v", ErrUnmarshaling, tok)
+	}
+	for dec.More() {
+		var item T
+		if err := dec.Decode(&item); err != nil {
+			return fmt.Errorf("%w: %s", ErrUnmarshaling, err)
+		}
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return fmt.Errorf("%w: %s", ErrUnmarshaling, err)
+	}
+	return nil
+}
+
+// seekField advances dec to the value of field of the object starting at the next token, skipping preceding fields
+func seekField(dec *json.Decoder, field string) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("expected object, got %v", tok)
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if key, ok := tok.(string); ok && key == field {
+			return nil
+		}
+		var skipped json.RawMessage
+		if err := dec.Decode(&skipped); err != nil {
+			return err
+		}
+	}
+	return fmt.Errorf("field %q not found", field)
+}
@@ -0,0 +1,137 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamItems(t *testing.T) {
+	errStop := errors.New("stop")
+
+	tests := map[string]struct {
+		input     string
+		path      string
+		stopAt    int
+		expected  []testStruct
+		withError error
+	}{
+		"top-level array": {
+			input:    `[{"a":"x","b":1},{"a":"y","b":2}]`,
+			expected: []testStruct{{A: "x", B: 1}, {A: "y", B: 2}},
+		},
+		"nested field": {
+			input:    `{"total":2,"meta":{"x":[1,2]},"matchTargets":{"apiTargets":[],"websiteTargets":[{"a":"x","b":1},{"a":"y","b":2}]}}`,
+			path:     "matchTargets.websiteTargets",
+			expected: []testStruct{{A: "x", B: 1}, {A: "y", B: 2}},
+		},
+		"null array": {
+			input: `{"items":null}`,
+			path:  "items",
+		},
+		"callback error stops decoding": {
+			input:     `[{"a":"x","b":1},{"a":"y","b":2}]`,
+			stopAt:    1,
+			expected:  []testStruct{{A: "x", B: 1}},
+			withError: errStop,
+		},
+		"missing field": {
+			input:     `{"other":[]}`,
+			path:      "items",
+			withError: ErrUnmarshaling,
+		},
+		"not an array": {
+			input:     `{"items":{"a":"x"}}`,
+			path:      "items",
+			withError: ErrUnmarshaling,
+		},
+		"malformed item": {
+			input:     `[{"a":"x","b":1},{"a":1}]`,
+			expected:  []testStruct{{A: "x", B: 1}},
+			withError: ErrUnmarshaling,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var items []testStruct
+			err := StreamItems(strings.NewReader(test.input), test.path, func(item testStruct) error {
+				items = append(items, item)
+				if test.stopAt > 0 && len(items) == test.stopAt {
+					return errStop
+				}
+				return nil
+			})
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, test.expected, items)
+		})
+	}
+}
+
+func TestSession_ExecStreaming(t *testing.T) {
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"a":"text","b":12345678901234567890,"c":"unknown"}`))
+		assert.NoError(t, err)
+	}))
+	defer mockServer.Close()
+	serverURL, err := url.Parse(mockServer.URL)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		decoder   *DecoderOptions
+		out       interface{}
+		expected  interface{}
+		withError error
+	}{
+		"decodes from the stream": {
+			out:      &map[string]interface{}{},
+			expected: &map[string]interface{}{"a": "text", "b": 12345678901234567890.0, "c": "unknown"},
+		},
+		"with decoder options": {
+			decoder:  &DecoderOptions{UseNumber: true},
+			out:      &map[string]interface{}{},
+			expected: &map[string]interface{}{"a": "text", "b": "12345678901234567890", "c": "unknown"},
+		},
+		"unknown fields": {
+			decoder:   &DecoderOptions{DisallowUnknownFields: true},
+			out:       &struct{ A string }{},
+			withError: ErrUnmarshaling,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			opts := []Option{WithSigner(&edgegrid.Config{Host: serverURL.Host}), WithClient(mockServer.Client())}
+			if test.decoder != nil {
+				opts = append(opts, WithDecoderOptions(*test.decoder))
+			}
+			s, err := New(opts...)
+			require.NoError(t, err)
+
+			ctx := ContextWithOptions(context.Background(), WithContextStreaming())
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, "/appsec/v1/export", nil)
+			require.NoError(t, err)
+			_, err = s.Exec(req, test.out)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			if m, ok := test.out.(*map[string]interface{}); ok && test.decoder != nil {
+				(*m)["b"] = (*m)["b"].(interface{ String() string }).String()
+			}
+			assert.Equal(t, test.expected, test.out)
+		})
+	}
+}
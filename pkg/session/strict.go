@@ -0,0 +1,164 @@
+package session
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+type (
+	// StrictMode controls how responses not matching the SDK types are handled, see WithStrictResponses
+	StrictMode int
+
+	// ResponseValidator can be implemented by response types to validate decoded responses in strict mode
+	ResponseValidator interface {
+		ValidateResponse() error
+	}
+
+	// SchemaDriftError reports a response which does not match the SDK type it is decoded into. It matches ErrSchemaDrift.
+	SchemaDriftError struct {
+		// Type is the name of the SDK type, e.g. *appsec.GetMatchTargetResponse
+		Type string
+		// UnknownFields lists the paths of the response fields the SDK type does not capture,
+		// e.g. matchTargets.websiteTargets[].fileExtensions
+		UnknownFields []string
+		// Err is the error returned by ValidateResponse, if any
+		Err error
+	}
+)
+
+const (
+	// StrictOff disables strict response checks; this is the default
+	StrictOff StrictMode = iota
+	// StrictLog logs a warning when a response does not match the SDK type it is decoded into
+	StrictLog
+	// StrictError makes Exec fail with ErrSchemaDrift when a response does not match the SDK type it is decoded into
+	StrictError
+)
+
+var (
+	// ErrSchemaDrift is returned in StrictError mode when a response contains fields unknown to the SDK type
+	// or fails its ValidateResponse check
+	ErrSchemaDrift = errors.New("response schema drift")
+)
+
+// WithStrictResponses enables strict checks of successful responses decoded by Exec.
+// Responses are decoded as usual, then compared with the output type to find all the fields it does not capture and,
+// if the output type implements ResponseValidator, validated. Depending on the mode, mismatches are logged
+// with the unknown fields or returned as a *SchemaDriftError.
+func WithStrictResponses(mode StrictMode) Option {
+	return func(s *session) {
+		s.strict = mode
+	}
+}
+
+// checkResponse verifies that data decodes into the type of out without unknown fields and that out is valid
+func checkResponse(data []byte, out interface{}) error {
+	drift := &SchemaDriftError{Type: fmt.Sprintf("%T", out)}
+
+	typ := reflect.TypeOf(out)
+	if typ.Kind() == reflect.Ptr {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		var v interface{}
+		if err := dec.Decode(&v); err != nil {
+			return fmt.Errorf("%w: %T: %s", ErrSchemaDrift, out, err)
+		}
+		drift.UnknownFields = unknownFields(v, typ.Elem(), "")
+	}
+
+	if v, ok := out.(ResponseValidator); ok {
+		drift.Err = v.ValidateResponse()
+	}
+	if len(drift.UnknownFields) == 0 && drift.Err == nil {
+		return nil
+	}
+	return drift
+}
+
+// unknownFields returns the paths of the object fields in v which have no matching field in typ.
+// Types decoding themselves, interfaces and maps accept any field.
+func unknownFields(v interface{}, typ reflect.Type, path string) []string {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if reflect.PtrTo(typ).Implements(unmarshalerType) {
+		return nil
+	}
+
+	var unknown []string
+	switch v := v.(type) {
+	case map[string]interface{}:
+		switch typ.Kind() {
+		case reflect.Map:
+			for key, value := range v {
+				unknown = append(unknown, unknownFields(value, typ.Elem(), joinPath(path, key))...)
+			}
+		case reflect.Struct:
+			fields := jsonFields(typ)
+			for key, value := range v {
+				fieldType, ok := lookupField(fields, key)
+				if !ok {
+					unknown = append(unknown, joinPath(path, key))
+					continue
+				}
+				unknown = append(unknown, unknownFields(value, fieldType, joinPath(path, key))...)
+			}
+		}
+	case []interface{}:
+		if typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array {
+			for _, item := range v {
+				unknown = append(unknown, unknownFields(item, typ.Elem(), path+"[]")...)
+			}
+		}
+	}
+	sort.Strings(unknown)
+	return dedupe(unknown)
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// dedupe removes adjacent duplicates from a sorted slice, e.g. the same unknown field of several array items
+func dedupe(paths []string) []string {
+	if len(paths) < 2 {
+		return paths
+	}
+	out := paths[:1]
+	for _, p := range paths[1:] {
+		if p != out[len(out)-1] {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// Error lists the unknown fields and the validation error
+func (e *SchemaDriftError) Error() string {
+	msg := fmt.Sprintf("%s: %s", ErrSchemaDrift, e.Type)
+	if len(e.UnknownFields) > 0 {
+		msg += fmt.Sprintf(": unknown fields: %s", strings.Join(e.UnknownFields, ", "))
+	}
+	if e.Err != nil {
+		msg += fmt.Sprintf(": %s", e.Err)
+	}
+	return msg
+}
+
+// Is matches ErrSchemaDrift
+func (e *SchemaDriftError) Is(target error) bool {
+	return target == ErrSchemaDrift
+}
+
+// Unwrap returns the validation error
+func (e *SchemaDriftError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,143 @@
+package session
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/edgegrid"
+	"github.com/apex/log"
+	"github.com/apex/log/handlers/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type validatedStruct struct {
+	A string `json:"a"`
+}
+
+func (v *validatedStruct) ValidateResponse() error {
+	if v.A == "" {
+		return errors.New("a is required")
+	}
+	return nil
+}
+
+func TestSession_ExecStrictResponses(t *testing.T) {
+	tests := map[string]struct {
+		mode         StrictMode
+		responseBody string
+		out          interface{}
+		expected     interface{}
+		expectedLogs int
+		withError    error
+	}{
+		"strict off, unknown field ignored": {
+			mode:         StrictOff,
+			responseBody: `{"a":"text","b":1,"c":true}`,
+			out:          &testStruct{},
+			expected:     &testStruct{A: "text", B: 1},
+		},
+		"strict log, unknown field logged": {
+			mode:         StrictLog,
+			responseBody: `{"a":"text","b":1,"c":true}`,
+			out:          &testStruct{},
+			expected:     &testStruct{A: "text", B: 1},
+			expectedLogs: 1,
+		},
+		"strict error, matching response": {
+			mode:         StrictError,
+			responseBody: `{"a":"text","b":1}`,
+			out:          &testStruct{},
+			expected:     &testStruct{A: "text", B: 1},
+		},
+		"strict error, unknown field": {
+			mode:         StrictError,
+			responseBody: `{"a":"text","b":1,"c":true}`,
+			out:          &testStruct{},
+			withError:    ErrSchemaDrift,
+		},
+		"strict error, validation failed": {
+			mode:         StrictError,
+			responseBody: `{"a":""}`,
+			out:          &validatedStruct{},
+			withError:    ErrSchemaDrift,
+		},
+		"strict error, map output": {
+			mode:         StrictError,
+			responseBody: `{"a":"text"}`,
+			out:          &map[string]interface{}{},
+			expected:     &map[string]interface{}{"a": "text"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			serverURL, err := url.Parse(mockServer.URL)
+			require.NoError(t, err)
+
+			handler := memory.New()
+			s, err := New(
+				WithSigner(&edgegrid.Config{Host: serverURL.Host}),
+				WithClient(mockServer.Client()),
+				WithLog(&log.Logger{Handler: handler, Level: log.DebugLevel}),
+				WithStrictResponses(test.mode),
+			)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodGet, "/test", nil)
+			require.NoError(t, err)
+			_, err = s.Exec(req, test.out)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, test.out)
+			assert.Len(t, handler.Entries, test.expectedLogs)
+		})
+	}
+}
+
+func TestCheckResponse_UnknownFields(t *testing.T) {
+	type (
+		target struct {
+			ID        int64             `json:"targetId"`
+			Hostnames []string          `json:"hostnames"`
+			Raw       json.RawMessage   `json:"raw"`
+			Labels    map[string]string `json:"labels"`
+		}
+		embedded struct {
+			Version int `json:"version"`
+		}
+		response struct {
+			embedded
+			Targets  []target    `json:"targets"`
+			Default  *target     `json:"default"`
+			Any      interface{} `json:"any"`
+			Internal string      `json:"-"`
+		}
+	)
+
+	body := `{"version":7,"targets":[{"targetId":1,"hostnames":["a"],"sequence":1},{"TargetID":2,"sequence":2,"bypass":true}],
+		"default":{"targetId":3,"raw":{"x":1},"labels":{"k":"v"},"extra":{}},"any":{"x":1},"Internal":"x","createdBy":"user"}`
+	err := checkResponse([]byte(body), &response{})
+
+	var drift *SchemaDriftError
+	require.True(t, errors.As(err, &drift))
+	assert.True(t, errors.Is(err, ErrSchemaDrift))
+	assert.Equal(t, "*session.response", drift.Type)
+	assert.Equal(t, []string{"Internal", "createdBy", "default.extra", "targets[].bypass", "targets[].sequence"}, drift.UnknownFields)
+	assert.Equal(t, "response schema drift: *session.response: unknown fields: Internal, createdBy, default.extra, targets[].bypass, targets[].sequence", err.Error())
+
+	assert.NoError(t, checkResponse([]byte(`{"version":7,"targets":[{"targetId":1}]}`), &response{}))
+}
@@ -0,0 +1,101 @@
+package session
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+type (
+	// Tracer traces the Exec calls of a session, e.g. to show SDK calls in distributed traces, see WithTracer.
+	// Implementations must be safe for concurrent use; see the oteltracing module for an OpenTelemetry implementation.
+	Tracer interface {
+		// StartSpan starts the span of an Exec call and may inject its trace context in the request headers
+		StartSpan(r *http.Request, start SpanStart) Span
+	}
+
+	// Span is the span of an Exec call
+	Span interface {
+		// End records the outcome of the call and ends the span
+		End(end SpanEnd)
+	}
+
+	// SpanStart describes the request a span is started for
+	SpanStart struct {
+		// Name is the method and route of the request, e.g. GET /appsec/v1/configs/{id}
+		Name string
+		// Family is the API family, e.g. appsec
+		Family string
+		// Method is the HTTP method
+		Method string
+		// Route is the path with identifiers replaced by {id}, e.g. /appsec/v1/configs/{id}
+		Route string
+	}
+
+	// SpanEnd describes the outcome of an Exec call
+	SpanEnd struct {
+		// StatusCode is the status code of the response, zero if no response was received
+		StatusCode int
+		// ClientRequestID is the ID generated by the session, sent in the ClientRequestIDHeader header
+		ClientRequestID string
+		// RequestID is the ID Akamai assigned to the request, as returned in the response headers
+		RequestID string
+		// Err is the error returned by Exec, if any
+		Err error
+	}
+)
+
+// versionSegment matches API version path segments, which are kept in path templates
+var versionSegment = regexp.MustCompile(`^v\d+$`)
+
+// WithTracer makes the session start a span with the tracer for every Exec call
+func WithTracer(tracer Tracer) Option {
+	return func(s *session) {
+		s.tracer = tracer
+	}
+}
+
+// startSpan starts the span of the request
+func (s *session) startSpan(r *http.Request) Span {
+	route := pathTemplate(r.URL.Path)
+	return s.tracer.StartSpan(r, SpanStart{
+		Name:   r.Method + " " + route,
+		Family: apiFamily(r),
+		Method: r.Method,
+		Route:  route,
+	})
+}
+
+// endSpan records the outcome of the request and ends its span
+func endSpan(span Span, resp *http.Response, err error) {
+	end := SpanEnd{Err: err}
+	if resp != nil {
+		metadata := Metadata(resp)
+		end.StatusCode = metadata.StatusCode
+		end.ClientRequestID = metadata.ClientRequestID
+		end.RequestID = metadata.RequestID
+	}
+	span.End(end)
+}
+
+// pathTemplate returns the path with segments holding identifiers, i.e. containing digits, replaced by {id},
+// keeping the cardinality of span names low
+func pathTemplate(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.ContainsAny(segment, "0123456789") && !versionSegment.MatchString(segment) {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// akamaiRequestID returns the ID Akamai assigned to the request, as returned in the response headers
+func akamaiRequestID(resp *http.Response) string {
+	for _, header := range []string{"X-Trace-Id", "X-Request-Id"} {
+		if id := resp.Header.Get(header); id != "" {
+			return id
+		}
+	}
+	return ""
+}
@@ -0,0 +1,97 @@
+package session
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	mockTracer struct {
+		starts []SpanStart
+		ends   []SpanEnd
+	}
+
+	mockSpan struct {
+		tracer *mockTracer
+	}
+)
+
+func (t *mockTracer) StartSpan(r *http.Request, start SpanStart) Span {
+	t.starts = append(t.starts, start)
+	r.Header.Set("traceparent", "00-trace-span-01")
+	return mockSpan{tracer: t}
+}
+
+func (s mockSpan) End(end SpanEnd) {
+	s.tracer.ends = append(s.tracer.ends, end)
+}
+
+func TestSession_Tracer(t *testing.T) {
+	tests := map[string]struct {
+		path          string
+		status        int
+		expectedStart SpanStart
+		expectedEnd   SpanEnd
+	}{
+		"successful call": {
+			path:   "/appsec/v1/configs/12345/versions/3/security-policies/abc1_23456",
+			status: http.StatusOK,
+			expectedStart: SpanStart{
+				Name:   "GET /appsec/v1/configs/{id}/versions/{id}/security-policies/{id}",
+				Family: "appsec",
+				Method: http.MethodGet,
+				Route:  "/appsec/v1/configs/{id}/versions/{id}/security-policies/{id}",
+			},
+			expectedEnd: SpanEnd{StatusCode: http.StatusOK, RequestID: "trace-123"},
+		},
+		"failed call": {
+			path:   "/papi/v1/properties/prp_1",
+			status: http.StatusNotFound,
+			expectedStart: SpanStart{
+				Name:   "GET /papi/v1/properties/{id}",
+				Family: "papi",
+				Method: http.MethodGet,
+				Route:  "/papi/v1/properties/{id}",
+			},
+			expectedEnd: SpanEnd{StatusCode: http.StatusNotFound, RequestID: "trace-123"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "00-trace-span-01", r.Header.Get("traceparent"))
+				w.Header().Set("X-Trace-Id", "trace-123")
+				w.WriteHeader(test.status)
+			}))
+			defer mockServer.Close()
+			serverURL, err := url.Parse(mockServer.URL)
+			require.NoError(t, err)
+
+			tracer := &mockTracer{}
+			s, err := New(
+				WithSigner(&edgegrid.Config{Host: serverURL.Host}),
+				WithClient(mockServer.Client()),
+				WithTracer(tracer),
+			)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodGet, test.path, nil)
+			require.NoError(t, err)
+			_, err = s.Exec(req, nil)
+			require.NoError(t, err)
+
+			assert.Equal(t, []SpanStart{test.expectedStart}, tracer.starts)
+			require.Len(t, tracer.ends, 1)
+			assert.NotEmpty(t, tracer.ends[0].ClientRequestID)
+			tracer.ends[0].ClientRequestID = ""
+			assert.Equal(t, test.expectedEnd, tracer.ends[0])
+		})
+	}
+}
@@ -0,0 +1,267 @@
+package session
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSession_Proxy(t *testing.T) {
+	var proxied int
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied++
+		assert.Equal(t, "akab-xxxx.luna.akamaiapis.net", r.URL.Host)
+		assert.Equal(t, "Basic "+base64.StdEncoding.EncodeToString([]byte("user:secret")), r.Header.Get("Proxy-Authorization"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer proxy.Close()
+
+	client := &http.Client{}
+	s, err := New(
+		WithSigner(&edgegrid.Config{Host: "akab-xxxx.luna.akamaiapis.net"}),
+		WithClient(client),
+		WithBaseURL("http://akab-xxxx.luna.akamaiapis.net"),
+		WithProxy("http://user:secret@"+proxy.Listener.Addr().String()),
+	)
+	require.NoError(t, err)
+	assert.NotSame(t, client, s.Client(), "client set with WithClient must not be modified")
+	assert.Nil(t, client.Transport)
+
+	req, err := http.NewRequest(http.MethodGet, "/test", nil)
+	require.NoError(t, err)
+	resp, err := s.Exec(req, nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 1, proxied)
+}
+
+func TestSession_TLSConfig(t *testing.T) {
+	mockServer := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	mockServer.TLS = &tls.Config{MaxVersion: tls.VersionTLS12}
+	mockServer.StartTLS()
+	defer mockServer.Close()
+	serverURL, err := url.Parse(mockServer.URL)
+	require.NoError(t, err)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(mockServer.Certificate())
+
+	tests := map[string]struct {
+		tlsConfig *tls.Config
+		withError bool
+	}{
+		"server certificate trusted": {
+			tlsConfig: &tls.Config{RootCAs: pool},
+		},
+		"server certificate not trusted": {
+			tlsConfig: &tls.Config{},
+			withError: true,
+		},
+		"server TLS version too low": {
+			tlsConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS13},
+			withError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			s, err := New(
+				WithSigner(&edgegrid.Config{Host: serverURL.Host}),
+				WithClient(&http.Client{}),
+				WithTLSConfig(test.tlsConfig),
+			)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodGet, "/test", nil)
+			require.NoError(t, err)
+			resp, err := s.Exec(req, nil)
+			if test.withError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+	}
+}
+
+func TestSession_Timeouts(t *testing.T) {
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer mockServer.Close()
+	serverURL, err := url.Parse(mockServer.URL)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		timeouts  Timeouts
+		options   []ContextOption
+		withError bool
+	}{
+		"no timeouts": {},
+		"overall timeout exceeded": {
+			timeouts:  Timeouts{Overall: 50 * time.Millisecond},
+			withError: true,
+		},
+		"overall timeout extended for the call": {
+			timeouts: Timeouts{Overall: 50 * time.Millisecond},
+			options:  []ContextOption{WithContextTimeout(5 * time.Second)},
+		},
+		"overall timeout disabled for the call": {
+			timeouts: Timeouts{Overall: 50 * time.Millisecond},
+			options:  []ContextOption{WithContextTimeout(-1)},
+		},
+		"response header timeout exceeded": {
+			timeouts:  Timeouts{ResponseHeader: 50 * time.Millisecond, Dial: time.Second, TLSHandshake: time.Second},
+			withError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			s, err := New(
+				WithSigner(&edgegrid.Config{Host: serverURL.Host}),
+				WithClient(mockServer.Client()),
+				WithTimeouts(test.timeouts),
+			)
+			require.NoError(t, err)
+
+			ctx := ContextWithOptions(context.Background(), test.options...)
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, "/test", nil)
+			require.NoError(t, err)
+			resp, err := s.Exec(req, nil)
+			if test.withError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.NoError(t, resp.Body.Close())
+		})
+	}
+}
+
+func TestSession_ConnectionPool(t *testing.T) {
+	client := &http.Client{}
+	s, err := New(
+		WithSigner(&edgegrid.Config{}),
+		WithClient(client),
+		WithConnectionPool(ConnectionPool{
+			MaxIdleConns:        200,
+			MaxIdleConnsPerHost: 50,
+			MaxConnsPerHost:     64,
+			IdleConnTimeout:     2 * time.Minute,
+		}),
+	)
+	require.NoError(t, err)
+	assert.Nil(t, client.Transport)
+
+	transport, ok := s.Client().Transport.(*http.Transport)
+	require.True(t, ok)
+	assert.Equal(t, 200, transport.MaxIdleConns)
+	assert.Equal(t, 50, transport.MaxIdleConnsPerHost)
+	assert.Equal(t, 64, transport.MaxConnsPerHost)
+	assert.Equal(t, 2*time.Minute, transport.IdleConnTimeout)
+	assert.Equal(t, http.DefaultTransport.(*http.Transport).TLSHandshakeTimeout, transport.TLSHandshakeTimeout)
+}
+
+func TestSession_HTTP2(t *testing.T) {
+	mockServer := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	mockServer.EnableHTTP2 = true
+	mockServer.StartTLS()
+	defer mockServer.Close()
+	serverURL, err := url.Parse(mockServer.URL)
+	require.NoError(t, err)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(mockServer.Certificate())
+
+	tests := map[string]struct {
+		client        *http.Client
+		options       []Option
+		expectedProto int
+	}{
+		"default transport": {
+			client:        &http.Client{},
+			options:       []Option{WithTLSConfig(&tls.Config{RootCAs: pool})},
+			expectedProto: 2,
+		},
+		"custom transport": {
+			client:        &http.Client{Transport: &http.Transport{}},
+			options:       []Option{WithTLSConfig(&tls.Config{RootCAs: pool})},
+			expectedProto: 1,
+		},
+		"custom transport, HTTP/2 enabled": {
+			client:        &http.Client{Transport: &http.Transport{}},
+			options:       []Option{WithTLSConfig(&tls.Config{RootCAs: pool}), WithHTTP2(true)},
+			expectedProto: 2,
+		},
+		"default transport, HTTP/2 disabled": {
+			client:        &http.Client{},
+			options:       []Option{WithTLSConfig(&tls.Config{RootCAs: pool, NextProtos: []string{"h2", "http/1.1"}}), WithHTTP2(false)},
+			expectedProto: 1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			s, err := New(append([]Option{
+				WithSigner(&edgegrid.Config{Host: serverURL.Host}),
+				WithClient(test.client),
+			}, test.options...)...)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodGet, "/test", nil)
+			require.NoError(t, err)
+			resp, err := s.Exec(req, nil)
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, test.expectedProto, resp.ProtoMajor)
+		})
+	}
+}
+
+func TestSession_TransportOptionsErrors(t *testing.T) {
+	tests := map[string]struct {
+		options []Option
+	}{
+		"invalid proxy URL": {
+			options: []Option{WithProxy("proxy.example.com")},
+		},
+		"client transport is not *http.Transport": {
+			options: []Option{
+				WithClient(&http.Client{Transport: roundTripperFunc(http.DefaultTransport.RoundTrip)}),
+				WithProxy("http://proxy.example.com:3128"),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := New(append([]Option{WithSigner(&edgegrid.Config{})}, test.options...)...)
+			assert.True(t, errors.Is(err, ErrInvalidArgument), "want: %s; got: %s", ErrInvalidArgument, err)
+		})
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
@@ -0,0 +1,114 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type (
+	// CredentialsInfo describes the API client of the credentials a session signs requests with, see VerifyCredentials
+	CredentialsInfo struct {
+		ClientID           string             `json:"clientId"`
+		ClientName         string             `json:"clientName"`
+		ClientDescription  string             `json:"clientDescription"`
+		AllowAccountSwitch bool               `json:"allowAccountSwitch"`
+		IsLocked           bool               `json:"isLocked"`
+		APIAccess          CredentialsScope   `json:"apiAccess"`
+		Credentials        []ClientCredential `json:"credentials"`
+		// Credential is the entry of Credentials matching the client token the request was signed with, if found
+		Credential *ClientCredential `json:"-"`
+	}
+
+	// CredentialsScope lists the APIs the API client has access to
+	CredentialsScope struct {
+		AllAccessibleAPIs bool       `json:"allAccessibleApis"`
+		APIs              []APIScope `json:"apis"`
+	}
+
+	// APIScope is the access level of the API client to an API
+	APIScope struct {
+		APIID       int64  `json:"apiId"`
+		APIName     string `json:"apiName"`
+		AccessLevel string `json:"accessLevel"`
+		Endpoint    string `json:"endPoint"`
+	}
+
+	// ClientCredential describes a set of credentials of the API client
+	ClientCredential struct {
+		CredentialID int64     `json:"credentialId"`
+		ClientToken  string    `json:"clientToken"`
+		Status       string    `json:"status"`
+		CreatedOn    time.Time `json:"createdOn"`
+		ExpiresOn    time.Time `json:"expiresOn"`
+	}
+)
+
+const verifyCredentialsPath = "/identity-management/v3/api-clients/self?apiAccess=true&credentials=true"
+
+var (
+	// ErrVerifyCredentials is returned when VerifyCredentials fails
+	ErrVerifyCredentials = errors.New("verify credentials")
+	// ErrInvalidCredentials is returned by VerifyCredentials when the API rejects the credentials
+	ErrInvalidCredentials = errors.New("invalid credentials")
+)
+
+// VerifyCredentials fetches the API client of the credentials sess signs requests with, so that applications can fail
+// fast on bad credentials and check their scopes and expiry. Credentials rejected by the API are reported
+// as ErrInvalidCredentials.
+func VerifyCredentials(ctx context.Context, sess Session) (*CredentialsInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, verifyCredentialsPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrVerifyCredentials, err)
+	}
+
+	var info CredentialsInfo
+	resp, err := sess.Exec(req, &info)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrVerifyCredentials, err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCredentials, problemDetail(resp))
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrVerifyCredentials, problemDetail(resp))
+	}
+
+	token := clientToken(req)
+	for i := range info.Credentials {
+		if token != "" && info.Credentials[i].ClientToken == token {
+			info.Credential = &info.Credentials[i]
+			break
+		}
+	}
+	return &info, nil
+}
+
+// Expired reports whether the credential expired at given time
+func (c ClientCredential) Expired(at time.Time) bool {
+	return !c.ExpiresOn.IsZero() && !at.Before(c.ExpiresOn)
+}
+
+// problemDetail describes the error response
+func problemDetail(resp *http.Response) string {
+	if problem := readProblem(resp); problem != nil && (problem.Title != "" || problem.Detail != "") {
+		return fmt.Sprintf("%d %s: %s", resp.StatusCode, problem.Title, problem.Detail)
+	}
+	return resp.Status
+}
+
+// clientToken returns the client token of the Authorization header of a signed request
+func clientToken(r *http.Request) string {
+	auth := r.Header.Get("Authorization")
+	for _, field := range strings.Split(auth, ";") {
+		if i := strings.Index(field, "client_token="); i >= 0 {
+			return field[i+len("client_token="):]
+		}
+	}
+	return ""
+}
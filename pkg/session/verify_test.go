@@ -0,0 +1,112 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyCredentials(t *testing.T) {
+	tests := map[string]struct {
+		responseStatus int
+		responseBody   string
+		expected       *CredentialsInfo
+		withError      error
+	}{
+		"200 OK": {
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+    "clientId": "abcd1234",
+    "clientName": "terraform",
+    "allowAccountSwitch": true,
+    "apiAccess": {
+        "allAccessibleApis": false,
+        "apis": [
+            {"apiId": 5580, "apiName": "Application Security", "accessLevel": "READ-WRITE", "endPoint": "/appsec"}
+        ]
+    },
+    "credentials": [
+        {"credentialId": 1, "clientToken": "akab-old", "status": "INACTIVE", "createdOn": "2021-01-01T00:00:00Z", "expiresOn": "2023-01-01T00:00:00Z"},
+        {"credentialId": 2, "clientToken": "akab-current", "status": "ACTIVE", "createdOn": "2023-01-01T00:00:00Z", "expiresOn": "2025-01-01T00:00:00Z"}
+    ]
+}`,
+			expected: &CredentialsInfo{
+				ClientID:           "abcd1234",
+				ClientName:         "terraform",
+				AllowAccountSwitch: true,
+				APIAccess: CredentialsScope{
+					APIs: []APIScope{{APIID: 5580, APIName: "Application Security", AccessLevel: "READ-WRITE", Endpoint: "/appsec"}},
+				},
+				Credentials: []ClientCredential{
+					{
+						CredentialID: 1,
+						ClientToken:  "akab-old",
+						Status:       "INACTIVE",
+						CreatedOn:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+						ExpiresOn:    time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+					},
+					{
+						CredentialID: 2,
+						ClientToken:  "akab-current",
+						Status:       "ACTIVE",
+						CreatedOn:    time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+						ExpiresOn:    time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+					},
+				},
+			},
+		},
+		"401 invalid credentials": {
+			responseStatus: http.StatusUnauthorized,
+			responseBody:   `{"type": "https://problems.luna.akamaiapis.net/-/pep-authn/deny", "title": "Not authorized", "detail": "The signature does not match", "status": 401}`,
+			withError:      ErrInvalidCredentials,
+		},
+		"500 internal server error": {
+			responseStatus: http.StatusInternalServerError,
+			responseBody:   `{"title": "Internal Server Error", "status": 500}`,
+			withError:      ErrVerifyCredentials,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/identity-management/v3/api-clients/self?apiAccess=true&credentials=true", r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			serverURL, err := url.Parse(mockServer.URL)
+			require.NoError(t, err)
+
+			s, err := New(
+				WithSigner(&edgegrid.Config{Host: serverURL.Host, ClientToken: "akab-current"}),
+				WithClient(mockServer.Client()),
+			)
+			require.NoError(t, err)
+
+			info, err := VerifyCredentials(context.Background(), s)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, info.Credential)
+			assert.Equal(t, "akab-current", info.Credential.ClientToken)
+			assert.False(t, info.Credential.Expired(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))
+			assert.True(t, info.Credential.Expired(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)))
+			info.Credential = nil
+			assert.Equal(t, test.expected, info)
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package tools
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strconv"
+)
+
+type (
+	// CanonicalOptions configures CanonicalJSON
+	CanonicalOptions struct {
+		// KeepNull keeps object members with null values, which are removed by default
+		KeepNull bool
+		// KeepEmpty keeps object members with empty objects or arrays as values, which are removed by default
+		KeepEmpty bool
+	}
+)
+
+// CanonicalJSON returns the canonical form of JSON document, such as a rule tree or an exported security configuration,
+// so documents returned or stored by different SDK versions can be compared byte by byte.
+// In the canonical form:
+//   - object keys are sorted and insignificant whitespace is removed
+//   - object members with null values or empty objects and arrays are removed, unless kept by opts
+//   - numbers are formatted in their shortest form, e.g. 1.0 and 1e0 become 1
+//   - HTML characters in strings are not escaped
+func CanonicalJSON(data []byte, opts ...CanonicalOptions) ([]byte, error) {
+	var options CanonicalOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(canonicalize(value, options)); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// CanonicalJSONOf marshals v and returns its canonical form, see CanonicalJSON
+func CanonicalJSONOf(v interface{}, opts ...CanonicalOptions) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return CanonicalJSON(data, opts...)
+}
+
+// JSONEqual reports whether JSON documents are semantically equal, that is, have the same canonical form
+func JSONEqual(a, b []byte, opts ...CanonicalOptions) (bool, error) {
+	canonicalA, err := CanonicalJSON(a, opts...)
+	if err != nil {
+		return false, err
+	}
+	canonicalB, err := CanonicalJSON(b, opts...)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(canonicalA, canonicalB), nil
+}
+
+func canonicalize(value interface{}, opts CanonicalOptions) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, member := range v {
+			member = canonicalize(member, opts)
+			if isDropped(member, opts) {
+				delete(v, key)
+				continue
+			}
+			v[key] = member
+		}
+		return v
+	case []interface{}:
+		for i, element := range v {
+			v[i] = canonicalize(element, opts)
+		}
+		return v
+	case json.Number:
+		return canonicalNumber(v)
+	default:
+		return v
+	}
+}
+
+func isDropped(value interface{}, opts CanonicalOptions) bool {
+	switch v := value.(type) {
+	case nil:
+		return !opts.KeepNull
+	case map[string]interface{}:
+		return len(v) == 0 && !opts.KeepEmpty
+	case []interface{}:
+		return len(v) == 0 && !opts.KeepEmpty
+	}
+	return false
+}
+
+// canonicalNumber formats n without fraction or exponent when it is an integer and in the shortest form otherwise
+func canonicalNumber(n json.Number) json.Number {
+	if i, err := n.Int64(); err == nil {
+		return json.Number(strconv.FormatInt(i, 10))
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return n
+	}
+	if f == math.Trunc(f) && math.Abs(f) < 1<<53 {
+		return json.Number(strconv.FormatInt(int64(f), 10))
+	}
+	return json.Number(strconv.FormatFloat(f, 'g', -1, 64))
+}
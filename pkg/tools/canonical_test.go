@@ -0,0 +1,79 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalJSON(t *testing.T) {
+	tests := map[string]struct {
+		given     string
+		opts      []CanonicalOptions
+		expected  string
+		withError bool
+	}{
+		"sorts keys and removes whitespace": {
+			given:    `{"name": "default", "children": [], "behaviors": [{"options": {"b": 1, "a": "x"}, "name": "origin"}]}`,
+			expected: `{"behaviors":[{"name":"origin","options":{"a":"x","b":1}}],"name":"default"}`,
+		},
+		"removes null and empty members": {
+			given:    `{"a": null, "b": {}, "c": [], "d": {"e": null}, "f": "", "g": [null, {}]}`,
+			expected: `{"f":"","g":[null,{}]}`,
+		},
+		"keeps null and empty members": {
+			given:    `{"a": null, "b": {}, "c": []}`,
+			opts:     []CanonicalOptions{{KeepNull: true, KeepEmpty: true}},
+			expected: `{"a":null,"b":{},"c":[]}`,
+		},
+		"formats numbers": {
+			given:    `[1.0, 1e0, 10E2, 0.50, 12345678901234567890, -0.0, 1.5e-7, 9007199254740993]`,
+			expected: `[1,1,1000,0.5,1.2345678901234567e+19,0,1.5e-07,9007199254740993]`,
+		},
+		"does not escape HTML": {
+			given:    `{"value": "<a&b>"}`,
+			expected: `{"value":"<a&b>"}`,
+		},
+		"invalid JSON": {
+			given:     `{"a":`,
+			withError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, err := CanonicalJSON([]byte(test.given), test.opts...)
+			if test.withError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, string(result))
+		})
+	}
+}
+
+func TestCanonicalJSONOf(t *testing.T) {
+	type rule struct {
+		Name     string   `json:"name"`
+		Comments *string  `json:"comments"`
+		Children []string `json:"children"`
+	}
+	result, err := CanonicalJSONOf(rule{Name: "default"})
+	require.NoError(t, err)
+	assert.Equal(t, `{"name":"default"}`, string(result))
+}
+
+func TestJSONEqual(t *testing.T) {
+	equal, err := JSONEqual([]byte(`{"a": 1.0, "b": null}`), []byte(`{"a":1}`))
+	require.NoError(t, err)
+	assert.True(t, equal)
+
+	equal, err = JSONEqual([]byte(`{"a": 1}`), []byte(`{"a": 2}`))
+	require.NoError(t, err)
+	assert.False(t, equal)
+
+	_, err = JSONEqual([]byte(`{`), []byte(`{}`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,141 @@
+package tools
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ErrQueryEncoding is returned when a value cannot be encoded into query parameters
+var ErrQueryEncoding = errors.New("query encoding")
+
+// QueryValues encodes the fields of struct v tagged with `query:"name"` into query parameters, e.g.:
+//
+//	type GetPropertiesRequest struct {
+//		ContractID string `query:"contractId"`
+//		GroupID    string `query:"groupId,omitempty"`
+//	}
+//
+// Strings, booleans, numbers and pointers to them are supported, as well as slices of them encoded as
+// repeated parameters, or as a single comma-separated parameter with the comma option.
+// Fields with the omitempty option are skipped when they have zero values, nil pointers, including nil slice
+// elements, are always skipped.
+// Untagged fields and fields tagged with "-" are ignored.
+func QueryValues(v interface{}) (url.Values, error) {
+	values := url.Values{}
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return values, nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: expected struct, got %s", ErrQueryEncoding, rv.Kind())
+	}
+
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		tag, ok := field.Tag.Lookup("query")
+		if !ok || tag == "-" || !field.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = field.Name
+		}
+		var omitEmpty, comma bool
+		for _, opt := range strings.Split(opts, ",") {
+			switch opt {
+			case "omitempty":
+				omitEmpty = true
+			case "comma":
+				comma = true
+			}
+		}
+
+		fv := rv.Field(i)
+		if fv.Kind() == reflect.Ptr {
+			if fv.IsNil() {
+				continue
+			}
+			fv = fv.Elem()
+		}
+		if omitEmpty && fv.IsZero() {
+			continue
+		}
+
+		if fv.Kind() == reflect.Slice || fv.Kind() == reflect.Array {
+			list := make([]string, 0, fv.Len())
+			for j := 0; j < fv.Len(); j++ {
+				ev := fv.Index(j)
+				if ev.Kind() == reflect.Ptr {
+					if ev.IsNil() {
+						continue
+					}
+					ev = ev.Elem()
+				}
+				s, err := queryValue(ev)
+				if err != nil {
+					return nil, fmt.Errorf("%w: field %s: %s", ErrQueryEncoding, field.Name, err)
+				}
+				list = append(list, s)
+			}
+			if comma {
+				values.Add(name, strings.Join(list, ","))
+			} else {
+				values[name] = append(values[name], list...)
+			}
+			continue
+		}
+		s, err := queryValue(fv)
+		if err != nil {
+			return nil, fmt.Errorf("%w: field %s: %s", ErrQueryEncoding, field.Name, err)
+		}
+		values.Add(name, s)
+	}
+	return values, nil
+}
+
+// AppendQuery returns uri with the query parameters of v added, see QueryValues
+func AppendQuery(uri string, v interface{}) (string, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", err
+	}
+	values, err := QueryValues(v)
+	if err != nil {
+		return "", err
+	}
+	q := u.Query()
+	for name, list := range values {
+		for _, value := range list {
+			q.Add(name, value)
+		}
+	}
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+func queryValue(v reflect.Value) (string, error) {
+	if s, ok := v.Interface().(fmt.Stringer); ok {
+		return s.String(), nil
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits()), nil
+	}
+	return "", fmt.Errorf("unsupported type %s", v.Type())
+}
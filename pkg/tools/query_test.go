@@ -0,0 +1,116 @@
+package tools
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryValues(t *testing.T) {
+	type network string
+	type request struct {
+		ConfigID  int      `json:"-"`
+		Hostname  string   `query:"hostname"`
+		Contract  string   `query:"contractId,omitempty"`
+		Validate  bool     `query:"validateHostnames"`
+		Page      *int     `query:"page"`
+		Size      *int     `query:"size"`
+		Types     []string `query:"types,omitempty"`
+		Options   []string `query:"options,omitempty,comma"`
+		Network   network  `query:"network,omitempty"`
+		Ratio     float64  `query:"ratio,omitempty"`
+		Ignored   string   `query:"-"`
+		Timeout   time.Duration
+		NoName    uint `query:",omitempty"`
+		unchanged string
+	}
+
+	tests := map[string]struct {
+		given     interface{}
+		expected  url.Values
+		withError error
+	}{
+		"encodes tagged fields": {
+			given: request{
+				ConfigID: 1,
+				Hostname: "a&b.example.com",
+				Validate: true,
+				Page:     IntPtr(2),
+				Types:    []string{"A", "AAAA"},
+				Options:  []string{"mapDetails", "useCases"},
+				Network:  "STAGING",
+				Ratio:    0.5,
+				Ignored:  "x",
+				NoName:   3,
+			},
+			expected: url.Values{
+				"hostname":          {"a&b.example.com"},
+				"validateHostnames": {"true"},
+				"page":              {"2"},
+				"types":             {"A", "AAAA"},
+				"options":           {"mapDetails,useCases"},
+				"network":           {"STAGING"},
+				"ratio":             {"0.5"},
+				"NoName":            {"3"},
+			},
+		},
+		"skips empty values": {
+			given: &request{},
+			expected: url.Values{
+				"hostname":          {""},
+				"validateHostnames": {"false"},
+			},
+		},
+		"slice of pointers": {
+			given: struct {
+				IDs []*int `query:"id"`
+			}{IDs: []*int{IntPtr(1), nil, IntPtr(3)}},
+			expected: url.Values{
+				"id": {"1", "3"},
+			},
+		},
+		"nil pointer": {
+			given:    (*request)(nil),
+			expected: url.Values{},
+		},
+		"not a struct": {
+			given:     "hostname",
+			withError: ErrQueryEncoding,
+		},
+		"unsupported field": {
+			given: struct {
+				Value map[string]string `query:"value"`
+			}{Value: map[string]string{}},
+			withError: ErrQueryEncoding,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, err := QueryValues(test.given)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestAppendQuery(t *testing.T) {
+	params := struct {
+		Hostname string `query:"hostname"`
+	}{Hostname: "a+b&c=d.example.com"}
+
+	uri, err := AppendQuery("/appsec/v1/configs/1/versions/2/hostname-coverage/overlapping?type=website", params)
+	require.NoError(t, err)
+	assert.Equal(t, "/appsec/v1/configs/1/versions/2/hostname-coverage/overlapping?hostname=a%2Bb%26c%3Dd.example.com&type=website", uri)
+
+	_, err = AppendQuery("/%zz", params)
+	assert.Error(t, err)
+}
@@ -0,0 +1,126 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
+type (
+	// CheckFunc polls the state of a long-running operation, such as an activation.
+	// It returns the current status of the operation and whether the operation has finished.
+	// Returning an error stops waiting immediately.
+	CheckFunc func(ctx context.Context) (status string, done bool, err error)
+
+	// WaitOptions configures WaitFor. Zero values are replaced with defaults.
+	WaitOptions struct {
+		// Interval is the delay before the second check, defaults to 10 seconds
+		Interval time.Duration
+		// MaxInterval caps the delay between checks, defaults to 1 minute
+		MaxInterval time.Duration
+		// Multiplier is the factor by which the delay grows after each check, defaults to 1.5
+		Multiplier float64
+		// Jitter is the fraction of the delay randomly added or subtracted, defaults to 0.2; a negative value disables it
+		Jitter float64
+		// Timeout limits the total waiting time; when not set, waiting is only limited by the context
+		Timeout time.Duration
+		// OnProgress, if set, is called after every check which did not finish the operation
+		OnProgress func(Progress)
+	}
+
+	// Progress describes the state of the awaited operation after a single check
+	Progress struct {
+		Attempt int
+		Status  string
+		Elapsed time.Duration
+		Next    time.Duration
+	}
+)
+
+var (
+	// ErrWaitTimeout is returned when the operation did not finish within WaitOptions.Timeout
+	ErrWaitTimeout = errors.New("timed out waiting for operation to finish")
+)
+
+const (
+	defaultWaitInterval    = 10 * time.Second
+	defaultWaitMaxInterval = time.Minute
+	defaultWaitMultiplier  = 1.5
+	defaultWaitJitter      = 0.2
+)
+
+// WaitFor calls check with exponentially growing, jittered delays until it reports the operation is done,
+// it returns an error, the timeout passes or ctx is done
+func WaitFor(ctx context.Context, check CheckFunc, opts WaitOptions) error {
+	opts = opts.withDefaults()
+
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	for attempt := 1; ; attempt++ {
+		status, done, err := check(ctx)
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+
+		delay := opts.delay(attempt)
+		if opts.OnProgress != nil {
+			opts.OnProgress(Progress{Attempt: attempt, Status: status, Elapsed: time.Since(start), Next: delay})
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) && opts.Timeout > 0 {
+				return fmt.Errorf("%w: last status: %s", ErrWaitTimeout, status)
+			}
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (o WaitOptions) withDefaults() WaitOptions {
+	if o.Interval <= 0 {
+		o.Interval = defaultWaitInterval
+	}
+	if o.MaxInterval <= 0 {
+		o.MaxInterval = defaultWaitMaxInterval
+	}
+	if o.MaxInterval < o.Interval {
+		o.MaxInterval = o.Interval
+	}
+	if o.Multiplier < 1 {
+		o.Multiplier = defaultWaitMultiplier
+	}
+	if o.Jitter == 0 {
+		o.Jitter = defaultWaitJitter
+	}
+	if o.Jitter > 1 {
+		o.Jitter = 1
+	}
+	return o
+}
+
+// delay returns the jittered delay to use after given attempt
+func (o WaitOptions) delay(attempt int) time.Duration {
+	d := float64(o.Interval) * math.Pow(o.Multiplier, float64(attempt-1))
+	if d > float64(o.MaxInterval) {
+		d = float64(o.MaxInterval)
+	}
+	if o.Jitter > 0 {
+		d += d * o.Jitter * (2*rand.Float64() - 1)
+	}
+	return time.Duration(d)
+}
@@ -0,0 +1,75 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func statuses(list ...string) CheckFunc {
+	i := 0
+	return func(context.Context) (string, bool, error) {
+		status := list[i]
+		if i < len(list)-1 {
+			i++
+		}
+		return status, status == "DONE", nil
+	}
+}
+
+func TestWaitFor(t *testing.T) {
+	fast := WaitOptions{Interval: time.Millisecond, MaxInterval: 2 * time.Millisecond, Jitter: -1}
+
+	t.Run("finishes after progress updates", func(t *testing.T) {
+		var progress []Progress
+		opts := fast
+		opts.OnProgress = func(p Progress) {
+			progress = append(progress, p)
+		}
+		err := WaitFor(context.Background(), statuses("NEW", "PENDING", "DONE"), opts)
+		require.NoError(t, err)
+		require.Len(t, progress, 2)
+		assert.Equal(t, 1, progress[0].Attempt)
+		assert.Equal(t, "NEW", progress[0].Status)
+		assert.Equal(t, time.Millisecond, progress[0].Next)
+		assert.Equal(t, "PENDING", progress[1].Status)
+		assert.Equal(t, 1500*time.Microsecond, progress[1].Next)
+	})
+
+	t.Run("check error stops waiting", func(t *testing.T) {
+		checkErr := errors.New("oops")
+		err := WaitFor(context.Background(), func(context.Context) (string, bool, error) {
+			return "", false, checkErr
+		}, fast)
+		assert.True(t, errors.Is(err, checkErr))
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		opts := fast
+		opts.Timeout = 10 * time.Millisecond
+		err := WaitFor(context.Background(), statuses("PENDING"), opts)
+		assert.True(t, errors.Is(err, ErrWaitTimeout), "want: %s; got: %s", ErrWaitTimeout, err)
+		assert.Contains(t, err.Error(), "PENDING")
+	})
+
+	t.Run("context cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		opts := fast
+		opts.OnProgress = func(Progress) { cancel() }
+		err := WaitFor(ctx, statuses("PENDING"), opts)
+		assert.True(t, errors.Is(err, context.Canceled))
+	})
+}
+
+func TestWaitOptionsDelay(t *testing.T) {
+	opts := WaitOptions{}.withDefaults()
+	for attempt := 1; attempt < 20; attempt++ {
+		d := opts.delay(attempt)
+		assert.True(t, d >= time.Duration(float64(defaultWaitInterval)*(1-defaultWaitJitter)), "delay too short: %s", d)
+		assert.True(t, d <= time.Duration(float64(defaultWaitMaxInterval)*(1+defaultWaitJitter)), "delay too long: %s", d)
+	}
+}
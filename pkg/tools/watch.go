@@ -0,0 +1,107 @@
+package tools
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	// Event is a status update of an operation watched with Watch
+	Event struct {
+		Progress
+		// Done is set on the last event when the operation finished successfully
+		Done bool
+		// Err is set on the last event when checking the operation failed or timed out
+		Err error
+		// PreviousStatus is the status of the previous event, empty for the first one
+		PreviousStatus string
+	}
+)
+
+// Watch polls the operation like WaitFor, but instead of blocking it delivers an Event after every check
+// on the returned channel, e.g.:
+//
+//	for event := range tools.Watch(ctx, check, tools.WaitOptions{}) {
+//		fmt.Printf("attempt %d: %s\n", event.Attempt, event.Status)
+//		if event.Err != nil {
+//			// handle error
+//		}
+//	}
+//
+// The last event has either Done or Err set and the channel is closed afterwards.
+// When ctx is canceled, the channel is closed without further events, so the receiver may stop reading at any time
+// as long as it cancels ctx. Events are not buffered, polling pauses until the receiver reads the pending event.
+func Watch(ctx context.Context, check CheckFunc, opts WaitOptions) <-chan Event {
+	events := make(chan Event)
+
+	go func() {
+		defer close(events)
+
+		send := func(e Event) bool {
+			select {
+			case events <- e:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		start := time.Now()
+		var last Progress
+		var previous string
+		emit := func(e Event) bool {
+			e.PreviousStatus = previous
+			previous = e.Status
+			return send(e)
+		}
+		watched := func(ctx context.Context) (string, bool, error) {
+			status, done, err := check(ctx)
+			last = Progress{Attempt: last.Attempt + 1, Status: status, Elapsed: time.Since(start)}
+			return status, done, err
+		}
+
+		onProgress := opts.OnProgress
+		opts.OnProgress = func(p Progress) {
+			if onProgress != nil {
+				onProgress(p)
+			}
+			emit(Event{Progress: p})
+		}
+
+		err := WaitFor(ctx, watched, opts)
+		if ctx.Err() != nil {
+			return
+		}
+		emit(Event{Progress: last, Done: err == nil, Err: err})
+	}()
+
+	return events
+}
+
+// WatchChanges is like Watch, but delivers only the events reporting a status different from the previous one
+// and the last event, so the receiver can react to state transitions, e.g. from PENDING to ACTIVE.
+func WatchChanges(ctx context.Context, check CheckFunc, opts WaitOptions) <-chan Event {
+	changes := make(chan Event)
+
+	go func() {
+		defer close(changes)
+
+		var reported string
+		first := true
+		for e := range Watch(ctx, check, opts) {
+			last := e.Done || e.Err != nil
+			if !first && !last && e.Status == reported {
+				continue
+			}
+			e.PreviousStatus = reported
+			first, reported = false, e.Status
+			select {
+			case changes <- e:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return changes
+}
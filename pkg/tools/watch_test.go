@@ -0,0 +1,121 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func collect(events <-chan Event) []Event {
+	var result []Event
+	for e := range events {
+		result = append(result, e)
+	}
+	return result
+}
+
+func TestWatch(t *testing.T) {
+	fast := WaitOptions{Interval: time.Millisecond, MaxInterval: 2 * time.Millisecond, Jitter: -1}
+
+	t.Run("emits every status and closes when done", func(t *testing.T) {
+		events := collect(Watch(context.Background(), statuses("NEW", "PENDING", "DONE"), fast))
+		require.Len(t, events, 3)
+		assert.Equal(t, "NEW", events[0].Status)
+		assert.Equal(t, 1, events[0].Attempt)
+		assert.False(t, events[0].Done)
+		assert.Equal(t, "PENDING", events[1].Status)
+		assert.Equal(t, "DONE", events[2].Status)
+		assert.Equal(t, 3, events[2].Attempt)
+		assert.True(t, events[2].Done)
+		assert.NoError(t, events[2].Err)
+	})
+
+	t.Run("last event carries check error", func(t *testing.T) {
+		errCheck := errors.New("check failed")
+		events := collect(Watch(context.Background(), func(context.Context) (string, bool, error) {
+			return "FAILED", false, errCheck
+		}, fast))
+		require.Len(t, events, 1)
+		assert.False(t, events[0].Done)
+		assert.True(t, errors.Is(events[0].Err, errCheck))
+		assert.Equal(t, "FAILED", events[0].Status)
+	})
+
+	t.Run("last event carries timeout", func(t *testing.T) {
+		opts := fast
+		opts.Timeout = 10 * time.Millisecond
+		events := collect(Watch(context.Background(), statuses("PENDING"), opts))
+		require.NotEmpty(t, events)
+		last := events[len(events)-1]
+		assert.True(t, errors.Is(last.Err, ErrWaitTimeout), "want: %s; got: %s", ErrWaitTimeout, last.Err)
+	})
+
+	t.Run("stops on cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		events := Watch(ctx, statuses("PENDING"), fast)
+		<-events
+		cancel()
+		select {
+		case <-drain(events):
+		case <-time.After(time.Second):
+			t.Fatal("channel not closed after cancellation")
+		}
+	})
+
+	t.Run("calls OnProgress", func(t *testing.T) {
+		var progress []Progress
+		opts := fast
+		opts.OnProgress = func(p Progress) {
+			progress = append(progress, p)
+		}
+		collect(Watch(context.Background(), statuses("NEW", "DONE"), opts))
+		require.Len(t, progress, 1)
+		assert.Equal(t, "NEW", progress[0].Status)
+	})
+}
+
+func drain(events <-chan Event) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		for range events {
+		}
+		close(done)
+	}()
+	return done
+}
+
+func TestWatchChanges(t *testing.T) {
+	fast := WaitOptions{Interval: time.Millisecond, MaxInterval: 2 * time.Millisecond, Jitter: -1}
+
+	t.Run("emits status transitions and the last event", func(t *testing.T) {
+		events := collect(WatchChanges(context.Background(), statuses("NEW", "NEW", "PENDING", "PENDING", "DONE"), fast))
+		require.Len(t, events, 3)
+		assert.Equal(t, "NEW", events[0].Status)
+		assert.Equal(t, "", events[0].PreviousStatus)
+		assert.Equal(t, "PENDING", events[1].Status)
+		assert.Equal(t, "NEW", events[1].PreviousStatus)
+		assert.Equal(t, 3, events[1].Attempt)
+		assert.Equal(t, "DONE", events[2].Status)
+		assert.Equal(t, "PENDING", events[2].PreviousStatus)
+		assert.True(t, events[2].Done)
+	})
+
+	t.Run("emits last event with unchanged status", func(t *testing.T) {
+		errCheck := errors.New("check failed")
+		calls := 0
+		events := collect(WatchChanges(context.Background(), func(context.Context) (string, bool, error) {
+			calls++
+			if calls == 3 {
+				return "PENDING", false, errCheck
+			}
+			return "PENDING", false, nil
+		}, fast))
+		require.Len(t, events, 2)
+		assert.Equal(t, "PENDING", events[1].Status)
+		assert.True(t, errors.Is(events[1].Err, errCheck))
+	})
+}